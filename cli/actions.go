@@ -3,11 +3,14 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"regexp"
 	"strconv"
@@ -21,6 +24,7 @@ import (
 	"github.com/immesys/wave/iapi"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 //ParseDuration is a little like the existing time.ParseDuration
@@ -50,8 +54,65 @@ func ParseDuration(s string) (*time.Duration, error) {
 	return &rv, nil
 }
 
+//bearerToken attaches the agent token to every request
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+func getAgentTLSConfig(c *cli.Context) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.GlobalString("agent-tls-ca") != "" {
+		capem, err := ioutil.ReadFile(c.GlobalString("agent-tls-ca"))
+		if err != nil {
+			fmt.Printf("could not read agent CA: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(capem) {
+			fmt.Printf("agent CA file contains no certificates\n")
+			os.Exit(1)
+		}
+	}
+	if c.GlobalString("agent-tls-cert") != "" {
+		cert, err := tls.LoadX509KeyPair(c.GlobalString("agent-tls-cert"), c.GlobalString("agent-tls-key"))
+		if err != nil {
+			fmt.Printf("could not load client certificate: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg
+}
+
 func getConn(c *cli.Context) pb.WAVEClient {
-	conn, err := grpc.Dial(c.GlobalString("agent"), grpc.WithInsecure(), grpc.FailOnNonTempDialError(true), grpc.WithBlock())
+	return getConnTo(c, c.GlobalString("agent"))
+}
+
+//getConnTo connects to the given agent using the global TLS and token
+//options
+func getConnTo(c *cli.Context, agent string) pb.WAVEClient {
+	opts := []grpc.DialOption{grpc.FailOnNonTempDialError(true), grpc.WithBlock()}
+	if c.GlobalBool("agent-tls") {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(getAgentTLSConfig(c))))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if strings.HasPrefix(agent, "unix://") {
+		opts = append(opts, grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", strings.TrimPrefix(addr, "unix://"), timeout)
+		}))
+	}
+	if c.GlobalString("token") != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(c.GlobalString("token"))))
+	}
+	conn, err := grpc.Dial(agent, opts...)
 	if err != nil {
 		fmt.Printf("failed to connect to agent: %v\n", err)
 		os.Exit(1)
@@ -96,17 +157,49 @@ func actionMkEntity(c *cli.Context) error {
 			os.Exit(1)
 		}
 	}
+	var keyScheme string
+	switch c.String("keyscheme") {
+	case "ed25519":
+		keyScheme = eapi.KeySchemeEd25519
+	case "p256":
+		keyScheme = eapi.KeySchemeECDSA_P256
+	default:
+		fmt.Printf("unknown key scheme %q\n", c.String("keyscheme"))
+		os.Exit(1)
+	}
 	var revloc *pb.Location
 	if c.String("revocationlocation") != "" {
 		revloc = &pb.Location{
 			AgentLocation: c.String("revocationlocation"),
 		}
 	}
+	var schedule *pb.PartitionSchedule
+	if c.String("partitiontiers") != "" {
+		schedule = &pb.PartitionSchedule{
+			SlotSize: int32(c.Int("partitionslotsize")),
+		}
+		for _, t := range strings.Split(c.String("partitiontiers"), ",") {
+			tier, err := ParseDuration(t)
+			if err != nil || tier == nil {
+				fmt.Printf("bad partition tier %q\n", t)
+				os.Exit(1)
+			}
+			schedule.Tiers = append(schedule.Tiers, int64(*tier))
+		}
+		maxRange, err := ParseDuration(c.String("partitionmaxrange"))
+		if err != nil || maxRange == nil {
+			fmt.Printf("bad partition max range: %v\n", err)
+			os.Exit(1)
+		}
+		schedule.MaxRange = int64(*maxRange)
+	}
 	resp, err := conn.CreateEntity(context.Background(), &pb.CreateEntityParams{
 		ValidFrom:          time.Now().UnixNano() / 1e6,
 		ValidUntil:         time.Now().Add(*expiry).UnixNano() / 1e6,
 		SecretPassphrase:   string(pass),
 		RevocationLocation: revloc,
+		KeyScheme:          keyScheme,
+		PartitionSchedule:  schedule,
 	})
 	if err != nil {
 		fmt.Printf("error: %v\n", err)
@@ -164,50 +257,43 @@ func loadEntitySecretDER(filename string) []byte {
 	}
 	return block.Bytes
 }
-func actionRTGrant(c *cli.Context) error {
-	expires, err := ParseDuration(c.String("expiry"))
+//syncPerspective resyncs the perspective graph and waits for it to complete
+func syncPerspective(conn pb.WAVEClient, perspective *pb.Perspective) {
+	resp, err := conn.ResyncPerspectiveGraph(context.Background(), &pb.ResyncPerspectiveGraphParams{
+		Perspective: perspective,
+	})
 	if err != nil {
-		fmt.Printf("bad expiry\n")
+		fmt.Printf("error: %v\n", err)
 		os.Exit(1)
 	}
-	conn := getConn(c)
-	perspective := getPerspective(c.String("attester"), c.String("passphrase"), "missing attesting entity secret\n")
-
-	if !c.Bool("skipsync") {
-		resp, err := conn.ResyncPerspectiveGraph(context.Background(), &pb.ResyncPerspectiveGraphParams{
-			Perspective: perspective,
-		})
-		if err != nil {
-			fmt.Printf("error: %v\n", err)
-			os.Exit(1)
-		}
-		if resp.Error != nil {
-			fmt.Printf("error: %v\n", resp.Error.Message)
-			os.Exit(1)
-		}
-		srv, err := conn.WaitForSyncComplete(context.Background(), &pb.SyncParams{
-			Perspective: perspective,
-		})
-		for {
-			rv, err := srv.Recv()
-			if err == io.EOF {
-				break
-			}
-			fmt.Printf("Synchronized %d/%d entities\n", rv.CompletedSyncs, rv.TotalSyncRequests)
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	srv, err := conn.WaitForSyncComplete(context.Background(), &pb.SyncParams{
+		Perspective: perspective,
+	})
+	for {
+		rv, err := srv.Recv()
+		if err == io.EOF {
+			break
 		}
-		fmt.Printf("Perspective graph sync complete\n")
+		fmt.Printf("Synchronized %d/%d entities\n", rv.CompletedSyncs, rv.TotalSyncRequests)
 	}
+	fmt.Printf("Perspective graph sync complete\n")
+}
 
-	subject := resolveEntityNameOrHashOrFile(conn, perspective, c.String("subject"), "missing subject entity")
-
+//parseRTreePolicy builds a policy from statements of the form
+//permset:perm[,perm,perm...]@namespace/resource
+func parseRTreePolicy(conn pb.WAVEClient, perspective *pb.Perspective, args []string, indirections int, partition string) *pb.RTreePolicy {
 	statements := []*pb.RTreePolicyStatement{}
 
 	var namespace []byte
-	if len(c.Args()) == 0 {
+	if len(args) == 0 {
 		fmt.Printf("need to specify some statements\n")
 		os.Exit(1)
 	}
-	for _, a := range c.Args() {
+	for _, a := range args {
 		atsplit := strings.SplitN(a, "@", -1)
 		if len(atsplit) != 2 {
 			fmt.Printf("%v\n", atsplit)
@@ -245,17 +331,38 @@ func actionRTGrant(c *cli.Context) error {
 			Resource:      nsrez[1],
 		})
 	}
-	vizparts := strings.Split(c.String("partition"), "/")
+	vizparts := strings.Split(partition, "/")
 	vizuri := make([][]byte, len(vizparts))
 	for idx, s := range vizparts {
 		vizuri[idx] = []byte(s)
 	}
-	pol := &pb.RTreePolicy{
+	return &pb.RTreePolicy{
 		Namespace:     namespace,
-		Indirections:  uint32(c.Int("indirections")),
+		Indirections:  uint32(indirections),
 		Statements:    statements,
 		VisibilityURI: vizuri,
 	}
+}
+
+func actionRTGrant(c *cli.Context) error {
+	expires, err := ParseDuration(c.String("expiry"))
+	if err != nil {
+		fmt.Printf("bad expiry\n")
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("attester"), c.String("passphrase"), "missing attesting entity secret\n")
+
+	if !c.Bool("skipsync") {
+		syncPerspective(conn, perspective)
+	}
+
+	if c.String("from-file") != "" {
+		return rtGrantFromFile(c, conn, perspective)
+	}
+
+	subject := resolveEntityNameOrHashOrFile(conn, perspective, c.String("subject"), "missing subject entity")
+	pol := parseRTreePolicy(conn, perspective, c.Args(), c.Int("indirections"), c.String("partition"))
 	inspectresponse, err := conn.Inspect(context.Background(), &pb.InspectParams{
 		Content: perspective.EntitySecret.DER,
 	})
@@ -301,7 +408,10 @@ func actionRTGrant(c *cli.Context) error {
 		Policy: &pb.Policy{
 			RTreePolicy: pol,
 		},
+		RevocationLists: parseRevocationListReferences(conn, perspective, c.StringSlice("revocationlist")),
+		Revokers:        parseRevokerReferences(conn, perspective, c.StringSlice("revoker")),
 	}
+	confirmResolvedNames(c)
 	resp, err := conn.CreateAttestation(context.Background(), params)
 	if err != nil {
 		fmt.Printf("error: %v\n", err)
@@ -433,6 +543,7 @@ func actionResolve(c *cli.Context) error {
 			}
 			if resp.Error != nil {
 				fmt.Printf("could not resolve name: [%d] %s\n", resp.Error.Code, resp.Error.Message)
+				printNameCandidates(resp.Candidates)
 				os.Exit(1)
 			}
 			reverse := ReverseName(conn, perspective, resp.Entity.Hash)
@@ -495,6 +606,9 @@ func actionInspect(c *cli.Context) error {
 				fmt.Printf("   - Attester invalid: %v\n", resp.Attestation.Validity.SrcInvalid)
 			}
 		}
+		if resp.ThresholdProposal != nil {
+			PrintThresholdProposal(resp.ThresholdProposal, conn, nil)
+		}
 	}
 	return nil
 }
@@ -615,7 +729,7 @@ func getPerspective(file string, passphrase string, msg string) *pb.Perspective
 	if file != "" {
 		pass := []byte(passphrase)
 		if len(pass) == 0 {
-			fmt.Printf("passphrase for entity secret: ")
+			fmt.Fprintf(os.Stderr, "passphrase for entity secret: ")
 			var err error
 			pass, err = gopass.GetPasswdMasked()
 			if err != nil {
@@ -796,8 +910,10 @@ func resolveEntityNameOrHashOrFile(conn pb.WAVEClient, perspective *pb.Perspecti
 		}
 		if resp.Error != nil {
 			fmt.Printf("could not resolve name %q: %s\n", in, resp.Error.Message)
+			printNameCandidates(resp.Candidates)
 			os.Exit(1)
 		}
+		printResolvedName(in, resp)
 		return resp.Entity.Hash
 	}
 	//Resolve as file
@@ -819,6 +935,44 @@ func resolveEntityNameOrHashOrFile(conn pb.WAVEClient, perspective *pb.Perspecti
 	os.Exit(1)
 	return nil
 }
+//resolvedNames are the names that resolveEntityNameOrHashOrFile has
+//resolved, which must be confirmed before anything is signed
+var resolvedNames = make(map[string]bool)
+
+//printResolvedName shows the hash a name resolved to and the declarations
+//it was derived from, starting at the perspective. It writes to stderr as
+//commands like encrypt write their result to stdout
+func printResolvedName(name string, resp *pb.ResolveNameResponse) {
+	if resolvedNames[name] {
+		return
+	}
+	resolvedNames[name] = true
+	fmt.Fprintf(os.Stderr, "resolved %q -> %s\n", name, base64.URLEncoding.EncodeToString(resp.Entity.Hash))
+	for i := len(resp.Derivation) - 1; i >= 0; i-- {
+		nd := resp.Derivation[i]
+		fmt.Fprintf(os.Stderr, "  %s named %s %q (declaration %s)\n",
+			base64.URLEncoding.EncodeToString(nd.Attester),
+			base64.URLEncoding.EncodeToString(nd.Subject),
+			nd.Name,
+			base64.URLEncoding.EncodeToString(nd.Hash))
+	}
+}
+
+//confirmResolvedNames asks the user to check the resolved names before
+//signing, unless --yes was given
+func confirmResolvedNames(c *cli.Context) {
+	if len(resolvedNames) == 0 || c.Bool("yes") {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "sign using the resolved names above? [y/N] ")
+	var answer string
+	fmt.Scanln(&answer)
+	if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+		fmt.Fprintf(os.Stderr, "not signed\n")
+		os.Exit(1)
+	}
+}
+
 func actionNameDecl(c *cli.Context) error {
 	if len(c.Args()) != 2 {
 		fmt.Printf("usage: name [flags] entity name\n")
@@ -884,6 +1038,7 @@ func actionNameDecl(c *cli.Context) error {
 			params.Partition = [][]byte{[]byte("privatenamedeclarations")}
 		}
 	}
+	confirmResolvedNames(c)
 	resp, err := conn.CreateNameDeclaration(context.Background(), &params)
 	if err != nil {
 		fmt.Printf("unable to create name: %v\n", err)
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/howeyc/gopass"
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/wve"
+	"github.com/urfave/cli"
+)
+
+//actionBackupSplit splits an entity secret into shares. This is done
+//locally so the secret is never sent to the agent
+func actionBackupSplit(c *cli.Context) error {
+	if c.String("entity") == "" {
+		fmt.Printf("missing entity secrets\n")
+		os.Exit(1)
+	}
+	der := loadEntitySecretDER(c.String("entity"))
+	var passphrase *string
+	if c.String("passphrase") != "" {
+		pass := c.String("passphrase")
+		passphrase = &pass
+	}
+	split, werr := iapi.SplitEntitySecrets(context.Background(), &iapi.PSplitEntitySecrets{
+		DER:        der,
+		Passphrase: passphrase,
+		Threshold:  c.Int("threshold"),
+		Total:      c.Int("shares"),
+	})
+	if werr != nil && werr.Code() == wve.PassphraseRequired {
+		fmt.Printf("passphrase for entity secret: ")
+		pass, err := gopass.GetPasswdMasked()
+		if err != nil {
+			fmt.Printf("could not read passphrase: %v\n", err)
+			os.Exit(1)
+		}
+		spass := string(pass)
+		split, werr = iapi.SplitEntitySecrets(context.Background(), &iapi.PSplitEntitySecrets{
+			DER:        der,
+			Passphrase: &spass,
+			Threshold:  c.Int("threshold"),
+			Total:      c.Int("shares"),
+		})
+	}
+	if werr != nil {
+		fmt.Printf("error: %v\n", werr)
+		os.Exit(1)
+	}
+	for idx, share := range split.Shares {
+		entity := base64.URLEncoding.EncodeToString(share.Entity.Multihash())
+		bl := pem.Block{
+			Type: eapi.PEM_ENTITY_SECRET_SHARE,
+			Headers: map[string]string{
+				"Entity":    entity,
+				"Share":     fmt.Sprintf("%d of %d", share.Index(), share.Total()),
+				"Threshold": strconv.Itoa(share.Threshold()),
+			},
+			Bytes: split.DERs[idx],
+		}
+		filename := filepath.Join(c.String("outdir"), fmt.Sprintf("share_%s_%d_of_%d.pem", entity, share.Index(), share.Total()))
+		err := ioutil.WriteFile(filename, pem.EncodeToMemory(&bl), 0600)
+		if err != nil {
+			fmt.Printf("could not write share file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("wrote share: %s\n", filename)
+	}
+	fmt.Printf("any %d of the %d shares can recover the entity secret\n", c.Int("threshold"), c.Int("shares"))
+	fmt.Printf("each share must be kept as safely as the entity secret itself\n")
+	return nil
+}
+
+//actionBackupRecover rebuilds an entity secret from share files and
+//encrypts it under a new passphrase
+func actionBackupRecover(c *cli.Context) error {
+	if len(c.Args()) == 0 {
+		fmt.Printf("missing share files\n")
+		os.Exit(1)
+	}
+	shares := [][]byte{}
+	for _, filename := range c.Args() {
+		contents, err := ioutil.ReadFile(filename)
+		if err != nil {
+			fmt.Printf("could not read file %q: %v\n", filename, err)
+			os.Exit(1)
+		}
+		block, _ := pem.Decode(contents)
+		if block == nil || block.Type != eapi.PEM_ENTITY_SECRET_SHARE {
+			fmt.Printf("file %q is not an entity secret share\n", filename)
+			os.Exit(1)
+		}
+		shares = append(shares, block.Bytes)
+	}
+	var passphrase *string
+	if !c.Bool("nopassphrase") {
+		fmt.Printf("enter a new passphrase for the recovered entity: ")
+		pass, err := gopass.GetPasswdMasked()
+		if err != nil {
+			fmt.Printf("could not read passphrase: %v\n", err)
+			os.Exit(1)
+		}
+		spass := string(pass)
+		passphrase = &spass
+	}
+	rv, werr := iapi.RecoverEntitySecrets(context.Background(), &iapi.PRecoverEntitySecrets{
+		Shares:        shares,
+		NewPassphrase: passphrase,
+	})
+	if werr != nil {
+		fmt.Printf("error: %v\n", werr)
+		os.Exit(1)
+	}
+	bl := pem.Block{
+		Type:  eapi.PEM_ENTITY_SECRET,
+		Bytes: rv.DER,
+	}
+	stringhash := base64.URLEncoding.EncodeToString(rv.EntitySecrets.Entity.Keccak256HI().Multihash())
+	filename := "ent_" + stringhash + ".pem"
+	if c.String("outfile") != "" {
+		filename = c.String("outfile")
+	}
+	err := ioutil.WriteFile(filename, pem.EncodeToMemory(&bl), 0600)
+	if err != nil {
+		fmt.Printf("could not write entity file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote entity: %s\n", filename)
+	return nil
+}
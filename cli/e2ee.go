@@ -0,0 +1,523 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+)
+
+//These commands can write their result to stdout, so messages go to stderr
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, args...)
+	os.Exit(1)
+}
+
+//openInput opens the named file, or stdin if the name is empty or "-"
+func openInput(name string) io.ReadCloser {
+	if name == "" || name == "-" {
+		return os.Stdin
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		fail("could not open %q: %v\n", name, err)
+	}
+	return f
+}
+
+//openOutput creates the named file, or uses stdout if the name is empty
+//or "-"
+func openOutput(name string) io.WriteCloser {
+	if name == "" || name == "-" {
+		return os.Stdout
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		fail("could not create %q: %v\n", name, err)
+	}
+	return f
+}
+
+//pendingOutput is an output file that only replaces the named file when it
+//is committed, so a failed decryption leaves no partial plaintext behind.
+//Standard output is written to directly
+type pendingOutput struct {
+	f    *os.File
+	name string
+}
+
+func openPendingOutput(name string) *pendingOutput {
+	if name == "" || name == "-" {
+		return &pendingOutput{f: os.Stdout}
+	}
+	//The temporary file is in the same directory so the rename is atomic
+	f, err := ioutil.TempFile(filepath.Dir(name), "."+filepath.Base(name)+".tmp")
+	if err != nil {
+		fail("could not create %q: %v\n", name, err)
+	}
+	return &pendingOutput{f: f, name: name}
+}
+
+func (o *pendingOutput) Write(p []byte) (int, error) {
+	return o.f.Write(p)
+}
+
+//Commit moves the output over the named file
+func (o *pendingOutput) Commit() error {
+	if o.name == "" {
+		return nil
+	}
+	if err := o.f.Close(); err != nil {
+		os.Remove(o.f.Name())
+		return err
+	}
+	if err := os.Rename(o.f.Name(), o.name); err != nil {
+		os.Remove(o.f.Name())
+		return err
+	}
+	return nil
+}
+
+//Abort discards the output
+func (o *pendingOutput) Abort() {
+	if o.name == "" {
+		return
+	}
+	o.f.Close()
+	os.Remove(o.f.Name())
+}
+
+//inputArg returns the input file named by the single optional argument
+func inputArg(c *cli.Context) string {
+	if len(c.Args()) > 1 {
+		fail("expected at most one input file\n")
+	}
+	return c.Args().First()
+}
+
+//contentPerspective loads the perspective for a command that may read its
+//content from stdin, where a passphrase prompt cannot be answered
+func contentPerspective(c *cli.Context, input string) *pb.Perspective {
+	if (input == "" || input == "-") && c.String("passphrase") == "" {
+		fail("--passphrase is required when reading from stdin\n")
+	}
+	return getPerspective(c.String("entity"), c.String("passphrase"), "missing entity secrets\n")
+}
+
+//validityWindow returns the window that decryption keys must cover, in
+//ms since the epoch
+func validityWindow(c *cli.Context) (int64, int64) {
+	validFrom := time.Now()
+	if c.String("validfrom") != "" {
+		t, err := time.Parse(time.RFC3339, c.String("validfrom"))
+		if err != nil {
+			fail("bad --validfrom, expected RFC3339: %v\n", err)
+		}
+		validFrom = t
+	}
+	validity, err := ParseDuration(c.String("validity"))
+	if err != nil || validity == nil {
+		fail("bad --validity\n")
+	}
+	return validFrom.UnixNano() / 1e6, validFrom.Add(*validity).UnixNano() / 1e6
+}
+
+//encryptionSubjects resolves the --subject recipients
+func encryptionSubjects(c *cli.Context, conn pb.WAVEClient, perspective *pb.Perspective) []*pb.EncryptionSubject {
+	rv := []*pb.EncryptionSubject{}
+	for _, sub := range c.StringSlice("subject") {
+		hash := resolveEntityNameOrHashOrFile(conn, perspective, sub, "missing subject entity\n")
+		rv = append(rv, &pb.EncryptionSubject{
+			Hash:     hash,
+			Location: entityLocation(conn, hash, "could not find subject location"),
+		})
+	}
+	return rv
+}
+
+//encryptionTargets resolves the given namespace/resource pairs and the
+//--target recipients
+func encryptionTargets(c *cli.Context, conn pb.WAVEClient, perspective *pb.Perspective, targets [][]string, validFrom int64, validUntil int64) []*pb.EncryptionNamespace {
+	for _, t := range c.StringSlice("target") {
+		nsrez := strings.SplitN(t, "/", 2)
+		if len(nsrez) != 2 || nsrez[1] == "" {
+			fail("expected --target of form namespace/resource\n")
+		}
+		targets = append(targets, nsrez)
+	}
+	rv := []*pb.EncryptionNamespace{}
+	for _, t := range targets {
+		ns := resolveEntityNameOrHashOrFile(conn, perspective, t[0], "missing namespace entity\n")
+		rv = append(rv, &pb.EncryptionNamespace{
+			Namespace:         ns,
+			NamespaceLocation: entityLocation(conn, ns, "could not find namespace location"),
+			Resource:          t[1],
+			ValidFrom:         validFrom,
+			ValidUntil:        validUntil,
+		})
+	}
+	return rv
+}
+
+func actionEncrypt(c *cli.Context) error {
+	if len(c.StringSlice("subject")) == 0 && c.String("namespace") == "" && len(c.StringSlice("target")) == 0 {
+		fail("specify a --subject, --namespace or --target to encrypt to\n")
+	}
+	conn := getConn(c)
+	input := inputArg(c)
+	var perspective *pb.Perspective
+	if c.String("entity") != "" {
+		//Only used to resolve names
+		perspective = contentPerspective(c, input)
+	}
+	validFrom, validUntil := validityWindow(c)
+	params := &pb.EncryptMessageParams{
+		Subjects: encryptionSubjects(c, conn, perspective),
+	}
+	targets := [][]string{}
+	if c.String("namespace") != "" {
+		if c.String("resource") == "" {
+			fail("--resource is required when encrypting to a namespace\n")
+		}
+		targets = append(targets, []string{c.String("namespace"), c.String("resource")})
+	}
+	params.Namespaces = encryptionTargets(c, conn, perspective, targets, validFrom, validUntil)
+
+	in := openInput(input)
+	defer in.Close()
+	out := openOutput(c.String("outfile"))
+	defer out.Close()
+	if c.Bool("stream") {
+		encryptStream(conn, params, in, out)
+		return nil
+	}
+	content, err := ioutil.ReadAll(in)
+	if err != nil {
+		fail("could not read input: %v\n", err)
+	}
+	params.Content = content
+	resp, err := conn.EncryptMessage(context.Background(), params)
+	if err != nil {
+		fail("error: %v\n", err)
+	}
+	if resp.Error != nil {
+		fail("error: %s\n", resp.Error.Message)
+	}
+	if _, err := out.Write(resp.Ciphertext); err != nil {
+		fail("could not write output: %v\n", err)
+	}
+	return nil
+}
+
+//encryptStream encrypts the input in segments, so that it does not have to
+//fit in memory
+func encryptStream(conn pb.WAVEClient, params *pb.EncryptMessageParams, in io.Reader, out io.Writer) {
+	stream, err := conn.EncryptStream(context.Background())
+	if err != nil {
+		fail("error: %v\n", err)
+	}
+	err = stream.Send(&pb.EncryptStreamParams{
+		Recipients: params,
+	})
+	if err != nil {
+		fail("error: %v\n", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				done <- nil
+				return
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+			if resp.Error != nil {
+				done <- fmt.Errorf("%s", resp.Error.Message)
+				return
+			}
+			if _, err := out.Write(resp.Ciphertext); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+	buf := make([]byte, 64*1024)
+	for {
+		n, rerr := in.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.EncryptStreamParams{Content: buf[:n]}); err != nil {
+				//The reason is reported by Recv
+				break
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			fail("could not read input: %v\n", rerr)
+		}
+	}
+	stream.CloseSend()
+	if err := <-done; err != nil {
+		fail("error: %v\n", err)
+	}
+}
+
+func actionDecrypt(c *cli.Context) error {
+	input := inputArg(c)
+	conn := getConn(c)
+	perspective := contentPerspective(c, input)
+	in := openInput(input)
+	defer in.Close()
+	br := bufio.NewReader(in)
+	first, err := br.Peek(1)
+	if err != nil {
+		fail("could not read input: %v\n", err)
+	}
+	out := openPendingOutput(c.String("outfile"))
+	abort := func(format string, args ...interface{}) {
+		out.Abort()
+		fail(format, args...)
+	}
+
+	//Streamed messages start with the header length, which begins with a
+	//zero byte. DER encoded messages never do
+	if first[0] != 0 {
+		ciphertext, err := ioutil.ReadAll(br)
+		if err != nil {
+			abort("could not read input: %v\n", err)
+		}
+		resp, err := conn.DecryptMessage(context.Background(), &pb.DecryptMessageParams{
+			Perspective: perspective,
+			Ciphertext:  ciphertext,
+			ResyncFirst: !c.Bool("skipsync"),
+		})
+		if err != nil {
+			abort("error: %v\n", err)
+		}
+		if resp.Error != nil {
+			abort("error: %s\n", resp.Error.Message)
+		}
+		if _, err := out.Write(resp.Content); err != nil {
+			abort("could not write output: %v\n", err)
+		}
+		if err := out.Commit(); err != nil {
+			fail("could not write output: %v\n", err)
+		}
+		return nil
+	}
+
+	stream, err := conn.DecryptStream(context.Background())
+	if err != nil {
+		abort("error: %v\n", err)
+	}
+	err = stream.Send(&pb.DecryptStreamParams{
+		Perspective: perspective,
+		ResyncFirst: !c.Bool("skipsync"),
+	})
+	if err != nil {
+		abort("error: %v\n", err)
+	}
+	go func() {
+		buf := make([]byte, 64*1024)
+		for {
+			n, rerr := br.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&pb.DecryptStreamParams{Ciphertext: buf[:n]}); err != nil {
+					break
+				}
+			}
+			if rerr != nil {
+				break
+			}
+		}
+		stream.CloseSend()
+	}()
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			//The server only ends the stream cleanly after the
+			//authenticated last segment
+			if err := out.Commit(); err != nil {
+				fail("could not write output: %v\n", err)
+			}
+			return nil
+		}
+		if err != nil {
+			abort("error: %v\n", err)
+		}
+		if resp.Error != nil {
+			abort("error: %s\n", resp.Error.Message)
+		}
+		if _, err := out.Write(resp.Content); err != nil {
+			abort("could not write output: %v\n", err)
+		}
+	}
+}
+
+//actionReencrypt decrypts stored messages and encrypts them again for a
+//fresh window or a new namespace, reporting who loses access. Streamed
+//messages stay streamed, but must fit in memory
+func actionReencrypt(c *cli.Context) error {
+	inputs := []string(c.Args())
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+	if len(inputs) > 1 && c.String("outdir") == "" {
+		fail("--outdir is required when re-encrypting more than one file\n")
+	}
+	conn := getConn(c)
+	perspective := contentPerspective(c, inputs[0])
+	validFrom, validUntil := validityWindow(c)
+	params := &pb.ReencryptMessageParams{
+		Perspective: perspective,
+		ResyncFirst: !c.Bool("skipsync"),
+		Subjects:    encryptionSubjects(c, conn, perspective),
+		Namespaces:  encryptionTargets(c, conn, perspective, nil, validFrom, validUntil),
+		ValidFrom:   validFrom,
+		ValidUntil:  validUntil,
+	}
+	if len(params.Subjects) == 0 && len(params.Namespaces) == 0 && c.String("namespace") != "" {
+		params.NewNamespace = resolveEntityNameOrHashOrFile(conn, perspective, c.String("namespace"), "missing namespace entity\n")
+		params.NewNamespaceLocation = entityLocation(conn, params.NewNamespace, "could not find namespace location")
+	} else if c.String("namespace") != "" {
+		fail("--namespace can not be combined with --subject or --target\n")
+	}
+	for _, input := range inputs {
+		in := openInput(input)
+		ciphertext, err := ioutil.ReadAll(in)
+		in.Close()
+		if err != nil {
+			fail("could not read input: %v\n", err)
+		}
+		params.Ciphertext = ciphertext
+		resp, err := conn.ReencryptMessage(context.Background(), params)
+		if err != nil {
+			fail("error: %v\n", err)
+		}
+		if resp.Error != nil {
+			fail("%s: %s\n", input, resp.Error.Message)
+		}
+		//Only sync before the first message
+		params.ResyncFirst = false
+		output := c.String("outfile")
+		if c.String("outdir") != "" {
+			output = filepath.Join(c.String("outdir"), filepath.Base(input))
+		}
+		out := openOutput(output)
+		_, err = out.Write(resp.Ciphertext)
+		out.Close()
+		if err != nil {
+			fail("could not write output: %v\n", err)
+		}
+		for _, r := range resp.LostAccess {
+			fmt.Fprintf(os.Stderr, "%s: %s loses access\n", input, describeMessageRecipient(r, resp.Recipients))
+		}
+		if len(resp.LostAccess) == 0 {
+			fmt.Fprintf(os.Stderr, "%s: no recipients lose access\n", input)
+		}
+	}
+	return nil
+}
+
+//describeMessageRecipient names a recipient that lost access. If the new
+//message is still for the same namespace resource, only the grants that do
+//not cover the new validity window are lost
+func describeMessageRecipient(r *pb.MessageRecipient, recipients []*pb.MessageRecipient) string {
+	if r.Direct {
+		if len(r.Subject) == 0 {
+			return "an unidentified direct recipient"
+		}
+		return "direct recipient " + base64.URLEncoding.EncodeToString(r.Subject)
+	}
+	ns := base64.URLEncoding.EncodeToString(r.Namespace)
+	if r.Resource == "" {
+		return "an unknown resource on namespace " + ns
+	}
+	for _, n := range recipients {
+		if !n.Direct && bytes.Equal(n.Namespace, r.Namespace) && n.Resource == r.Resource {
+			return "decrypt permission holders on " + ns + "/" + r.Resource + " whose grants do not cover the new validity window"
+		}
+	}
+	return "decrypt permission holders on " + ns + "/" + r.Resource
+}
+
+func actionSign(c *cli.Context) error {
+	input := inputArg(c)
+	conn := getConn(c)
+	perspective := contentPerspective(c, input)
+	in := openInput(input)
+	defer in.Close()
+	content, err := ioutil.ReadAll(in)
+	if err != nil {
+		fail("could not read input: %v\n", err)
+	}
+	resp, err := conn.Sign(context.Background(), &pb.SignParams{
+		Perspective: perspective,
+		Content:     content,
+	})
+	if err != nil {
+		fail("error: %v\n", err)
+	}
+	if resp.Error != nil {
+		fail("error: %s\n", resp.Error.Message)
+	}
+	out := openOutput(c.String("outfile"))
+	defer out.Close()
+	if _, err := out.Write(resp.Signature); err != nil {
+		fail("could not write signature: %v\n", err)
+	}
+	return nil
+}
+
+func actionVerifySig(c *cli.Context) error {
+	if c.String("signer") == "" || c.String("signature") == "" {
+		fail("--signer and --signature are required\n")
+	}
+	conn := getConn(c)
+	input := inputArg(c)
+	var perspective *pb.Perspective
+	if c.String("entity") != "" {
+		//Only used to resolve names
+		perspective = contentPerspective(c, input)
+	}
+	signer := resolveEntityNameOrHashOrFile(conn, perspective, c.String("signer"), "missing signer entity\n")
+	signature, err := ioutil.ReadFile(c.String("signature"))
+	if err != nil {
+		fail("could not read signature: %v\n", err)
+	}
+	in := openInput(input)
+	defer in.Close()
+	content, err := ioutil.ReadAll(in)
+	if err != nil {
+		fail("could not read input: %v\n", err)
+	}
+	resp, err := conn.VerifySignature(context.Background(), &pb.VerifySignatureParams{
+		Signer:         signer,
+		SignerLocation: entityLocation(conn, signer, "could not find signer location"),
+		Signature:      signature,
+		Content:        content,
+	})
+	if err != nil {
+		fail("error: %v\n", err)
+	}
+	if resp.Error != nil {
+		fail("signature is not valid: %s\n", resp.Error.Message)
+	}
+	fmt.Fprintf(os.Stderr, "signature is valid\n")
+	return nil
+}
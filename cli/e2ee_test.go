@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/localdb/lls"
+	"github.com/immesys/wave/localdb/poc"
+	"github.com/immesys/wave/storage/memoryserver"
+	"github.com/immesys/wave/storage/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+const testAgent = "127.0.0.1:14410"
+
+var agent *eapi.EAPI
+var inmem pb.Location
+
+func init() {
+	go memoryserver.Main()
+	time.Sleep(100 * time.Millisecond)
+	cfg := make(map[string]map[string]string)
+	cfg["default"] = make(map[string]string)
+	cfg["default"]["provider"] = "http_v1"
+	cfg["default"]["url"] = "http://localhost:8080/v1"
+	inmem.LocationURI = &pb.LocationURI{
+		URI:     "http://localhost:8080/v1",
+		Version: 1,
+	}
+	inmem.AgentLocation = "default"
+	si, err := overlay.NewOverlay(cfg)
+	if err != nil {
+		panic(err)
+	}
+	iapi.InjectStorageInterface(si)
+	tdir, _ := ioutil.TempDir("", "lls")
+	llsdb, err := lls.NewLowLevelStorage(tdir)
+	if err != nil {
+		panic(err)
+	}
+	agent = eapi.NewEAPI(poc.NewPOC(llsdb))
+	agent.StartServer(testAgent, "")
+}
+
+//createTestEntity publishes an entity and writes its secrets to dir. It
+//returns the secrets file and the hash as wv expects it on the command line
+func createTestEntity(t *testing.T, dir string, name string) (string, string) {
+	ctx := context.Background()
+	rv, err := agent.CreateEntity(ctx, &pb.CreateEntityParams{
+		SecretPassphrase: "password",
+	})
+	require.NoError(t, err)
+	require.Nil(t, rv.Error)
+	pub, err := agent.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      rv.PublicDER,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, pub.Error)
+	file := filepath.Join(dir, name+".ent")
+	err = ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{
+		Type:  eapi.PEM_ENTITY_SECRET,
+		Bytes: rv.SecretDER,
+	}), 0600)
+	require.NoError(t, err)
+	return file, base64.URLEncoding.EncodeToString(pub.Hash)
+}
+
+func runWV(t *testing.T, args ...string) {
+	err := newApp().Run(append([]string{"wv", "--agent", testAgent}, args...))
+	require.NoError(t, err)
+}
+
+func TestEncryptReencryptDecrypt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wve2ee")
+	require.NoError(t, err)
+	alice, aliceHash := createTestEntity(t, dir, "alice")
+	bob, bobHash := createTestEntity(t, dir, "bob")
+	content := make([]byte, 200*1024)
+	rand.Read(content)
+	plain := filepath.Join(dir, "plain")
+	require.NoError(t, ioutil.WriteFile(plain, content, 0600))
+
+	for _, stream := range []bool{false, true} {
+		encrypted := filepath.Join(dir, "encrypted")
+		args := []string{"encrypt", "--subject", aliceHash, "-o", encrypted}
+		if stream {
+			args = append(args, "--stream")
+		}
+		runWV(t, append(args, plain)...)
+		ciphertext, err := ioutil.ReadFile(encrypted)
+		require.NoError(t, err)
+		//Streamed messages start with a zero byte, DER never does
+		require.Equal(t, stream, ciphertext[0] == 0)
+
+		reencrypted := filepath.Join(dir, "reencrypted")
+		runWV(t, "reencrypt", "-e", alice, "--passphrase", "password", "--skipsync",
+			"--subject", bobHash, "-o", reencrypted, encrypted)
+		ciphertext, err = ioutil.ReadFile(reencrypted)
+		require.NoError(t, err)
+		require.Equal(t, stream, ciphertext[0] == 0)
+
+		decrypted := filepath.Join(dir, "decrypted")
+		runWV(t, "decrypt", "-e", bob, "--passphrase", "password", "--skipsync",
+			"-o", decrypted, reencrypted)
+		readback, err := ioutil.ReadFile(decrypted)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(content, readback))
+	}
+}
+
+func TestDescribeMessageRecipient(t *testing.T) {
+	ns := []byte("namespace")
+	lost := &pb.MessageRecipient{
+		Namespace: ns,
+		Resource:  "foo/bar",
+	}
+	//Moved to another namespace, every permission holder loses access
+	moved := []*pb.MessageRecipient{
+		{
+			Namespace: []byte("other"),
+			Resource:  "foo/bar",
+		},
+	}
+	require.NotContains(t, describeMessageRecipient(lost, moved), "validity window")
+	//Refreshed on the same namespace, only old grants lose access
+	refreshed := []*pb.MessageRecipient{
+		{
+			Namespace: ns,
+			Resource:  "foo/bar",
+		},
+	}
+	require.Contains(t, describeMessageRecipient(lost, refreshed), "do not cover the new validity window")
+}
+
+func TestPendingOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wvoutput")
+	require.NoError(t, err)
+	name := filepath.Join(dir, "plain")
+	require.NoError(t, ioutil.WriteFile(name, []byte("old"), 0600))
+
+	//An aborted output leaves the existing file alone and nothing else
+	out := openPendingOutput(name)
+	_, err = out.Write([]byte("partial"))
+	require.NoError(t, err)
+	out.Abort()
+	contents, err := ioutil.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, []byte("old"), contents)
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	out = openPendingOutput(name)
+	_, err = out.Write([]byte("new"))
+	require.NoError(t, err)
+	require.NoError(t, out.Commit())
+	contents, err = ioutil.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), contents)
+	files, err = ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestEncryptToNamedSubjectOnStdout(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wve2ee")
+	require.NoError(t, err)
+	alice, _ := createTestEntity(t, dir, "alice")
+	bob, bobHash := createTestEntity(t, dir, "bob")
+	subject, err := base64.URLEncoding.DecodeString(bobHash)
+	require.NoError(t, err)
+	perspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER:        loadEntitySecretDER(alice),
+			Passphrase: []byte("password"),
+		},
+		Location: &inmem,
+	}
+	nd, err := agent.CreateNameDeclaration(ctx, &pb.CreateNameDeclarationParams{
+		Perspective:     perspective,
+		Name:            "bob",
+		Subject:         subject,
+		SubjectLocation: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, nd.Error)
+	srv, err := agent.ResyncPerspectiveGraph(ctx, &pb.ResyncPerspectiveGraphParams{
+		Perspective: perspective,
+	})
+	require.NoError(t, err)
+	require.Nil(t, srv.Error)
+	for {
+		ss, err := agent.SyncStatus(ctx, &pb.SyncParams{
+			Perspective: perspective,
+		})
+		require.NoError(t, err)
+		require.Nil(t, ss.Error)
+		if ss.CompletedSyncs == ss.TotalSyncRequests {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	content := []byte("a message for bob")
+	plain := filepath.Join(dir, "plain")
+	require.NoError(t, ioutil.WriteFile(plain, content, 0600))
+
+	//The ciphertext goes to stdout, so the resolved name must not
+	encrypted := filepath.Join(dir, "encrypted")
+	f, err := os.Create(encrypted)
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = f
+	runWV(t, "encrypt", "-e", alice, "--passphrase", "password", "--subject", "bob", plain)
+	os.Stdout = stdout
+	require.NoError(t, f.Close())
+	ciphertext, err := ioutil.ReadFile(encrypted)
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), "resolved")
+
+	decrypted := filepath.Join(dir, "decrypted")
+	runWV(t, "decrypt", "-e", bob, "--passphrase", "password", "--skipsync",
+		"-o", decrypted, encrypted)
+	readback, err := ioutil.ReadFile(decrypted)
+	require.NoError(t, err)
+	require.Equal(t, content, readback)
+}
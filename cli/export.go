@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+)
+
+func readProof(filename string) []byte {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Printf("could not read proof %q: %v\n", filename, err)
+		os.Exit(1)
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil || block.Type != eapi.PEM_EXPLICIT_PROOF {
+		fmt.Printf("file %q is not a proof\n", filename)
+		os.Exit(1)
+	}
+	return block.Bytes
+}
+
+//actionExportX509 writes the entity as a self-signed X.509 certificate. If
+//a proof is given it also generates a key and writes a short-lived leaf
+//certificate for it that carries the proof
+func actionExportX509(c *cli.Context) error {
+	conn := getConn(c)
+	perspective := getPerspective(c.String("entity"), c.String("passphrase"), "missing entity secrets\n")
+	params := &pb.CreateX509CertificateParams{
+		Perspective: perspective,
+	}
+	var leafKey *ecdsa.PrivateKey
+	if c.String("proof") != "" {
+		validFor, err := ParseDuration(c.String("validity"))
+		if err != nil || validFor == nil {
+			fmt.Printf("bad validity\n")
+			os.Exit(1)
+		}
+		leafKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			fmt.Printf("could not generate key: %v\n", err)
+			os.Exit(1)
+		}
+		pub, err := x509.MarshalPKIXPublicKey(leafKey.Public())
+		if err != nil {
+			fmt.Printf("could not marshal key: %v\n", err)
+			os.Exit(1)
+		}
+		params.ProofDER = readProof(c.String("proof"))
+		params.LeafPublicKey = pub
+		params.ValidFor = validFor.Nanoseconds() / 1e6
+	}
+	resp, err := conn.CreateX509Certificate(context.Background(), params)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+
+	outfilename := fmt.Sprintf("cert_%s.pem", base64.URLEncoding.EncodeToString(entityHash(conn, perspective, "could not inspect entity")))
+	if c.String("outfile") != "" {
+		outfilename = c.String("outfile")
+	}
+	//The leaf comes first so the file can be used as a TLS certificate chain
+	chain := []byte{}
+	if leafKey != nil {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: resp.LeafCertificate,
+		})...)
+	}
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: resp.EntityCertificate,
+	})...)
+	err = ioutil.WriteFile(outfilename, chain, 0644)
+	if err != nil {
+		fmt.Printf("could not write certificate file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote certificate: %s\n", outfilename)
+	if leafKey == nil {
+		return nil
+	}
+
+	keyfilename := strings.TrimSuffix(outfilename, ".pem") + ".key"
+	if c.String("keyout") != "" {
+		keyfilename = c.String("keyout")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(leafKey)
+	if err != nil {
+		fmt.Printf("could not marshal key: %v\n", err)
+		os.Exit(1)
+	}
+	err = ioutil.WriteFile(keyfilename, pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}), 0600)
+	if err != nil {
+		fmt.Printf("could not write key file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote key: %s\n", keyfilename)
+	return nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+//grantSpec is a single attestation in a grant file. Fields that are not
+//given default to the command line flags
+type grantSpec struct {
+	Subject      string   `yaml:"subject"`
+	Statements   []string `yaml:"statements"`
+	Expiry       string   `yaml:"expiry"`
+	Indirections *int     `yaml:"indirections"`
+	Partition    *string  `yaml:"partition"`
+}
+
+//loadGrantFile reads a list of grants from a YAML file:
+//
+//  - subject: alice
+//    statements: ["ns:read,write@ns/building1/*"]
+//    expiry: 90d
+//
+//or a CSV file with a header naming the columns subject, statements,
+//expiry, indirections and partition. In CSV files multiple statements
+//are separated by spaces
+func loadGrantFile(filename string) ([]grantSpec, error) {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(filepath.Ext(filename)) == ".csv" {
+		return parseGrantCSV(string(contents))
+	}
+	rv := []grantSpec{}
+	if err := yaml.UnmarshalStrict(contents, &rv); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func parseGrantCSV(contents string) ([]grantSpec, error) {
+	r := csv.NewReader(strings.NewReader(contents))
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header row")
+	}
+	columns := make(map[string]int)
+	for idx, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "subject", "statements", "expiry", "indirections", "partition":
+			columns[name] = idx
+		default:
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+	if _, ok := columns["subject"]; !ok {
+		return nil, fmt.Errorf("missing subject column")
+	}
+	if _, ok := columns["statements"]; !ok {
+		return nil, fmt.Errorf("missing statements column")
+	}
+	rv := []grantSpec{}
+	for ridx, rec := range records[1:] {
+		field := func(name string) (string, bool) {
+			idx, ok := columns[name]
+			if !ok || rec[idx] == "" {
+				return "", false
+			}
+			return rec[idx], true
+		}
+		spec := grantSpec{}
+		spec.Subject, _ = field("subject")
+		statements, _ := field("statements")
+		spec.Statements = strings.Fields(statements)
+		spec.Expiry, _ = field("expiry")
+		if v, ok := field("indirections"); ok {
+			indirections, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: bad indirections %q", ridx+2, v)
+			}
+			spec.Indirections = &indirections
+		}
+		if v, ok := field("partition"); ok {
+			spec.Partition = &v
+		}
+		rv = append(rv, spec)
+	}
+	return rv, nil
+}
+
+//rtGrantFromFile creates all the attestations in a grant file with a single
+//batch call. Every grant is checked before anything is created, and a
+//failure to create one does not stop the others
+func rtGrantFromFile(c *cli.Context, conn pb.WAVEClient, perspective *pb.Perspective) error {
+	if len(c.Args()) != 0 {
+		fmt.Printf("statements must be given in the grant file\n")
+		os.Exit(1)
+	}
+	if c.String("subject") != "" || c.String("outfile") != "" {
+		fmt.Printf("--subject and --outfile cannot be used with --from-file\n")
+		os.Exit(1)
+	}
+	specs, err := loadGrantFile(c.String("from-file"))
+	if err != nil {
+		fmt.Printf("could not load grant file: %v\n", err)
+		os.Exit(1)
+	}
+	if len(specs) == 0 {
+		fmt.Printf("grant file is empty\n")
+		os.Exit(1)
+	}
+	items := []*pb.CreateAttestationParams{}
+	for idx, spec := range specs {
+		expiry := c.String("expiry")
+		if spec.Expiry != "" {
+			expiry = spec.Expiry
+		}
+		expires, err := ParseDuration(expiry)
+		if err != nil || expires == nil {
+			fmt.Printf("grant %d: bad expiry\n", idx+1)
+			os.Exit(1)
+		}
+		indirections := c.Int("indirections")
+		if spec.Indirections != nil {
+			indirections = *spec.Indirections
+		}
+		partition := c.String("partition")
+		if spec.Partition != nil {
+			partition = *spec.Partition
+		}
+		subject := resolveEntityNameOrHashOrFile(conn, perspective, spec.Subject, fmt.Sprintf("grant %d: missing subject entity", idx+1))
+		subjresp, err := conn.ResolveHash(context.Background(), &pb.ResolveHashParams{
+			Hash: subject,
+		})
+		if err != nil {
+			fmt.Printf("grant %d: could not find subject location: %v\n", idx+1, err)
+			os.Exit(1)
+		}
+		if subjresp.Error != nil {
+			fmt.Printf("grant %d: could not find subject location: %v\n", idx+1, subjresp.Error.Message)
+			os.Exit(1)
+		}
+		items = append(items, &pb.CreateAttestationParams{
+			BodyScheme:      eapi.BodySchemeWaveRef1,
+			SubjectHash:     subject,
+			SubjectLocation: subjresp.Location,
+			ValidFrom:       time.Now().UnixNano() / 1e6,
+			ValidUntil:      time.Now().Add(*expires).UnixNano() / 1e6,
+			Policy: &pb.Policy{
+				RTreePolicy: parseRTreePolicy(conn, perspective, spec.Statements, indirections, partition),
+			},
+			Publish: !c.Bool("nopublish"),
+		})
+	}
+	confirmResolvedNames(c)
+	resp, err := conn.CreateAttestations(context.Background(), &pb.CreateAttestationsParams{
+		Perspective:  perspective,
+		Attestations: items,
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	failed := 0
+	for idx, r := range resp.Results {
+		if r.Error != nil {
+			fmt.Printf("grant %d (%s): error: %s\n", idx+1, specs[idx].Subject, r.Error.Message)
+			failed++
+			continue
+		}
+		bl := pem.Block{
+			Type:  eapi.PEM_ATTESTATION,
+			Bytes: r.DER,
+		}
+		outfilename := fmt.Sprintf("att_%s.pem", base64.URLEncoding.EncodeToString(r.Hash))
+		err = ioutil.WriteFile(outfilename, pem.EncodeToMemory(&bl), 0600)
+		if err != nil {
+			fmt.Printf("grant %d (%s): could not write attestation file: %v\n", idx+1, specs[idx].Subject, err)
+			failed++
+			continue
+		}
+		fmt.Printf("grant %d (%s): wrote attestation: %s\n", idx+1, specs[idx].Subject, outfilename)
+	}
+	if !c.Bool("nopublish") {
+		fmt.Printf("published %d attestations\n", len(specs)-failed)
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d grants failed\n", failed, len(specs))
+		os.Exit(1)
+	}
+	return nil
+}
@@ -9,6 +9,10 @@ import (
 const VersionFlag = "0.4.2"
 
 func main() {
+	newApp().Run(os.Args)
+}
+
+func newApp() *cli.App {
 	app := cli.NewApp()
 	app.Name = "wv"
 	app.Usage = "WAVE command line tool"
@@ -22,10 +26,35 @@ func main() {
 	app.Flags = []cli.Flag{
 		cli.StringFlag{
 			Name:   "agent",
-			Usage:  "set the wave agent",
+			Usage:  "set the wave agent, host:port or unix:///path/to/socket",
 			Value:  "127.0.0.1:410",
 			EnvVar: "WAVE_AGENT",
 		},
+		cli.StringFlag{
+			Name:   "token",
+			Usage:  "the bearer token to present to the agent",
+			EnvVar: "WAVE_AGENT_TOKEN",
+		},
+		cli.BoolFlag{
+			Name:   "agent-tls",
+			Usage:  "connect to the agent using TLS",
+			EnvVar: "WAVE_AGENT_TLS",
+		},
+		cli.StringFlag{
+			Name:   "agent-tls-ca",
+			Usage:  "the CA to verify the agent certificate with (default: system roots)",
+			EnvVar: "WAVE_AGENT_TLS_CA",
+		},
+		cli.StringFlag{
+			Name:   "agent-tls-cert",
+			Usage:  "a client certificate to present to the agent",
+			EnvVar: "WAVE_AGENT_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "agent-tls-key",
+			Usage:  "the key for the client certificate",
+			EnvVar: "WAVE_AGENT_TLS_KEY",
+		},
 	}
 	oflag := cli.StringFlag{
 		Name:  "outfile, o",
@@ -62,6 +91,7 @@ func main() {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:   "attester",
+					Usage:  "the attester, or a revoker named in the attestation",
 					EnvVar: "WAVE_DEFAULT_ENTITY",
 				},
 				cli.StringFlag{
@@ -78,6 +108,45 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:  "revocationlist",
+			Usage: "manage revocation lists",
+			Subcommands: []cli.Command{
+				{
+					Name:      "update",
+					Usage:     "publish a new version of a revocation list",
+					Action:    cli.ActionFunc(actionRevocationListUpdate),
+					ArgsUsage: "[attestation files to revoke...]",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:   "entity, e",
+							Usage:  "the revoker entity secrets",
+							EnvVar: "WAVE_DEFAULT_ENTITY",
+						},
+						cli.StringFlag{
+							Name:  "passphrase",
+							Usage: "the passphrase to use if required",
+						},
+						cli.StringFlag{
+							Name:  "list",
+							Usage: "the name of the revocation list",
+						},
+						cli.StringSliceFlag{
+							Name:  "revoke",
+							Usage: "a revocation ID to add to the list",
+						},
+						cli.StringSliceFlag{
+							Name:  "unrevoke",
+							Usage: "a revocation ID to remove from the list",
+						},
+						cli.StringFlag{
+							Name:  "validity",
+							Usage: "how long until the list must be republished e.g. 1d",
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:    "mkentity",
 			Aliases: []string{"mke"},
@@ -103,6 +172,25 @@ func main() {
 					Name:  "nopublish",
 					Usage: "do not publish the entity",
 				},
+				cli.StringFlag{
+					Name:  "keyscheme",
+					Value: "ed25519",
+					Usage: "the signing key scheme, ed25519 or p256",
+				},
+				cli.StringFlag{
+					Name:  "partitiontiers",
+					Usage: "the WR1 partition tiers for this namespace, coarsest first e.g. 28d,7d,1d (default: weekly tiers). Finer tiers need a shorter --partitionmaxrange",
+				},
+				cli.IntFlag{
+					Name:  "partitionslotsize",
+					Value: 4,
+					Usage: "the size in bytes of each partition time slot",
+				},
+				cli.StringFlag{
+					Name:  "partitionmaxrange",
+					Value: "3y",
+					Usage: "the longest validity range of attestations and messages on this namespace",
+				},
 
 				oflag,
 			},
@@ -153,7 +241,7 @@ func main() {
 				},
 				cli.StringFlag{
 					Name:  "subject",
-					Usage: "the recipient entity hash",
+					Usage: "the recipient entity hash or WAVE name",
 				},
 				cli.StringFlag{
 					Name:  "partition",
@@ -172,10 +260,368 @@ func main() {
 					Name:  "skipsync",
 					Usage: "skip graph sync before granting",
 				},
+				cli.StringFlag{
+					Name:  "from-file",
+					Usage: "create every grant listed in this YAML or CSV file",
+				},
+				cli.StringSliceFlag{
+					Name:  "revocationlist",
+					Usage: "also allow revocation by adding to a revocation list, as list@revoker",
+				},
+				cli.StringSliceFlag{
+					Name:  "revoker",
+					Usage: "also allow this entity to revoke the attestation",
+				},
+				cli.BoolFlag{
+					Name:  "yes, y",
+					Usage: "do not ask to confirm resolved names",
+				},
 				// grant pset:perm,perm,perm@ns/suffix
 				oflag,
 			},
 		},
+		{
+			Name:  "threshold",
+			Usage: "create an RTree attestation that needs co-signatures",
+			Subcommands: []cli.Command{
+				{
+					Name:      "propose",
+					Usage:     "propose a threshold attestation",
+					Action:    cli.ActionFunc(actionThresholdPropose),
+					ArgsUsage: "permset:perm[,perm,perm...]@namespace/resource [permset...]",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:   "expiry, e",
+							Value:  "30d",
+							Usage:  "set the expiry measured from now e.g. 10d5h10s",
+							EnvVar: "WAVE_DEFAULT_EXPIRY",
+						},
+						cli.StringFlag{
+							Name:   "attester",
+							Usage:  "the granting entity secrets",
+							EnvVar: "WAVE_DEFAULT_ENTITY",
+						},
+						cli.StringFlag{
+							Name:  "indirections, indir",
+							Usage: "set how many redelegations is permitted",
+						},
+						cli.StringFlag{
+							Name:  "subject",
+							Usage: "the recipient entity hash or WAVE name",
+						},
+						cli.StringFlag{
+							Name:  "partition",
+							Usage: "the partition that this attestation falls into",
+							Value: "",
+						},
+						cli.StringSliceFlag{
+							Name:  "cosigner",
+							Usage: "an entity that may co-sign, repeat for each co-signer",
+						},
+						cli.IntFlag{
+							Name:  "threshold, m",
+							Value: 2,
+							Usage: "the number of co-signatures required",
+						},
+						cli.StringFlag{
+							Name:  "passphrase",
+							Usage: "the passphrase to use if required",
+						},
+						cli.BoolFlag{
+							Name:  "skipsync",
+							Usage: "skip graph sync before proposing",
+						},
+						cli.BoolFlag{
+							Name:  "yes, y",
+							Usage: "do not ask to confirm resolved names",
+						},
+						oflag,
+					},
+				},
+				{
+					Name:      "cosign",
+					Usage:     "add a co-signature to a proposal",
+					Action:    cli.ActionFunc(actionThresholdCoSign),
+					ArgsUsage: "proposal.pem",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:   "cosigner",
+							Usage:  "the co-signing entity secrets",
+							EnvVar: "WAVE_DEFAULT_ENTITY",
+						},
+						cli.StringFlag{
+							Name:  "passphrase",
+							Usage: "the passphrase to use if required",
+						},
+						cli.BoolFlag{
+							Name:  "yes, y",
+							Usage: "do not ask for confirmation",
+						},
+						cli.BoolFlag{
+							Name:  "skipsync",
+							Usage: "skip graph sync before co-signing",
+						},
+						cli.StringFlag{
+							Name:  "outfile, o",
+							Usage: "write to this file instead of replacing the proposal",
+						},
+					},
+				},
+				{
+					Name:      "finalize",
+					Usage:     "create the attestation from co-signed proposals",
+					Action:    cli.ActionFunc(actionThresholdFinalize),
+					ArgsUsage: "proposal.pem [proposal.pem...]",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:   "attester",
+							Usage:  "the granting entity secrets",
+							EnvVar: "WAVE_DEFAULT_ENTITY",
+						},
+						cli.StringFlag{
+							Name:  "passphrase",
+							Usage: "the passphrase to use if required",
+						},
+						cli.BoolFlag{
+							Name:  "nopublish",
+							Usage: "do not publish the attestation",
+						},
+						cli.BoolFlag{
+							Name:  "skipsync",
+							Usage: "skip graph sync before creating",
+						},
+						oflag,
+					},
+				},
+			},
+		},
+		{
+			Name:  "export",
+			Usage: "export wave objects in other formats",
+			Subcommands: []cli.Command{
+				{
+					Name:   "x509",
+					Usage:  "export an entity, and optionally a proof, as X.509 certificates",
+					Action: cli.ActionFunc(actionExportX509),
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:   "entity, e",
+							Usage:  "the entity secrets",
+							EnvVar: "WAVE_DEFAULT_ENTITY",
+						},
+						cli.StringFlag{
+							Name:  "passphrase",
+							Usage: "the passphrase to use if required",
+						},
+						cli.StringFlag{
+							Name:  "proof",
+							Usage: "issue a leaf certificate carrying this proof, the entity must be its subject",
+						},
+						cli.StringFlag{
+							Name:  "validity",
+							Value: "1h",
+							Usage: "how long the leaf certificate is valid for, capped at the proof expiry",
+						},
+						cli.StringFlag{
+							Name:  "keyout",
+							Usage: "save the leaf private key to this file",
+						},
+						oflag,
+					},
+				},
+			},
+		},
+		{
+			Name:      "encrypt",
+			Usage:     "encrypt a file (or stdin) to entities and namespace resources",
+			Action:    cli.ActionFunc(actionEncrypt),
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "subject",
+					Usage: "an entity that can decrypt the message, repeat for each entity",
+				},
+				cli.StringSliceFlag{
+					Name:  "target",
+					Usage: "a namespace/resource whose decrypt permission holders can decrypt the message, repeat for each resource",
+				},
+				cli.StringFlag{
+					Name:  "namespace",
+					Usage: "the namespace of the resource that grants decryption",
+				},
+				cli.StringFlag{
+					Name:  "resource",
+					Usage: "the resource, holders of decrypt permission on it can decrypt the message",
+				},
+				cli.StringFlag{
+					Name:  "validfrom",
+					Usage: "the start of the window that decryption keys must cover, RFC3339 (default: now)",
+				},
+				cli.StringFlag{
+					Name:  "validity",
+					Value: "30d",
+					Usage: "the length of the window that decryption keys must cover",
+				},
+				cli.BoolFlag{
+					Name:  "stream",
+					Usage: "encrypt in segments so the input does not have to fit in memory, the output is a stream rather than a WaveEncryptedMessage",
+				},
+				cli.StringFlag{
+					Name:  "entity, e",
+					Usage: "entity secrets used to resolve names",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				oflag,
+			},
+		},
+		{
+			Name:      "decrypt",
+			Usage:     "decrypt a file (or stdin)",
+			Action:    cli.ActionFunc(actionDecrypt),
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "entity, e",
+					Usage:  "the decrypting entity secrets",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				cli.BoolFlag{
+					Name:  "skipsync",
+					Usage: "skip graph sync before decrypting",
+				},
+				oflag,
+			},
+		},
+		{
+			Name:      "reencrypt",
+			Usage:     "decrypt messages and encrypt them again for a fresh window or a new namespace, reporting who loses access",
+			Action:    cli.ActionFunc(actionReencrypt),
+			ArgsUsage: "[files...]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "entity, e",
+					Usage:  "the decrypting entity secrets",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				cli.StringFlag{
+					Name:  "namespace",
+					Usage: "move the namespace recipients to this namespace (default: keep their namespace)",
+				},
+				cli.StringSliceFlag{
+					Name:  "subject",
+					Usage: "encrypt to this entity instead of the original recipients, repeat for each entity",
+				},
+				cli.StringSliceFlag{
+					Name:  "target",
+					Usage: "encrypt to this namespace/resource instead of the original recipients, repeat for each resource",
+				},
+				cli.StringFlag{
+					Name:  "validfrom",
+					Usage: "the start of the new window that decryption keys must cover, RFC3339 (default: now)",
+				},
+				cli.StringFlag{
+					Name:  "validity",
+					Value: "30d",
+					Usage: "the length of the new window that decryption keys must cover",
+				},
+				cli.StringFlag{
+					Name:  "outdir",
+					Usage: "write each re-encrypted file to this directory under its own name",
+				},
+				cli.BoolFlag{
+					Name:  "skipsync",
+					Usage: "skip graph sync before decrypting",
+				},
+				oflag,
+			},
+		},
+		{
+			Name:      "sign",
+			Usage:     "sign a file (or stdin)",
+			Action:    cli.ActionFunc(actionSign),
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "entity, e",
+					Usage:  "the signing entity secrets",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				oflag,
+			},
+		},
+		{
+			Name:      "verifysig",
+			Usage:     "verify the signature of a file (or stdin)",
+			Action:    cli.ActionFunc(actionVerifySig),
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "signer",
+					Usage: "the entity that signed the content",
+				},
+				cli.StringFlag{
+					Name:  "signature",
+					Usage: "the file holding the signature",
+				},
+				cli.StringFlag{
+					Name:  "entity, e",
+					Usage: "entity secrets used to resolve names",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+			},
+		},
+		{
+			Name:   "ssh-cert",
+			Usage:  "get an SSH certificate from an agent that is an SSH certificate authority",
+			Action: cli.ActionFunc(actionSSHCert),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "entity, e",
+					Usage:  "the entity secrets, the entity must be the subject of the proof",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				cli.StringFlag{
+					Name:  "proof",
+					Usage: "a proof of the certificate authority's SSH permission",
+				},
+				cli.StringFlag{
+					Name:  "key, k",
+					Usage: "the SSH public key to certify (default: ~/.ssh/id_ed25519.pub)",
+				},
+				cli.StringFlag{
+					Name:   "ca",
+					Usage:  "the certificate authority agent, if not the local agent",
+					EnvVar: "WAVE_SSH_CA",
+				},
+				cli.StringFlag{
+					Name:  "validity",
+					Usage: "how long the certificate is valid for, capped at the proof expiry",
+				},
+				oflag,
+			},
+		},
 		{
 			Name:   "publish",
 			Usage:  "send a wave object to a location",
@@ -220,6 +666,119 @@ func main() {
 					Name:  "partition",
 					Usage: "(optional) which partition to encrypt this under",
 				},
+				cli.BoolFlag{
+					Name:  "yes, y",
+					Usage: "do not ask to confirm resolved names",
+				},
+			},
+		},
+		{
+			Name:   "rotate",
+			Usage:  "replace an entity with a successor and re-issue its grants",
+			Action: cli.ActionFunc(actionRotate),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "entity",
+					Usage:  "the entity secrets being replaced",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				cli.StringFlag{
+					Name:  "successor",
+					Usage: "the (published) successor entity secrets",
+				},
+				cli.StringFlag{
+					Name:  "successor-passphrase",
+					Usage: "the successor passphrase to use if required",
+				},
+				cli.StringFlag{
+					Name:  "grace",
+					Value: "30d",
+					Usage: "how long grants to the old entity remain usable by the successor",
+				},
+				cli.BoolFlag{
+					Name:  "skipsync",
+					Usage: "skip graph sync before rotating",
+				},
+			},
+		},
+		{
+			Name:  "backup",
+			Usage: "split an entity secret into shares or recover it from them",
+			Subcommands: []cli.Command{
+				{
+					Name:   "split",
+					Usage:  "split an entity secret into k-of-n shares",
+					Action: cli.ActionFunc(actionBackupSplit),
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:   "entity",
+							Usage:  "the entity secrets to back up",
+							EnvVar: "WAVE_DEFAULT_ENTITY",
+						},
+						cli.StringFlag{
+							Name:  "passphrase",
+							Usage: "the passphrase to use if required",
+						},
+						cli.IntFlag{
+							Name:  "threshold, k",
+							Value: 3,
+							Usage: "the number of shares needed to recover the secret",
+						},
+						cli.IntFlag{
+							Name:  "shares, n",
+							Value: 5,
+							Usage: "the number of shares to create",
+						},
+						cli.StringFlag{
+							Name:  "outdir",
+							Value: ".",
+							Usage: "the directory to write the shares to",
+						},
+					},
+				},
+				{
+					Name:   "recover",
+					Usage:  "recover an entity secret from share files",
+					Action: cli.ActionFunc(actionBackupRecover),
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "outfile, o",
+							Usage: "override the output filename",
+						},
+						cli.BoolFlag{
+							Name:  "nopassphrase",
+							Usage: "do not encrypt the recovered entity secret",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "passwd",
+			Usage:  "change the passphrase of an entity secret file, upgrading its keyring to Argon2id",
+			Action: cli.ActionFunc(actionPasswd),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "entity",
+					Usage:  "the entity secrets to re-encrypt",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the current passphrase to use if required",
+				},
+				cli.BoolFlag{
+					Name:  "nopassphrase",
+					Usage: "store the entity secret unencrypted",
+				},
+				cli.StringFlag{
+					Name:  "outfile, o",
+					Usage: "write to this file instead of replacing the entity file",
+				},
 			},
 		},
 		{
@@ -233,6 +792,55 @@ func main() {
 			Usage:  "verify a proof",
 			Action: cli.ActionFunc(actionVerify),
 		},
+		{
+			Name:   "names",
+			Usage:  "list the names an entity has declared, or the names for an entity",
+			Action: cli.ActionFunc(actionNames),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "perspective",
+					Usage:  "the entity to use as a perspective",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+				cli.StringFlag{
+					Name:  "attester",
+					Usage: "list the names declared by this entity (default the perspective)",
+				},
+				cli.StringFlag{
+					Name:  "subject",
+					Usage: "list the names that point to this entity",
+				},
+				cli.BoolFlag{
+					Name:  "all",
+					Usage: "include expired and revoked names",
+				},
+				cli.BoolFlag{
+					Name:  "skipsync",
+					Usage: "skip graph sync before listing",
+				},
+			},
+		},
+		{
+			Name:      "pin",
+			Usage:     "trust the subject of a name declaration when its name is ambiguous or has changed",
+			ArgsUsage: "<name declaration hash>",
+			Action:    cli.ActionFunc(actionPin),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:   "perspective",
+					Usage:  "the entity to use as a perspective",
+					EnvVar: "WAVE_DEFAULT_ENTITY",
+				},
+				cli.StringFlag{
+					Name:  "passphrase",
+					Usage: "the passphrase to use if required",
+				},
+			},
+		},
 		{
 			Name:   "resolve",
 			Usage:  "print information about a hash/name",
@@ -255,5 +863,5 @@ func main() {
 		},
 	}
 
-	app.Run(os.Args)
+	return app
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+)
+
+//actionNames lists the names an entity has declared, or the names that
+//point to an entity
+func actionNames(c *cli.Context) error {
+	if c.String("attester") != "" && c.String("subject") != "" {
+		fmt.Printf("specify --attester or --subject, not both\n")
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("perspective"), c.String("passphrase"), "missing perspective entity secrets\n")
+	if !c.Bool("skipsync") {
+		syncPerspective(conn, perspective)
+	}
+	params := &pb.ListNameDeclarationsParams{
+		Perspective: perspective,
+		OnlyValid:   !c.Bool("all"),
+	}
+	if c.String("attester") != "" {
+		params.Attester = resolveEntityNameOrHashOrFile(conn, perspective, c.String("attester"), "missing attester entity\n")
+	}
+	if c.String("subject") != "" {
+		params.Subject = resolveEntityNameOrHashOrFile(conn, perspective, c.String("subject"), "missing subject entity\n")
+	}
+	resp, err := conn.ListNameDeclarations(context.Background(), params)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	if len(resp.Results) == 0 {
+		fmt.Printf("no name declarations found\n")
+		return nil
+	}
+	for _, nd := range resp.Results {
+		PrintNameDeclaration(nd, conn, perspective, params.Subject != nil)
+	}
+	return nil
+}
+
+//PrintNameDeclaration prints one line for a name declaration. When
+//listing by subject the attester is the interesting part
+func PrintNameDeclaration(nd *pb.NameDeclaration, c pb.WAVEClient, p *pb.Perspective, showAttester bool) {
+	status := "valid"
+	switch {
+	case nd.Validity == nil:
+		status = "unknown"
+	case nd.Validity.Revoked:
+		status = "revoked"
+	case nd.Validity.Expired:
+		status = "expired"
+	case nd.Validity.NotValidYet:
+		status = "not valid yet"
+	case !nd.Validity.Valid:
+		status = "invalid"
+	}
+	visibility := "public"
+	if len(nd.Namespace) != 0 {
+		visibility = "private"
+	}
+	expires := time.Unix(0, nd.ValidUntil*1e6).Format(time.RFC3339)
+	if showAttester {
+		fmt.Printf("%-20s by %s (%s)  expires %s  %s, %s\n", nd.Name,
+			base64.URLEncoding.EncodeToString(nd.Attester), ReverseName(c, p, nd.Attester),
+			expires, status, visibility)
+		return
+	}
+	fmt.Printf("%-20s -> %s  expires %s  %s, %s\n", nd.Name,
+		base64.URLEncoding.EncodeToString(nd.Subject), expires, status, visibility)
+}
+
+//printNameCandidates lists the declarations that a name could refer to
+//when the lookup was ambiguous or no longer matches the pinned entity
+func printNameCandidates(candidates []*pb.NameDeclaration) {
+	if len(candidates) == 0 {
+		return
+	}
+	fmt.Printf("candidates:\n")
+	for _, nd := range candidates {
+		fmt.Printf(" %s -> %s\n", base64.URLEncoding.EncodeToString(nd.Hash),
+			base64.URLEncoding.EncodeToString(nd.Subject))
+	}
+	fmt.Printf("use \"wv pin <declaration hash>\" to trust one of them\n")
+}
+
+//actionPin makes the perspective trust the subject of the given name
+//declaration for that name from now on
+func actionPin(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		fmt.Printf("expected a single name declaration hash\n")
+		os.Exit(1)
+	}
+	hash, err := base64.URLEncoding.DecodeString(c.Args()[0])
+	if err != nil {
+		fmt.Printf("bad name declaration hash: %q\n", c.Args()[0])
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("perspective"), c.String("passphrase"), "missing perspective entity secrets\n")
+	resp, err := conn.PinName(context.Background(), &pb.PinNameParams{
+		Perspective:     perspective,
+		NameDeclaration: hash,
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	fmt.Printf("pinned name declaration %s\n", c.Args()[0])
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/howeyc/gopass"
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+	"github.com/urfave/cli"
+)
+
+//actionPasswd re-encrypts an entity secret file under a new passphrase
+//using the Argon2id keyring scheme. This is done locally so the secret is
+//never sent to the agent
+func actionPasswd(c *cli.Context) error {
+	filename := c.String("entity")
+	if filename == "" {
+		fmt.Printf("missing entity secrets\n")
+		os.Exit(1)
+	}
+	der := loadEntitySecretDER(filename)
+	var passphrase *string
+	if c.String("passphrase") != "" {
+		pass := c.String("passphrase")
+		passphrase = &pass
+	}
+	_, werr := iapi.ParseEntitySecrets(context.Background(), &iapi.PParseEntitySecrets{
+		DER:        der,
+		Passphrase: passphrase,
+	})
+	if werr != nil && werr.Code() == wve.PassphraseRequired {
+		fmt.Printf("current passphrase for entity secret: ")
+		pass, err := gopass.GetPasswdMasked()
+		if err != nil {
+			fmt.Printf("could not read passphrase: %v\n", err)
+			os.Exit(1)
+		}
+		spass := string(pass)
+		passphrase = &spass
+	}
+	var newPassphrase *string
+	if !c.Bool("nopassphrase") {
+		fmt.Printf("enter a new passphrase (blank to keep the current one): ")
+		pass, err := gopass.GetPasswdMasked()
+		if err != nil {
+			fmt.Printf("could not read passphrase: %v\n", err)
+			os.Exit(1)
+		}
+		if len(pass) == 0 {
+			if passphrase == nil {
+				fmt.Printf("the entity has no current passphrase, use --nopassphrase to keep it unencrypted\n")
+				os.Exit(1)
+			}
+			newPassphrase = passphrase
+		} else {
+			fmt.Printf("repeat the new passphrase: ")
+			again, err := gopass.GetPasswdMasked()
+			if err != nil {
+				fmt.Printf("could not read passphrase: %v\n", err)
+				os.Exit(1)
+			}
+			if string(again) != string(pass) {
+				fmt.Printf("passphrases do not match\n")
+				os.Exit(1)
+			}
+			spass := string(pass)
+			newPassphrase = &spass
+		}
+	}
+	rv, werr := iapi.ReencryptEntitySecrets(context.Background(), &iapi.PReencryptEntitySecrets{
+		DER:           der,
+		Passphrase:    passphrase,
+		NewPassphrase: newPassphrase,
+	})
+	if werr != nil {
+		fmt.Printf("error: %v\n", werr)
+		os.Exit(1)
+	}
+	bl := pem.Block{
+		Type:  eapi.PEM_ENTITY_SECRET,
+		Bytes: rv.DER,
+	}
+	outfile := filename
+	if c.String("outfile") != "" {
+		outfile = c.String("outfile")
+	}
+	//Write to a temporary file first so a failure cannot lose the secret
+	tmp := outfile + ".tmp"
+	err := ioutil.WriteFile(tmp, pem.EncodeToMemory(&bl), 0600)
+	if err != nil {
+		fmt.Printf("could not write entity file: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Rename(tmp, outfile); err != nil {
+		os.Remove(tmp)
+		fmt.Printf("could not write entity file: %v\n", err)
+		os.Exit(1)
+	}
+	if rv.PreviousScheme.Equal(serdes.KeyringAES128_GCM_PBKDF2OID) {
+		fmt.Printf("upgraded keyring from PBKDF2 to Argon2id\n")
+	}
+	fmt.Printf("wrote entity: %s\n", outfile)
+	return nil
+}
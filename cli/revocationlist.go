@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+)
+
+//parseRevocationListReferences resolves list@revoker arguments into
+//references to revocation lists
+func parseRevocationListReferences(conn pb.WAVEClient, perspective *pb.Perspective, refs []string) []*pb.RevocationListReference {
+	rv := []*pb.RevocationListReference{}
+	for _, ref := range refs {
+		parts := strings.SplitN(ref, "@", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			fmt.Printf("bad revocation list %q, expected list@revoker\n", ref)
+			os.Exit(1)
+		}
+		revoker := resolveEntityNameOrHashOrFile(conn, perspective, parts[1], "missing revoker entity")
+		rv = append(rv, &pb.RevocationListReference{
+			Revoker:         revoker,
+			RevokerLocation: entityLocation(conn, revoker, "could not find revoker location"),
+			ListID:          []byte(parts[0]),
+		})
+	}
+	return rv
+}
+
+//parseRevokerReferences resolves entities that can revoke an attestation
+//in addition to the attester
+func parseRevokerReferences(conn pb.WAVEClient, perspective *pb.Perspective, revokers []string) []*pb.RevokerReference {
+	rv := []*pb.RevokerReference{}
+	for _, r := range revokers {
+		revoker := resolveEntityNameOrHashOrFile(conn, perspective, r, "missing revoker entity")
+		rv = append(rv, &pb.RevokerReference{
+			Revoker:         revoker,
+			RevokerLocation: entityLocation(conn, revoker, "could not find revoker location"),
+		})
+	}
+	return rv
+}
+
+//readAttestation reads the DER from a PEM_ATTESTATION file
+func readAttestation(filename string) []byte {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Printf("could not read attestation %q: %v\n", filename, err)
+		os.Exit(1)
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil || block.Type != eapi.PEM_ATTESTATION {
+		fmt.Printf("file %q is not an attestation\n", filename)
+		os.Exit(1)
+	}
+	return block.Bytes
+}
+
+func decodeRevocationIDs(ids []string) [][]byte {
+	rv := [][]byte{}
+	for _, id := range ids {
+		b, err := base64.URLEncoding.DecodeString(id)
+		if err != nil {
+			fmt.Printf("bad revocation ID %q: %v\n", id, err)
+			os.Exit(1)
+		}
+		rv = append(rv, b)
+	}
+	return rv
+}
+
+//actionRevocationListUpdate publishes a new version of a revocation list
+//held by the entity, adding the given attestations to it
+func actionRevocationListUpdate(c *cli.Context) error {
+	if c.String("list") == "" {
+		fmt.Printf("missing list name\n")
+		os.Exit(1)
+	}
+	var nextUpdate int64
+	if c.String("validity") != "" {
+		d, err := ParseDuration(c.String("validity"))
+		if err != nil || d == nil {
+			fmt.Printf("bad validity\n")
+			os.Exit(1)
+		}
+		nextUpdate = time.Now().Add(*d).UnixNano() / 1e6
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("entity"), c.String("passphrase"), "missing revoker entity secrets\n")
+	params := &pb.UpdateRevocationListParams{
+		Perspective: perspective,
+		ListID:      []byte(c.String("list")),
+		Revoke:      decodeRevocationIDs(c.StringSlice("revoke")),
+		Unrevoke:    decodeRevocationIDs(c.StringSlice("unrevoke")),
+		NextUpdate:  nextUpdate,
+	}
+	for _, filename := range c.Args() {
+		params.Attestations = append(params.Attestations, readAttestation(filename))
+	}
+	resp, err := conn.UpdateRevocationList(context.Background(), params)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	fmt.Printf("published revocation list %q version %d with %d revocations\n", c.String("list"), resp.Version, len(resp.Revoked))
+	return nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+)
+
+//entityHash returns the hash of the entity in a perspective
+func entityHash(conn pb.WAVEClient, perspective *pb.Perspective, msg string) []byte {
+	resp, err := conn.Inspect(context.Background(), &pb.InspectParams{
+		Content: perspective.EntitySecret.DER,
+	})
+	if err != nil {
+		fmt.Printf("%s: %v\n", msg, err)
+		os.Exit(1)
+	}
+	if resp.Entity == nil {
+		fmt.Printf("%s: file is not an entity secret\n", msg)
+		os.Exit(1)
+	}
+	return resp.Entity.Hash
+}
+
+//actionRotate names a successor to an entity and re-issues the entity's
+//outgoing grants from the successor. Grants to the old entity remain
+//usable by the successor until the grace window ends
+func actionRotate(c *cli.Context) error {
+	grace, err := ParseDuration(c.String("grace"))
+	if err != nil || grace == nil {
+		fmt.Printf("bad grace window\n")
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	oldPerspective := getPerspective(c.String("entity"), c.String("passphrase"), "missing entity secrets\n")
+	newPerspective := getPerspective(c.String("successor"), c.String("successor-passphrase"), "missing successor entity secrets\n")
+	oldHash := entityHash(conn, oldPerspective, "could not read entity")
+	newHash := entityHash(conn, newPerspective, "could not read successor")
+
+	if !c.Bool("skipsync") {
+		syncPerspective(conn, oldPerspective)
+	}
+
+	newresp, err := conn.ResolveHash(context.Background(), &pb.ResolveHashParams{
+		Hash: newHash,
+	})
+	if err != nil {
+		fmt.Printf("could not find successor location: %v\n", err)
+		os.Exit(1)
+	}
+	if newresp.Error != nil {
+		fmt.Printf("could not find successor location (is it published?): %v\n", newresp.Error.Message)
+		os.Exit(1)
+	}
+
+	sresp, err := conn.CreateEntitySuccession(context.Background(), &pb.CreateEntitySuccessionParams{
+		Perspective:       oldPerspective,
+		Successor:         newHash,
+		SuccessorLocation: newresp.Location,
+		GraceUntil:        time.Now().Add(*grace).UnixNano() / 1e6,
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if sresp.Error != nil {
+		fmt.Printf("error: %v\n", sresp.Error.Message)
+		os.Exit(1)
+	}
+	graceUntil := time.Unix(0, sresp.GraceUntil*1e6)
+	fmt.Printf("published succession %s\n", base64.URLEncoding.EncodeToString(sresp.Hash))
+
+	grants, skipped := rotationGrants(conn, oldPerspective, oldHash)
+	for _, reason := range skipped {
+		fmt.Printf("skipped %s\n", reason)
+	}
+
+	failed := 0
+	if len(grants) > 0 {
+		items := make([]*pb.CreateAttestationParams, len(grants))
+		for idx, a := range grants {
+			items[idx] = &pb.CreateAttestationParams{
+				BodyScheme:      eapi.BodySchemeWaveRef1,
+				SubjectHash:     a.SubjectHash,
+				SubjectLocation: a.SubjectLocation,
+				ValidFrom:       time.Now().UnixNano() / 1e6,
+				ValidUntil:      a.Body.ValidUntil,
+				Policy:          a.Body.Policy,
+				Publish:         true,
+			}
+		}
+		cresp, err := conn.CreateAttestations(context.Background(), &pb.CreateAttestationsParams{
+			Perspective:  newPerspective,
+			Attestations: items,
+			Sync:         !c.Bool("skipsync"),
+		})
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if cresp.Error != nil {
+			fmt.Printf("error: %v\n", cresp.Error.Message)
+			os.Exit(1)
+		}
+		for idx, r := range cresp.Results {
+			old := base64.URLEncoding.EncodeToString(grants[idx].Hash)
+			if r.Error != nil {
+				fmt.Printf("could not re-issue %s: %s\n", old, r.Error.Message)
+				failed++
+				continue
+			}
+			fmt.Printf("re-issued %s as %s\n", old, base64.URLEncoding.EncodeToString(r.Hash))
+		}
+	}
+	fmt.Printf("re-issued %d of %d grants\n", len(grants)-failed, len(grants)+len(skipped))
+	fmt.Printf("grants to the old entity are usable by its successor until %s\n", graceUntil.Format(time.RFC3339))
+	fmt.Printf("they should be re-granted to the successor and the old entity revoked before then\n")
+	if failed > 0 || len(skipped) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+//rotationGrants finds the grants made by the old entity that are still in
+//force. Grants whose policy can not be recovered are returned as reasons
+//instead, so that they can be re-issued by hand
+func rotationGrants(conn pb.WAVEClient, perspective *pb.Perspective, oldHash []byte) ([]*pb.Attestation, []string) {
+	grants := []*pb.Attestation{}
+	skipped := []string{}
+	var cursor []byte
+	for {
+		lresp, err := conn.LookupAttestations(context.Background(), &pb.LookupAttestationsParams{
+			Perspective:    perspective,
+			FromEntity:     oldHash,
+			ExcludeRevoked: true,
+			PageSize:       100,
+			Cursor:         cursor,
+		})
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+		if lresp.Error != nil {
+			fmt.Printf("error: %v\n", lresp.Error.Message)
+			os.Exit(1)
+		}
+		for _, a := range lresp.Results {
+			if a.Validity == nil || !a.Validity.Valid {
+				continue
+			}
+			name := base64.URLEncoding.EncodeToString(a.Hash)
+			if a.Body == nil {
+				a.Body = grantBody(conn, a)
+			}
+			if a.Body == nil {
+				skipped = append(skipped, name+": its body can not be decrypted from this perspective")
+				continue
+			}
+			if a.Body.Policy == nil {
+				skipped = append(skipped, name+": it has no policy")
+				continue
+			}
+			grants = append(grants, a)
+		}
+		if len(lresp.NextCursor) == 0 {
+			break
+		}
+		cursor = lresp.NextCursor
+	}
+	return grants, skipped
+}
+
+//grantBody decrypts the body of a grant with the verifier key the granter
+//kept when creating it. It returns nil if there is no such key
+func grantBody(conn pb.WAVEClient, a *pb.Attestation) *pb.AttestationBody {
+	if len(a.VerifierKey) == 0 && len(a.ProverKey) == 0 {
+		return nil
+	}
+	resp, err := conn.Inspect(context.Background(), &pb.InspectParams{
+		Content:     a.DER,
+		VerifierKey: a.VerifierKey,
+		ProverKey:   a.ProverKey,
+	})
+	if err != nil || resp.Error != nil || resp.Attestation == nil {
+		return nil
+	}
+	return resp.Attestation.Body
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"testing"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGrantBody(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wvrotate")
+	require.NoError(t, err)
+	granter, _ := createTestEntity(t, dir, "granter")
+	_, subjectHash := createTestEntity(t, dir, "subject")
+	subject, err := base64.URLEncoding.DecodeString(subjectHash)
+	require.NoError(t, err)
+	att, err := agent.CreateAttestation(context.Background(), &pb.CreateAttestationParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER:        loadEntitySecretDER(granter),
+				Passphrase: []byte("password"),
+			},
+			Location: &inmem,
+		},
+		BodyScheme:      eapi.BodySchemeWaveRef1,
+		SubjectHash:     subject,
+		SubjectLocation: &inmem,
+		Policy: &pb.Policy{
+			TrustLevelPolicy: &pb.TrustLevelPolicy{
+				Trust: 3,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+
+	grpcconn, err := grpc.Dial(testAgent, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer grpcconn.Close()
+	conn := pb.NewWAVEClient(grpcconn)
+
+	//A grant whose body the perspective could not decrypt is recovered
+	//with the verifier key kept by the granter
+	body := grantBody(conn, &pb.Attestation{
+		DER:         att.DER,
+		Hash:        att.Hash,
+		VerifierKey: att.VerifierKey,
+	})
+	require.NotNil(t, body)
+	require.NotNil(t, body.Policy)
+	require.EqualValues(t, 3, body.Policy.TrustLevelPolicy.Trust)
+
+	//Without the key it can not be, and rotate reports it as skipped
+	body = grantBody(conn, &pb.Attestation{
+		DER:  att.DER,
+		Hash: att.Hash,
+	})
+	require.Nil(t, body)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/immesys/wave/iapi"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh"
+)
+
+//actionSSHCert asks an SSH certificate authority agent for a certificate
+//for an SSH key, using a proof that the entity holds the CA's permission.
+//The local agent signs the request so the entity secrets never leave it
+func actionSSHCert(c *cli.Context) error {
+	if c.String("proof") == "" {
+		fmt.Printf("missing proof, create one with wv rtprove\n")
+		os.Exit(1)
+	}
+	keyfile := c.String("key")
+	if keyfile == "" {
+		keyfile = filepath.Join(os.Getenv("HOME"), ".ssh", "id_ed25519.pub")
+	}
+	pubbytes, err := ioutil.ReadFile(keyfile)
+	if err != nil {
+		fmt.Printf("could not read public key %q: %v\n", keyfile, err)
+		os.Exit(1)
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(pubbytes)
+	if err != nil {
+		fmt.Printf("could not parse public key %q: %v\n", keyfile, err)
+		os.Exit(1)
+	}
+	var validFor int64
+	if c.String("validity") != "" {
+		d, err := ParseDuration(c.String("validity"))
+		if err != nil || d == nil {
+			fmt.Printf("bad validity\n")
+			os.Exit(1)
+		}
+		validFor = d.Nanoseconds() / 1e6
+	}
+
+	conn := getConn(c)
+	perspective := getPerspective(c.String("entity"), c.String("passphrase"), "missing entity secrets\n")
+	sig, err := conn.Sign(context.Background(), &pb.SignParams{
+		Perspective: perspective,
+		Content:     iapi.SSHCertificateRequestContent(pub),
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if sig.Error != nil {
+		fmt.Printf("error: %v\n", sig.Error.Message)
+		os.Exit(1)
+	}
+
+	ca := conn
+	if c.String("ca") != "" {
+		ca = getConnTo(c, c.String("ca"))
+	}
+	resp, err := ca.CreateSSHCertificate(context.Background(), &pb.CreateSSHCertificateParams{
+		ProofDER:  readProof(c.String("proof")),
+		PublicKey: pubbytes,
+		Signature: sig.Signature,
+		ValidFor:  validFor,
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	//ssh looks for the certificate next to the key
+	outfilename := strings.TrimSuffix(keyfile, ".pub") + "-cert.pub"
+	if c.String("outfile") != "" {
+		outfilename = c.String("outfile")
+	}
+	err = ioutil.WriteFile(outfilename, resp.Certificate, 0644)
+	if err != nil {
+		fmt.Printf("could not write certificate file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote certificate: %s\n", outfilename)
+	fmt.Printf("  Principals: %s\n", strings.Join(resp.Principals, ", "))
+	fmt.Printf("     Expires: %s\n", time.Unix(0, resp.ValidBefore*1e6))
+	fmt.Printf("   Signed by: %s", resp.CaPublicKey)
+	return nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/eapi"
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/urfave/cli"
+)
+
+//entityLocation finds where a published entity is stored
+func entityLocation(conn pb.WAVEClient, hash []byte, msg string) *pb.Location {
+	resp, err := conn.ResolveHash(context.Background(), &pb.ResolveHashParams{
+		Hash: hash,
+	})
+	if err != nil {
+		fmt.Printf("%s: %v\n", msg, err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("%s (is it published?): %v\n", msg, resp.Error.Message)
+		os.Exit(1)
+	}
+	return resp.Location
+}
+
+func readThresholdProposal(filename string) []byte {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Printf("could not read proposal %q: %v\n", filename, err)
+		os.Exit(1)
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil || block.Type != eapi.PEM_THRESHOLD_PROPOSAL {
+		fmt.Printf("file %q is not a threshold proposal\n", filename)
+		os.Exit(1)
+	}
+	return block.Bytes
+}
+
+func writeThresholdProposal(filename string, der []byte) {
+	bl := pem.Block{
+		Type:  eapi.PEM_THRESHOLD_PROPOSAL,
+		Bytes: der,
+	}
+	err := ioutil.WriteFile(filename, pem.EncodeToMemory(&bl), 0600)
+	if err != nil {
+		fmt.Printf("could not write proposal file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote proposal: %s\n", filename)
+}
+
+func PrintThresholdProposal(tp *pb.ThresholdProposal, c pb.WAVEClient, p *pb.Perspective) {
+	fmt.Printf("= Threshold proposal\n")
+	fmt.Printf("    Attester: %s\n", base64.URLEncoding.EncodeToString(tp.Attester))
+	fmt.Printf("     Subject: %s\n", base64.URLEncoding.EncodeToString(tp.Subject))
+	if p != nil {
+		fmt.Printf("  Subj. Name: %s\n", ReverseName(c, p, tp.Subject))
+	}
+	fmt.Printf("     Created: %s\n", time.Unix(0, tp.ValidFrom*1e6))
+	fmt.Printf("     Expires: %s\n", time.Unix(0, tp.ValidUntil*1e6))
+	if tp.Policy != nil && tp.Policy.RTreePolicy != nil {
+		rt := tp.Policy.RTreePolicy
+		fmt.Printf("   Namespace: %s\n", base64.URLEncoding.EncodeToString(rt.Namespace))
+		fmt.Printf("  Indirections: %d\n", rt.Indirections)
+		fmt.Printf("  Statements:\n")
+		for idx, st := range rt.Statements {
+			fmt.Printf("  [%02d] Permission set: %s\n", idx, base64.URLEncoding.EncodeToString(st.PermissionSet))
+			fmt.Printf("       Permissions: %s\n", strings.Join(st.Permissions, ", "))
+			fmt.Printf("       Resource: %s\n", st.Resource)
+		}
+	}
+	fmt.Printf("  Threshold: %d of %d\n", tp.Threshold, len(tp.CoSigners))
+	signed := make(map[string]bool)
+	for _, h := range tp.SignedBy {
+		signed[string(h)] = true
+	}
+	for _, h := range tp.CoSigners {
+		mark := " "
+		if signed[string(h)] {
+			mark = "x"
+		}
+		fmt.Printf("   [%s] %s\n", mark, base64.URLEncoding.EncodeToString(h))
+	}
+}
+
+//actionThresholdPropose creates a proposal for an RTree attestation that
+//needs co-signatures before it can be created
+func actionThresholdPropose(c *cli.Context) error {
+	expires, err := ParseDuration(c.String("expiry"))
+	if err != nil {
+		fmt.Printf("bad expiry\n")
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("attester"), c.String("passphrase"), "missing attesting entity secret\n")
+	if !c.Bool("skipsync") {
+		syncPerspective(conn, perspective)
+	}
+	subject := resolveEntityNameOrHashOrFile(conn, perspective, c.String("subject"), "missing subject entity")
+	pol := parseRTreePolicy(conn, perspective, c.Args(), c.Int("indirections"), c.String("partition"))
+	if len(c.StringSlice("cosigner")) == 0 {
+		fmt.Printf("missing co-signers\n")
+		os.Exit(1)
+	}
+	cosigners := []*pb.ThresholdCoSigner{}
+	for _, cs := range c.StringSlice("cosigner") {
+		hash := resolveEntityNameOrHashOrFile(conn, perspective, cs, "missing co-signer entity")
+		cosigners = append(cosigners, &pb.ThresholdCoSigner{
+			Hash:     hash,
+			Location: entityLocation(conn, hash, "could not find co-signer location"),
+		})
+	}
+	confirmResolvedNames(c)
+	resp, err := conn.CreateThresholdProposal(context.Background(), &pb.CreateThresholdProposalParams{
+		Perspective:     perspective,
+		SubjectHash:     subject,
+		SubjectLocation: entityLocation(conn, subject, "could not find subject location"),
+		ValidFrom:       time.Now().UnixNano() / 1e6,
+		ValidUntil:      time.Now().Add(*expires).UnixNano() / 1e6,
+		Policy: &pb.Policy{
+			RTreePolicy: pol,
+		},
+		Threshold: int64(c.Int("threshold")),
+		CoSigners: cosigners,
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	outfilename := fmt.Sprintf("proposal_%s.pem", base64.URLEncoding.EncodeToString(subject))
+	if c.String("outfile") != "" {
+		outfilename = c.String("outfile")
+	}
+	writeThresholdProposal(outfilename, resp.DER)
+	return nil
+}
+
+//actionThresholdCoSign adds a co-signature to a proposal. The agent checks
+//the attester's signature before signing
+func actionThresholdCoSign(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		fmt.Printf("expected a single proposal file\n")
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("cosigner"), c.String("passphrase"), "missing co-signer entity secret\n")
+	if !c.Bool("skipsync") {
+		syncPerspective(conn, perspective)
+	}
+	insp, err := conn.Inspect(context.Background(), &pb.InspectParams{
+		Content: readThresholdProposal(c.Args()[0]),
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if insp.Error != nil {
+		fmt.Printf("error: %v\n", insp.Error.Message)
+		os.Exit(1)
+	}
+	if insp.ThresholdProposal == nil {
+		fmt.Printf("file is not a threshold proposal\n")
+		os.Exit(1)
+	}
+	PrintThresholdProposal(insp.ThresholdProposal, conn, perspective)
+	if !c.Bool("yes") {
+		fmt.Printf("co-sign this proposal? [y/N] ")
+		var answer string
+		fmt.Scanln(&answer)
+		if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+			fmt.Printf("not signed\n")
+			os.Exit(1)
+		}
+	}
+	resp, err := conn.CoSignThresholdProposal(context.Background(), &pb.CoSignThresholdProposalParams{
+		Perspective: perspective,
+		DER:         readThresholdProposal(c.Args()[0]),
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	outfilename := c.Args()[0]
+	if c.String("outfile") != "" {
+		outfilename = c.String("outfile")
+	}
+	writeThresholdProposal(outfilename, resp.DER)
+	return nil
+}
+
+//actionThresholdFinalize creates the attestation from one or more
+//co-signed copies of a proposal
+func actionThresholdFinalize(c *cli.Context) error {
+	if len(c.Args()) == 0 {
+		fmt.Printf("expected co-signed proposal files\n")
+		os.Exit(1)
+	}
+	conn := getConn(c)
+	perspective := getPerspective(c.String("attester"), c.String("passphrase"), "missing attesting entity secret\n")
+	if !c.Bool("skipsync") {
+		syncPerspective(conn, perspective)
+	}
+	proposals := [][]byte{}
+	for _, filename := range c.Args() {
+		proposals = append(proposals, readThresholdProposal(filename))
+	}
+	resp, err := conn.CreateThresholdAttestation(context.Background(), &pb.CreateThresholdAttestationParams{
+		Perspective: perspective,
+		Proposals:   proposals,
+		BodyScheme:  eapi.BodySchemeWaveRef1,
+		Publish:     !c.Bool("nopublish"),
+	})
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+	if resp.Error != nil {
+		fmt.Printf("error: %v\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	bl := pem.Block{
+		Type:  eapi.PEM_ATTESTATION,
+		Bytes: resp.DER,
+	}
+	outfilename := fmt.Sprintf("att_%s.pem", base64.URLEncoding.EncodeToString(resp.Hash))
+	if c.String("outfile") != "" {
+		outfilename = c.String("outfile")
+	}
+	err = ioutil.WriteFile(outfilename, pem.EncodeToMemory(&bl), 0600)
+	if err != nil {
+		fmt.Printf("could not write attestation file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote attestation: %s\n", outfilename)
+	if !c.Bool("nopublish") {
+		fmt.Printf("published attestation\n")
+	}
+	return nil
+}
@@ -0,0 +1,673 @@
+package eapi
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/immesys/wave/iapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//AuditConfig enables the audit log. It is normally loaded from the
+//[audit] section of wave.toml
+type AuditConfig struct {
+	//The file that audit records are appended to
+	File string
+	//Rotate the file when it grows beyond this many megabytes. Zero means
+	//never rotate
+	MaxSizeMB int64
+	//How many rotated files to keep (file.1, file.2 ...)
+	MaxFiles int
+	//Include the hash of the previous record in every record so that
+	//deletion or modification of records can be detected
+	HashChain bool
+}
+
+//Enabled returns true if an audit log should be written
+func (a *AuditConfig) Enabled() bool {
+	return a != nil && a.File != ""
+}
+
+//AuditRecord is a single line of the audit log. It never contains secrets:
+//entity secrets, passphrases, plaintexts and signed content are reduced to
+//hashes or omitted
+type AuditRecord struct {
+	Time        time.Time              `json:"time"`
+	Seq         uint64                 `json:"seq"`
+	Method      string                 `json:"method"`
+	Clients     []string               `json:"clients,omitempty"`
+	Perspective string                 `json:"perspective,omitempty"`
+	Outcome     string                 `json:"outcome"`
+	ErrorCode   int32                  `json:"errorCode,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+	Started     uint64                 `json:"started,omitempty"`
+	Prev        string                 `json:"prev,omitempty"`
+	Hash        string                 `json:"hash,omitempty"`
+}
+
+const (
+	AuditOutcomeStarted = "started"
+	AuditOutcomeOK      = "ok"
+	AuditOutcomeError   = "error"
+	AuditOutcomeDenied  = "denied"
+	AuditOutcomeFailed  = "failed"
+)
+
+//auditFailedTrailer is set on calls that completed but whose outcome
+//could not be written to the audit log
+const auditFailedTrailer = "wave-audit-failed"
+
+//These RPCs are audited in addition to the mutating ones
+var auditedMethods = map[string]bool{
+	"BuildRTreeProof":      true,
+	"VerifyProof":          true,
+	"CreateSSHCertificate": true,
+	"EncryptMessage":       true,
+	"EncryptStream":        true,
+}
+
+//AuditLog is an append only log of agent operations, one JSON object per
+//line
+type AuditLog struct {
+	cfg  *AuditConfig
+	auth *AuthConfig
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+	seq  uint64
+	prev string
+}
+
+//NewAuditLog opens (or continues) the audit log described by cfg. The
+//auth configuration is used to name the clients in records and may be nil
+func NewAuditLog(cfg *AuditConfig, auth *AuthConfig) (*AuditLog, error) {
+	rv := &AuditLog{
+		cfg:  cfg,
+		auth: auth,
+	}
+	if rv.auth == nil {
+		rv.auth = &AuthConfig{}
+	}
+	//Continue the sequence and chain from the last record written
+	last, err := lastAuditRecord(cfg.File)
+	if err == nil && last == nil {
+		//We may have rotated just before stopping
+		last, err = lastAuditRecord(cfg.File + ".1")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		rv.seq = last.Seq
+		rv.prev = last.Hash
+	}
+	if err := rv.open(); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func lastAuditRecord(file string) (*AuditRecord, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var last *AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		rec := AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			//A torn final write, we will append after it
+			continue
+		}
+		last = &rec
+	}
+	return last, scanner.Err()
+}
+
+func (l *AuditLog) open() error {
+	f, err := os.OpenFile(l.cfg.File, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.f = f
+	l.size = st.Size()
+	return nil
+}
+
+//rotate must be called with the mutex held
+func (l *AuditLog) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	for i := l.cfg.MaxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", l.cfg.File, i), fmt.Sprintf("%s.%d", l.cfg.File, i+1))
+	}
+	if l.cfg.MaxFiles > 0 {
+		if err := os.Rename(l.cfg.File, l.cfg.File+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(l.cfg.File); err != nil {
+		return err
+	}
+	return l.open()
+}
+
+//hashRecord computes the chain hash of a record, which covers every field
+//(including the previous hash) except the hash itself
+func hashRecord(rec *AuditRecord) (string, error) {
+	cp := *rec
+	cp.Hash = ""
+	body, err := json.Marshal(&cp)
+	if err != nil {
+		return "", err
+	}
+	dg := sha256.Sum256(body)
+	return hex.EncodeToString(dg[:]), nil
+}
+
+//Write appends a record to the log, filling in the time, sequence number
+//and chain hash
+func (l *AuditLog) Write(rec *AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	rec.Seq = l.seq
+	if rec.Time.IsZero() {
+		rec.Time = time.Now().UTC()
+	}
+	if l.cfg.HashChain {
+		rec.Prev = l.prev
+		h, err := hashRecord(rec)
+		if err != nil {
+			return err
+		}
+		rec.Hash = h
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if l.cfg.MaxSizeMB > 0 && l.size > 0 && l.size+int64(len(line)) > l.cfg.MaxSizeMB*1024*1024 {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if err := l.f.Sync(); err != nil {
+		return err
+	}
+	l.prev = rec.Hash
+	return nil
+}
+
+//Close closes the underlying file
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
+
+//VerifyAuditChain checks the hash chain of a hash chained audit log. prev
+//is the hash of the record preceding the first one in r (the last record
+//of the previous file after rotation), or "" at the start of the log. It
+//returns the hash of the last record
+func VerifyAuditChain(r io.Reader, prev string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		rec := AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return "", fmt.Errorf("line %d: malformed record: %v", lineno, err)
+		}
+		if rec.Prev != prev {
+			return "", fmt.Errorf("line %d: chain broken, a record is missing or was modified", lineno)
+		}
+		h, err := hashRecord(&rec)
+		if err != nil {
+			return "", err
+		}
+		if h != rec.Hash {
+			return "", fmt.Errorf("line %d: record hash mismatch, the record was modified", lineno)
+		}
+		prev = rec.Hash
+	}
+	return prev, scanner.Err()
+}
+
+func auditHash(content []byte) string {
+	dg := sha256.Sum256(content)
+	return hex.EncodeToString(dg[:])
+}
+
+func b64(h []byte) string {
+	if len(h) == 0 {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString(h)
+}
+
+//entityRef is like b64 for fields that may hold a WAVE name instead of a
+//hash, which are logged as given
+func entityRef(h []byte) string {
+	if len(h) != 0 && !iapi.HashSchemeInstanceFromMultihash(h).Supported() {
+		return string(h)
+	}
+	return b64(h)
+}
+
+func auditRecipients(p *pb.EncryptMessageParams) []string {
+	rv := []string{}
+	if len(p.SubjectHash) != 0 {
+		rv = append(rv, entityRef(p.SubjectHash))
+	}
+	if len(p.Namespace) != 0 {
+		rv = append(rv, entityRef(p.Namespace)+"/"+p.Resource)
+	}
+	for _, s := range p.Subjects {
+		rv = append(rv, entityRef(s.Hash))
+	}
+	for _, n := range p.Namespaces {
+		rv = append(rv, entityRef(n.Namespace)+"/"+n.Resource)
+	}
+	return rv
+}
+
+func auditPolicy(p *pb.Policy) interface{} {
+	if p == nil {
+		return nil
+	}
+	if p.TrustLevelPolicy != nil {
+		return map[string]interface{}{"trust": p.TrustLevelPolicy.Trust}
+	}
+	if p.RTreePolicy != nil {
+		return auditRTreePolicy(p.RTreePolicy.Namespace, p.RTreePolicy.Indirections, p.RTreePolicy.Statements)
+	}
+	return nil
+}
+
+func auditRTreePolicy(ns []byte, indirections uint32, statements []*pb.RTreePolicyStatement) interface{} {
+	sts := []string{}
+	for _, st := range statements {
+		sts = append(sts, fmt.Sprintf("%s:%s@%s/%s", b64(st.PermissionSet), strings.Join(st.Permissions, ","), entityRef(ns), st.Resource))
+	}
+	return map[string]interface{}{
+		"namespace":    entityRef(ns),
+		"indirections": indirections,
+		"statements":   sts,
+	}
+}
+
+//auditDetails extracts the non-secret details of a request and its
+//response
+func auditDetails(req interface{}, resp interface{}) map[string]interface{} {
+	d := make(map[string]interface{})
+	switch r := req.(type) {
+	case *pb.CreateEntityParams:
+		d["validFrom"] = r.ValidFrom
+		d["validUntil"] = r.ValidUntil
+		d["encrypted"] = r.SecretPassphrase != ""
+		if rv, ok := resp.(*pb.CreateEntityResponse); ok {
+			d["entity"] = b64(rv.Hash)
+		}
+	case *pb.CreateAttestationParams:
+		d["subject"] = entityRef(r.SubjectHash)
+		d["bodyScheme"] = r.BodyScheme
+		d["validFrom"] = r.ValidFrom
+		d["validUntil"] = r.ValidUntil
+		d["policy"] = auditPolicy(r.Policy)
+		d["publish"] = r.Publish
+		if len(r.Revokers) != 0 {
+			revokers := []string{}
+			for _, ref := range r.Revokers {
+				revokers = append(revokers, b64(ref.Revoker))
+			}
+			d["revokers"] = revokers
+		}
+		if rv, ok := resp.(*pb.CreateAttestationResponse); ok {
+			d["attestation"] = b64(rv.Hash)
+		}
+	case *pb.CreateAttestationsParams:
+		subjects := []string{}
+		for _, a := range r.Attestations {
+			subjects = append(subjects, entityRef(a.GetSubjectHash()))
+		}
+		d["subjects"] = subjects
+		if rv, ok := resp.(*pb.CreateAttestationsResponse); ok {
+			created := []string{}
+			for _, res := range rv.Results {
+				if res != nil && res.Error == nil {
+					created = append(created, b64(res.Hash))
+				}
+			}
+			d["attestations"] = created
+		}
+	case *pb.CreateThresholdProposalParams:
+		d["subject"] = entityRef(r.SubjectHash)
+		d["validFrom"] = r.ValidFrom
+		d["validUntil"] = r.ValidUntil
+		d["policy"] = auditPolicy(r.Policy)
+		d["threshold"] = r.Threshold
+		cosigners := []string{}
+		for _, cs := range r.CoSigners {
+			cosigners = append(cosigners, b64(cs.Hash))
+		}
+		d["coSigners"] = cosigners
+		if rv, ok := resp.(*pb.ThresholdProposalResponse); ok {
+			d["proposalHash"] = auditHash(rv.DER)
+		}
+	case *pb.CoSignThresholdProposalParams:
+		d["proposalHash"] = auditHash(r.DER)
+		if rv, ok := resp.(*pb.ThresholdProposalResponse); ok && rv.Proposal != nil {
+			d["attester"] = b64(rv.Proposal.Attester)
+			d["subject"] = b64(rv.Proposal.Subject)
+			d["policy"] = auditPolicy(rv.Proposal.Policy)
+		}
+	case *pb.CreateThresholdAttestationParams:
+		proposals := []string{}
+		for _, der := range r.Proposals {
+			proposals = append(proposals, auditHash(der))
+		}
+		d["proposalHashes"] = proposals
+		d["bodyScheme"] = r.BodyScheme
+		d["publish"] = r.Publish
+		if rv, ok := resp.(*pb.CreateAttestationResponse); ok {
+			d["attestation"] = b64(rv.Hash)
+		}
+	case *pb.CreateX509CertificateParams:
+		d["proofHash"] = auditHash(r.ProofDER)
+		d["leafKeyHash"] = auditHash(r.LeafPublicKey)
+		d["validFor"] = r.ValidFor
+		if rv, ok := resp.(*pb.CreateX509CertificateResponse); ok {
+			d["entityCertificate"] = auditHash(rv.EntityCertificate)
+			d["leafCertificate"] = auditHash(rv.LeafCertificate)
+		}
+	case *pb.CreateSSHCertificateParams:
+		d["proofHash"] = auditHash(r.ProofDER)
+		d["publicKeyHash"] = auditHash(r.PublicKey)
+		d["validFor"] = r.ValidFor
+		if rv, ok := resp.(*pb.CreateSSHCertificateResponse); ok {
+			d["subject"] = b64(rv.Subject)
+			d["principals"] = rv.Principals
+			d["validBefore"] = rv.ValidBefore
+		}
+	case *pb.UpdateRevocationListParams:
+		d["listID"] = b64(r.ListID)
+		d["revoke"] = len(r.Revoke)
+		d["unrevoke"] = len(r.Unrevoke)
+		attestations := []string{}
+		for _, der := range r.Attestations {
+			attestations = append(attestations, auditHash(der))
+		}
+		d["attestations"] = attestations
+		if rv, ok := resp.(*pb.UpdateRevocationListResponse); ok {
+			d["version"] = rv.Version
+			d["revoked"] = len(rv.Revoked)
+		}
+	case *pb.PrecomputeKeyBundleParams:
+		d["validFrom"] = r.ValidFrom
+		d["validUntil"] = r.ValidUntil
+		d["policy"] = auditPolicy(r.Policy)
+		if rv, ok := resp.(*pb.PrecomputeKeyBundleResponse); ok {
+			d["keys"] = rv.Keys
+			d["generated"] = rv.Generated
+		}
+	case *pb.PublishEntityParams:
+		d["contentHash"] = auditHash(r.DER)
+		if rv, ok := resp.(*pb.PublishEntityResponse); ok {
+			d["entity"] = b64(rv.Hash)
+		}
+	case *pb.PublishAttestationParams:
+		d["contentHash"] = auditHash(r.DER)
+		if rv, ok := resp.(*pb.PublishAttestationResponse); ok {
+			d["attestation"] = b64(rv.Hash)
+		}
+	case *pb.AddAttestationParams:
+		d["contentHash"] = auditHash(r.DER)
+	case *pb.CreateNameDeclarationParams:
+		d["name"] = r.Name
+		d["subject"] = b64(r.Subject)
+		d["namespace"] = b64(r.Namespace)
+		d["validFrom"] = r.ValidFrom
+		d["validUntil"] = r.ValidUntil
+		if rv, ok := resp.(*pb.CreateNameDeclarationResponse); ok {
+			d["nameDeclaration"] = b64(rv.Hash)
+		}
+	case *pb.PinNameParams:
+		d["nameDeclaration"] = b64(r.NameDeclaration)
+	case *pb.CreateEntitySuccessionParams:
+		d["successor"] = b64(r.Successor)
+		d["graceUntil"] = r.GraceUntil
+		if rv, ok := resp.(*pb.CreateEntitySuccessionResponse); ok {
+			d["succession"] = b64(rv.Hash)
+			d["graceUntil"] = rv.GraceUntil
+		}
+	case *pb.MarkEntityInterestingParams:
+		d["entity"] = b64(r.Entity)
+	case *pb.RevokeParams:
+		d["attestation"] = b64(r.AttestationHash)
+		d["nameDeclaration"] = b64(r.NameDeclarationHash)
+		d["revokePerspective"] = r.RevokePerspective
+	case *pb.SignParams:
+		//A hash of the content would let readers of the log confirm
+		//guesses of it
+		d["contentLength"] = len(r.Content)
+	case *pb.EncryptMessageParams:
+		d["contentLength"] = len(r.Content)
+		d["recipients"] = auditRecipients(r)
+	case *pb.EncryptStreamParams:
+		if r.Recipients != nil {
+			d["recipients"] = auditRecipients(r.Recipients)
+		}
+	case *pb.DecryptStreamParams:
+		d["resyncFirst"] = r.ResyncFirst
+	case *pb.DecryptMessageParams:
+		d["ciphertextHash"] = auditHash(r.Ciphertext)
+	case *pb.ReencryptMessageParams:
+		d["ciphertextHash"] = auditHash(r.Ciphertext)
+		d["newNamespace"] = entityRef(r.NewNamespace)
+		if rv, ok := resp.(*pb.ReencryptMessageResponse); ok {
+			d["lostAccess"] = len(rv.LostAccess)
+		}
+	case *pb.BuildRTreeProofParams:
+		d["subject"] = entityRef(r.SubjectHash)
+		d["policy"] = auditRTreePolicy(r.Namespace, 0, r.Statements)
+		if rv, ok := resp.(*pb.BuildRTreeProofResponse); ok && rv.Result != nil {
+			d["expiry"] = rv.Result.Expiry
+			d["attestations"] = len(rv.Result.Elements)
+			d["proofHash"] = auditHash(rv.ProofDER)
+		}
+	case *pb.VerifyProofParams:
+		d["proofHash"] = auditHash(r.ProofDER)
+		d["requiredSubject"] = b64(r.Subject)
+		if r.RequiredRTreePolicy != nil {
+			d["requiredPolicy"] = auditRTreePolicy(r.RequiredRTreePolicy.Namespace, r.RequiredRTreePolicy.Indirections, r.RequiredRTreePolicy.Statements)
+		}
+		if rv, ok := resp.(*pb.VerifyProofResponse); ok && rv.Result != nil {
+			d["subject"] = b64(rv.Result.Subject)
+			d["policy"] = auditPolicy(rv.Result.Policy)
+			d["expiry"] = rv.Result.Expiry
+		}
+	}
+	//Drop empty values to keep the log readable
+	for k, v := range d {
+		if s, ok := v.(string); ok && s == "" {
+			delete(d, k)
+		}
+	}
+	return d
+}
+
+//Audited returns true if calls to the given method are recorded
+func (l *AuditLog) Audited(method string) bool {
+	return mutatingMethods[method] || auditedMethods[method]
+}
+
+//begin records that a call is about to run. A call that can not be
+//recorded is not run, so nothing happens that the log does not know about
+func (l *AuditLog) begin(ctx context.Context, method string, req interface{}) (uint64, error) {
+	rec := &AuditRecord{
+		Method:  method,
+		Clients: l.auth.principals(ctx),
+		Outcome: AuditOutcomeStarted,
+	}
+	if req != nil {
+		rec.Perspective, _ = perspectiveHash(ctx, req)
+		rec.Details = auditDetails(req, nil)
+	}
+	if err := l.Write(rec); err != nil {
+		fmt.Printf("could not write audit log: %v\n", err)
+		return 0, status.Errorf(codes.Unavailable, "could not write audit log")
+	}
+	return rec.Seq, nil
+}
+
+//finish records the outcome of a call that begin recorded. The call has
+//already happened by now, so if the record can not be written the caller
+//still gets the real result and the failure is flagged in a trailer
+func (l *AuditLog) finish(rec *AuditRecord, started uint64, setTrailer func(metadata.MD)) {
+	rec.Started = started
+	if err := l.Write(rec); err != nil {
+		fmt.Printf("could not write audit log: %v\n", err)
+		setTrailer(metadata.Pairs(auditFailedTrailer, err.Error()))
+	}
+}
+
+func (l *AuditLog) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	if !l.Audited(method) {
+		return handler(ctx, req)
+	}
+	started, err := l.begin(ctx, method, req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	var perr *pb.Error
+	if er, ok := resp.(interface {
+		GetError() *pb.Error
+	}); ok {
+		perr = er.GetError()
+	}
+	rec := l.newRecord(ctx, method, req, auditDetails(req, resp), perr, err)
+	l.finish(rec, started, func(md metadata.MD) {
+		grpc.SetTrailer(ctx, md)
+	})
+	return resp, err
+}
+
+//newRecord describes the outcome of a call. perr is the error returned in
+//the response and err the error returned by the handler
+func (l *AuditLog) newRecord(ctx context.Context, method string, req interface{}, details map[string]interface{}, perr *pb.Error, err error) *AuditRecord {
+	rec := &AuditRecord{
+		Method:  method,
+		Clients: l.auth.principals(ctx),
+		Outcome: AuditOutcomeOK,
+		Details: details,
+	}
+	rec.Perspective, _ = perspectiveHash(ctx, req)
+	if err != nil {
+		rec.Outcome = AuditOutcomeFailed
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.PermissionDenied:
+			rec.Outcome = AuditOutcomeDenied
+		}
+		rec.Error = err.Error()
+	} else if perr != nil {
+		rec.Outcome = AuditOutcomeError
+		rec.ErrorCode = perr.Code
+		rec.Error = perr.Message
+	}
+	return rec
+}
+
+//auditedStream remembers the first message received on a stream, which
+//carries the parameters, and the first error sent back
+type auditedStream struct {
+	grpc.ServerStream
+	first    interface{}
+	received int
+	sent     int
+	perr     *pb.Error
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.first == nil {
+		s.first = m
+	}
+	s.received++
+	return nil
+}
+
+func (s *auditedStream) SendMsg(m interface{}) error {
+	if er, ok := m.(interface {
+		GetError() *pb.Error
+	}); ok && er.GetError() != nil && s.perr == nil {
+		s.perr = er.GetError()
+	}
+	s.sent++
+	return s.ServerStream.SendMsg(m)
+}
+
+//streamInterceptor records a streamed call once it has finished. The
+//parameters only arrive with the first message, so the record made before
+//the call names just the method and the clients
+func (l *AuditLog) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	if !l.Audited(method) {
+		return handler(srv, ss)
+	}
+	started, err := l.begin(ss.Context(), method, nil)
+	if err != nil {
+		return err
+	}
+	as := &auditedStream{ServerStream: ss}
+	err = handler(srv, as)
+	req := as.first
+	if esp, ok := req.(*pb.EncryptStreamParams); ok && esp.Recipients != nil {
+		//The perspective is inside the recipients
+		req = esp.Recipients
+	}
+	details := auditDetails(as.first, nil)
+	details["received"] = as.received
+	details["sent"] = as.sent
+	rec := l.newRecord(ss.Context(), method, req, details, as.perr, err)
+	l.finish(rec, started, ss.SetTrailer)
+	return err
+}
@@ -0,0 +1,300 @@
+package eapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func tempAuditLog(t *testing.T, cfg *AuditConfig) (*AuditLog, string) {
+	dir, err := ioutil.TempDir("", "waveaudit")
+	require.NoError(t, err)
+	cfg.File = filepath.Join(dir, "audit.log")
+	l, err := NewAuditLog(cfg, nil)
+	require.NoError(t, err)
+	return l, dir
+}
+
+func TestAuditHashChain(t *testing.T) {
+	l, dir := tempAuditLog(t, &AuditConfig{HashChain: true})
+	defer os.RemoveAll(dir)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, l.Write(&AuditRecord{
+			Method:  "Sign",
+			Outcome: AuditOutcomeOK,
+			Details: map[string]interface{}{"n": i},
+		}))
+	}
+	require.NoError(t, l.Close())
+
+	//Reopening continues the chain
+	l, err := NewAuditLog(l.cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, l.Write(&AuditRecord{Method: "Revoke", Outcome: AuditOutcomeOK}))
+	require.NoError(t, l.Close())
+
+	contents, err := ioutil.ReadFile(l.cfg.File)
+	require.NoError(t, err)
+	_, err = VerifyAuditChain(bytes.NewReader(contents), "")
+	require.NoError(t, err)
+
+	//Modifying a record is detected
+	tampered := bytes.Replace(contents, []byte(`"method":"Revoke"`), []byte(`"method":"Sign"`), 1)
+	_, err = VerifyAuditChain(bytes.NewReader(tampered), "")
+	require.Error(t, err)
+
+	//So is removing one
+	lines := bytes.SplitAfter(contents, []byte("\n"))
+	removed := bytes.Join(append(lines[:2:2], lines[3:]...), nil)
+	_, err = VerifyAuditChain(bytes.NewReader(removed), "")
+	require.Error(t, err)
+}
+
+func TestAuditRotation(t *testing.T) {
+	l, dir := tempAuditLog(t, &AuditConfig{HashChain: true, MaxSizeMB: 1, MaxFiles: 2})
+	defer os.RemoveAll(dir)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.Write(&AuditRecord{
+			Method:  "Sign",
+			Outcome: AuditOutcomeOK,
+			Error:   strings.Repeat("x", 300*1024),
+		}))
+	}
+	require.NoError(t, l.Close())
+	_, err := os.Stat(l.cfg.File + ".1")
+	require.NoError(t, err)
+	_, err = os.Stat(l.cfg.File + ".2")
+	require.NoError(t, err)
+	_, err = os.Stat(l.cfg.File + ".3")
+	require.True(t, os.IsNotExist(err))
+
+	//The chain continues across files
+	first, err := os.Open(l.cfg.File + ".1")
+	require.NoError(t, err)
+	defer first.Close()
+	second, err := os.Open(l.cfg.File)
+	require.NoError(t, err)
+	defer second.Close()
+	rec, err := lastAuditRecord(l.cfg.File + ".2")
+	require.NoError(t, err)
+	prev, err := VerifyAuditChain(first, rec.Hash)
+	require.NoError(t, err)
+	_, err = VerifyAuditChain(second, prev)
+	require.NoError(t, err)
+}
+
+func TestAuditNoSecrets(t *testing.T) {
+	_, sec, _ := createAndPublishEntity(t)
+	l, dir := tempAuditLog(t, &AuditConfig{})
+	defer os.RemoveAll(dir)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.WAVE/Sign"}
+	content := []byte("a very private message")
+	req := &pb.SignParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER:        sec,
+				Passphrase: []byte("hunter2"),
+			},
+		},
+		Content: content,
+	}
+	_, err := l.unaryInterceptor(context.Background(), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return eapi.Sign(ctx, req.(*pb.SignParams))
+	})
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	contents, err := ioutil.ReadFile(l.cfg.File)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `"method":"Sign"`)
+	require.Contains(t, string(contents), `"contentLength":22`)
+	require.NotContains(t, string(contents), auditHash(content))
+	require.NotContains(t, string(contents), "hunter2")
+	require.NotContains(t, string(contents), string(content))
+	require.False(t, bytes.Contains(contents, sec[len(sec)/2:len(sec)/2+16]))
+}
+
+func TestAuditEncryptNoContent(t *testing.T) {
+	content := []byte("a very private message")
+	d := auditDetails(&pb.EncryptMessageParams{
+		Subjects: []*pb.EncryptionSubject{
+			{Hash: []byte("subject")},
+		},
+		Content: content,
+	}, nil)
+	require.Equal(t, len(content), d["contentLength"])
+	require.NotNil(t, d["recipients"])
+	require.NotContains(t, d, "contentHash")
+}
+
+func TestAuditOutcomes(t *testing.T) {
+	l, dir := tempAuditLog(t, &AuditConfig{})
+	defer os.RemoveAll(dir)
+	auth := &AuthConfig{
+		Rule: []AuthRule{
+			{
+				Clients:  []string{"uid:1000"},
+				Entities: []string{"*"},
+			},
+		},
+	}
+	interceptor := chainUnary([]grpc.UnaryServerInterceptor{l.unaryInterceptor, auth.unaryInterceptor})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.WAVE/Revoke"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.RevokeResponse{Error: &pb.Error{Code: 1, Message: "nope"}}, nil
+	}
+
+	_, err := interceptor(uidContext(1000), &pb.RevokeParams{}, info, handler)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	auth.Rule[0].Mutate = true
+	_, err = interceptor(uidContext(1000), &pb.RevokeParams{}, info, handler)
+	require.NoError(t, err)
+
+	//Unaudited methods are not recorded
+	_, err = interceptor(uidContext(1000), &pb.ListLocationsParams{}, &grpc.UnaryServerInfo{FullMethod: "/pb.WAVE/ListLocations"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.ListLocationsResponse{}, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	f, err := os.Open(l.cfg.File)
+	require.NoError(t, err)
+	defer f.Close()
+	contents, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(contents), []byte("\n"))
+	require.Equal(t, 4, len(lines))
+	require.Contains(t, string(lines[0]), `"outcome":"started"`)
+	require.Contains(t, string(lines[1]), `"outcome":"denied"`)
+	require.Contains(t, string(lines[1]), `"started":1`)
+	require.Contains(t, string(lines[2]), `"outcome":"started"`)
+	require.Contains(t, string(lines[3]), `"outcome":"error"`)
+	require.Contains(t, string(lines[3]), `"clients":["uid:1000"]`)
+	require.Contains(t, string(lines[3]), `"started":3`)
+}
+
+//fakeTransportStream collects the trailer set on a unary call
+type fakeTransportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *fakeTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestAuditWriteFailure(t *testing.T) {
+	l, dir := tempAuditLog(t, &AuditConfig{})
+	defer os.RemoveAll(dir)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.WAVE/Revoke"}
+	ts := &fakeTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+
+	//If the outcome can not be recorded the completed call still returns
+	//its real response, and the failure is flagged in the trailer
+	resp, err := l.unaryInterceptor(ctx, &pb.RevokeParams{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		require.NoError(t, l.Close())
+		return &pb.RevokeResponse{}, nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.NotEmpty(t, ts.trailer.Get(auditFailedTrailer))
+
+	//If the call can not be recorded before it runs, it does not run
+	ran := false
+	_, err = l.unaryInterceptor(ctx, &pb.RevokeParams{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		ran = true
+		return &pb.RevokeResponse{}, nil
+	})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.False(t, ran)
+
+	contents, err := ioutil.ReadFile(l.cfg.File)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(contents), []byte("\n"))
+	require.Equal(t, 1, len(lines))
+	require.Contains(t, string(lines[0]), `"outcome":"started"`)
+}
+
+//fakeDecryptStream plays back requests to a DecryptStream handler
+type fakeDecryptStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	in   []*pb.DecryptStreamParams
+	sent []*pb.DecryptStreamResponse
+}
+
+func (s *fakeDecryptStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeDecryptStream) RecvMsg(m interface{}) error {
+	if len(s.in) == 0 {
+		return io.EOF
+	}
+	*m.(*pb.DecryptStreamParams) = *s.in[0]
+	s.in = s.in[1:]
+	return nil
+}
+
+func (s *fakeDecryptStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m.(*pb.DecryptStreamResponse))
+	return nil
+}
+
+func TestAuditStream(t *testing.T) {
+	_, sec, _ := createAndPublishEntity(t)
+	l, dir := tempAuditLog(t, &AuditConfig{})
+	defer os.RemoveAll(dir)
+	ss := &fakeDecryptStream{
+		ctx: uidContext(1000),
+		in: []*pb.DecryptStreamParams{
+			{
+				Perspective: &pb.Perspective{
+					EntitySecret: &pb.EntitySecret{
+						DER: sec,
+					},
+				},
+			},
+			{Ciphertext: []byte("a very private message")},
+		},
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/pb.WAVE/DecryptStream"}
+	err := l.streamInterceptor(nil, ss, info, func(srv interface{}, ss grpc.ServerStream) error {
+		for {
+			m := &pb.DecryptStreamParams{}
+			if err := ss.RecvMsg(m); err == io.EOF {
+				break
+			}
+		}
+		return ss.SendMsg(&pb.DecryptStreamResponse{Error: &pb.Error{Code: 1, Message: "nope"}})
+	})
+	require.NoError(t, err)
+	require.Len(t, ss.sent, 1)
+	require.NoError(t, l.Close())
+
+	contents, err := ioutil.ReadFile(l.cfg.File)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(contents), []byte("\n"))
+	require.Equal(t, 2, len(lines))
+	require.Contains(t, string(lines[0]), `"method":"DecryptStream"`)
+	require.Contains(t, string(lines[0]), `"outcome":"started"`)
+	require.Contains(t, string(lines[1]), `"method":"DecryptStream"`)
+	require.Contains(t, string(lines[1]), `"outcome":"error"`)
+	require.Contains(t, string(lines[1]), `"perspective":"`)
+	require.Contains(t, string(lines[1]), `"received":2`)
+	require.NotContains(t, string(contents), "a very private message")
+}
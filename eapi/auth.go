@@ -0,0 +1,289 @@
+package eapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/immesys/wave/iapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+//AuthConfig controls who may talk to the agent and what they may do. It
+//is normally loaded from the [auth] section of wave.toml
+type AuthConfig struct {
+	//Named bearer tokens. A client presenting the token is known as
+	//token:<name>
+	Tokens map[string]string
+	//Origins that the HTTP gateway will answer CORS requests for. If
+	//empty, no CORS headers are sent. "*" allows any origin
+	CORSOrigins []string
+	//If no rules are given, every client may do everything
+	Rule []AuthRule
+
+	tls *certReloader
+}
+
+//The HTTP gateway passes on the common name of its client's certificate
+//in this metadata key. It is only trusted on connections from the gateway
+const gatewayClientHeader = "wave-gateway-client-cert"
+
+//AuthRule grants a set of clients the use of a set of perspective
+//entities
+type AuthRule struct {
+	//Client principals e.g. "uid:1000", "token:deploy", "cert:alice" or "*"
+	Clients []string
+	//Perspective entity hashes (base64) that may be used, or "*"
+	Entities []string
+	//Whether mutating RPCs (creating, publishing, revoking, signing) are
+	//permitted
+	Mutate bool
+}
+
+//Enabled returns true if the configuration restricts access at all
+func (a *AuthConfig) Enabled() bool {
+	return a != nil && len(a.Rule) > 0
+}
+
+//These RPCs create, publish or revoke objects, or use the perspective's
+//private keys on behalf of the caller
+var mutatingMethods = map[string]bool{
+	"CreateEntity":               true,
+	"CreateAttestation":          true,
+	"CreateAttestations":         true,
+	"CreateThresholdProposal":    true,
+	"CoSignThresholdProposal":    true,
+	"CreateThresholdAttestation": true,
+	"CreateX509Certificate":      true,
+	"PublishEntity":              true,
+	"PublishAttestation":         true,
+	"AddAttestation":             true,
+	"CreateNameDeclaration":      true,
+	"PinName":                    true,
+	"CreateEntitySuccession":     true,
+	"MarkEntityInteresting":      true,
+	"Revoke":                     true,
+	"UpdateRevocationList":       true,
+	"PrecomputeKeyBundle":        true,
+	"Sign":                       true,
+	"DecryptMessage":             true,
+	"ReencryptMessage":           true,
+	"DecryptStream":              true,
+}
+
+//UnixPeerInfo is the AuthInfo attached to connections accepted on the
+//unix socket
+type UnixPeerInfo struct {
+	UID int
+	GID int
+	PID int
+}
+
+func (u UnixPeerInfo) AuthType() string {
+	return "unix"
+}
+
+//unixPeerCredentials are transport credentials that perform no
+//handshake but record the peer's uid/gid so that it can be used for
+//authorization
+type unixPeerCredentials struct{}
+
+func (u unixPeerCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+func (u unixPeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	info, err := peerCredentials(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, info, nil
+}
+func (u unixPeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "unix"}
+}
+func (u unixPeerCredentials) Clone() credentials.TransportCredentials {
+	return u
+}
+func (u unixPeerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+//principals returns all the names that the client on the other end of
+//ctx is known by
+func (a *AuthConfig) principals(ctx context.Context) []string {
+	rv := []string{}
+	gateway := false
+	if p, ok := peer.FromContext(ctx); ok && p.AuthInfo != nil {
+		switch ai := p.AuthInfo.(type) {
+		case UnixPeerInfo:
+			rv = append(rv, fmt.Sprintf("uid:%d", ai.UID))
+		case credentials.TLSInfo:
+			//Client certificates have already been verified against the
+			//client CA during the handshake
+			if len(ai.State.PeerCertificates) > 0 {
+				leaf := ai.State.PeerCertificates[0]
+				if a.tls != nil && a.tls.isSelf(leaf.Raw) {
+					//This is our HTTP gateway, which tells us who its
+					//client was
+					gateway = true
+				} else {
+					rv = append(rv, "cert:"+leaf.Subject.CommonName)
+				}
+			}
+		}
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok && gateway {
+		for _, cn := range md.Get(gatewayClientHeader) {
+			rv = append(rv, "cert:"+cn)
+		}
+	}
+	if ok {
+		for _, hdr := range md.Get("authorization") {
+			if !strings.HasPrefix(hdr, "Bearer ") {
+				continue
+			}
+			tok := []byte(strings.TrimPrefix(hdr, "Bearer "))
+			for name, value := range a.Tokens {
+				if subtle.ConstantTimeCompare(tok, []byte(value)) == 1 {
+					rv = append(rv, "token:"+name)
+				}
+			}
+		}
+	}
+	return rv
+}
+
+func (r *AuthRule) matchesClient(principals []string) bool {
+	for _, c := range r.Clients {
+		if c == "*" {
+			return true
+		}
+		for _, p := range principals {
+			if c == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *AuthRule) matchesEntity(hash string) bool {
+	for _, e := range r.Entities {
+		if e == "*" || e == hash {
+			return true
+		}
+	}
+	return false
+}
+
+//perspectiveHash extracts the hash of the perspective entity from the
+//request, if it has one. The public half of the entity is readable without
+//the passphrase so this does not decrypt anything
+func perspectiveHash(ctx context.Context, req interface{}) (string, bool) {
+	pr, ok := req.(interface {
+		GetPerspective() *pb.Perspective
+	})
+	if !ok || pr.GetPerspective() == nil || pr.GetPerspective().EntitySecret == nil {
+		return "", false
+	}
+	der := pr.GetPerspective().EntitySecret.DER
+	pblock, _ := pem.Decode(der)
+	if pblock != nil {
+		der = pblock.Bytes
+	}
+	rv, _ := iapi.ParseEntitySecrets(ctx, &iapi.PParseEntitySecrets{
+		DER: der,
+	})
+	if rv == nil || rv.Entity == nil {
+		//This will fail properly inside the handler
+		return "", true
+	}
+	return base64.URLEncoding.EncodeToString(rv.Entity.Keccak256HI().Multihash()), true
+}
+
+//Authorize checks if the client in ctx may invoke the given method
+//with the given request
+func (a *AuthConfig) Authorize(ctx context.Context, fullMethod string, req interface{}) error {
+	if !a.Enabled() {
+		return nil
+	}
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	principals := a.principals(ctx)
+	mutating := mutatingMethods[method]
+	phash, hasPerspective := perspectiveHash(ctx, req)
+	matchedClient := false
+	for idx := range a.Rule {
+		rule := &a.Rule[idx]
+		if !rule.matchesClient(principals) {
+			continue
+		}
+		matchedClient = true
+		if mutating && !rule.Mutate {
+			continue
+		}
+		if hasPerspective && !rule.matchesEntity(phash) {
+			continue
+		}
+		return nil
+	}
+	if !matchedClient {
+		return status.Errorf(codes.Unauthenticated, "client %v is not permitted to use this agent", principals)
+	}
+	if hasPerspective {
+		return status.Errorf(codes.PermissionDenied, "client %v may not call %s as %s", principals, method, phash)
+	}
+	return status.Errorf(codes.PermissionDenied, "client %v may not call %s", principals, method)
+}
+
+func (a *AuthConfig) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.Authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+//authorizedStream checks every message received on a server stream
+type authorizedStream struct {
+	grpc.ServerStream
+	auth       *AuthConfig
+	fullMethod string
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.auth.Authorize(s.Context(), s.fullMethod, m)
+}
+
+func (a *AuthConfig) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &authorizedStream{
+		ServerStream: ss,
+		auth:         a,
+		fullMethod:   info.FullMethod,
+	})
+}
+
+func (a *AuthConfig) allowsOrigin(origin string) bool {
+	if a == nil {
+		return false
+	}
+	for _, o := range a.CORSOrigins {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	return false
+}
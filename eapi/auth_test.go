@@ -0,0 +1,105 @@
+package eapi
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func uidContext(uid int) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: UnixPeerInfo{UID: uid},
+	})
+}
+
+func TestAuthDisabled(t *testing.T) {
+	var auth *AuthConfig
+	err := auth.Authorize(context.Background(), "/pb.WAVE/Sign", &pb.SignParams{})
+	require.NoError(t, err)
+}
+
+func TestAuthUID(t *testing.T) {
+	_, sec, hash := createAndPublishEntity(t)
+	auth := &AuthConfig{
+		Rule: []AuthRule{
+			{
+				Clients:  []string{"uid:1000"},
+				Entities: []string{base64.URLEncoding.EncodeToString(hash)},
+				Mutate:   true,
+			},
+			{
+				Clients:  []string{"uid:1001"},
+				Entities: []string{"*"},
+			},
+		},
+	}
+	req := &pb.SignParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: sec,
+			},
+		},
+	}
+	require.NoError(t, auth.Authorize(uidContext(1000), "/pb.WAVE/Sign", req))
+
+	//Read only client
+	err := auth.Authorize(uidContext(1001), "/pb.WAVE/Sign", req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, auth.Authorize(uidContext(1001), "/pb.WAVE/ResolveName", &pb.ResolveNameParams{
+		Perspective: req.Perspective,
+	}))
+
+	//Unknown client
+	err = auth.Authorize(uidContext(1002), "/pb.WAVE/ListLocations", &pb.ListLocationsParams{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthEntityRestriction(t *testing.T) {
+	_, _, hash := createAndPublishEntity(t)
+	_, othersec, _ := createAndPublishEntity(t)
+	auth := &AuthConfig{
+		Rule: []AuthRule{
+			{
+				Clients:  []string{"uid:1000"},
+				Entities: []string{base64.URLEncoding.EncodeToString(hash)},
+				Mutate:   true,
+			},
+		},
+	}
+	err := auth.Authorize(uidContext(1000), "/pb.WAVE/Sign", &pb.SignParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: othersec,
+			},
+		},
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthToken(t *testing.T) {
+	auth := &AuthConfig{
+		Tokens: map[string]string{
+			"deploy": "secrettoken",
+		},
+		Rule: []AuthRule{
+			{
+				Clients:  []string{"token:deploy"},
+				Entities: []string{"*"},
+				Mutate:   true,
+			},
+		},
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secrettoken"))
+	require.NoError(t, auth.Authorize(ctx, "/pb.WAVE/CreateEntity", &pb.CreateEntityParams{}))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrongtoken"))
+	err := auth.Authorize(ctx, "/pb.WAVE/CreateEntity", &pb.CreateEntityParams{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -4,7 +4,12 @@ const BodySchemePlaintext = "1.3.6.1.4.1.51157.3.1"
 const BodySchemeWaveRef1 = "1.3.6.1.4.1.51157.3.2"
 const BodySchemePreSharedKey = "1.3.6.1.4.1.51157.3.3"
 
+const KeySchemeEd25519 = "1.3.6.1.4.1.51157.11.1"
+const KeySchemeECDSA_P256 = "1.3.6.1.4.1.51157.11.11"
+
 const PEM_ENTITY_SECRET = "WAVE ENTITY SECRET"
 const PEM_ENTITY = "WAVE ENTITY"
 const PEM_ATTESTATION = "WAVE ATTESTATION"
 const PEM_EXPLICIT_PROOF = "WAVE EXPLICIT PROOF"
+const PEM_ENTITY_SECRET_SHARE = "WAVE ENTITY SECRET SHARE"
+const PEM_THRESHOLD_PROPOSAL = "WAVE THRESHOLD PROPOSAL"
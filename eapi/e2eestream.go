@@ -0,0 +1,158 @@
+package eapi
+
+import (
+	"io"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/wve"
+)
+
+//How much plaintext DecryptStream sends in each message
+const decryptStreamChunkSize = 64 * 1024
+
+//sendWriter sends each write as a message on a stream
+type sendWriter func(b []byte) error
+
+func (w sendWriter) Write(b []byte) (int, error) {
+	if err := w(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+//recvReader reads the content of the messages received on a stream,
+//starting with the content of the first message
+type recvReader struct {
+	recv func() ([]byte, error)
+	buf  []byte
+}
+
+func (r *recvReader) Read(b []byte) (int, error) {
+	for len(r.buf) == 0 {
+		next, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = next
+	}
+	n := copy(b, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (e *EAPI) EncryptStream(srv pb.WAVE_EncryptStreamServer) error {
+	ctx := srv.Context()
+	first, err := srv.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if first.Recipients == nil {
+		return srv.Send(&pb.EncryptStreamResponse{
+			Error: ToError(wve.Err(wve.MissingParameter, "missing recipients")),
+		})
+	}
+	params, werr := e.messageRecipients(ctx, first.Recipients)
+	if werr != nil {
+		return srv.Send(&pb.EncryptStreamResponse{
+			Error: ToError(werr),
+		})
+	}
+	rv, werr := iapi.EncryptStream(ctx, &iapi.PEncryptStream{
+		Subject:           params.Subject,
+		Namespace:         params.Namespace,
+		NamespaceLocation: params.NamespaceLocation,
+		Resource:          params.Resource,
+		ValidAfter:        params.ValidAfter,
+		ValidBefore:       params.ValidBefore,
+		Subjects:          params.Subjects,
+		Targets:           params.Targets,
+		SegmentSize:       int(first.SegmentSize),
+		Output: sendWriter(func(b []byte) error {
+			return srv.Send(&pb.EncryptStreamResponse{
+				Ciphertext: b,
+			})
+		}),
+	})
+	if werr != nil {
+		return srv.Send(&pb.EncryptStreamResponse{
+			Error: ToError(werr),
+		})
+	}
+	msg := first
+	for {
+		if _, err := rv.Writer.Write(msg.Content); err != nil {
+			return err
+		}
+		msg, err = srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return rv.Writer.Close()
+}
+
+func (e *EAPI) DecryptStream(srv pb.WAVE_DecryptStreamServer) error {
+	ctx := srv.Context()
+	first, err := srv.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	secret, dctx, werr := e.messageDecryptor(ctx, first.Perspective, first.ResyncFirst)
+	if werr != nil {
+		return srv.Send(&pb.DecryptStreamResponse{
+			Error: ToError(werr),
+		})
+	}
+	in := &recvReader{
+		buf: first.Ciphertext,
+		recv: func() ([]byte, error) {
+			msg, err := srv.Recv()
+			if err != nil {
+				return nil, err
+			}
+			return msg.Ciphertext, nil
+		},
+	}
+	rv, werr := iapi.DecryptStream(ctx, &iapi.PDecryptStream{
+		Decryptor: secret,
+		Dctx:      dctx,
+		Input:     in,
+	})
+	if werr != nil {
+		return srv.Send(&pb.DecryptStreamResponse{
+			Error: ToError(werr),
+		})
+	}
+	buf := make([]byte, decryptStreamChunkSize)
+	for {
+		n, err := rv.Reader.Read(buf)
+		if n > 0 {
+			if serr := srv.Send(&pb.DecryptStreamResponse{
+				Content: buf[:n],
+			}); serr != nil {
+				return serr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if werr, ok := err.(wve.WVE); ok {
+				return srv.Send(&pb.DecryptStreamResponse{
+					Error: ToError(werr),
+				})
+			}
+			return err
+		}
+	}
+}
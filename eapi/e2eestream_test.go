@@ -0,0 +1,133 @@
+package eapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeEncryptStream struct {
+	grpc.ServerStream
+	in  []*pb.EncryptStreamParams
+	out []*pb.EncryptStreamResponse
+}
+
+func (s *fakeEncryptStream) Context() context.Context {
+	return context.Background()
+}
+func (s *fakeEncryptStream) Send(m *pb.EncryptStreamResponse) error {
+	m.Ciphertext = append([]byte{}, m.Ciphertext...)
+	s.out = append(s.out, m)
+	return nil
+}
+func (s *fakeEncryptStream) Recv() (*pb.EncryptStreamParams, error) {
+	if len(s.in) == 0 {
+		return nil, io.EOF
+	}
+	rv := s.in[0]
+	s.in = s.in[1:]
+	return rv, nil
+}
+
+type fakeDecryptStream struct {
+	grpc.ServerStream
+	in  []*pb.DecryptStreamParams
+	out []*pb.DecryptStreamResponse
+}
+
+func (s *fakeDecryptStream) Context() context.Context {
+	return context.Background()
+}
+func (s *fakeDecryptStream) Send(m *pb.DecryptStreamResponse) error {
+	m.Content = append([]byte{}, m.Content...)
+	s.out = append(s.out, m)
+	return nil
+}
+func (s *fakeDecryptStream) Recv() (*pb.DecryptStreamParams, error) {
+	if len(s.in) == 0 {
+		return nil, io.EOF
+	}
+	rv := s.in[0]
+	s.in = s.in[1:]
+	return rv, nil
+}
+
+func TestEncryptDecryptStream(t *testing.T) {
+	ctx := context.Background()
+	public, secret := createEntity(t)
+	pub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      public,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, pub.Error)
+
+	msg := make([]byte, 300*1024+17)
+	rand.Read(msg)
+	enc := &fakeEncryptStream{}
+	enc.in = append(enc.in, &pb.EncryptStreamParams{
+		Recipients: &pb.EncryptMessageParams{
+			SubjectHash:     pub.Hash,
+			SubjectLocation: &inmem,
+		},
+		SegmentSize: 4096,
+	})
+	for off := 0; off < len(msg); off += 10000 {
+		end := off + 10000
+		if end > len(msg) {
+			end = len(msg)
+		}
+		enc.in = append(enc.in, &pb.EncryptStreamParams{
+			Content: msg[off:end],
+		})
+	}
+	require.NoError(t, eapi.EncryptStream(enc))
+
+	dec := &fakeDecryptStream{}
+	dec.in = append(dec.in, &pb.DecryptStreamParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: secret,
+			},
+			Location: &inmem,
+		},
+	})
+	for _, m := range enc.out {
+		require.Nil(t, m.Error)
+		dec.in = append(dec.in, &pb.DecryptStreamParams{
+			Ciphertext: m.Ciphertext,
+		})
+	}
+	require.NoError(t, eapi.DecryptStream(dec))
+	content := &bytes.Buffer{}
+	for _, m := range dec.out {
+		require.Nil(t, m.Error)
+		content.Write(m.Content)
+	}
+	require.Equal(t, msg, content.Bytes())
+
+	//Dropping the last piece of ciphertext is detected
+	dec.in = dec.in[:0]
+	dec.out = nil
+	dec.in = append(dec.in, &pb.DecryptStreamParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: secret,
+			},
+			Location: &inmem,
+		},
+	})
+	for _, m := range enc.out[:len(enc.out)-1] {
+		dec.in = append(dec.in, &pb.DecryptStreamParams{
+			Ciphertext: m.Ciphertext,
+		})
+	}
+	require.NoError(t, eapi.DecryptStream(dec))
+	require.NotNil(t, dec.out[len(dec.out)-1].Error)
+}
@@ -3,9 +3,13 @@ package eapi
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 	"net"
+	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,13 +21,17 @@ import (
 	"github.com/immesys/wave/serdes"
 	"github.com/immesys/wave/wve"
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type EAPI struct {
 	//engines  map[[32]byte]*engine.Engine
 	npengine  *engine.Engine
-	s         *grpc.Server
+	servers   []*grpc.Server
+	audit     *AuditLog
+	sshca     *sshCA
 	state     iapi.WaveState
 	escache   map[[32]byte]*engine.Engine
 	escachemu sync.RWMutex
@@ -41,16 +49,99 @@ func NewEAPI(state iapi.WaveState) *EAPI {
 	api.npengine = npengine
 	return api
 }
+
+//ServerConfig describes where and how the agent API is served
+type ServerConfig struct {
+	//The TCP address for the gRPC server, may be empty
+	ListenIP string
+	//The TCP address for the HTTP gateway, may be empty. The gateway
+	//connects to the gRPC server on ListenIP
+	HTTPListenIP string
+	//The path of a unix socket for the gRPC server, may be empty
+	ListenUnix string
+	//Client authentication and authorization, may be nil
+	Auth *AuthConfig
+	//TLS for the TCP listeners, may be nil
+	TLS *TLSConfig
+	//The audit log, may be nil
+	Audit *AuditConfig
+	//The SSH certificate authority, may be nil
+	SSH *SSHConfig
+}
+
 func (e *EAPI) StartServer(listenaddr string, httplistenaddr string) {
-	grpcServer := grpc.NewServer()
-	e.s = grpcServer
-	l, err := net.Listen("tcp", listenaddr)
-	if err != nil {
-		panic(err)
+	e.StartServerWithConfig(&ServerConfig{
+		ListenIP:     listenaddr,
+		HTTPListenIP: httplistenaddr,
+	})
+}
+
+func (e *EAPI) StartServerWithConfig(cfg *ServerConfig) {
+	var certs *certReloader
+	if cfg.TLS.Enabled() {
+		var err error
+		certs, err = newCertReloader(cfg.TLS)
+		if err != nil {
+			panic(err)
+		}
+		if cfg.Auth != nil {
+			cfg.Auth.tls = certs
+		}
+	}
+	if cfg.Audit.Enabled() {
+		audit, err := NewAuditLog(cfg.Audit, cfg.Auth)
+		if err != nil {
+			panic(err)
+		}
+		e.audit = audit
+	}
+	if cfg.SSH.Enabled() {
+		ca, err := newSSHCA(cfg.SSH)
+		if err != nil {
+			panic(err)
+		}
+		e.sshca = ca
+	}
+	if cfg.ListenIP != "" {
+		opts := e.serverOptions(cfg)
+		if certs != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(certs.serverConfig())))
+		}
+		grpcServer := grpc.NewServer(opts...)
+		e.servers = append(e.servers, grpcServer)
+		l, err := net.Listen("tcp", cfg.ListenIP)
+		if err != nil {
+			panic(err)
+		}
+		pb.RegisterWAVEServer(grpcServer, e)
+		go grpcServer.Serve(l)
+		if cfg.HTTPListenIP != "" {
+			go runHTTPserver(cfg.ListenIP, cfg.HTTPListenIP, cfg.Auth, certs)
+		}
+	}
+	if cfg.ListenUnix != "" {
+		opts := append([]grpc.ServerOption{grpc.Creds(unixPeerCredentials{})}, e.serverOptions(cfg)...)
+		grpcServer := grpc.NewServer(opts...)
+		e.servers = append(e.servers, grpcServer)
+		//Remove a stale socket from a previous run
+		if err := os.Remove(cfg.ListenUnix); err != nil && !os.IsNotExist(err) {
+			panic(err)
+		}
+		l, err := net.Listen("unix", cfg.ListenUnix)
+		if err != nil {
+			panic(err)
+		}
+		//Without rules there is no uid check, so only our own user may connect
+		mode := os.FileMode(0600)
+		if cfg.Auth.Enabled() {
+			mode = 0666
+		}
+		if err := os.Chmod(cfg.ListenUnix, mode); err != nil {
+			panic(err)
+		}
+		pb.RegisterWAVEServer(grpcServer, e)
+		go grpcServer.Serve(l)
 	}
-	pb.RegisterWAVEServer(grpcServer, e)
-	go grpcServer.Serve(l)
-	go runHTTPserver(listenaddr, httplistenaddr)
 }
 func (e *EAPI) GetEngine(ctx context.Context, in *pb.Perspective) (*engine.Engine, wve.WVE) {
 	if in == nil {
@@ -123,6 +214,25 @@ func (e *EAPI) Inspect(ctx context.Context, p *pb.InspectParams) (*pb.InspectRes
 			Entity: ConvertEntityWVal(es.Entity, validity),
 		}, nil
 	}
+	//Try as threshold proposal
+	tprv, err := iapi.ParseThresholdProposal(ctx, &iapi.PParseThresholdProposal{
+		DER: der,
+	})
+	if err == nil {
+		tp := tprv.Proposal
+		attloc := iapi.LocationSchemeInstanceFor(&tp.CanonicalForm.TBS.AttesterLocation)
+		attester, _, uerr := eng.LookupEntity(ctx, tp.Attester, attloc)
+		if uerr == nil && attester != nil {
+			if werr := tp.VerifyAttester(ctx, attester); werr != nil {
+				return &pb.InspectResponse{
+					Error: ToError(werr),
+				}, nil
+			}
+		}
+		return &pb.InspectResponse{
+			ThresholdProposal: ToPbThresholdProposal(tp),
+		}, nil
+	}
 	//Try as attestation
 	kpdctx := iapi.NewKeyPoolDecryptionContext()
 	if p.ProverKey != nil {
@@ -195,13 +305,37 @@ func (e *EAPI) CreateEntity(ctx context.Context, p *pb.CreateEntityParams) (*pb.
 	if p.SecretPassphrase != "" {
 		params.Passphrase = iapi.String(p.SecretPassphrase)
 	}
+	switch p.KeyScheme {
+	case "", KeySchemeEd25519:
+	case KeySchemeECDSA_P256:
+		params.SigningScheme = serdes.EntityECDSA_P256OID
+	default:
+		return &pb.CreateEntityResponse{
+			Error: ToError(wve.Err(wve.UnsupportedKeyScheme, "unknown key scheme")),
+		}, nil
+	}
+	if p.PartitionSchedule != nil {
+		schedule, werr := iapi.NewWR1PartitionSchedule(&serdes.WR1PartitionSchedule{
+			Tiers:    p.PartitionSchedule.Tiers,
+			SlotSize: int(p.PartitionSchedule.SlotSize),
+			MaxRange: p.PartitionSchedule.MaxRange,
+		})
+		if werr != nil {
+			return &pb.CreateEntityResponse{
+				Error: ToError(werr),
+			}, nil
+		}
+		params.PartitionSchedule = schedule
+	}
 	if params.CommitmentRevocationLocation != nil && !params.CommitmentRevocationLocation.Supported() {
 		panic("unsupported location")
 		//actually the IAPI functions should test the parameters better
 	}
 	resp, err := iapi.NewEntity(ctx, params)
 	if err != nil {
-		panic(err)
+		return &pb.CreateEntityResponse{
+			Error: ToError(err),
+		}, nil
 	}
 	hi := iapi.KECCAK256.Instance(resp.PublicDER)
 	return &pb.CreateEntityResponse{
@@ -217,13 +351,21 @@ func (e *EAPI) CreateAttestation(ctx context.Context, p *pb.CreateAttestationPar
 			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
 		}, nil
 	}
-	subHash := iapi.HashSchemeInstanceFromMultihash(p.SubjectHash)
+	subHash, namedLoc, err := resolveEntityRef(ctx, eng, p.SubjectHash, "subject")
+	if err != nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(err),
+		}, nil
+	}
 	subLoc, err := LocationSchemeInstance(p.SubjectLocation)
 	if err != nil {
 		return &pb.CreateAttestationResponse{
 			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
 		}, nil
 	}
+	if subLoc == nil {
+		subLoc = namedLoc
+	}
 	if subLoc == nil {
 		subLoc = iapi.SI().DefaultLocation(ctx)
 	}
@@ -251,7 +393,19 @@ func (e *EAPI) CreateAttestation(ctx context.Context, p *pb.CreateAttestationPar
 	}
 	dctx := engine.NewEngineDecryptionContext(eng)
 	dctx.AutoLoadPartitionSecrets(true)
-	pol, err := e.ConvertPolicy(p.Policy)
+	pbpol, err := resolvePolicyNames(ctx, eng, p.Policy)
+	if err != nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	pol, err := e.ConvertPolicy(pbpol)
+	if err != nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	revocations, err := revocationOptions(ctx, p.RevocationLists, p.Revokers)
 	if err != nil {
 		return &pb.CreateAttestationResponse{
 			Error: ToError(err),
@@ -268,6 +422,7 @@ func (e *EAPI) CreateAttestation(ctx context.Context, p *pb.CreateAttestationPar
 		SubjectLocation:   subLoc,
 		ValidFrom:         TimeFromInt64MillisWithDefault(p.ValidFrom, time.Now()),
 		ValidUntil:        TimeFromInt64MillisWithDefault(p.ValidUntil, time.Now().Add(30*24*time.Hour)),
+		Revocations:       revocations,
 	}
 	if params.BodyScheme == nil {
 		return &pb.CreateAttestationResponse{
@@ -317,6 +472,101 @@ func (e *EAPI) CreateAttestation(ctx context.Context, p *pb.CreateAttestationPar
 		Hash:        hi.Multihash(),
 	}, nil
 }
+
+//revocationOptions creates a revocation option, with a new ID, for each
+//referenced revocation list and delegated revoker
+func revocationOptions(ctx context.Context, lists []*pb.RevocationListReference, revokers []*pb.RevokerReference) ([]iapi.RevocationSchemeInstance, wve.WVE) {
+	rv := []iapi.RevocationSchemeInstance{}
+	revokerDetails := func(hash []byte, pbloc *pb.Location) (iapi.HashSchemeInstance, iapi.LocationSchemeInstance, wve.WVE) {
+		revoker := iapi.HashSchemeInstanceFromMultihash(hash)
+		if !revoker.Supported() {
+			return nil, nil, wve.Err(wve.InvalidParameter, "bad revoker hash")
+		}
+		loc, err := LocationSchemeInstance(pbloc)
+		if err != nil {
+			return nil, nil, wve.ErrW(wve.InvalidParameter, "could not parse revoker location", err)
+		}
+		if loc == nil {
+			loc = iapi.SI().DefaultLocation(ctx)
+		}
+		return revoker, loc, nil
+	}
+	for _, ref := range lists {
+		revoker, loc, werr := revokerDetails(ref.Revoker, ref.RevokerLocation)
+		if werr != nil {
+			return nil, werr
+		}
+		if len(ref.ListID) == 0 {
+			return nil, wve.Err(wve.MissingParameter, "missing revocation list ID")
+		}
+		rv = append(rv, iapi.NewRevocationListSchemeInstance(revoker, loc, ref.ListID, true))
+	}
+	for _, ref := range revokers {
+		revoker, loc, werr := revokerDetails(ref.Revoker, ref.RevokerLocation)
+		if werr != nil {
+			return nil, werr
+		}
+		rv = append(rv, iapi.NewDelegatedRevocationSchemeInstance(revoker, loc, true))
+	}
+	return rv, nil
+}
+
+//How many attestations CreateAttestations creates at a time by default
+const DefaultCreateConcurrency = 8
+
+func (e *EAPI) CreateAttestations(ctx context.Context, p *pb.CreateAttestationsParams) (*pb.CreateAttestationsResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.CreateAttestationsResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	if p.Sync {
+		uerr := eng.ResyncEntireGraph(ctx)
+		if uerr != nil {
+			return &pb.CreateAttestationsResponse{
+				Error: ToError(wve.ErrW(wve.UnknownError, "could not sync graph", uerr)),
+			}, nil
+		}
+		select {
+		case <-eng.WaitForEmptySyncQueue():
+		case <-ctx.Done():
+			return &pb.CreateAttestationsResponse{
+				Error: ToError(wve.ErrW(wve.UnknownError, "could not sync graph", ctx.Err())),
+			}, nil
+		}
+	}
+	concurrency := int(p.Concurrency)
+	if concurrency <= 0 {
+		concurrency = DefaultCreateConcurrency
+	}
+	results := make([]*pb.CreateAttestationResponse, len(p.Attestations))
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	wg.Add(len(p.Attestations))
+	for idx, item := range p.Attestations {
+		if item == nil {
+			results[idx] = &pb.CreateAttestationResponse{
+				Error: ToError(wve.Err(wve.InvalidParameter, "missing attestation parameters")),
+			}
+			wg.Done()
+			continue
+		}
+		params := *item
+		params.Perspective = p.Perspective
+		sem <- struct{}{}
+		go func(idx int, params *pb.CreateAttestationParams) {
+			defer wg.Done()
+			//CreateAttestation reports all errors in band
+			results[idx], _ = e.CreateAttestation(ctx, params)
+			<-sem
+		}(idx, &params)
+	}
+	wg.Wait()
+	return &pb.CreateAttestationsResponse{
+		Results: results,
+	}, nil
+}
 func (e *EAPI) PublishEntity(ctx context.Context, p *pb.PublishEntityParams) (*pb.PublishEntityResponse, error) {
 	loc, err := LocationSchemeInstance(p.Location)
 	if err != nil {
@@ -413,60 +663,138 @@ func (e *EAPI) AddAttestation(ctx context.Context, p *pb.AddAttestationParams) (
 	}
 	return &pb.AddAttestationResponse{}, nil
 }
-func (e *EAPI) LookupAttestations(ctx context.Context, p *pb.LookupAttestationsParams) (*pb.LookupAttestationsResponse, error) {
+//lookupFilter converts the filters and cursor of a lookup request
+func lookupFilter(p *pb.LookupAttestationsParams) (*iapi.LookupFromFilter, wve.WVE) {
+	if p.ExcludeRevoked && p.OnlyRevoked {
+		return nil, wve.Err(wve.InvalidParameter, "you should specify ExcludeRevoked or OnlyRevoked, not both")
+	}
+	filter := &iapi.LookupFromFilter{
+		Namespace:      p.Namespace,
+		PermissionSet:  p.PermissionSet,
+		Permission:     p.Permission,
+		ResourcePrefix: p.ResourcePrefix,
+	}
+	if p.ExpiresAfter != 0 {
+		t := time.Unix(0, p.ExpiresAfter*1e6)
+		filter.ExpiresAfter = &t
+	}
+	if p.ExpiresBefore != 0 {
+		t := time.Unix(0, p.ExpiresBefore*1e6)
+		filter.ExpiresBefore = &t
+	}
+	if p.ExcludeRevoked {
+		filter.Revoked = iapi.Bool(false)
+	}
+	if p.OnlyRevoked {
+		filter.Revoked = iapi.Bool(true)
+	}
+	if len(p.Cursor) != 0 {
+		hi := iapi.HashSchemeInstanceFromMultihash(p.Cursor)
+		if !hi.Supported() {
+			return nil, wve.Err(wve.InvalidParameter, "cursor is not valid")
+		}
+		filter.After = hi
+	}
+	return filter, nil
+}
+
+//lookupAttestations performs the lookup described by p, calling emit for
+//every result until it returns false
+func (e *EAPI) lookupAttestations(ctx context.Context, p *pb.LookupAttestationsParams, emit func(*pb.Attestation) bool) wve.WVE {
 	if len(p.ToEntity) != 0 && len(p.FromEntity) != 0 {
-		return &pb.LookupAttestationsResponse{
-			Error: ToError(wve.Err(wve.InvalidParameter, "you should specify To entity or From entity, not both")),
-		}, nil
+		return wve.Err(wve.InvalidParameter, "you should specify To entity or From entity, not both")
+	}
+	if len(p.ToEntity) == 0 && len(p.FromEntity) == 0 {
+		return wve.Err(wve.InvalidParameter, "you should specify To entity or From entity")
 	}
 	eng, err := e.GetEngine(ctx, p.Perspective)
 	if err != nil {
-		return &pb.LookupAttestationsResponse{
-			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
-		}, nil
+		return wve.ErrW(wve.InvalidParameter, "could not create perspective", err)
+	}
+	filter, err := lookupFilter(p)
+	if err != nil {
+		return err
 	}
+	//Cancelling stops the lookup if emit no longer wants results
+	subctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	var chlr chan *engine.LookupResult
 	var cherr chan error
-	filter := &iapi.LookupFromFilter{}
 	if len(p.FromEntity) != 0 {
 		hi := iapi.HashSchemeInstanceFromMultihash(p.FromEntity)
 		if !hi.Supported() {
-			return &pb.LookupAttestationsResponse{
-				Error: ToError(wve.Err(wve.InvalidMultihash, "FromEntity is not a supported multihash")),
-			}, nil
+			return wve.Err(wve.InvalidMultihash, "FromEntity is not a supported multihash")
 		}
-		chlr, cherr = eng.LookupAttestationsFrom(ctx, hi, filter)
-	} else if len(p.ToEntity) != 0 {
+		chlr, cherr = eng.LookupAttestationsFrom(subctx, hi, filter)
+	} else {
 		hi := iapi.HashSchemeInstanceFromMultihash(p.ToEntity)
 		if !hi.Supported() {
-			return &pb.LookupAttestationsResponse{
-				Error: ToError(wve.Err(wve.InvalidMultihash, "FromEntity is not a supported multihash")),
-			}, nil
+			return wve.Err(wve.InvalidMultihash, "ToEntity is not a supported multihash")
 		}
-		chlr, cherr = eng.LookupAttestationsTo(ctx, hi, filter)
+		chlr, cherr = eng.LookupAttestationsTo(subctx, hi, filter)
 	}
-	rv := &pb.LookupAttestationsResponse{}
-	rva := []*pb.Attestation{}
-results:
 	for {
 		select {
 		case lr, ok := <-chlr:
 			if !ok {
 				//We are done consuming results
-				break results
+				return nil
+			}
+			if !emit(ConvertLookupResult(lr)) {
+				return nil
 			}
-			rva = append(rva, ConvertLookupResult(lr))
 		case err, ok := <-cherr:
 			if ok {
-				return &pb.LookupAttestationsResponse{
-					Error: ToError(wve.ErrW(wve.LookupFailure, "could not complete lookup", err)),
-				}, nil
+				return wve.ErrW(wve.LookupFailure, "could not complete lookup", err)
 			}
 		}
 	}
+}
+
+func (e *EAPI) LookupAttestations(ctx context.Context, p *pb.LookupAttestationsParams) (*pb.LookupAttestationsResponse, error) {
+	rv := &pb.LookupAttestationsResponse{}
+	rva := []*pb.Attestation{}
+	err := e.lookupAttestations(ctx, p, func(a *pb.Attestation) bool {
+		if p.PageSize > 0 && len(rva) == int(p.PageSize) {
+			//There is at least one more result
+			rv.NextCursor = rva[len(rva)-1].Hash
+			return false
+		}
+		rva = append(rva, a)
+		return true
+	})
+	if err != nil {
+		return &pb.LookupAttestationsResponse{
+			Error: ToError(err),
+		}, nil
+	}
 	rv.Results = rva
 	return rv, nil
 }
+
+//LookupAttestationsStream sends each result in its own message, with
+//NextCursor set so that an interrupted lookup can be resumed
+func (e *EAPI) LookupAttestationsStream(p *pb.LookupAttestationsParams, srv pb.WAVE_LookupAttestationsStreamServer) error {
+	var senderr error
+	count := 0
+	err := e.lookupAttestations(srv.Context(), p, func(a *pb.Attestation) bool {
+		senderr = srv.Send(&pb.LookupAttestationsResponse{
+			Results:    []*pb.Attestation{a},
+			NextCursor: a.Hash,
+		})
+		count++
+		return senderr == nil && (p.PageSize <= 0 || count < int(p.PageSize))
+	})
+	if senderr != nil {
+		return senderr
+	}
+	if err != nil {
+		srv.Send(&pb.LookupAttestationsResponse{
+			Error: ToError(err),
+		})
+	}
+	return nil
+}
 func (e *EAPI) ResyncPerspectiveGraph(ctx context.Context, p *pb.ResyncPerspectiveGraphParams) (*pb.ResyncPerspectiveGraphResponse, error) {
 	eng, err := e.GetEngine(ctx, p.Perspective)
 	if err != nil {
@@ -631,8 +959,8 @@ func (e *EAPI) Sign(ctx context.Context, p *pb.SignParams) (*pb.SignResponse, er
 	}
 	sig := serdes.Signature{}
 	key := eng.Perspective().MessageSigningKey()
-	sig.Scheme = serdes.EntityEd25519OID
-	sigbin, err := key.(*iapi.EntitySecretKey_Ed25519).SignMessage(ctx, p.Content)
+	sig.Scheme = key.CanonicalForm().Key.OID
+	sigbin, err := key.SignMessage(ctx, p.Content)
 	if err != nil {
 		return &pb.SignResponse{
 			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not sign", err)),
@@ -691,6 +1019,28 @@ func (e *EAPI) VerifyProof(ctx context.Context, p *pb.VerifyProofParams) (*pb.Ve
 		}
 		proof.Elements[idx] = ConvertProofAttestation(att)
 	}
+	//The entities on both sides of a succession are path entities too
+	for _, succession := range resp.Successions {
+		for _, ent := range []struct {
+			hash iapi.HashSchemeInstance
+			loc  iapi.LocationSchemeInstance
+		}{
+			{succession.Predecessor, succession.PredecessorLocation},
+			{succession.Successor, succession.SuccessorLocation},
+		} {
+			found, val, err := eng.LookupEntity(ctx, ent.hash, ent.loc)
+			if err != nil {
+				return &pb.VerifyProofResponse{
+					Error: ToError(wve.ErrW(wve.InternalError, "could not check succession entity", err)),
+				}, nil
+			}
+			if found == nil || !val.Valid {
+				return &pb.VerifyProofResponse{
+					Error: ToError(wve.Err(wve.ProofInvalid, "proof follows a succession with an expired or revoked entity")),
+				}, nil
+			}
+		}
+	}
 
 	for idx, path := range resp.Paths {
 		prp := &pb.ProofPath{}
@@ -804,6 +1154,104 @@ func (e *EAPI) ResolveHash(ctx context.Context, p *pb.ResolveHashParams) (*pb.Re
 		Error: ToError(wve.Err(wve.LookupFailure, "no objects found")),
 	}, nil
 }
+func (e *EAPI) CheckRevocations(ctx context.Context, p *pb.CheckRevocationsParams) (*pb.CheckRevocationsResponse, error) {
+	var en *engine.Engine
+	if p.Perspective == nil {
+		en = e.GetEngineNoPerspective()
+	} else {
+		var err wve.WVE
+		en, err = e.GetEngine(ctx, p.Perspective)
+		if err != nil {
+			return &pb.CheckRevocationsResponse{
+				Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+			}, nil
+		}
+	}
+	locs, err := iapi.SI().RegisteredLocations(ctx)
+	if err != nil {
+		return &pb.CheckRevocationsResponse{
+			Error: ToError(wve.ErrW(wve.StorageError, "could not get locations", err)),
+		}, nil
+	}
+	maxAge := time.Duration(p.MaxAge) * time.Millisecond
+	rv := &pb.CheckRevocationsResponse{}
+	check := func(hash []byte, find func(hi iapi.HashSchemeInstance, loc iapi.LocationSchemeInstance) ([]iapi.RevocationSchemeInstance, bool, error)) error {
+		st := &pb.RevocationStatus{
+			Hash:    hash,
+			Status:  engine.RevocationUnknown,
+			Message: "object not found",
+		}
+		rv.Statuses = append(rv.Statuses, st)
+		hi := iapi.HashSchemeInstanceFromMultihash(hash)
+		if !hi.Supported() {
+			st.Message = "invalid hash"
+			return nil
+		}
+		for _, loc := range locs {
+			revocations, found, err := find(hi, loc)
+			if err != nil || !found {
+				continue
+			}
+			status, err := en.CheckRevocations(ctx, revocations, maxAge)
+			if err != nil {
+				return err
+			}
+			st.Status = status.Status
+			st.Message = status.Message
+			if !status.LastChecked.IsZero() {
+				st.LastChecked = status.LastChecked.UnixNano() / 1e6
+			}
+			if status.Location != nil {
+				st.Location = ToPbLocation(status.Location)
+			}
+			return nil
+		}
+		return nil
+	}
+	for _, hash := range p.AttestationHashes {
+		err := check(hash, func(hi iapi.HashSchemeInstance, loc iapi.LocationSchemeInstance) ([]iapi.RevocationSchemeInstance, bool, error) {
+			att, _, err := en.LookupAttestationNoPerspective(ctx, hi, nil, loc)
+			if att == nil {
+				return nil, false, err
+			}
+			return att.Revocations, true, err
+		})
+		if err != nil {
+			return &pb.CheckRevocationsResponse{
+				Error: ToError(wve.ErrW(wve.InternalError, "could not check revocations", err)),
+			}, nil
+		}
+	}
+	for _, hash := range p.EntityHashes {
+		err := check(hash, func(hi iapi.HashSchemeInstance, loc iapi.LocationSchemeInstance) ([]iapi.RevocationSchemeInstance, bool, error) {
+			ent, _, err := en.LookupEntity(ctx, hi, loc)
+			if ent == nil {
+				return nil, false, err
+			}
+			return ent.Revocations, true, err
+		})
+		if err != nil {
+			return &pb.CheckRevocationsResponse{
+				Error: ToError(wve.ErrW(wve.InternalError, "could not check revocations", err)),
+			}, nil
+		}
+	}
+	for _, hash := range p.NameDeclarationHashes {
+		err := check(hash, func(hi iapi.HashSchemeInstance, loc iapi.LocationSchemeInstance) ([]iapi.RevocationSchemeInstance, bool, error) {
+			nd, _, err := en.LookupNameDeclaration(ctx, hi, loc)
+			if nd == nil {
+				return nil, false, err
+			}
+			return nd.Revocations, true, err
+		})
+		if err != nil {
+			return &pb.CheckRevocationsResponse{
+				Error: ToError(wve.ErrW(wve.InternalError, "could not check revocations", err)),
+			}, nil
+		}
+	}
+	return rv, nil
+}
 func (e *EAPI) BuildRTreeProof(ctx context.Context, p *pb.BuildRTreeProofParams) (*pb.BuildRTreeProofResponse, error) {
 	eng, werr := e.GetEngine(ctx, p.Perspective)
 	if werr != nil {
@@ -823,14 +1271,20 @@ func (e *EAPI) BuildRTreeProof(ctx context.Context, p *pb.BuildRTreeProofParams)
 		<-waitchan
 	}
 
-	if len(p.SubjectHash) == 0 {
-		p.SubjectHash = eng.Perspective().Entity.Keccak256HI().Multihash()
+	subject := eng.Perspective().Entity.Keccak256HI()
+	if len(p.SubjectHash) != 0 {
+		subject, _, werr = resolveEntityRef(ctx, eng, p.SubjectHash, "subject")
+		if werr != nil {
+			return &pb.BuildRTreeProofResponse{
+				Error: ToError(werr),
+			}, nil
+		}
 	}
 	spol := serdes.RTreePolicy{}
-	ehash := iapi.HashSchemeInstanceFromMultihash(p.Namespace)
-	if !ehash.Supported() {
+	ehash, _, werr := resolveEntityRef(ctx, eng, p.Namespace, "namespace")
+	if werr != nil {
 		return &pb.BuildRTreeProofResponse{
-			Error: ToError(wve.ErrW(wve.InvalidParameter, "bad namespace", werr)),
+			Error: ToError(werr),
 		}, nil
 	}
 	ext := ehash.CanonicalForm()
@@ -857,7 +1311,7 @@ func (e *EAPI) BuildRTreeProof(ctx context.Context, p *pb.BuildRTreeProofParams)
 		panic(err)
 	}
 	tb, err := rtree.NewRTreeBuilder(ctx, &rtree.Params{
-		Subject:      iapi.HashSchemeInstanceFromMultihash(p.SubjectHash),
+		Subject:      subject,
 		Engine:       eng,
 		Policy:       pol,
 		Start:        pol.WR1DomainEntity(),
@@ -930,6 +1384,13 @@ func (e *EAPI) BuildRTreeProof(ctx context.Context, p *pb.BuildRTreeProofParams)
 			}
 		}
 
+		for _, cosigner := range edge.CoSigners {
+			entities[cosigner.Keccak256HI().MultihashString()], err = cosigner.DER()
+			if err != nil {
+				panic(err)
+			}
+		}
+
 		subjecthi, subjectloc := edge.LRes.Attestation.Subject()
 		entity, validity, err := eng.LookupEntity(ctx, subjecthi, subjectloc)
 		if err != nil || !validity.Valid {
@@ -953,6 +1414,34 @@ func (e *EAPI) BuildRTreeProof(ctx context.Context, p *pb.BuildRTreeProofParams)
 	for _, ent := range entities {
 		formalProof.Entities = append(formalProof.Entities, ent)
 	}
+	//Paths that end at a predecessor of the subject carry the succession
+	//so that the verifier can follow it to the subject
+	included := make(map[string]bool)
+	for _, path := range sol.Paths {
+		if len(path) == 0 {
+			continue
+		}
+		last, _ := path[len(path)-1].LRes.Attestation.Subject()
+		succession := tb.Succession(last)
+		if succession == nil || included[last.MultihashString()] {
+			continue
+		}
+		included[last.MultihashString()] = true
+		sder, werr := succession.DER()
+		if werr != nil {
+			return &pb.BuildRTreeProofResponse{
+				Error: ToError(werr),
+			}, nil
+		}
+		formalProof.Extensions = append(formalProof.Extensions, serdes.Extension{
+			ExtensionID: serdes.ProofSuccessionOID,
+			Critical:    true,
+			Value:       sder,
+		})
+		if succession.GraceUntil().Before(expiry) {
+			expiry = succession.GraceUntil()
+		}
+	}
 	wrappedFormalProof := serdes.WaveWireObject{
 		Content: asn1.NewExternal(formalProof),
 	}
@@ -980,70 +1469,133 @@ func (e *EAPI) BuildRTreeProof(ctx context.Context, p *pb.BuildRTreeProofParams)
 	return resp, nil
 }
 
-func (e *EAPI) EncryptMessage(ctx context.Context, p *pb.EncryptMessageParams) (*pb.EncryptMessageResponse, error) {
-	eng := e.GetEngineNoPerspective()
+//messageRecipients resolves the recipients of an encrypted message
+func (e *EAPI) messageRecipients(ctx context.Context, p *pb.EncryptMessageParams) (*iapi.PEncryptMessage, wve.WVE) {
 	params := iapi.PEncryptMessage{}
-	if len(p.SubjectHash) != 0 {
-		subHash := iapi.HashSchemeInstanceFromMultihash(p.SubjectHash)
-		subLoc, err := LocationSchemeInstance(p.SubjectLocation)
-		if err != nil {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.ErrW(wve.InvalidParameter, "could not load subject location", err)),
-			}, nil
-		}
-		if subLoc == nil {
-			subLoc = iapi.SI().DefaultLocation(ctx)
-		}
-		sub, val, uerr := eng.LookupEntity(ctx, subHash, subLoc)
-		if uerr != nil {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.ErrW(wve.LookupFailure, "could not resolve subject", uerr)),
-			}, nil
+	//The perspective is only used to resolve names
+	names := e.GetEngineNoPerspective()
+	if p.Perspective != nil {
+		eng, werr := e.GetEngine(ctx, p.Perspective)
+		if werr != nil {
+			return nil, wve.ErrW(wve.InvalidParameter, "could not create perspective", werr)
 		}
-		if !val.Valid {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.Err(wve.LookupFailure, "subject entity is no longer valid")),
-			}, nil
+		names = eng
+	}
+	if len(p.SubjectHash) != 0 {
+		sub, werr := e.messageSubject(ctx, names, p.SubjectHash, p.SubjectLocation)
+		if werr != nil {
+			return nil, werr
 		}
 		params.Subject = sub
 	}
 	if len(p.Namespace) != 0 {
-		validFrom := TimeFromInt64MillisWithDefault(p.ValidFrom, time.Now())
-		validUntil := TimeFromInt64MillisWithDefault(p.ValidUntil, time.Now())
-		params.ValidAfter = validFrom
-		params.ValidBefore = validUntil
-		nsHash := iapi.HashSchemeInstanceFromMultihash(p.Namespace)
-		if !nsHash.Supported() {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.Err(wve.InvalidParameter, "could not parse namespace")),
-			}, nil
+		target, werr := e.messageTarget(ctx, names, &pb.EncryptionNamespace{
+			Namespace:         p.Namespace,
+			NamespaceLocation: p.NamespaceLocation,
+			Resource:          p.Resource,
+			ValidFrom:         p.ValidFrom,
+			ValidUntil:        p.ValidUntil,
+		})
+		if werr != nil {
+			return nil, werr
 		}
-		nsLoc, err := LocationSchemeInstance(p.NamespaceLocation)
-		if err != nil {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse namespace location", err)),
-			}, nil
+		params.Namespace = target.Namespace
+		params.NamespaceLocation = target.NamespaceLocation
+		params.Resource = target.Resource
+		params.ValidAfter = target.ValidAfter
+		params.ValidBefore = target.ValidBefore
+	}
+	for _, s := range p.Subjects {
+		if len(s.Hash) == 0 {
+			return nil, wve.Err(wve.InvalidParameter, "subject hash is missing")
 		}
-		if nsLoc == nil {
-			nsLoc = iapi.SI().DefaultLocation(ctx)
+		sub, werr := e.messageSubject(ctx, names, s.Hash, s.Location)
+		if werr != nil {
+			return nil, werr
 		}
-		ns, val, uerr := eng.LookupEntity(ctx, nsHash, nsLoc)
-		if uerr != nil {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.ErrW(wve.LookupFailure, "could not resolve namespace", uerr)),
-			}, nil
+		params.Subjects = append(params.Subjects, sub)
+	}
+	for _, n := range p.Namespaces {
+		target, werr := e.messageTarget(ctx, names, n)
+		if werr != nil {
+			return nil, werr
 		}
-		if !val.Valid {
-			return &pb.EncryptMessageResponse{
-				Error: ToError(wve.Err(wve.LookupFailure, "namespace entity is no longer valid")),
-			}, nil
-		}
-		params.Namespace = ns
-		params.NamespaceLocation = nsLoc
-		params.Resource = p.Resource
+		params.Targets = append(params.Targets, target)
+	}
+	return &params, nil
+}
+
+//messageSubject resolves a direct encryption recipient. Names are resolved
+//from the perspective of the names engine
+func (e *EAPI) messageSubject(ctx context.Context, names *engine.Engine, hash []byte, location *pb.Location) (*iapi.Entity, wve.WVE) {
+	eng := e.GetEngineNoPerspective()
+	subHash, namedLoc, werr := resolveEntityRef(ctx, names, hash, "subject")
+	if werr != nil {
+		return nil, werr
+	}
+	subLoc, err := LocationSchemeInstance(location)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not load subject location", err)
+	}
+	if subLoc == nil {
+		subLoc = namedLoc
+	}
+	if subLoc == nil {
+		subLoc = iapi.SI().DefaultLocation(ctx)
+	}
+	sub, val, uerr := eng.LookupEntity(ctx, subHash, subLoc)
+	if uerr != nil {
+		return nil, wve.ErrW(wve.LookupFailure, "could not resolve subject", uerr)
+	}
+	if !val.Valid {
+		return nil, wve.Err(wve.LookupFailure, "subject entity is no longer valid")
+	}
+	return sub, nil
+}
+
+//messageTarget resolves an OAQUE encryption recipient. Names are resolved
+//from the perspective of the names engine
+func (e *EAPI) messageTarget(ctx context.Context, names *engine.Engine, p *pb.EncryptionNamespace) (*iapi.EncryptionTarget, wve.WVE) {
+	eng := e.GetEngineNoPerspective()
+	nsHash, namedLoc, werr := resolveEntityRef(ctx, names, p.Namespace, "namespace")
+	if werr != nil {
+		return nil, werr
+	}
+	nsLoc, err := LocationSchemeInstance(p.NamespaceLocation)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not parse namespace location", err)
+	}
+	if nsLoc == nil {
+		nsLoc = namedLoc
+	}
+	if nsLoc == nil {
+		nsLoc = iapi.SI().DefaultLocation(ctx)
+	}
+	ns, val, uerr := eng.LookupEntity(ctx, nsHash, nsLoc)
+	if uerr != nil {
+		return nil, wve.ErrW(wve.LookupFailure, "could not resolve namespace", uerr)
+	}
+	if !val.Valid {
+		return nil, wve.Err(wve.LookupFailure, "namespace entity is no longer valid")
+	}
+	return &iapi.EncryptionTarget{
+		Namespace:         ns,
+		NamespaceLocation: nsLoc,
+		Resource:          p.Resource,
+		ValidAfter:        TimeFromInt64MillisWithDefault(p.ValidFrom, time.Now()),
+		ValidBefore:       TimeFromInt64MillisWithDefault(p.ValidUntil, time.Now()),
+	}, nil
+}
+
+func (e *EAPI) EncryptMessage(ctx context.Context, p *pb.EncryptMessageParams) (*pb.EncryptMessageResponse, error) {
+	params, err := e.messageRecipients(ctx, p)
+	if err != nil {
+		return &pb.EncryptMessageResponse{
+			Error: ToError(err),
+		}, nil
 	}
 	params.Content = p.Content
-	rv, err := iapi.EncryptMessage(ctx, &params)
+	rv, err := iapi.EncryptMessage(ctx, params)
 	if err != nil {
 		return &pb.EncryptMessageResponse{
 			Error: ToError(err),
@@ -1054,28 +1606,35 @@ func (e *EAPI) EncryptMessage(ctx context.Context, p *pb.EncryptMessageParams) (
 	}, nil
 }
 
-func (e *EAPI) DecryptMessage(ctx context.Context, p *pb.DecryptMessageParams) (*pb.DecryptMessageResponse, error) {
-	eng, err := e.GetEngine(ctx, p.Perspective)
+//messageDecryptor returns the perspective entity and a decryption
+//context for decrypting messages as that entity
+func (e *EAPI) messageDecryptor(ctx context.Context, perspective *pb.Perspective, resyncFirst bool) (*iapi.EntitySecrets, iapi.WR1MessageDecryptionContext, wve.WVE) {
+	eng, err := e.GetEngine(ctx, perspective)
 	if err != nil {
-		return &pb.DecryptMessageResponse{
-			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
-		}, nil
+		return nil, nil, wve.ErrW(wve.InvalidParameter, "could not create perspective", err)
 	}
 
-	if p.ResyncFirst {
+	if resyncFirst {
 		uerr := eng.ResyncEntireGraph(ctx)
 		if uerr != nil {
-			return &pb.DecryptMessageResponse{
-				Error: ToError(wve.ErrW(wve.UnknownError, "could not sync graph", uerr)),
-			}, nil
+			return nil, nil, wve.ErrW(wve.UnknownError, "could not sync graph", uerr)
 		}
 		waitchan := eng.WaitForEmptySyncQueue()
 		<-waitchan
 	}
 
-	secret := eng.Perspective()
 	dctx := engine.NewEngineDecryptionContext(eng)
 	dctx.AutoLoadPartitionSecrets(true)
+	return eng.Perspective(), dctx, nil
+}
+
+func (e *EAPI) DecryptMessage(ctx context.Context, p *pb.DecryptMessageParams) (*pb.DecryptMessageResponse, error) {
+	secret, dctx, err := e.messageDecryptor(ctx, p.Perspective, p.ResyncFirst)
+	if err != nil {
+		return &pb.DecryptMessageResponse{
+			Error: ToError(err),
+		}, nil
+	}
 	params := iapi.PDecryptMessage{
 		Decryptor:  secret,
 		Ciphertext: p.Ciphertext,
@@ -1093,6 +1652,157 @@ func (e *EAPI) DecryptMessage(ctx context.Context, p *pb.DecryptMessageParams) (
 
 }
 
+//reencryptRecipients returns the recipients of a re-encrypted message.
+//Without explicit recipients the namespace recipients of the original
+//message are kept, moved to the new namespace if there is one, in the new
+//validity window. The window defaults to 30 days from now
+func (e *EAPI) reencryptRecipients(ctx context.Context, p *pb.ReencryptMessageParams, dec *iapi.PDecryptMessage) (*iapi.PEncryptMessage, wve.WVE) {
+	if len(p.Subjects) != 0 || len(p.Namespaces) != 0 {
+		if len(p.NewNamespace) != 0 {
+			return nil, wve.Err(wve.InvalidParameter, "newNamespace can not be combined with subjects or namespaces")
+		}
+		return e.messageRecipients(ctx, &pb.EncryptMessageParams{
+			Perspective: p.Perspective,
+			Subjects:    p.Subjects,
+			Namespaces:  p.Namespaces,
+		})
+	}
+	names, werr := e.GetEngine(ctx, p.Perspective)
+	if werr != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not create perspective", werr)
+	}
+	old, werr := iapi.ReadMessageRecipients(ctx, dec)
+	if werr != nil {
+		return nil, werr
+	}
+	validFrom := TimeFromInt64MillisWithDefault(p.ValidFrom, time.Now())
+	validUntil := TimeFromInt64MillisWithDefault(p.ValidUntil, validFrom.Add(30*24*time.Hour))
+	params := &iapi.PEncryptMessage{}
+	seen := make(map[string]bool)
+	for _, r := range old {
+		//Recipients we can not see the resource of are reported as
+		//losing access
+		if r.Direct || r.Resource == "" {
+			continue
+		}
+		n := &pb.EncryptionNamespace{
+			Namespace:         r.Namespace.Multihash(),
+			NamespaceLocation: ToPbLocation(r.NamespaceLocation),
+			Resource:          r.Resource,
+			ValidFrom:         validFrom.UnixNano() / 1e6,
+			ValidUntil:        validUntil.UnixNano() / 1e6,
+		}
+		if len(p.NewNamespace) != 0 {
+			n.Namespace = p.NewNamespace
+			n.NamespaceLocation = p.NewNamespaceLocation
+		}
+		key := string(n.Namespace) + "/" + n.Resource
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		target, werr := e.messageTarget(ctx, names, n)
+		if werr != nil {
+			return nil, werr
+		}
+		params.Targets = append(params.Targets, target)
+	}
+	if len(params.Targets) == 0 {
+		return nil, wve.Err(wve.InvalidParameter, "the message has no namespace recipients to keep, new recipients are required")
+	}
+	return params, nil
+}
+
+func (e *EAPI) ReencryptMessage(ctx context.Context, p *pb.ReencryptMessageParams) (*pb.ReencryptMessageResponse, error) {
+	secret, dctx, err := e.messageDecryptor(ctx, p.Perspective, p.ResyncFirst)
+	if err != nil {
+		return &pb.ReencryptMessageResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	dec := &iapi.PDecryptMessage{
+		Decryptor:  secret,
+		Ciphertext: p.Ciphertext,
+		Dctx:       dctx,
+	}
+	recipients, err := e.reencryptRecipients(ctx, p, dec)
+	if err != nil {
+		return &pb.ReencryptMessageResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	rv, err := iapi.ReencryptMessage(ctx, &iapi.PReencryptMessage{
+		Decryptor:  secret,
+		Dctx:       dctx,
+		Ciphertext: p.Ciphertext,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return &pb.ReencryptMessageResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	resp := &pb.ReencryptMessageResponse{
+		Ciphertext: rv.Ciphertext,
+	}
+	for _, r := range rv.Recipients {
+		resp.Recipients = append(resp.Recipients, ConvertMessageRecipient(r))
+	}
+	for _, r := range rv.LostAccess {
+		resp.LostAccess = append(resp.LostAccess, ConvertMessageRecipient(r))
+	}
+	return resp, nil
+}
+
+//resolveEntityRef parses a field that holds either an entity hash or a WAVE
+//name. Names are resolved from the perspective of the engine. Names can not
+//be confused with hashes because they are never valid multihashes. The
+//location is only returned for names, where the declaration gives it
+func resolveEntityRef(ctx context.Context, eng *engine.Engine, ref []byte, what string) (iapi.HashSchemeInstance, iapi.LocationSchemeInstance, wve.WVE) {
+	hi := iapi.HashSchemeInstanceFromMultihash(ref)
+	if hi.Supported() {
+		return hi, nil, nil
+	}
+	name := string(ref)
+	for _, part := range strings.Split(name, ".") {
+		if !iapi.IsNameDeclarationValid(part) {
+			return nil, nil, wve.Err(wve.InvalidParameter, fmt.Sprintf("%s is not a hash or WAVE name", what))
+		}
+	}
+	if eng.Perspective() == nil {
+		return nil, nil, wve.Err(wve.InvalidParameter, fmt.Sprintf("%s is a WAVE name, which requires a perspective", what))
+	}
+	ndz, err := eng.LookupFullName(ctx, eng.Perspective().Entity.Keccak256HI(), name)
+	if _, ok := err.(*engine.NameCandidatesError); ok {
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, wve.ErrW(wve.LookupFailure, fmt.Sprintf("could not resolve %s name %q", what, name), err)
+	}
+	if ndz == nil {
+		return nil, nil, wve.Err(wve.LookupFailure, fmt.Sprintf("could not resolve %s name %q", what, name))
+	}
+	return ndz[0].Subject, ndz[0].SubjectLocation, nil
+}
+
+//resolvePolicyNames returns the policy with a namespace given as a WAVE
+//name replaced by its hash. The policy passed in is not modified because
+//batch calls share it between items
+func resolvePolicyNames(ctx context.Context, eng *engine.Engine, in *pb.Policy) (*pb.Policy, wve.WVE) {
+	if in == nil || in.RTreePolicy == nil {
+		return in, nil
+	}
+	ns, _, err := resolveEntityRef(ctx, eng, in.RTreePolicy.Namespace, "policy namespace")
+	if err != nil {
+		return nil, err
+	}
+	rtp := *in.RTreePolicy
+	rtp.Namespace = ns.Multihash()
+	return &pb.Policy{
+		RTreePolicy: &rtp,
+	}, nil
+}
+
 func (e *EAPI) ResolveName(ctx context.Context, p *pb.ResolveNameParams) (*pb.ResolveNameResponse, error) {
 	eng, err := e.GetEngine(ctx, p.Perspective)
 	if err != nil {
@@ -1105,6 +1815,15 @@ func (e *EAPI) ResolveName(ctx context.Context, p *pb.ResolveNameParams) (*pb.Re
 		attester = iapi.HashSchemeInstanceFromMultihash(p.TopLevelAttester)
 	}
 	ndz, err := eng.LookupFullName(ctx, attester, p.Name)
+	if ce, ok := err.(*engine.NameCandidatesError); ok {
+		rv := &pb.ResolveNameResponse{
+			Error: ToError(ce),
+		}
+		for _, nd := range ce.Candidates {
+			rv.Candidates = append(rv.Candidates, ConvertNDWVal(nd, &engine.Validity{Valid: true}))
+		}
+		return rv, nil
+	}
 	if err != nil {
 		return &pb.ResolveNameResponse{
 			Error: ToError(wve.ErrW(wve.LookupFailure, "name could not be resolved", err)),
@@ -1255,6 +1974,536 @@ func (e *EAPI) CreateNameDeclaration(ctx context.Context, p *pb.CreateNameDeclar
 	}, nil
 }
 
+func (e *EAPI) CreateEntitySuccession(ctx context.Context, p *pb.CreateEntitySuccessionParams) (*pb.CreateEntitySuccessionResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	succloc, err := LocationSchemeInstance(p.SuccessorLocation)
+	if err != nil {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse successor location", err)),
+		}, nil
+	}
+	if succloc == nil {
+		succloc = iapi.SI().DefaultLocation(ctx)
+	}
+	succ := iapi.HashSchemeInstanceFromMultihash(p.Successor)
+	if !succ.Supported() {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "bad successor hash")),
+		}, nil
+	}
+	succent, val, uerr := eng.LookupEntity(ctx, succ, succloc)
+	if uerr != nil {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not lookup successor entity", uerr)),
+		}, nil
+	}
+	if succent == nil {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(wve.Err(wve.LookupFailure, "could not lookup successor entity")),
+		}, nil
+	}
+	if !val.Valid {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "successor entity is not valid")),
+		}, nil
+	}
+	params := iapi.PCreateEntitySuccession{
+		Predecessor:         eng.Perspective(),
+		PredecessorLocation: eng.PerspectiveLocation(),
+		Successor:           succent,
+		SuccessorLocation:   succloc,
+	}
+	if p.GraceUntil != 0 {
+		t := time.Unix(0, p.GraceUntil*1e6)
+		params.GraceUntil = &t
+	}
+	createrv, err := iapi.CreateEntitySuccession(ctx, &params)
+	if err != nil {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(err),
+		}, nil
+	}
+
+	//Publish it to both the predecessor's and the successor's queues so
+	//that anyone interested in either entity learns of it
+	var hash iapi.HashSchemeInstance
+	for _, target := range []struct {
+		loc iapi.LocationSchemeInstance
+		ent iapi.HashSchemeInstance
+	}{
+		{eng.PerspectiveLocation(), eng.Perspective().Entity.Keccak256HI()},
+		{succloc, succ},
+	} {
+		h, uerr := iapi.SI().PutBlob(ctx, target.loc, createrv.DER)
+		if uerr != nil {
+			return &pb.CreateEntitySuccessionResponse{
+				Error: ToError(wve.ErrW(wve.StorageError, "could not add succession to storage", uerr)),
+			}, nil
+		}
+		if hash == nil {
+			hash = h
+		}
+		uerr = iapi.SI().Enqueue(ctx, target.loc, target.ent, h)
+		if uerr != nil {
+			return &pb.CreateEntitySuccessionResponse{
+				Error: ToError(wve.ErrW(wve.StorageError, "could not add succession to storage", uerr)),
+			}, nil
+		}
+	}
+
+	err = eng.InsertSuccession(ctx, createrv.Succession)
+	if err != nil {
+		return &pb.CreateEntitySuccessionResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	return &pb.CreateEntitySuccessionResponse{
+		DER:        createrv.DER,
+		Hash:       hash.Multihash(),
+		GraceUntil: createrv.Succession.GraceUntil().UnixNano() / 1e6,
+	}, nil
+}
+
+func (e *EAPI) CreateThresholdProposal(ctx context.Context, p *pb.CreateThresholdProposalParams) (*pb.ThresholdProposalResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	subHash, namedLoc, err := resolveEntityRef(ctx, eng, p.SubjectHash, "subject")
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	subLoc, err := LocationSchemeInstance(p.SubjectLocation)
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse subject location", err)),
+		}, nil
+	}
+	if subLoc == nil {
+		subLoc = namedLoc
+	}
+	if subLoc == nil {
+		subLoc = iapi.SI().DefaultLocation(ctx)
+	}
+	subject, val, uerr := eng.LookupEntity(ctx, subHash, subLoc)
+	if uerr != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(wve.ErrW(wve.LookupFailure, "could not resolve subject", uerr)),
+		}, nil
+	}
+	if subject == nil || !val.Valid {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "subject is not valid")),
+		}, nil
+	}
+	params := &iapi.PCreateThresholdProposal{
+		Attester:         eng.Perspective(),
+		AttesterLocation: eng.PerspectiveLocation(),
+		Subject:          subject,
+		SubjectLocation:  subLoc,
+		ValidFrom:        TimeFromInt64MillisWithDefault(p.ValidFrom, time.Now()),
+		ValidUntil:       TimeFromInt64MillisWithDefault(p.ValidUntil, time.Now().Add(30*24*time.Hour)),
+		Threshold:        int(p.Threshold),
+	}
+	for _, cs := range p.CoSigners {
+		loc, err := LocationSchemeInstance(cs.Location)
+		if err != nil {
+			return &pb.ThresholdProposalResponse{
+				Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse co-signer location", err)),
+			}, nil
+		}
+		if loc == nil {
+			loc = iapi.SI().DefaultLocation(ctx)
+		}
+		ent, val, uerr := eng.LookupEntity(ctx, iapi.HashSchemeInstanceFromMultihash(cs.Hash), loc)
+		if uerr != nil {
+			return &pb.ThresholdProposalResponse{
+				Error: ToError(wve.ErrW(wve.LookupFailure, "could not resolve co-signer", uerr)),
+			}, nil
+		}
+		if ent == nil || !val.Valid {
+			return &pb.ThresholdProposalResponse{
+				Error: ToError(wve.Err(wve.InvalidParameter, "co-signer is not valid")),
+			}, nil
+		}
+		params.CoSigners = append(params.CoSigners, ent)
+		params.CoSignerLocations = append(params.CoSignerLocations, loc)
+	}
+	pbpol, err := resolvePolicyNames(ctx, eng, p.Policy)
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	params.Policy, err = e.ConvertPolicy(pbpol)
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	resp, err := iapi.CreateThresholdProposal(ctx, params)
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	return &pb.ThresholdProposalResponse{
+		DER:      resp.DER,
+		Proposal: ToPbThresholdProposal(resp.Proposal),
+	}, nil
+}
+
+//parseThresholdProposal parses a proposal and checks the attester's
+//signature on it from the perspective of the engine
+func parseThresholdProposal(ctx context.Context, eng *engine.Engine, der []byte) (*iapi.ThresholdProposal, wve.WVE) {
+	if pblock, _ := pem.Decode(der); pblock != nil {
+		der = pblock.Bytes
+	}
+	rv, werr := iapi.ParseThresholdProposal(ctx, &iapi.PParseThresholdProposal{
+		DER: der,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	tp := rv.Proposal
+	attloc := iapi.LocationSchemeInstanceFor(&tp.CanonicalForm.TBS.AttesterLocation)
+	attester, val, err := eng.LookupEntity(ctx, tp.Attester, attloc)
+	if err != nil {
+		return nil, wve.ErrW(wve.LookupFailure, "could not resolve attester", err)
+	}
+	if attester == nil || !val.Valid {
+		return nil, wve.Err(wve.InvalidParameter, "attester is not valid")
+	}
+	if werr := tp.VerifyAttester(ctx, attester); werr != nil {
+		return nil, werr
+	}
+	return tp, nil
+}
+
+func (e *EAPI) CoSignThresholdProposal(ctx context.Context, p *pb.CoSignThresholdProposalParams) (*pb.ThresholdProposalResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	tp, werr := parseThresholdProposal(ctx, eng, p.DER)
+	if werr != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(werr),
+		}, nil
+	}
+	resp, werr := iapi.CoSignThresholdProposal(ctx, &iapi.PCoSignThresholdProposal{
+		Proposal: tp,
+		CoSigner: eng.Perspective(),
+	})
+	if werr != nil {
+		return &pb.ThresholdProposalResponse{
+			Error: ToError(werr),
+		}, nil
+	}
+	return &pb.ThresholdProposalResponse{
+		DER:      resp.DER,
+		Proposal: ToPbThresholdProposal(resp.Proposal),
+	}, nil
+}
+
+func (e *EAPI) CreateThresholdAttestation(ctx context.Context, p *pb.CreateThresholdAttestationParams) (*pb.CreateAttestationResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	if len(p.Proposals) == 0 {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(wve.Err(wve.MissingParameter, "no proposals given")),
+		}, nil
+	}
+	proposals := []*iapi.ThresholdProposal{}
+	for _, der := range p.Proposals {
+		tp, werr := parseThresholdProposal(ctx, eng, der)
+		if werr != nil {
+			return &pb.CreateAttestationResponse{
+				Error: ToError(werr),
+			}, nil
+		}
+		proposals = append(proposals, tp)
+	}
+	subLoc := iapi.LocationSchemeInstanceFor(&proposals[0].CanonicalForm.TBS.SubjectLocation)
+	ent, val, uerr := eng.LookupEntity(ctx, proposals[0].Subject, subLoc)
+	if uerr != nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(wve.ErrW(wve.LookupFailure, "could not resolve subject", uerr)),
+		}, nil
+	}
+	if ent == nil || !val.Valid {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "subject is not valid")),
+		}, nil
+	}
+	bodyScheme := ConvertBodyScheme(p.BodyScheme)
+	if bodyScheme == nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "invalid body scheme")),
+		}, nil
+	}
+	dctx := engine.NewEngineDecryptionContext(eng)
+	dctx.AutoLoadPartitionSecrets(true)
+	resp, err := iapi.CreateThresholdAttestation(ctx, &iapi.PCreateThresholdAttestation{
+		Proposals:         proposals,
+		BodyScheme:        bodyScheme,
+		EncryptionContext: dctx,
+		Attester:          eng.Perspective(),
+		Subject:           ent,
+		CoSignerContext:   eng.ValidEntityContext(),
+	})
+	if err != nil {
+		return &pb.CreateAttestationResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	hi := iapi.KECCAK256.Instance(resp.DER)
+	if p.Publish {
+		rvp, err := iapi.ParseAttestation(ctx, &iapi.PParseAttestation{
+			DER: resp.DER,
+		})
+		if err != nil {
+			return &pb.CreateAttestationResponse{
+				Error: ToError(err),
+			}, nil
+		}
+		if rvp.IsMalformed {
+			return &pb.CreateAttestationResponse{
+				Error: ToError(wve.Err(wve.InternalError, "attestation is malformed")),
+			}, nil
+		}
+		hi, uerr := iapi.SI().PutAttestation(ctx, subLoc, rvp.Attestation)
+		if uerr != nil {
+			return &pb.CreateAttestationResponse{
+				Error: ToError(wve.ErrW(wve.StorageError, "could not put attestation", uerr)),
+			}, nil
+		}
+		uerr = iapi.SI().Enqueue(ctx, subLoc, ent.Keccak256HI(), hi)
+		if uerr != nil {
+			return &pb.CreateAttestationResponse{
+				Error: ToError(wve.ErrW(wve.StorageError, "could not enqueue attestation", uerr)),
+			}, nil
+		}
+	}
+	return &pb.CreateAttestationResponse{
+		DER:         resp.DER,
+		VerifierKey: resp.VerifierKey,
+		ProverKey:   resp.ProverKey,
+		Hash:        hi.Multihash(),
+	}, nil
+}
+
+func (e *EAPI) CreateX509Certificate(ctx context.Context, p *pb.CreateX509CertificateParams) (*pb.CreateX509CertificateResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.CreateX509CertificateResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	if len(p.ProofDER) == 0 {
+		resp, werr := iapi.CreateEntityCertificate(ctx, &iapi.PCreateEntityCertificate{
+			Entity: eng.Perspective(),
+		})
+		if werr != nil {
+			return &pb.CreateX509CertificateResponse{
+				Error: ToError(werr),
+			}, nil
+		}
+		return &pb.CreateX509CertificateResponse{
+			EntityCertificate: resp.DER,
+		}, nil
+	}
+	leafKey, err := x509.ParsePKIXPublicKey(p.LeafPublicKey)
+	if err != nil {
+		return &pb.CreateX509CertificateResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse leaf public key", err)),
+		}, nil
+	}
+	resp, werr := iapi.CreateProofCertificate(ctx, &iapi.PCreateProofCertificate{
+		Subject:   eng.Perspective(),
+		ProofDER:  p.ProofDER,
+		VCtx:      engine.NewEngineDecryptionContext(eng),
+		PublicKey: leafKey,
+		ValidFor:  time.Duration(p.ValidFor) * time.Millisecond,
+	})
+	if werr != nil {
+		return &pb.CreateX509CertificateResponse{
+			Error: ToError(werr),
+		}, nil
+	}
+	return &pb.CreateX509CertificateResponse{
+		EntityCertificate: resp.Chain[1],
+		LeafCertificate:   resp.Chain[0],
+	}, nil
+}
+
+func (e *EAPI) CreateSSHCertificate(ctx context.Context, p *pb.CreateSSHCertificateParams) (*pb.CreateSSHCertificateResponse, error) {
+	if e.sshca == nil {
+		return &pb.CreateSSHCertificateResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "this agent is not an SSH certificate authority")),
+		}, nil
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(p.PublicKey)
+	if err != nil {
+		return &pb.CreateSSHCertificateResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse public key", err)),
+		}, nil
+	}
+	der := p.ProofDER
+	pblock, _ := pem.Decode(p.ProofDER)
+	if pblock != nil {
+		der = pblock.Bytes
+	}
+	validFor := time.Duration(p.ValidFor) * time.Millisecond
+	if e.sshca.maxValidity != 0 && (validFor == 0 || validFor > e.sshca.maxValidity) {
+		validFor = e.sshca.maxValidity
+	}
+	resp, werr := iapi.CreateSSHCertificate(ctx, &iapi.PCreateSSHCertificate{
+		ProofDER:   der,
+		VCtx:       engine.NewEngineDecryptionContext(e.GetEngineNoPerspective()),
+		PublicKey:  pub,
+		Signature:  p.Signature,
+		Permission: e.sshca.permission,
+		CA:         e.sshca.signer,
+		ValidFor:   validFor,
+	})
+	if werr != nil {
+		return &pb.CreateSSHCertificateResponse{
+			Error: ToError(werr),
+		}, nil
+	}
+	return &pb.CreateSSHCertificateResponse{
+		Certificate: ssh.MarshalAuthorizedKey(resp.Certificate),
+		CaPublicKey: ssh.MarshalAuthorizedKey(e.sshca.signer.PublicKey()),
+		Principals:  resp.Principals,
+		Subject:     resp.Subject.Multihash(),
+		ValidBefore: int64(resp.Certificate.ValidBefore) * 1000,
+	}, nil
+}
+
+func (e *EAPI) UpdateRevocationList(ctx context.Context, p *pb.UpdateRevocationListParams) (*pb.UpdateRevocationListResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	if len(p.ListID) == 0 {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(wve.Err(wve.MissingParameter, "missing revocation list ID")),
+		}, nil
+	}
+	revoker := eng.Perspective().Entity.Keccak256HI()
+	loc := eng.PerspectiveLocation()
+	revoked := make(map[string]bool)
+	for _, id := range p.Revoke {
+		revoked[string(id)] = true
+	}
+	for _, der := range p.Attestations {
+		rvp, err := iapi.ParseAttestation(ctx, &iapi.PParseAttestation{
+			DER: der,
+		})
+		if err != nil {
+			return &pb.UpdateRevocationListResponse{
+				Error: ToError(err),
+			}, nil
+		}
+		found := false
+		for _, ro := range rvp.Attestation.Revocations {
+			rl, ok := ro.(*iapi.RevocationListSchemeInstance)
+			if !ok || !iapi.HashSchemeInstanceEqual(rl.Revoker(), revoker) || !bytes.Equal(rl.RLBody.ListID, p.ListID) {
+				continue
+			}
+			revoked[string(rl.RLBody.ID)] = true
+			found = true
+		}
+		if !found {
+			return &pb.UpdateRevocationListResponse{
+				Error: ToError(wve.Err(wve.InvalidParameter, "attestation cannot be revoked with this list")),
+			}, nil
+		}
+	}
+
+	//Start from the latest published version
+	iapi.ForgetRevocationList(revoker, loc, p.ListID)
+	latest, err := iapi.LatestRevocationList(ctx, iapi.SI(), revoker, loc, p.ListID)
+	if err != nil {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	version := 1
+	if latest != nil {
+		version = latest.Version() + 1
+		for _, id := range latest.Revoked() {
+			revoked[string(id)] = true
+		}
+	}
+	for _, id := range p.Unrevoke {
+		delete(revoked, string(id))
+	}
+	ids := [][]byte{}
+	for id := range revoked {
+		ids = append(ids, []byte(id))
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i], ids[j]) < 0 })
+	params := &iapi.PCreateRevocationList{
+		Revoker: eng.Perspective(),
+		ListID:  p.ListID,
+		Version: version,
+		Revoked: ids,
+	}
+	if p.NextUpdate != 0 {
+		t := time.Unix(0, p.NextUpdate*1e6)
+		params.NextUpdate = &t
+	}
+	createrv, err := iapi.CreateRevocationList(ctx, params)
+	if err != nil {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	hashScheme, uerr := iapi.SI().HashSchemeFor(loc)
+	if uerr != nil {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(wve.ErrW(wve.UnsupportedHashScheme, "could not get hash scheme for location", uerr)),
+		}, nil
+	}
+	h, uerr := iapi.SI().PutBlob(ctx, loc, createrv.DER)
+	if uerr != nil {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(wve.ErrW(wve.StorageError, "could not add revocation list to storage", uerr)),
+		}, nil
+	}
+	uerr = iapi.SI().Enqueue(ctx, loc, iapi.RevocationListQueue(hashScheme, revoker, p.ListID), h)
+	if uerr != nil {
+		return &pb.UpdateRevocationListResponse{
+			Error: ToError(wve.ErrW(wve.StorageError, "could not add revocation list to storage", uerr)),
+		}, nil
+	}
+	iapi.ForgetRevocationList(revoker, loc, p.ListID)
+	return &pb.UpdateRevocationListResponse{
+		DER:     createrv.DER,
+		Version: int64(version),
+		Revoked: ids,
+	}, nil
+}
+
 func (e *EAPI) MarkEntityInteresting(ctx context.Context, p *pb.MarkEntityInterestingParams) (*pb.MarkEntityInterestingResponse, error) {
 	eng, err := e.GetEngine(ctx, p.Perspective)
 	if err != nil {
@@ -1318,6 +2567,90 @@ func (e *EAPI) ResolveReverseName(ctx context.Context, p *pb.ResolveReverseNameP
 	}, nil
 }
 
+func (e *EAPI) PinName(ctx context.Context, p *pb.PinNameParams) (*pb.PinNameResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.PinNameResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	hi := iapi.HashSchemeInstanceFromMultihash(p.NameDeclaration)
+	if !hi.Supported() {
+		return &pb.PinNameResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "bad name declaration hash")),
+		}, nil
+	}
+	loc, err := LocationSchemeInstance(p.Location)
+	if err != nil {
+		return &pb.PinNameResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not parse location", err)),
+		}, nil
+	}
+	if loc == nil {
+		loc = iapi.SI().DefaultLocation(ctx)
+	}
+	nd, _, uerr := eng.LookupNameDeclaration(ctx, hi, loc)
+	if uerr != nil {
+		return &pb.PinNameResponse{
+			Error: ToError(wve.ErrW(wve.LookupFailure, "could not resolve name declaration", uerr)),
+		}, nil
+	}
+	if nd == nil {
+		return &pb.PinNameResponse{
+			Error: ToError(wve.Err(wve.LookupFailure, "could not resolve name declaration")),
+		}, nil
+	}
+	err = eng.PinNameDeclaration(ctx, nd)
+	if err != nil {
+		return &pb.PinNameResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	return &pb.PinNameResponse{}, nil
+}
+
+func (e *EAPI) ListNameDeclarations(ctx context.Context, p *pb.ListNameDeclarationsParams) (*pb.ListNameDeclarationsResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.ListNameDeclarationsResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	if len(p.Attester) != 0 && len(p.Subject) != 0 {
+		return &pb.ListNameDeclarationsResponse{
+			Error: ToError(wve.Err(wve.InvalidParameter, "you should specify attester or subject, not both")),
+		}, nil
+	}
+	var attester, subject iapi.HashSchemeInstance
+	switch {
+	case len(p.Subject) != 0:
+		subject, _, err = resolveEntityRef(ctx, eng, p.Subject, "subject")
+	case len(p.Attester) != 0:
+		attester, _, err = resolveEntityRef(ctx, eng, p.Attester, "attester")
+	default:
+		attester = eng.Perspective().Entity.Keccak256HI()
+	}
+	if err != nil {
+		return &pb.ListNameDeclarationsResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	ndz, err := eng.ListNameDeclarations(ctx, attester, subject)
+	if err != nil {
+		return &pb.ListNameDeclarationsResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	rv := &pb.ListNameDeclarationsResponse{}
+	for _, res := range ndz {
+		if p.OnlyValid && !res.Validity.Valid {
+			continue
+		}
+		rv.Results = append(rv.Results, ConvertNDWVal(res.NameDeclaration, res.Validity))
+	}
+	return rv, nil
+}
+
 func (e *EAPI) Revoke(ctx context.Context, p *pb.RevokeParams) (*pb.RevokeResponse, error) {
 	eng, err := e.GetEngine(ctx, p.Perspective)
 	if err != nil {
@@ -1358,9 +2691,19 @@ func (e *EAPI) Revoke(ctx context.Context, p *pb.RevokeParams) (*pb.RevokeRespon
 			}
 			rvk, loc, werr := eng.Perspective().AttestationRevocationDetails(att)
 			if werr != nil {
-				return &pb.RevokeResponse{
-					Error: ToError(werr),
-				}, nil
+				//The perspective may be a delegated revoker instead
+				delegated, derr := publishRevocationStatements(ctx, eng.Perspective(), att)
+				if derr != nil {
+					return &pb.RevokeResponse{
+						Error: ToError(derr),
+					}, nil
+				}
+				if !delegated {
+					return &pb.RevokeResponse{
+						Error: ToError(werr),
+					}, nil
+				}
+				break
 			}
 			fmt.Printf("put the revocation blob\n")
 			_, err = iapi.SI().PutBlob(ctx, loc, rvk)
@@ -1455,6 +2798,46 @@ func (e *EAPI) Revoke(ctx context.Context, p *pb.RevokeParams) (*pb.RevokeRespon
 	return &pb.RevokeResponse{}, nil
 }
 
+//publishRevocationStatements revokes every delegated revocation option in
+//the attestation that names the entity as the revoker. It returns false if
+//there are none
+func publishRevocationStatements(ctx context.Context, revoker *iapi.EntitySecrets, att *iapi.Attestation) (bool, wve.WVE) {
+	found := false
+	for _, ro := range att.Revocations {
+		dr, ok := ro.(*iapi.DelegatedRevocationSchemeInstance)
+		if !ok || !iapi.HashSchemeInstanceEqual(dr.Revoker(), revoker.Entity.Keccak256HI()) {
+			continue
+		}
+		loc := dr.RevokerLocation()
+		if !loc.Supported() {
+			continue
+		}
+		found = true
+		st, werr := iapi.CreateRevocationStatement(ctx, &iapi.PCreateRevocationStatement{
+			Revoker:    revoker,
+			Revocation: dr,
+		})
+		if werr != nil {
+			return false, werr
+		}
+		hashScheme, err := iapi.SI().HashSchemeFor(loc)
+		if err != nil {
+			return false, wve.ErrW(wve.UnsupportedHashScheme, "could not get hash scheme for revoker location", err)
+		}
+		h, err := iapi.SI().PutBlob(ctx, loc, st.DER)
+		if err != nil {
+			return false, wve.ErrW(wve.InternalError, "could not publish revocation", err)
+		}
+		queue := iapi.DelegatedRevocationQueue(hashScheme, dr.Revoker(), dr.DRBody.ID)
+		err = iapi.SI().Enqueue(ctx, loc, queue, h)
+		if err != nil {
+			return false, wve.ErrW(wve.InternalError, "could not publish revocation", err)
+		}
+		iapi.ForgetDelegatedRevocation(queue, loc)
+	}
+	return found, nil
+}
+
 func (e *EAPI) CompactProof(ctx context.Context, p *pb.CompactProofParams) (*pb.CompactProofResponse, error) {
 	rv, err := iapi.CompactProof(ctx, &iapi.PCompactProof{
 		DER: p.DER,
@@ -1468,3 +2851,45 @@ func (e *EAPI) CompactProof(ctx context.Context, p *pb.CompactProofParams) (*pb.
 		ProofDER: rv.DER,
 	}, nil
 }
+
+func (e *EAPI) PrecomputeKeyBundle(ctx context.Context, p *pb.PrecomputeKeyBundleParams) (*pb.PrecomputeKeyBundleResponse, error) {
+	eng, err := e.GetEngine(ctx, p.Perspective)
+	if err != nil {
+		return &pb.PrecomputeKeyBundleResponse{
+			Error: ToError(wve.ErrW(wve.InvalidParameter, "could not create perspective", err)),
+		}, nil
+	}
+	pol, err := e.ConvertPolicy(p.Policy)
+	if err != nil {
+		return &pb.PrecomputeKeyBundleResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	dctx := engine.NewEngineDecryptionContext(eng)
+	validFrom := TimeFromInt64MillisWithDefault(p.ValidFrom, time.Now())
+	validUntil := TimeFromInt64MillisWithDefault(p.ValidUntil, validFrom.Add(30*24*time.Hour))
+	rv, err := iapi.PrecomputeWR1Bundle(ctx, &iapi.PPrecomputeWR1Bundle{
+		EncryptionContext: dctx,
+		Attester:          eng.Perspective(),
+		Policy:            pol,
+		ValidFrom:         *validFrom,
+		ValidUntil:        *validUntil,
+	})
+	if err != nil {
+		return &pb.PrecomputeKeyBundleResponse{
+			Error: ToError(err),
+		}, nil
+	}
+	return &pb.PrecomputeKeyBundleResponse{
+		Keys:      int64(rv.Keys),
+		Generated: int64(rv.Generated),
+	}, nil
+}
+
+func (e *EAPI) KeyBundleCacheStats(ctx context.Context, p *pb.KeyBundleCacheStatsParams) (*pb.KeyBundleCacheStatsResponse, error) {
+	stats := iapi.GetWR1BundleCacheStats()
+	return &pb.KeyBundleCacheStatsResponse{
+		Hits:   stats.Hits,
+		Misses: stats.Misses,
+	}, nil
+}
@@ -1,8 +1,13 @@
 package eapi
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/x509"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
@@ -16,10 +21,12 @@ import (
 	"github.com/immesys/wave/iapi"
 	"github.com/immesys/wave/localdb/lls"
 	"github.com/immesys/wave/localdb/poc"
+	"github.com/immesys/wave/serdes"
 	"github.com/immesys/wave/storage/memoryserver"
 	"github.com/immesys/wave/storage/overlay"
 	multihash "github.com/multiformats/go-multihash"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 var eapi *EAPI
@@ -413,6 +420,106 @@ func TestE2EEOAQUEEncryptionNS(t *testing.T) {
 	require.Equal(t, decrv.Content, msg)
 }
 
+func TestE2EEReencryptNewNamespace(t *testing.T) {
+	ctx := context.Background()
+	srcPublic, srcSecret := createEntity(t)
+	dstPublic, dstSecret := createEntity(t)
+	srcpub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      srcPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	dstpub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      dstPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	srcperspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: srcSecret,
+		},
+		Location: &inmem,
+	}
+	dstperspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: dstSecret,
+		},
+		Location: &inmem,
+	}
+	msg := make([]byte, 512)
+	rand.Read(msg)
+	encrv, err := eapi.EncryptMessage(ctx, &pb.EncryptMessageParams{
+		Content:           msg,
+		Namespace:         srcpub.Hash,
+		NamespaceLocation: &inmem,
+		Resource:          "foo/bar",
+	})
+	require.NoError(t, err)
+	require.Nil(t, encrv.Error)
+
+	//The namespace recipient is kept but moved to the new namespace
+	rerv, err := eapi.ReencryptMessage(ctx, &pb.ReencryptMessageParams{
+		Perspective:          srcperspective,
+		Ciphertext:           encrv.Ciphertext,
+		NewNamespace:         dstpub.Hash,
+		NewNamespaceLocation: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, rerv.Error)
+	require.Len(t, rerv.Recipients, 1)
+	require.Equal(t, dstpub.Hash, rerv.Recipients[0].Namespace)
+	require.Equal(t, "foo/bar", rerv.Recipients[0].Resource)
+	require.Len(t, rerv.LostAccess, 1)
+	require.Equal(t, srcpub.Hash, rerv.LostAccess[0].Namespace)
+
+	decrv, err := eapi.DecryptMessage(ctx, &pb.DecryptMessageParams{
+		Perspective: dstperspective,
+		Ciphertext:  rerv.Ciphertext,
+	})
+	require.NoError(t, err)
+	require.Nil(t, decrv.Error)
+	require.Equal(t, msg, decrv.Content)
+	decrv, err = eapi.DecryptMessage(ctx, &pb.DecryptMessageParams{
+		Perspective: srcperspective,
+		Ciphertext:  rerv.Ciphertext,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, decrv.Error)
+
+	//Without a new namespace the recipient is kept in a fresh window
+	rerv, err = eapi.ReencryptMessage(ctx, &pb.ReencryptMessageParams{
+		Perspective: srcperspective,
+		Ciphertext:  encrv.Ciphertext,
+	})
+	require.NoError(t, err)
+	require.Nil(t, rerv.Error)
+	require.Len(t, rerv.Recipients, 1)
+	require.Equal(t, srcpub.Hash, rerv.Recipients[0].Namespace)
+	decrv, err = eapi.DecryptMessage(ctx, &pb.DecryptMessageParams{
+		Perspective: srcperspective,
+		Ciphertext:  rerv.Ciphertext,
+	})
+	require.NoError(t, err)
+	require.Nil(t, decrv.Error)
+	require.Equal(t, msg, decrv.Content)
+
+	//A new namespace can not be combined with explicit recipients
+	rerv, err = eapi.ReencryptMessage(ctx, &pb.ReencryptMessageParams{
+		Perspective:          srcperspective,
+		Ciphertext:           encrv.Ciphertext,
+		NewNamespace:         dstpub.Hash,
+		NewNamespaceLocation: &inmem,
+		Subjects: []*pb.EncryptionSubject{
+			{
+				Hash:     dstpub.Hash,
+				Location: &inmem,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, rerv.Error)
+}
+
 func TestE2EEOAQUEEncryptionDelegated(t *testing.T) {
 	ctx := context.Background()
 	srcPublic, srcSecret := createEntity(t)
@@ -1512,6 +1619,296 @@ func TestCreateAttestationWithExpiredLookup(t *testing.T) {
 	require.EqualValues(t, false, res.Validity.Valid)
 }
 
+func TestCreateAttestations(t *testing.T) {
+	ctx := context.Background()
+	_, srcSecret, srcHash := createAndPublishEntity(t)
+	_, _, dst1 := createAndPublishEntity(t)
+	_, _, dst2 := createAndPublishEntity(t)
+	item := func(subject []byte) *pb.CreateAttestationParams {
+		return &pb.CreateAttestationParams{
+			BodyScheme:      BodySchemeWaveRef1,
+			SubjectHash:     subject,
+			SubjectLocation: &inmem,
+			Policy: &pb.Policy{
+				RTreePolicy: &pb.RTreePolicy{
+					Namespace: srcHash,
+					Statements: []*pb.RTreePolicyStatement{
+						&pb.RTreePolicyStatement{
+							PermissionSet: srcHash,
+							Permissions:   []string{"foo"},
+							Resource:      "a/b",
+						},
+					},
+				},
+			},
+			Publish: true,
+		}
+	}
+	//The unknown subject and the missing item fail but do not stop the
+	//others
+	unknown := iapi.KECCAK256.Instance([]byte("no such entity")).Multihash()
+	resp, err := eapi.CreateAttestations(ctx, &pb.CreateAttestationsParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: srcSecret,
+			},
+			Location: &inmem,
+		},
+		Attestations: []*pb.CreateAttestationParams{item(dst1), item(unknown), item(dst2), nil},
+		Sync:         true,
+		Concurrency:  2,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+	require.Equal(t, 4, len(resp.Results))
+	require.Nil(t, resp.Results[0].Error)
+	require.NotNil(t, resp.Results[1].Error)
+	require.Nil(t, resp.Results[2].Error)
+	require.NotNil(t, resp.Results[3].Error)
+	require.NotEqual(t, resp.Results[0].Hash, resp.Results[2].Hash)
+
+	//They were published
+	for _, r := range []*pb.CreateAttestationResponse{resp.Results[0], resp.Results[2]} {
+		rv, err := eapi.ResolveHash(ctx, &pb.ResolveHashParams{
+			Hash: r.Hash,
+		})
+		require.NoError(t, err)
+		require.Nil(t, rv.Error)
+		require.NotNil(t, rv.Attestation)
+	}
+}
+
+func TestLookupAttestationsFilterAndPaginate(t *testing.T) {
+	ctx := context.Background()
+	_, srcSecret, srcHash := createAndPublishEntity(t)
+	_, dstSecret, dstHash := createAndPublishEntity(t)
+	dstperspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: dstSecret,
+		},
+		Location: &inmem,
+	}
+	createAt := func(perm string, res string) {
+		att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+			Perspective: &pb.Perspective{
+				EntitySecret: &pb.EntitySecret{
+					DER: srcSecret,
+				},
+				Location: &inmem,
+			},
+			BodyScheme:      BodySchemeWaveRef1,
+			SubjectHash:     dstHash,
+			SubjectLocation: &inmem,
+			Policy: &pb.Policy{
+				RTreePolicy: &pb.RTreePolicy{
+					Namespace: srcHash,
+					Statements: []*pb.RTreePolicyStatement{
+						&pb.RTreePolicyStatement{
+							PermissionSet: srcHash,
+							Permissions:   []string{perm},
+							Resource:      res,
+						},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, att.Error)
+		pubresp, err := eapi.PublishAttestation(ctx, &pb.PublishAttestationParams{
+			DER: att.DER,
+		})
+		require.NoError(t, err)
+		require.Nil(t, pubresp.Error)
+	}
+	createAt("foo", "a/b")
+	createAt("bar", "a/c")
+	createAt("foo", "x/y")
+	rv, err := eapi.ResyncPerspectiveGraph(ctx, &pb.ResyncPerspectiveGraphParams{
+		Perspective: dstperspective,
+	})
+	require.NoError(t, err)
+	require.Nil(t, rv.Error)
+	for {
+		ss, err := eapi.SyncStatus(ctx, &pb.SyncParams{
+			Perspective: dstperspective,
+		})
+		require.NoError(t, err)
+		require.Nil(t, ss.Error)
+		if ss.CompletedSyncs == ss.TotalSyncRequests {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	lookup := func(p *pb.LookupAttestationsParams) *pb.LookupAttestationsResponse {
+		p.Perspective = dstperspective
+		p.ToEntity = dstHash
+		resp, err := eapi.LookupAttestations(ctx, p)
+		require.NoError(t, err)
+		require.Nil(t, resp.Error)
+		return resp
+	}
+	require.Equal(t, 3, len(lookup(&pb.LookupAttestationsParams{}).Results))
+
+	//Pages do not overlap and together cover every result
+	page1 := lookup(&pb.LookupAttestationsParams{PageSize: 2})
+	require.Equal(t, 2, len(page1.Results))
+	require.NotEmpty(t, page1.NextCursor)
+	page2 := lookup(&pb.LookupAttestationsParams{PageSize: 2, Cursor: page1.NextCursor})
+	require.Equal(t, 1, len(page2.Results))
+	require.Empty(t, page2.NextCursor)
+	seen := make(map[string]bool)
+	for _, r := range append(page1.Results, page2.Results...) {
+		seen[string(r.Hash)] = true
+	}
+	require.Equal(t, 3, len(seen))
+
+	require.Equal(t, 2, len(lookup(&pb.LookupAttestationsParams{ResourcePrefix: "a/"}).Results))
+	require.Equal(t, 1, len(lookup(&pb.LookupAttestationsParams{Permission: "bar"}).Results))
+	require.Equal(t, 3, len(lookup(&pb.LookupAttestationsParams{PermissionSet: srcHash, Namespace: srcHash}).Results))
+	require.Equal(t, 0, len(lookup(&pb.LookupAttestationsParams{PermissionSet: dstHash}).Results))
+	require.Equal(t, 3, len(lookup(&pb.LookupAttestationsParams{ExcludeRevoked: true}).Results))
+	require.Equal(t, 0, len(lookup(&pb.LookupAttestationsParams{OnlyRevoked: true}).Results))
+	past := time.Now().Add(-time.Hour).UnixNano() / 1e6
+	require.Equal(t, 0, len(lookup(&pb.LookupAttestationsParams{ExpiresBefore: past}).Results))
+	require.Equal(t, 3, len(lookup(&pb.LookupAttestationsParams{ExpiresAfter: past}).Results))
+}
+
+func TestEntitySuccessionProof(t *testing.T) {
+	ctx := context.Background()
+	_, nsSecret, nsHash := createAndPublishEntity(t)
+	_, oldSecret, oldHash := createAndPublishEntity(t)
+	_, newSecret, newHash := createAndPublishEntity(t)
+	oldPerspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: oldSecret,
+		},
+		Location: &inmem,
+	}
+	statements := []*pb.RTreePolicyStatement{
+		&pb.RTreePolicyStatement{
+			PermissionSet: nsHash,
+			Permissions:   []string{"foo"},
+			Resource:      "a/b",
+		},
+	}
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: nsSecret,
+			},
+			Location: &inmem,
+		},
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     oldHash,
+		SubjectLocation: &inmem,
+		Policy: &pb.Policy{
+			RTreePolicy: &pb.RTreePolicy{
+				Namespace:  nsHash,
+				Statements: statements,
+			},
+		},
+		Publish: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+
+	grace := time.Now().Add(time.Hour)
+	sresp, err := eapi.CreateEntitySuccession(ctx, &pb.CreateEntitySuccessionParams{
+		Perspective:       oldPerspective,
+		Successor:         newHash,
+		SuccessorLocation: &inmem,
+		GraceUntil:        grace.UnixNano() / 1e6,
+	})
+	require.NoError(t, err)
+	require.Nil(t, sresp.Error)
+	require.Equal(t, grace.UnixNano()/1e6, sresp.GraceUntil)
+
+	rv, err := eapi.ResyncPerspectiveGraph(ctx, &pb.ResyncPerspectiveGraphParams{
+		Perspective: oldPerspective,
+	})
+	require.NoError(t, err)
+	require.Nil(t, rv.Error)
+	for {
+		ss, err := eapi.SyncStatus(ctx, &pb.SyncParams{
+			Perspective: oldPerspective,
+		})
+		require.NoError(t, err)
+		require.Nil(t, ss.Error)
+		if ss.CompletedSyncs == ss.TotalSyncRequests {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	//The old entity's grant is usable by the successor
+	resp, err := eapi.BuildRTreeProof(ctx, &pb.BuildRTreeProofParams{
+		Perspective: oldPerspective,
+		SubjectHash: newHash,
+		Namespace:   nsHash,
+		Statements:  statements,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+	require.True(t, resp.Result.Expiry <= grace.UnixNano()/1e6)
+
+	vresp, err := eapi.VerifyProof(ctx, &pb.VerifyProofParams{
+		ProofDER: resp.ProofDER,
+		Subject:  newHash,
+		RequiredRTreePolicy: &pb.RTreePolicy{
+			Namespace:  nsHash,
+			Statements: statements,
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, vresp.Error)
+	require.Equal(t, newHash, vresp.Result.Subject)
+
+	vresp, err = eapi.VerifyProof(ctx, &pb.VerifyProofParams{
+		ProofDER: resp.ProofDER,
+		Subject:  oldHash,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, vresp.Error)
+
+	//Grants to the old entity do not extend to unrelated entities
+	_, _, otherHash := createAndPublishEntity(t)
+	otherResp, err := eapi.BuildRTreeProof(ctx, &pb.BuildRTreeProofParams{
+		Perspective: oldPerspective,
+		SubjectHash: otherHash,
+		Namespace:   nsHash,
+		Statements:  statements,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, otherResp.Error)
+
+	//A revoked successor does not inherit the grants
+	rvkr, err := eapi.Revoke(ctx, &pb.RevokeParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: newSecret,
+			},
+			Location: &inmem,
+		},
+		RevokePerspective: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, rvkr.Error)
+	vresp, err = eapi.VerifyProof(ctx, &pb.VerifyProofParams{
+		ProofDER: resp.ProofDER,
+		Subject:  newHash,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, vresp.Error)
+	resp, err = eapi.BuildRTreeProof(ctx, &pb.BuildRTreeProofParams{
+		Perspective: oldPerspective,
+		SubjectHash: newHash,
+		Namespace:   nsHash,
+		Statements:  statements,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Error)
+}
+
 func TestBuildRTreeProof(t *testing.T) {
 	ctx := context.Background()
 	publics := make([][]byte, 9)
@@ -1756,3 +2153,734 @@ func TestBuildRTreeProofVerify(t *testing.T) {
 	// require.EqualValues(t, 5, len(resp.Results[0].Elements))
 	// require.EqualValues(t, pubs[8].Hash, resp.Results[0].Elements[4].SubjectHash)
 }
+
+func TestCreateX509Certificate(t *testing.T) {
+	ctx := context.Background()
+	tg := TG()
+	tg.Edge(t, "ns", "a", "1", 0)
+	proof := tg.Build(t, "a", "1")
+	require.Nil(t, proof.Error)
+	perspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: tg.secrets["a"],
+		},
+		Location: &inmem,
+	}
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafPub, err := x509.MarshalPKIXPublicKey(leafKey.Public())
+	require.NoError(t, err)
+	certs, err := eapi.CreateX509Certificate(ctx, &pb.CreateX509CertificateParams{
+		Perspective:   perspective,
+		ProofDER:      proof.ProofDER,
+		LeafPublicKey: leafPub,
+		ValidFor:      10 * 60 * 1000,
+	})
+	require.NoError(t, err)
+	require.Nil(t, certs.Error)
+
+	eng, werr := eapi.GetEngine(ctx, perspective)
+	require.NoError(t, werr)
+	vrv, werr := iapi.VerifyProofCertificate(ctx, &iapi.PVerifyProofCertificate{
+		Chain: [][]byte{certs.LeafCertificate, certs.EntityCertificate},
+		VCtx:  engine.NewEngineDecryptionContext(eng),
+	})
+	require.NoError(t, werr)
+	require.Equal(t, tg.pubs["a"].Hash, vrv.Entity.Keccak256HI().Multihash())
+	require.True(t, vrv.Leaf.NotAfter.Before(time.Now().Add(11*time.Minute)))
+
+	//The proof cannot be used by an entity that is not its subject
+	rv, err := eapi.CreateX509Certificate(ctx, &pb.CreateX509CertificateParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets["ns"],
+			},
+			Location: &inmem,
+		},
+		ProofDER:      proof.ProofDER,
+		LeafPublicKey: leafPub,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, rv.Error)
+
+	//A chain issued by another entity does not verify
+	other, err := eapi.CreateX509Certificate(ctx, &pb.CreateX509CertificateParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets["ns"],
+			},
+			Location: &inmem,
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, other.Error)
+	_, werr = iapi.VerifyProofCertificate(ctx, &iapi.PVerifyProofCertificate{
+		Chain: [][]byte{certs.LeafCertificate, other.EntityCertificate},
+		VCtx:  engine.NewEngineDecryptionContext(eng),
+	})
+	require.Error(t, werr)
+}
+
+func TestCreateSSHCertificate(t *testing.T) {
+	ctx := context.Background()
+	tg := TG()
+	tg.Edge(t, "ns", "a", "1", 0)
+	proof := tg.Build(t, "a", "1")
+	require.Nil(t, proof.Error)
+
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+	nshash := base64.URLEncoding.EncodeToString(tg.pubs["ns"].Hash)
+	perm, err := parseSSHPermission(fmt.Sprintf("%s:%x@%s/common/*", nshash, 1, nshash))
+	require.NoError(t, err)
+	eapi.sshca = &sshCA{
+		signer:      caSigner,
+		permission:  perm,
+		maxValidity: time.Hour,
+	}
+	defer func() { eapi.sshca = nil }()
+
+	userPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(userPub)
+	require.NoError(t, err)
+	sig, err := eapi.Sign(ctx, &pb.SignParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets["a"],
+			},
+			Location: &inmem,
+		},
+		Content: iapi.SSHCertificateRequestContent(sshPub),
+	})
+	require.NoError(t, err)
+	require.Nil(t, sig.Error)
+	rv, err := eapi.CreateSSHCertificate(ctx, &pb.CreateSSHCertificateParams{
+		ProofDER:  proof.ProofDER,
+		PublicKey: ssh.MarshalAuthorizedKey(sshPub),
+		Signature: sig.Signature,
+	})
+	require.NoError(t, err)
+	require.Nil(t, rv.Error)
+	require.Equal(t, []string{"resource"}, rv.Principals)
+
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey(rv.Certificate)
+	require.NoError(t, err)
+	cert := parsed.(*ssh.Certificate)
+	checker := ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool {
+			return bytes.Equal(auth.Marshal(), caSigner.PublicKey().Marshal())
+		},
+	}
+	require.NoError(t, checker.CheckCert("resource", cert))
+	require.Error(t, checker.CheckCert("root", cert))
+	require.True(t, time.Unix(int64(cert.ValidBefore), 0).Before(time.Now().Add(61*time.Minute)))
+
+	//Only the subject of the proof can use it
+	sig, err = eapi.Sign(ctx, &pb.SignParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets["ns"],
+			},
+			Location: &inmem,
+		},
+		Content: iapi.SSHCertificateRequestContent(sshPub),
+	})
+	require.NoError(t, err)
+	require.Nil(t, sig.Error)
+	rv, err = eapi.CreateSSHCertificate(ctx, &pb.CreateSSHCertificateParams{
+		ProofDER:  proof.ProofDER,
+		PublicKey: ssh.MarshalAuthorizedKey(sshPub),
+		Signature: sig.Signature,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, rv.Error)
+}
+
+func TestUpdateRevocationList(t *testing.T) {
+	ctx := context.Background()
+	nsPublic, nsSecret := createEntity(t)
+	nsPub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      nsPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, nsPub.Error)
+	aPublic, _ := createEntity(t)
+	aPub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      aPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, aPub.Error)
+	nsPerspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: nsSecret,
+		},
+		Location: &inmem,
+	}
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective:     nsPerspective,
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     aPub.Hash,
+		SubjectLocation: &inmem,
+		Policy: &pb.Policy{
+			TrustLevelPolicy: &pb.TrustLevelPolicy{
+				Trust: 3,
+			},
+		},
+		RevocationLists: []*pb.RevocationListReference{
+			{
+				Revoker:         nsPub.Hash,
+				RevokerLocation: &inmem,
+				ListID:          []byte("staff"),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+
+	parsed, werr := iapi.ParseAttestation(ctx, &iapi.PParseAttestation{
+		DER: att.DER,
+	})
+	require.Nil(t, werr)
+	var rl *iapi.RevocationListSchemeInstance
+	for _, r := range parsed.Attestation.Revocations {
+		if inst, ok := r.(*iapi.RevocationListSchemeInstance); ok {
+			rl = inst
+		}
+	}
+	require.NotNil(t, rl)
+	revoked, werr := rl.IsRevoked(ctx, iapi.SI())
+	require.Nil(t, werr)
+	require.False(t, revoked)
+
+	resp, err := eapi.UpdateRevocationList(ctx, &pb.UpdateRevocationListParams{
+		Perspective:  nsPerspective,
+		ListID:       []byte("staff"),
+		Attestations: [][]byte{att.DER},
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+	require.EqualValues(t, 1, resp.Version)
+	require.EqualValues(t, [][]byte{rl.RLBody.ID}, resp.Revoked)
+	revoked, werr = rl.IsRevoked(ctx, iapi.SI())
+	require.Nil(t, werr)
+	require.True(t, revoked)
+
+	resp, err = eapi.UpdateRevocationList(ctx, &pb.UpdateRevocationListParams{
+		Perspective: nsPerspective,
+		ListID:      []byte("staff"),
+		Unrevoke:    [][]byte{rl.RLBody.ID},
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+	require.EqualValues(t, 2, resp.Version)
+	require.Empty(t, resp.Revoked)
+	revoked, werr = rl.IsRevoked(ctx, iapi.SI())
+	require.Nil(t, werr)
+	require.False(t, revoked)
+
+	//An attestation that does not name the list cannot be added to it
+	resp, err = eapi.UpdateRevocationList(ctx, &pb.UpdateRevocationListParams{
+		Perspective:  nsPerspective,
+		ListID:       []byte("other"),
+		Attestations: [][]byte{att.DER},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Error)
+}
+
+func TestDelegatedRevoker(t *testing.T) {
+	ctx := context.Background()
+	tg := TG()
+	tg.Edge(t, "ns", "a", "1", 0)
+	tg.Edge(t, "ns", "revoker", "1", 0)
+	perspective := func(name string) *pb.Perspective {
+		return &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets[name],
+			},
+			Location: &inmem,
+		}
+	}
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective:     perspective("ns"),
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     tg.pubs["a"].Hash,
+		SubjectLocation: &inmem,
+		Policy: &pb.Policy{
+			TrustLevelPolicy: &pb.TrustLevelPolicy{
+				Trust: 3,
+			},
+		},
+		Revokers: []*pb.RevokerReference{
+			{
+				Revoker:         tg.pubs["revoker"].Hash,
+				RevokerLocation: &inmem,
+			},
+		},
+		Publish: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+	parsed, werr := iapi.ParseAttestation(ctx, &iapi.PParseAttestation{
+		DER: att.DER,
+	})
+	require.Nil(t, werr)
+	var dr *iapi.DelegatedRevocationSchemeInstance
+	for _, r := range parsed.Attestation.Revocations {
+		if inst, ok := r.(*iapi.DelegatedRevocationSchemeInstance); ok {
+			dr = inst
+		}
+	}
+	require.NotNil(t, dr)
+
+	//An entity that is not named cannot revoke
+	resp, err := eapi.Revoke(ctx, &pb.RevokeParams{
+		Perspective:     perspective("a"),
+		AttestationHash: att.Hash,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Error)
+	revoked, werr := dr.IsRevoked(ctx, iapi.SI())
+	require.Nil(t, werr)
+	require.False(t, revoked)
+
+	resp, err = eapi.Revoke(ctx, &pb.RevokeParams{
+		Perspective:     perspective("revoker"),
+		AttestationHash: att.Hash,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+	revoked, werr = dr.IsRevoked(ctx, iapi.SI())
+	require.Nil(t, werr)
+	require.True(t, revoked)
+}
+
+func TestCheckRevocations(t *testing.T) {
+	ctx := context.Background()
+	tg := TG()
+	tg.Edge(t, "ns", "a", "1", 0)
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets["ns"],
+			},
+			Location: &inmem,
+		},
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     tg.pubs["a"].Hash,
+		SubjectLocation: &inmem,
+		Policy: &pb.Policy{
+			TrustLevelPolicy: &pb.TrustLevelPolicy{
+				Trust: 3,
+			},
+		},
+		Publish: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+	attHash := att.Hash
+	params := &pb.CheckRevocationsParams{
+		AttestationHashes: [][]byte{attHash},
+		EntityHashes:      [][]byte{tg.pubs["a"].Hash, []byte("not a hash")},
+		MaxAge:            int64(time.Hour / time.Millisecond),
+	}
+	rv, err := eapi.CheckRevocations(ctx, params)
+	require.NoError(t, err)
+	require.Nil(t, rv.Error)
+	require.Len(t, rv.Statuses, 3)
+	for _, st := range rv.Statuses[:2] {
+		require.Equal(t, "valid", st.Status)
+		require.NotZero(t, st.LastChecked)
+		require.NotNil(t, st.Location)
+	}
+	require.Equal(t, "unknown", rv.Statuses[2].Status)
+
+	//A recent check is reused
+	again, err := eapi.CheckRevocations(ctx, params)
+	require.NoError(t, err)
+	require.Nil(t, again.Error)
+	require.Equal(t, rv.Statuses[0].LastChecked, again.Statuses[0].LastChecked)
+
+	resp, err := eapi.Revoke(ctx, &pb.RevokeParams{
+		Perspective: &pb.Perspective{
+			EntitySecret: &pb.EntitySecret{
+				DER: tg.secrets["ns"],
+			},
+			Location: &inmem,
+		},
+		AttestationHash: attHash,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+	rv, err = eapi.CheckRevocations(ctx, params)
+	require.NoError(t, err)
+	require.Nil(t, rv.Error)
+	require.Equal(t, "revoked", rv.Statuses[0].Status)
+	require.Equal(t, "valid", rv.Statuses[1].Status)
+}
+
+func TestE2EEOAQUEEncryptionPartitionSchedule(t *testing.T) {
+	ctx := context.Background()
+	nsrv, err := eapi.CreateEntity(ctx, &pb.CreateEntityParams{
+		ValidUntil: time.Now().Add(365*24*time.Hour).UnixNano() / 1e6,
+		PartitionSchedule: &pb.PartitionSchedule{
+			Tiers:    []int64{int64(time.Hour), int64(10 * time.Minute), int64(time.Minute)},
+			SlotSize: 4,
+			MaxRange: int64(24 * time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, nsrv.Error)
+	dstPublic, dstSecret := createEntity(t)
+	nspub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      nsrv.PublicDER,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, nspub.Error)
+	dstpub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      dstPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, dstpub.Error)
+	nsperspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: nsrv.SecretDER,
+		},
+		Location: &inmem,
+	}
+	dstperspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: dstSecret,
+		},
+		Location: &inmem,
+	}
+	msg := make([]byte, 512)
+	rand.Read(msg)
+	encrv, err := eapi.EncryptMessage(ctx, &pb.EncryptMessageParams{
+		Content:           msg,
+		Namespace:         nspub.Hash,
+		NamespaceLocation: &inmem,
+		Resource:          "foo/bar",
+		ValidUntil:        time.Now().Add(time.Hour).UnixNano() / 1e6,
+	})
+	require.NoError(t, err)
+	require.Nil(t, encrv.Error)
+
+	policy := &pb.Policy{
+		RTreePolicy: &pb.RTreePolicy{
+			Namespace:    nspub.Hash,
+			Indirections: 5,
+			Statements: []*pb.RTreePolicyStatement{
+				&pb.RTreePolicyStatement{
+					PermissionSet: []byte(consts.WaveBuiltinPSETBytes),
+					Permissions:   []string{consts.WaveBuiltinE2EE},
+					Resource:      "foo/*",
+				},
+			},
+		},
+	}
+	//The schedule does not allow ranges longer than a day
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective:     nsperspective,
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     dstpub.Hash,
+		SubjectLocation: &inmem,
+		ValidUntil:      time.Now().Add(48*time.Hour).UnixNano() / 1e6,
+		Policy:          policy,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, att.Error)
+
+	att, err = eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective:     nsperspective,
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     dstpub.Hash,
+		SubjectLocation: &inmem,
+		ValidUntil:      time.Now().Add(12*time.Hour).UnixNano() / 1e6,
+		Policy:          policy,
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+	rpa, werr := iapi.ParseAttestation(ctx, &iapi.PParseAttestation{
+		DER: att.DER,
+	})
+	require.NoError(t, werr)
+	require.True(t, rpa.Attestation.CanonicalForm.TBS.Body.OID.Equal(serdes.WR1BodyV2OID))
+
+	pubrv, err := eapi.PublishAttestation(ctx, &pb.PublishAttestationParams{
+		DER: att.DER,
+	})
+	require.NoError(t, err)
+	require.Nil(t, pubrv.Error)
+
+	decrv, err := eapi.DecryptMessage(ctx, &pb.DecryptMessageParams{
+		Perspective: dstperspective,
+		Ciphertext:  encrv.Ciphertext,
+		ResyncFirst: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, decrv.Error)
+	require.Equal(t, msg, decrv.Content)
+}
+
+func TestPrecomputeKeyBundle(t *testing.T) {
+	ctx := context.Background()
+	nsPublic, nsSecret := createEntity(t)
+	nsPub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      nsPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, nsPub.Error)
+	aPublic, _ := createEntity(t)
+	aPub, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      aPublic,
+		Location: &inmem,
+	})
+	require.NoError(t, err)
+	require.Nil(t, aPub.Error)
+	nsPerspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: nsSecret,
+		},
+		Location: &inmem,
+	}
+	policy := &pb.Policy{
+		RTreePolicy: &pb.RTreePolicy{
+			Namespace:    nsPub.Hash,
+			Indirections: 5,
+			Statements: []*pb.RTreePolicyStatement{
+				{
+					PermissionSet: nsPub.Hash,
+					Permissions:   []string{"foo"},
+					Resource:      "precompute/resource",
+				},
+			},
+		},
+	}
+	validFrom := time.Now().UnixNano() / 1e6
+	validUntil := validFrom + 60*24*60*60*1000
+	pre, err := eapi.PrecomputeKeyBundle(ctx, &pb.PrecomputeKeyBundleParams{
+		Perspective: nsPerspective,
+		Policy:      policy,
+		ValidFrom:   validFrom,
+		ValidUntil:  validUntil,
+	})
+	require.NoError(t, err)
+	require.Nil(t, pre.Error)
+	require.True(t, pre.Keys > 0)
+	require.EqualValues(t, pre.Keys, pre.Generated)
+
+	//Everything is cached now
+	pre2, err := eapi.PrecomputeKeyBundle(ctx, &pb.PrecomputeKeyBundleParams{
+		Perspective: nsPerspective,
+		Policy:      policy,
+		ValidFrom:   validFrom,
+		ValidUntil:  validUntil,
+	})
+	require.NoError(t, err)
+	require.Nil(t, pre2.Error)
+	require.EqualValues(t, pre.Keys, pre2.Keys)
+	require.EqualValues(t, 0, pre2.Generated)
+
+	before, err := eapi.KeyBundleCacheStats(ctx, &pb.KeyBundleCacheStatsParams{})
+	require.NoError(t, err)
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective:     nsPerspective,
+		BodyScheme:      BodySchemeWaveRef1,
+		SubjectHash:     aPub.Hash,
+		SubjectLocation: &inmem,
+		Policy:          policy,
+		ValidFrom:       validFrom,
+		ValidUntil:      validUntil,
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+	after, err := eapi.KeyBundleCacheStats(ctx, &pb.KeyBundleCacheStatsParams{})
+	require.NoError(t, err)
+	require.EqualValues(t, pre.Keys, after.Hits-before.Hits)
+	require.EqualValues(t, 0, after.Misses-before.Misses)
+}
+
+func TestNamedSubjectAndNamespace(t *testing.T) {
+	ctx := context.Background()
+	_, nsSecret, nsHash := createAndPublishEntity(t)
+	_, bSecret, bHash := createAndPublishEntity(t)
+	nsPerspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: nsSecret,
+		},
+		Location: &inmem,
+	}
+	bPerspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: bSecret,
+		},
+		Location: &inmem,
+	}
+	nameEntity := func(persp *pb.Perspective, name string, subject []byte) {
+		rv, err := eapi.CreateNameDeclaration(ctx, &pb.CreateNameDeclarationParams{
+			Perspective:     persp,
+			Name:            name,
+			Subject:         subject,
+			SubjectLocation: &inmem,
+		})
+		require.NoError(t, err)
+		require.Nil(t, rv.Error)
+		srv, err := eapi.ResyncPerspectiveGraph(ctx, &pb.ResyncPerspectiveGraphParams{
+			Perspective: persp,
+		})
+		require.NoError(t, err)
+		require.Nil(t, srv.Error)
+		for {
+			ss, err := eapi.SyncStatus(ctx, &pb.SyncParams{
+				Perspective: persp,
+			})
+			require.NoError(t, err)
+			require.Nil(t, ss.Error)
+			if ss.CompletedSyncs == ss.TotalSyncRequests {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	nameEntity(nsPerspective, "bob", bHash)
+	nameEntity(bPerspective, "home", nsHash)
+
+	policy := &pb.Policy{
+		RTreePolicy: &pb.RTreePolicy{
+			Namespace:    nsHash,
+			Indirections: 5,
+			Statements: []*pb.RTreePolicyStatement{
+				{
+					PermissionSet: nsHash,
+					Permissions:   []string{"foo"},
+					Resource:      "named/resource",
+				},
+			},
+		},
+	}
+	att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective: nsPerspective,
+		BodyScheme:  BodySchemeWaveRef1,
+		SubjectHash: []byte("bob"),
+		Policy:      policy,
+	})
+	require.NoError(t, err)
+	require.Nil(t, att.Error)
+	insp, err := eapi.Inspect(ctx, &pb.InspectParams{
+		Content: att.DER,
+	})
+	require.NoError(t, err)
+	require.Nil(t, insp.Error)
+	require.EqualValues(t, bHash, insp.Attestation.SubjectHash)
+	pubresp, err := eapi.PublishAttestation(ctx, &pb.PublishAttestationParams{
+		DER: att.DER,
+	})
+	require.NoError(t, err)
+	require.Nil(t, pubresp.Error)
+
+	//The subject resolves from the perspective, so other names fail
+	bad, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+		Perspective: nsPerspective,
+		BodyScheme:  BodySchemeWaveRef1,
+		SubjectHash: []byte("home"),
+		Policy:      policy,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, bad.Error)
+
+	//The namespace name is resolved from the prover's perspective
+	proof, err := eapi.BuildRTreeProof(ctx, &pb.BuildRTreeProofParams{
+		Perspective: bPerspective,
+		Namespace:   []byte("home"),
+		Statements: []*pb.RTreePolicyStatement{
+			{
+				PermissionSet: nsHash,
+				Permissions:   []string{"foo"},
+				Resource:      "named/resource",
+			},
+		},
+		ResyncFirst: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, proof.Error)
+	require.NotNil(t, proof.Result)
+}
+
+func TestListNameDeclarations(t *testing.T) {
+	ctx := context.Background()
+	_, aSecret, _ := createAndPublishEntity(t)
+	_, _, bHash := createAndPublishEntity(t)
+	_, _, cHash := createAndPublishEntity(t)
+	aPerspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: aSecret,
+		},
+		Location: &inmem,
+	}
+	for name, subject := range map[string][]byte{"carol": cHash, "bob": bHash} {
+		rv, err := eapi.CreateNameDeclaration(ctx, &pb.CreateNameDeclarationParams{
+			Perspective:     aPerspective,
+			Name:            name,
+			Subject:         subject,
+			SubjectLocation: &inmem,
+		})
+		require.NoError(t, err)
+		require.Nil(t, rv.Error)
+	}
+	srv, err := eapi.ResyncPerspectiveGraph(ctx, &pb.ResyncPerspectiveGraphParams{
+		Perspective: aPerspective,
+	})
+	require.NoError(t, err)
+	require.Nil(t, srv.Error)
+	for {
+		ss, err := eapi.SyncStatus(ctx, &pb.SyncParams{
+			Perspective: aPerspective,
+		})
+		require.NoError(t, err)
+		require.Nil(t, ss.Error)
+		if ss.CompletedSyncs == ss.TotalSyncRequests {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	//By default the perspective is the attester, sorted by name
+	lrv, err := eapi.ListNameDeclarations(ctx, &pb.ListNameDeclarationsParams{
+		Perspective: aPerspective,
+	})
+	require.NoError(t, err)
+	require.Nil(t, lrv.Error)
+	require.EqualValues(t, 2, len(lrv.Results))
+	require.EqualValues(t, "bob", lrv.Results[0].Name)
+	require.EqualValues(t, bHash, lrv.Results[0].Subject)
+	require.True(t, lrv.Results[0].Validity.Valid)
+	require.True(t, lrv.Results[0].ValidUntil > time.Now().UnixNano()/1e6)
+	require.EqualValues(t, "carol", lrv.Results[1].Name)
+
+	lrv, err = eapi.ListNameDeclarations(ctx, &pb.ListNameDeclarationsParams{
+		Perspective: aPerspective,
+		Subject:     []byte("carol"),
+		OnlyValid:   true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, lrv.Error)
+	require.EqualValues(t, 1, len(lrv.Results))
+	require.EqualValues(t, cHash, lrv.Results[0].Subject)
+
+	lrv, err = eapi.ListNameDeclarations(ctx, &pb.ListNameDeclarationsParams{
+		Perspective: aPerspective,
+		Attester:    bHash,
+		Subject:     cHash,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, lrv.Error)
+}
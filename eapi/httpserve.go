@@ -11,11 +11,13 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/immesys/wave/eapi/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 )
 
-func allowCORS(h http.Handler) http.Handler {
+func allowCORS(h http.Handler, auth *AuthConfig) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if origin := r.Header.Get("Origin"); origin != "" {
+		if origin := r.Header.Get("Origin"); origin != "" && auth.allowsOrigin(origin) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
 				preflightHandler(w, r)
@@ -27,7 +29,7 @@ func allowCORS(h http.Handler) http.Handler {
 }
 
 func preflightHandler(w http.ResponseWriter, r *http.Request) {
-	headers := []string{"Content-Type", "Accept"}
+	headers := []string{"Content-Type", "Accept", "Authorization"}
 	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ","))
 	methods := []string{"GET", "HEAD", "POST", "PUT", "DELETE"}
 	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ","))
@@ -46,15 +48,39 @@ func serveSwagger(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, p)
 }
 
-func runHTTPserver(dialaddr string, listenaddr string) {
+//gatewayHeaderMatcher stops HTTP clients from impersonating the gateway's
+//own metadata
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, runtime.MetadataHeaderPrefix+gatewayClientHeader) {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+//gatewayClientCert passes on the verified client certificate of an HTTPS
+//request to the gRPC server
+func gatewayClientCert(ctx context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	return metadata.Pairs(gatewayClientHeader, r.TLS.PeerCertificates[0].Subject.CommonName)
+}
+
+func runHTTPserver(dialaddr string, listenaddr string, auth *AuthConfig, certs *certReloader) {
 
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	mux := http.NewServeMux()
-	gw := runtime.NewServeMux()
+	gw := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithMetadata(gatewayClientCert),
+	)
 	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if certs != nil {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(certs.gatewayConfig()))}
+	}
 	err := pb.RegisterWAVEHandlerFromEndpoint(ctx, gw, dialaddr, opts)
 	if err != nil {
 		panic(err)
@@ -64,10 +90,16 @@ func runHTTPserver(dialaddr string, listenaddr string) {
 	mux.Handle("/", gw)
 	s := &http.Server{
 		Addr:    listenaddr,
-		Handler: allowCORS(mux),
+		Handler: allowCORS(mux, auth),
 	}
 
-	if err := s.ListenAndServe(); err != http.ErrServerClosed {
+	if certs != nil {
+		s.TLSConfig = certs.serverConfig()
+		err = s.ListenAndServeTLS("", "")
+	} else {
+		err = s.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
 		fmt.Printf("HTTP server failed to listen: %v\n", err)
 		panic(err)
 	}
@@ -0,0 +1,59 @@
+package eapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+//grpc only accepts a single interceptor of each kind, so these combine
+//several into one. The first interceptor is the outermost
+
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+func chainStream(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return next(srv, ss)
+	}
+}
+
+//serverOptions returns the interceptors for the configured auditing and
+//authorization. Auditing is outermost so that denied calls are recorded
+func (e *EAPI) serverOptions(cfg *ServerConfig) []grpc.ServerOption {
+	unary := []grpc.UnaryServerInterceptor{}
+	stream := []grpc.StreamServerInterceptor{}
+	if e.audit != nil {
+		unary = append(unary, e.audit.unaryInterceptor)
+		stream = append(stream, e.audit.streamInterceptor)
+	}
+	if cfg.Auth.Enabled() {
+		unary = append(unary, cfg.Auth.unaryInterceptor)
+		stream = append(stream, cfg.Auth.streamInterceptor)
+	}
+	rv := []grpc.ServerOption{}
+	if len(unary) > 0 {
+		rv = append(rv, grpc.UnaryInterceptor(chainUnary(unary)))
+	}
+	if len(stream) > 0 {
+		rv = append(rv, grpc.StreamInterceptor(chainStream(stream)))
+	}
+	return rv
+}
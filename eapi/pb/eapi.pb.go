@@ -28,6 +28,1207 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type RevocationListReference struct {
+	Revoker []byte `protobuf:"bytes,1,opt,name=revoker,proto3" json:"revoker,omitempty"`
+	// If omitted, the default location
+	RevokerLocation      *Location `protobuf:"bytes,2,opt,name=revokerLocation,proto3" json:"revokerLocation,omitempty"`
+	ListID               []byte    `protobuf:"bytes,3,opt,name=listID,proto3" json:"listID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RevocationListReference) Reset()         { *m = RevocationListReference{} }
+func (m *RevocationListReference) String() string { return proto.CompactTextString(m) }
+func (*RevocationListReference) ProtoMessage()    {}
+func (*RevocationListReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{0}
+}
+func (m *RevocationListReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevocationListReference.Unmarshal(m, b)
+}
+func (m *RevocationListReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevocationListReference.Marshal(b, m, deterministic)
+}
+func (dst *RevocationListReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevocationListReference.Merge(dst, src)
+}
+func (m *RevocationListReference) XXX_Size() int {
+	return xxx_messageInfo_RevocationListReference.Size(m)
+}
+func (m *RevocationListReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevocationListReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevocationListReference proto.InternalMessageInfo
+
+func (m *RevocationListReference) GetRevoker() []byte {
+	if m != nil {
+		return m.Revoker
+	}
+	return nil
+}
+
+func (m *RevocationListReference) GetRevokerLocation() *Location {
+	if m != nil {
+		return m.RevokerLocation
+	}
+	return nil
+}
+
+func (m *RevocationListReference) GetListID() []byte {
+	if m != nil {
+		return m.ListID
+	}
+	return nil
+}
+
+type UpdateRevocationListParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	ListID      []byte       `protobuf:"bytes,2,opt,name=listID,proto3" json:"listID,omitempty"`
+	// IDs to add to the list
+	Revoke [][]byte `protobuf:"bytes,3,rep,name=revoke,proto3" json:"revoke,omitempty"`
+	// Attestations (DER) to revoke. They must name this list
+	Attestations [][]byte `protobuf:"bytes,4,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	// IDs to remove from the list
+	Unrevoke [][]byte `protobuf:"bytes,5,rep,name=unrevoke,proto3" json:"unrevoke,omitempty"`
+	// ms since epoch, if omitted default = now+24 hours. The list must be
+	// updated again before then
+	NextUpdate           int64    `protobuf:"varint,6,opt,name=nextUpdate,proto3" json:"nextUpdate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRevocationListParams) Reset()         { *m = UpdateRevocationListParams{} }
+func (m *UpdateRevocationListParams) String() string { return proto.CompactTextString(m) }
+func (*UpdateRevocationListParams) ProtoMessage()    {}
+func (*UpdateRevocationListParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{1}
+}
+func (m *UpdateRevocationListParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRevocationListParams.Unmarshal(m, b)
+}
+func (m *UpdateRevocationListParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRevocationListParams.Marshal(b, m, deterministic)
+}
+func (dst *UpdateRevocationListParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRevocationListParams.Merge(dst, src)
+}
+func (m *UpdateRevocationListParams) XXX_Size() int {
+	return xxx_messageInfo_UpdateRevocationListParams.Size(m)
+}
+func (m *UpdateRevocationListParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRevocationListParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRevocationListParams proto.InternalMessageInfo
+
+func (m *UpdateRevocationListParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListParams) GetListID() []byte {
+	if m != nil {
+		return m.ListID
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListParams) GetRevoke() [][]byte {
+	if m != nil {
+		return m.Revoke
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListParams) GetAttestations() [][]byte {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListParams) GetUnrevoke() [][]byte {
+	if m != nil {
+		return m.Unrevoke
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListParams) GetNextUpdate() int64 {
+	if m != nil {
+		return m.NextUpdate
+	}
+	return 0
+}
+
+type UpdateRevocationListResponse struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	DER                  []byte   `protobuf:"bytes,2,opt,name=DER,proto3" json:"DER,omitempty"`
+	Version              int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Revoked              [][]byte `protobuf:"bytes,4,rep,name=revoked,proto3" json:"revoked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRevocationListResponse) Reset()         { *m = UpdateRevocationListResponse{} }
+func (m *UpdateRevocationListResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateRevocationListResponse) ProtoMessage()    {}
+func (*UpdateRevocationListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{2}
+}
+func (m *UpdateRevocationListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRevocationListResponse.Unmarshal(m, b)
+}
+func (m *UpdateRevocationListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRevocationListResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateRevocationListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRevocationListResponse.Merge(dst, src)
+}
+func (m *UpdateRevocationListResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateRevocationListResponse.Size(m)
+}
+func (m *UpdateRevocationListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRevocationListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRevocationListResponse proto.InternalMessageInfo
+
+func (m *UpdateRevocationListResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListResponse) GetDER() []byte {
+	if m != nil {
+		return m.DER
+	}
+	return nil
+}
+
+func (m *UpdateRevocationListResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UpdateRevocationListResponse) GetRevoked() [][]byte {
+	if m != nil {
+		return m.Revoked
+	}
+	return nil
+}
+
+type PrecomputeKeyBundleParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	Policy      *Policy      `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// If 0, will be set to time.Now. Ms since epoch
+	ValidFrom int64 `protobuf:"varint,3,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	// If 0, will be set to 30 days after validFrom. Ms since epoch
+	ValidUntil           int64    `protobuf:"varint,4,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrecomputeKeyBundleParams) Reset()         { *m = PrecomputeKeyBundleParams{} }
+func (m *PrecomputeKeyBundleParams) String() string { return proto.CompactTextString(m) }
+func (*PrecomputeKeyBundleParams) ProtoMessage()    {}
+func (*PrecomputeKeyBundleParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{3}
+}
+func (m *PrecomputeKeyBundleParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrecomputeKeyBundleParams.Unmarshal(m, b)
+}
+func (m *PrecomputeKeyBundleParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrecomputeKeyBundleParams.Marshal(b, m, deterministic)
+}
+func (dst *PrecomputeKeyBundleParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrecomputeKeyBundleParams.Merge(dst, src)
+}
+func (m *PrecomputeKeyBundleParams) XXX_Size() int {
+	return xxx_messageInfo_PrecomputeKeyBundleParams.Size(m)
+}
+func (m *PrecomputeKeyBundleParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrecomputeKeyBundleParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrecomputeKeyBundleParams proto.InternalMessageInfo
+
+func (m *PrecomputeKeyBundleParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *PrecomputeKeyBundleParams) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *PrecomputeKeyBundleParams) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *PrecomputeKeyBundleParams) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+type PrecomputeKeyBundleResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The number of keys in the bundle
+	Keys int64 `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	// The number of keys that were not already cached
+	Generated            int64    `protobuf:"varint,3,opt,name=generated,proto3" json:"generated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrecomputeKeyBundleResponse) Reset()         { *m = PrecomputeKeyBundleResponse{} }
+func (m *PrecomputeKeyBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*PrecomputeKeyBundleResponse) ProtoMessage()    {}
+func (*PrecomputeKeyBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{4}
+}
+func (m *PrecomputeKeyBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrecomputeKeyBundleResponse.Unmarshal(m, b)
+}
+func (m *PrecomputeKeyBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrecomputeKeyBundleResponse.Marshal(b, m, deterministic)
+}
+func (dst *PrecomputeKeyBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrecomputeKeyBundleResponse.Merge(dst, src)
+}
+func (m *PrecomputeKeyBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_PrecomputeKeyBundleResponse.Size(m)
+}
+func (m *PrecomputeKeyBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrecomputeKeyBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrecomputeKeyBundleResponse proto.InternalMessageInfo
+
+func (m *PrecomputeKeyBundleResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *PrecomputeKeyBundleResponse) GetKeys() int64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *PrecomputeKeyBundleResponse) GetGenerated() int64 {
+	if m != nil {
+		return m.Generated
+	}
+	return 0
+}
+
+type KeyBundleCacheStatsParams struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyBundleCacheStatsParams) Reset()         { *m = KeyBundleCacheStatsParams{} }
+func (m *KeyBundleCacheStatsParams) String() string { return proto.CompactTextString(m) }
+func (*KeyBundleCacheStatsParams) ProtoMessage()    {}
+func (*KeyBundleCacheStatsParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{5}
+}
+func (m *KeyBundleCacheStatsParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyBundleCacheStatsParams.Unmarshal(m, b)
+}
+func (m *KeyBundleCacheStatsParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyBundleCacheStatsParams.Marshal(b, m, deterministic)
+}
+func (dst *KeyBundleCacheStatsParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyBundleCacheStatsParams.Merge(dst, src)
+}
+func (m *KeyBundleCacheStatsParams) XXX_Size() int {
+	return xxx_messageInfo_KeyBundleCacheStatsParams.Size(m)
+}
+func (m *KeyBundleCacheStatsParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyBundleCacheStatsParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyBundleCacheStatsParams proto.InternalMessageInfo
+
+type KeyBundleCacheStatsResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// Since the agent started
+	Hits                 uint64   `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses               uint64   `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyBundleCacheStatsResponse) Reset()         { *m = KeyBundleCacheStatsResponse{} }
+func (m *KeyBundleCacheStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*KeyBundleCacheStatsResponse) ProtoMessage()    {}
+func (*KeyBundleCacheStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{6}
+}
+func (m *KeyBundleCacheStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyBundleCacheStatsResponse.Unmarshal(m, b)
+}
+func (m *KeyBundleCacheStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyBundleCacheStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *KeyBundleCacheStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyBundleCacheStatsResponse.Merge(dst, src)
+}
+func (m *KeyBundleCacheStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_KeyBundleCacheStatsResponse.Size(m)
+}
+func (m *KeyBundleCacheStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyBundleCacheStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyBundleCacheStatsResponse proto.InternalMessageInfo
+
+func (m *KeyBundleCacheStatsResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *KeyBundleCacheStatsResponse) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *KeyBundleCacheStatsResponse) GetMisses() uint64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+type CreateSSHCertificateParams struct {
+	// A proof that grants the agent's configured SSH permission
+	ProofDER []byte `protobuf:"bytes,1,opt,name=proofDER,proto3" json:"proofDER,omitempty"`
+	// The key to certify, in authorized_keys format
+	PublicKey []byte `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	// A signature by the proof subject over the request, see
+	// iapi.SSHCertificateRequestContent
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// ms, if omitted the certificate is valid until the proof expires
+	ValidFor             int64    `protobuf:"varint,4,opt,name=validFor,proto3" json:"validFor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSSHCertificateParams) Reset()         { *m = CreateSSHCertificateParams{} }
+func (m *CreateSSHCertificateParams) String() string { return proto.CompactTextString(m) }
+func (*CreateSSHCertificateParams) ProtoMessage()    {}
+func (*CreateSSHCertificateParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{7}
+}
+func (m *CreateSSHCertificateParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSSHCertificateParams.Unmarshal(m, b)
+}
+func (m *CreateSSHCertificateParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSSHCertificateParams.Marshal(b, m, deterministic)
+}
+func (dst *CreateSSHCertificateParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSSHCertificateParams.Merge(dst, src)
+}
+func (m *CreateSSHCertificateParams) XXX_Size() int {
+	return xxx_messageInfo_CreateSSHCertificateParams.Size(m)
+}
+func (m *CreateSSHCertificateParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSSHCertificateParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSSHCertificateParams proto.InternalMessageInfo
+
+func (m *CreateSSHCertificateParams) GetProofDER() []byte {
+	if m != nil {
+		return m.ProofDER
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateParams) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateParams) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateParams) GetValidFor() int64 {
+	if m != nil {
+		return m.ValidFor
+	}
+	return 0
+}
+
+type CreateSSHCertificateResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The certificate, in authorized_keys format
+	Certificate []byte `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// The CA key, in authorized_keys format
+	CaPublicKey []byte   `protobuf:"bytes,3,opt,name=caPublicKey,proto3" json:"caPublicKey,omitempty"`
+	Principals  []string `protobuf:"bytes,4,rep,name=principals,proto3" json:"principals,omitempty"`
+	Subject     []byte   `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	// ms since epoch
+	ValidBefore          int64    `protobuf:"varint,6,opt,name=validBefore,proto3" json:"validBefore,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateSSHCertificateResponse) Reset()         { *m = CreateSSHCertificateResponse{} }
+func (m *CreateSSHCertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateSSHCertificateResponse) ProtoMessage()    {}
+func (*CreateSSHCertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{8}
+}
+func (m *CreateSSHCertificateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSSHCertificateResponse.Unmarshal(m, b)
+}
+func (m *CreateSSHCertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSSHCertificateResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateSSHCertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSSHCertificateResponse.Merge(dst, src)
+}
+func (m *CreateSSHCertificateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateSSHCertificateResponse.Size(m)
+}
+func (m *CreateSSHCertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSSHCertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateSSHCertificateResponse proto.InternalMessageInfo
+
+func (m *CreateSSHCertificateResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateResponse) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateResponse) GetCaPublicKey() []byte {
+	if m != nil {
+		return m.CaPublicKey
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateResponse) GetPrincipals() []string {
+	if m != nil {
+		return m.Principals
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateResponse) GetSubject() []byte {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *CreateSSHCertificateResponse) GetValidBefore() int64 {
+	if m != nil {
+		return m.ValidBefore
+	}
+	return 0
+}
+
+type CreateX509CertificateParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	// If present, a leaf certificate embedding this proof is issued. The
+	// perspective entity must be the subject of the proof
+	ProofDER []byte `protobuf:"bytes,2,opt,name=proofDER,proto3" json:"proofDER,omitempty"`
+	// The PKIX DER public key of the leaf certificate
+	LeafPublicKey []byte `protobuf:"bytes,3,opt,name=leafPublicKey,proto3" json:"leafPublicKey,omitempty"`
+	// ms, if omitted default = 1 hour. The leaf never outlives the proof
+	ValidFor             int64    `protobuf:"varint,4,opt,name=validFor,proto3" json:"validFor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateX509CertificateParams) Reset()         { *m = CreateX509CertificateParams{} }
+func (m *CreateX509CertificateParams) String() string { return proto.CompactTextString(m) }
+func (*CreateX509CertificateParams) ProtoMessage()    {}
+func (*CreateX509CertificateParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{9}
+}
+func (m *CreateX509CertificateParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateX509CertificateParams.Unmarshal(m, b)
+}
+func (m *CreateX509CertificateParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateX509CertificateParams.Marshal(b, m, deterministic)
+}
+func (dst *CreateX509CertificateParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateX509CertificateParams.Merge(dst, src)
+}
+func (m *CreateX509CertificateParams) XXX_Size() int {
+	return xxx_messageInfo_CreateX509CertificateParams.Size(m)
+}
+func (m *CreateX509CertificateParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateX509CertificateParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateX509CertificateParams proto.InternalMessageInfo
+
+func (m *CreateX509CertificateParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CreateX509CertificateParams) GetProofDER() []byte {
+	if m != nil {
+		return m.ProofDER
+	}
+	return nil
+}
+
+func (m *CreateX509CertificateParams) GetLeafPublicKey() []byte {
+	if m != nil {
+		return m.LeafPublicKey
+	}
+	return nil
+}
+
+func (m *CreateX509CertificateParams) GetValidFor() int64 {
+	if m != nil {
+		return m.ValidFor
+	}
+	return 0
+}
+
+type CreateX509CertificateResponse struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	EntityCertificate    []byte   `protobuf:"bytes,2,opt,name=entityCertificate,proto3" json:"entityCertificate,omitempty"`
+	LeafCertificate      []byte   `protobuf:"bytes,3,opt,name=leafCertificate,proto3" json:"leafCertificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateX509CertificateResponse) Reset()         { *m = CreateX509CertificateResponse{} }
+func (m *CreateX509CertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateX509CertificateResponse) ProtoMessage()    {}
+func (*CreateX509CertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{10}
+}
+func (m *CreateX509CertificateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateX509CertificateResponse.Unmarshal(m, b)
+}
+func (m *CreateX509CertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateX509CertificateResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateX509CertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateX509CertificateResponse.Merge(dst, src)
+}
+func (m *CreateX509CertificateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateX509CertificateResponse.Size(m)
+}
+func (m *CreateX509CertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateX509CertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateX509CertificateResponse proto.InternalMessageInfo
+
+func (m *CreateX509CertificateResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CreateX509CertificateResponse) GetEntityCertificate() []byte {
+	if m != nil {
+		return m.EntityCertificate
+	}
+	return nil
+}
+
+func (m *CreateX509CertificateResponse) GetLeafCertificate() []byte {
+	if m != nil {
+		return m.LeafCertificate
+	}
+	return nil
+}
+
+type ThresholdCoSigner struct {
+	Hash                 []byte    `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Location             *Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ThresholdCoSigner) Reset()         { *m = ThresholdCoSigner{} }
+func (m *ThresholdCoSigner) String() string { return proto.CompactTextString(m) }
+func (*ThresholdCoSigner) ProtoMessage()    {}
+func (*ThresholdCoSigner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{11}
+}
+func (m *ThresholdCoSigner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThresholdCoSigner.Unmarshal(m, b)
+}
+func (m *ThresholdCoSigner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThresholdCoSigner.Marshal(b, m, deterministic)
+}
+func (dst *ThresholdCoSigner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdCoSigner.Merge(dst, src)
+}
+func (m *ThresholdCoSigner) XXX_Size() int {
+	return xxx_messageInfo_ThresholdCoSigner.Size(m)
+}
+func (m *ThresholdCoSigner) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdCoSigner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdCoSigner proto.InternalMessageInfo
+
+func (m *ThresholdCoSigner) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ThresholdCoSigner) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+type CreateThresholdProposalParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	// The subject hash or a WAVE name, resolved from the perspective
+	SubjectHash     []byte    `protobuf:"bytes,2,opt,name=subjectHash,proto3" json:"subjectHash,omitempty"`
+	SubjectLocation *Location `protobuf:"bytes,3,opt,name=subjectLocation,proto3" json:"subjectLocation,omitempty"`
+	// ms since epoch, if omitted default = now
+	ValidFrom int64 `protobuf:"varint,4,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	// ms since epoch, if omitted default = now+30 days
+	ValidUntil int64   `protobuf:"varint,5,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	Policy     *Policy `protobuf:"bytes,6,opt,name=policy,proto3" json:"policy,omitempty"`
+	// How many of the co-signers must sign
+	Threshold            int64                `protobuf:"varint,7,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	CoSigners            []*ThresholdCoSigner `protobuf:"bytes,8,rep,name=coSigners,proto3" json:"coSigners,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateThresholdProposalParams) Reset()         { *m = CreateThresholdProposalParams{} }
+func (m *CreateThresholdProposalParams) String() string { return proto.CompactTextString(m) }
+func (*CreateThresholdProposalParams) ProtoMessage()    {}
+func (*CreateThresholdProposalParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{12}
+}
+func (m *CreateThresholdProposalParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateThresholdProposalParams.Unmarshal(m, b)
+}
+func (m *CreateThresholdProposalParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateThresholdProposalParams.Marshal(b, m, deterministic)
+}
+func (dst *CreateThresholdProposalParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateThresholdProposalParams.Merge(dst, src)
+}
+func (m *CreateThresholdProposalParams) XXX_Size() int {
+	return xxx_messageInfo_CreateThresholdProposalParams.Size(m)
+}
+func (m *CreateThresholdProposalParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateThresholdProposalParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateThresholdProposalParams proto.InternalMessageInfo
+
+func (m *CreateThresholdProposalParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CreateThresholdProposalParams) GetSubjectHash() []byte {
+	if m != nil {
+		return m.SubjectHash
+	}
+	return nil
+}
+
+func (m *CreateThresholdProposalParams) GetSubjectLocation() *Location {
+	if m != nil {
+		return m.SubjectLocation
+	}
+	return nil
+}
+
+func (m *CreateThresholdProposalParams) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *CreateThresholdProposalParams) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+func (m *CreateThresholdProposalParams) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *CreateThresholdProposalParams) GetThreshold() int64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *CreateThresholdProposalParams) GetCoSigners() []*ThresholdCoSigner {
+	if m != nil {
+		return m.CoSigners
+	}
+	return nil
+}
+
+type CoSignThresholdProposalParams struct {
+	Perspective          *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	DER                  []byte       `protobuf:"bytes,2,opt,name=DER,proto3" json:"DER,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CoSignThresholdProposalParams) Reset()         { *m = CoSignThresholdProposalParams{} }
+func (m *CoSignThresholdProposalParams) String() string { return proto.CompactTextString(m) }
+func (*CoSignThresholdProposalParams) ProtoMessage()    {}
+func (*CoSignThresholdProposalParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{13}
+}
+func (m *CoSignThresholdProposalParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoSignThresholdProposalParams.Unmarshal(m, b)
+}
+func (m *CoSignThresholdProposalParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoSignThresholdProposalParams.Marshal(b, m, deterministic)
+}
+func (dst *CoSignThresholdProposalParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoSignThresholdProposalParams.Merge(dst, src)
+}
+func (m *CoSignThresholdProposalParams) XXX_Size() int {
+	return xxx_messageInfo_CoSignThresholdProposalParams.Size(m)
+}
+func (m *CoSignThresholdProposalParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoSignThresholdProposalParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoSignThresholdProposalParams proto.InternalMessageInfo
+
+func (m *CoSignThresholdProposalParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CoSignThresholdProposalParams) GetDER() []byte {
+	if m != nil {
+		return m.DER
+	}
+	return nil
+}
+
+type ThresholdProposal struct {
+	Attester   []byte   `protobuf:"bytes,1,opt,name=attester,proto3" json:"attester,omitempty"`
+	Subject    []byte   `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	ValidFrom  int64    `protobuf:"varint,3,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	ValidUntil int64    `protobuf:"varint,4,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	Policy     *Policy  `protobuf:"bytes,5,opt,name=policy,proto3" json:"policy,omitempty"`
+	Threshold  int64    `protobuf:"varint,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	CoSigners  [][]byte `protobuf:"bytes,7,rep,name=coSigners,proto3" json:"coSigners,omitempty"`
+	// The co-signers that have signed this copy. The signatures are not checked
+	SignedBy             [][]byte `protobuf:"bytes,8,rep,name=signedBy,proto3" json:"signedBy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThresholdProposal) Reset()         { *m = ThresholdProposal{} }
+func (m *ThresholdProposal) String() string { return proto.CompactTextString(m) }
+func (*ThresholdProposal) ProtoMessage()    {}
+func (*ThresholdProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{14}
+}
+func (m *ThresholdProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThresholdProposal.Unmarshal(m, b)
+}
+func (m *ThresholdProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThresholdProposal.Marshal(b, m, deterministic)
+}
+func (dst *ThresholdProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdProposal.Merge(dst, src)
+}
+func (m *ThresholdProposal) XXX_Size() int {
+	return xxx_messageInfo_ThresholdProposal.Size(m)
+}
+func (m *ThresholdProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdProposal proto.InternalMessageInfo
+
+func (m *ThresholdProposal) GetAttester() []byte {
+	if m != nil {
+		return m.Attester
+	}
+	return nil
+}
+
+func (m *ThresholdProposal) GetSubject() []byte {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *ThresholdProposal) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *ThresholdProposal) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+func (m *ThresholdProposal) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *ThresholdProposal) GetThreshold() int64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *ThresholdProposal) GetCoSigners() [][]byte {
+	if m != nil {
+		return m.CoSigners
+	}
+	return nil
+}
+
+func (m *ThresholdProposal) GetSignedBy() [][]byte {
+	if m != nil {
+		return m.SignedBy
+	}
+	return nil
+}
+
+type ThresholdProposalResponse struct {
+	Error                *Error             `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	DER                  []byte             `protobuf:"bytes,2,opt,name=DER,proto3" json:"DER,omitempty"`
+	Proposal             *ThresholdProposal `protobuf:"bytes,3,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ThresholdProposalResponse) Reset()         { *m = ThresholdProposalResponse{} }
+func (m *ThresholdProposalResponse) String() string { return proto.CompactTextString(m) }
+func (*ThresholdProposalResponse) ProtoMessage()    {}
+func (*ThresholdProposalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{15}
+}
+func (m *ThresholdProposalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThresholdProposalResponse.Unmarshal(m, b)
+}
+func (m *ThresholdProposalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThresholdProposalResponse.Marshal(b, m, deterministic)
+}
+func (dst *ThresholdProposalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdProposalResponse.Merge(dst, src)
+}
+func (m *ThresholdProposalResponse) XXX_Size() int {
+	return xxx_messageInfo_ThresholdProposalResponse.Size(m)
+}
+func (m *ThresholdProposalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdProposalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdProposalResponse proto.InternalMessageInfo
+
+func (m *ThresholdProposalResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ThresholdProposalResponse) GetDER() []byte {
+	if m != nil {
+		return m.DER
+	}
+	return nil
+}
+
+func (m *ThresholdProposalResponse) GetProposal() *ThresholdProposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+type CreateThresholdAttestationParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	// Copies of the same proposal, carrying between them enough co-signatures
+	Proposals            [][]byte `protobuf:"bytes,2,rep,name=proposals,proto3" json:"proposals,omitempty"`
+	BodyScheme           string   `protobuf:"bytes,3,opt,name=bodyScheme,proto3" json:"bodyScheme,omitempty"`
+	Publish              bool     `protobuf:"varint,4,opt,name=publish,proto3" json:"publish,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateThresholdAttestationParams) Reset()         { *m = CreateThresholdAttestationParams{} }
+func (m *CreateThresholdAttestationParams) String() string { return proto.CompactTextString(m) }
+func (*CreateThresholdAttestationParams) ProtoMessage()    {}
+func (*CreateThresholdAttestationParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{16}
+}
+func (m *CreateThresholdAttestationParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateThresholdAttestationParams.Unmarshal(m, b)
+}
+func (m *CreateThresholdAttestationParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateThresholdAttestationParams.Marshal(b, m, deterministic)
+}
+func (dst *CreateThresholdAttestationParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateThresholdAttestationParams.Merge(dst, src)
+}
+func (m *CreateThresholdAttestationParams) XXX_Size() int {
+	return xxx_messageInfo_CreateThresholdAttestationParams.Size(m)
+}
+func (m *CreateThresholdAttestationParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateThresholdAttestationParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateThresholdAttestationParams proto.InternalMessageInfo
+
+func (m *CreateThresholdAttestationParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CreateThresholdAttestationParams) GetProposals() [][]byte {
+	if m != nil {
+		return m.Proposals
+	}
+	return nil
+}
+
+func (m *CreateThresholdAttestationParams) GetBodyScheme() string {
+	if m != nil {
+		return m.BodyScheme
+	}
+	return ""
+}
+
+func (m *CreateThresholdAttestationParams) GetPublish() bool {
+	if m != nil {
+		return m.Publish
+	}
+	return false
+}
+
+type CreateEntitySuccessionParams struct {
+	Perspective       *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	Successor         []byte       `protobuf:"bytes,2,opt,name=successor,proto3" json:"successor,omitempty"`
+	SuccessorLocation *Location    `protobuf:"bytes,3,opt,name=successorLocation,proto3" json:"successorLocation,omitempty"`
+	// ms since epoch, if omitted default = now+30 days
+	GraceUntil           int64    `protobuf:"varint,4,opt,name=graceUntil,proto3" json:"graceUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateEntitySuccessionParams) Reset()         { *m = CreateEntitySuccessionParams{} }
+func (m *CreateEntitySuccessionParams) String() string { return proto.CompactTextString(m) }
+func (*CreateEntitySuccessionParams) ProtoMessage()    {}
+func (*CreateEntitySuccessionParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{17}
+}
+func (m *CreateEntitySuccessionParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEntitySuccessionParams.Unmarshal(m, b)
+}
+func (m *CreateEntitySuccessionParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEntitySuccessionParams.Marshal(b, m, deterministic)
+}
+func (dst *CreateEntitySuccessionParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEntitySuccessionParams.Merge(dst, src)
+}
+func (m *CreateEntitySuccessionParams) XXX_Size() int {
+	return xxx_messageInfo_CreateEntitySuccessionParams.Size(m)
+}
+func (m *CreateEntitySuccessionParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEntitySuccessionParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEntitySuccessionParams proto.InternalMessageInfo
+
+func (m *CreateEntitySuccessionParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CreateEntitySuccessionParams) GetSuccessor() []byte {
+	if m != nil {
+		return m.Successor
+	}
+	return nil
+}
+
+func (m *CreateEntitySuccessionParams) GetSuccessorLocation() *Location {
+	if m != nil {
+		return m.SuccessorLocation
+	}
+	return nil
+}
+
+func (m *CreateEntitySuccessionParams) GetGraceUntil() int64 {
+	if m != nil {
+		return m.GraceUntil
+	}
+	return 0
+}
+
+type CreateEntitySuccessionResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	DER   []byte `protobuf:"bytes,2,opt,name=DER,proto3" json:"DER,omitempty"`
+	Hash  []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// ms since epoch
+	GraceUntil           int64    `protobuf:"varint,4,opt,name=graceUntil,proto3" json:"graceUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateEntitySuccessionResponse) Reset()         { *m = CreateEntitySuccessionResponse{} }
+func (m *CreateEntitySuccessionResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateEntitySuccessionResponse) ProtoMessage()    {}
+func (*CreateEntitySuccessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{18}
+}
+func (m *CreateEntitySuccessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEntitySuccessionResponse.Unmarshal(m, b)
+}
+func (m *CreateEntitySuccessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEntitySuccessionResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateEntitySuccessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEntitySuccessionResponse.Merge(dst, src)
+}
+func (m *CreateEntitySuccessionResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateEntitySuccessionResponse.Size(m)
+}
+func (m *CreateEntitySuccessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEntitySuccessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEntitySuccessionResponse proto.InternalMessageInfo
+
+func (m *CreateEntitySuccessionResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CreateEntitySuccessionResponse) GetDER() []byte {
+	if m != nil {
+		return m.DER
+	}
+	return nil
+}
+
+func (m *CreateEntitySuccessionResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *CreateEntitySuccessionResponse) GetGraceUntil() int64 {
+	if m != nil {
+		return m.GraceUntil
+	}
+	return 0
+}
+
 type SignParams struct {
 	Perspective          *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
 	Content              []byte       `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
@@ -40,7 +1241,7 @@ func (m *SignParams) Reset()         { *m = SignParams{} }
 func (m *SignParams) String() string { return proto.CompactTextString(m) }
 func (*SignParams) ProtoMessage()    {}
 func (*SignParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{0}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{19}
 }
 func (m *SignParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignParams.Unmarshal(m, b)
@@ -86,7 +1287,7 @@ func (m *SignResponse) Reset()         { *m = SignResponse{} }
 func (m *SignResponse) String() string { return proto.CompactTextString(m) }
 func (*SignResponse) ProtoMessage()    {}
 func (*SignResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{1}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{20}
 }
 func (m *SignResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignResponse.Unmarshal(m, b)
@@ -134,7 +1335,7 @@ func (m *VerifySignatureParams) Reset()         { *m = VerifySignatureParams{} }
 func (m *VerifySignatureParams) String() string { return proto.CompactTextString(m) }
 func (*VerifySignatureParams) ProtoMessage()    {}
 func (*VerifySignatureParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{2}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{21}
 }
 func (m *VerifySignatureParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VerifySignatureParams.Unmarshal(m, b)
@@ -193,7 +1394,7 @@ func (m *VerifySignatureResponse) Reset()         { *m = VerifySignatureResponse
 func (m *VerifySignatureResponse) String() string { return proto.CompactTextString(m) }
 func (*VerifySignatureResponse) ProtoMessage()    {}
 func (*VerifySignatureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{3}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{22}
 }
 func (m *VerifySignatureResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VerifySignatureResponse.Unmarshal(m, b)
@@ -231,7 +1432,7 @@ func (m *CompactProofParams) Reset()         { *m = CompactProofParams{} }
 func (m *CompactProofParams) String() string { return proto.CompactTextString(m) }
 func (*CompactProofParams) ProtoMessage()    {}
 func (*CompactProofParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{4}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{23}
 }
 func (m *CompactProofParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CompactProofParams.Unmarshal(m, b)
@@ -270,7 +1471,7 @@ func (m *CompactProofResponse) Reset()         { *m = CompactProofResponse{} }
 func (m *CompactProofResponse) String() string { return proto.CompactTextString(m) }
 func (*CompactProofResponse) ProtoMessage()    {}
 func (*CompactProofResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{5}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{24}
 }
 func (m *CompactProofResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CompactProofResponse.Unmarshal(m, b)
@@ -318,7 +1519,7 @@ func (m *RevokeParams) Reset()         { *m = RevokeParams{} }
 func (m *RevokeParams) String() string { return proto.CompactTextString(m) }
 func (*RevokeParams) ProtoMessage()    {}
 func (*RevokeParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{6}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{25}
 }
 func (m *RevokeParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RevokeParams.Unmarshal(m, b)
@@ -377,7 +1578,7 @@ func (m *RevokeResponse) Reset()         { *m = RevokeResponse{} }
 func (m *RevokeResponse) String() string { return proto.CompactTextString(m) }
 func (*RevokeResponse) ProtoMessage()    {}
 func (*RevokeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{7}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{26}
 }
 func (m *RevokeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RevokeResponse.Unmarshal(m, b)
@@ -416,7 +1617,7 @@ func (m *ResolveReverseNameParams) Reset()         { *m = ResolveReverseNamePara
 func (m *ResolveReverseNameParams) String() string { return proto.CompactTextString(m) }
 func (*ResolveReverseNameParams) ProtoMessage()    {}
 func (*ResolveReverseNameParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{8}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{27}
 }
 func (m *ResolveReverseNameParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResolveReverseNameParams.Unmarshal(m, b)
@@ -447,53 +1648,164 @@ func (m *ResolveReverseNameParams) GetHash() []byte {
 	if m != nil {
 		return m.Hash
 	}
-	return nil
+	return nil
+}
+
+type ResolveReverseNameResponse struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveReverseNameResponse) Reset()         { *m = ResolveReverseNameResponse{} }
+func (m *ResolveReverseNameResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolveReverseNameResponse) ProtoMessage()    {}
+func (*ResolveReverseNameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{28}
+}
+func (m *ResolveReverseNameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveReverseNameResponse.Unmarshal(m, b)
+}
+func (m *ResolveReverseNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveReverseNameResponse.Marshal(b, m, deterministic)
+}
+func (dst *ResolveReverseNameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveReverseNameResponse.Merge(dst, src)
+}
+func (m *ResolveReverseNameResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolveReverseNameResponse.Size(m)
+}
+func (m *ResolveReverseNameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveReverseNameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveReverseNameResponse proto.InternalMessageInfo
+
+func (m *ResolveReverseNameResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ResolveReverseNameResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListNameDeclarationsParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	// Exactly one of attester or subject, either may be a WAVE name. If
+	// neither is given, the perspective entity is the attester
+	Attester []byte `protobuf:"bytes,2,opt,name=attester,proto3" json:"attester,omitempty"`
+	Subject  []byte `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// If true, expired and revoked declarations are omitted
+	OnlyValid            bool     `protobuf:"varint,4,opt,name=onlyValid,proto3" json:"onlyValid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNameDeclarationsParams) Reset()         { *m = ListNameDeclarationsParams{} }
+func (m *ListNameDeclarationsParams) String() string { return proto.CompactTextString(m) }
+func (*ListNameDeclarationsParams) ProtoMessage()    {}
+func (*ListNameDeclarationsParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{29}
+}
+func (m *ListNameDeclarationsParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNameDeclarationsParams.Unmarshal(m, b)
+}
+func (m *ListNameDeclarationsParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNameDeclarationsParams.Marshal(b, m, deterministic)
+}
+func (dst *ListNameDeclarationsParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNameDeclarationsParams.Merge(dst, src)
+}
+func (m *ListNameDeclarationsParams) XXX_Size() int {
+	return xxx_messageInfo_ListNameDeclarationsParams.Size(m)
+}
+func (m *ListNameDeclarationsParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNameDeclarationsParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNameDeclarationsParams proto.InternalMessageInfo
+
+func (m *ListNameDeclarationsParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *ListNameDeclarationsParams) GetAttester() []byte {
+	if m != nil {
+		return m.Attester
+	}
+	return nil
+}
+
+func (m *ListNameDeclarationsParams) GetSubject() []byte {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *ListNameDeclarationsParams) GetOnlyValid() bool {
+	if m != nil {
+		return m.OnlyValid
+	}
+	return false
 }
 
-type ResolveReverseNameResponse struct {
-	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ListNameDeclarationsResponse struct {
+	Error                *Error             `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Results              []*NameDeclaration `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *ResolveReverseNameResponse) Reset()         { *m = ResolveReverseNameResponse{} }
-func (m *ResolveReverseNameResponse) String() string { return proto.CompactTextString(m) }
-func (*ResolveReverseNameResponse) ProtoMessage()    {}
-func (*ResolveReverseNameResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{9}
+func (m *ListNameDeclarationsResponse) Reset()         { *m = ListNameDeclarationsResponse{} }
+func (m *ListNameDeclarationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNameDeclarationsResponse) ProtoMessage()    {}
+func (*ListNameDeclarationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{30}
 }
-func (m *ResolveReverseNameResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResolveReverseNameResponse.Unmarshal(m, b)
+func (m *ListNameDeclarationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNameDeclarationsResponse.Unmarshal(m, b)
 }
-func (m *ResolveReverseNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResolveReverseNameResponse.Marshal(b, m, deterministic)
+func (m *ListNameDeclarationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNameDeclarationsResponse.Marshal(b, m, deterministic)
 }
-func (dst *ResolveReverseNameResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResolveReverseNameResponse.Merge(dst, src)
+func (dst *ListNameDeclarationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNameDeclarationsResponse.Merge(dst, src)
 }
-func (m *ResolveReverseNameResponse) XXX_Size() int {
-	return xxx_messageInfo_ResolveReverseNameResponse.Size(m)
+func (m *ListNameDeclarationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNameDeclarationsResponse.Size(m)
 }
-func (m *ResolveReverseNameResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResolveReverseNameResponse.DiscardUnknown(m)
+func (m *ListNameDeclarationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNameDeclarationsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResolveReverseNameResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListNameDeclarationsResponse proto.InternalMessageInfo
 
-func (m *ResolveReverseNameResponse) GetError() *Error {
+func (m *ListNameDeclarationsResponse) GetError() *Error {
 	if m != nil {
 		return m.Error
 	}
 	return nil
 }
 
-func (m *ResolveReverseNameResponse) GetName() string {
+func (m *ListNameDeclarationsResponse) GetResults() []*NameDeclaration {
 	if m != nil {
-		return m.Name
+		return m.Results
 	}
-	return ""
+	return nil
 }
 
 type MarkEntityInterestingParams struct {
@@ -509,7 +1821,7 @@ func (m *MarkEntityInterestingParams) Reset()         { *m = MarkEntityInteresti
 func (m *MarkEntityInterestingParams) String() string { return proto.CompactTextString(m) }
 func (*MarkEntityInterestingParams) ProtoMessage()    {}
 func (*MarkEntityInterestingParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{10}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{31}
 }
 func (m *MarkEntityInterestingParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MarkEntityInterestingParams.Unmarshal(m, b)
@@ -561,7 +1873,7 @@ func (m *MarkEntityInterestingResponse) Reset()         { *m = MarkEntityInteres
 func (m *MarkEntityInterestingResponse) String() string { return proto.CompactTextString(m) }
 func (*MarkEntityInterestingResponse) ProtoMessage()    {}
 func (*MarkEntityInterestingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{11}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{32}
 }
 func (m *MarkEntityInterestingResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MarkEntityInterestingResponse.Unmarshal(m, b)
@@ -610,7 +1922,7 @@ func (m *CreateNameDeclarationParams) Reset()         { *m = CreateNameDeclarati
 func (m *CreateNameDeclarationParams) String() string { return proto.CompactTextString(m) }
 func (*CreateNameDeclarationParams) ProtoMessage()    {}
 func (*CreateNameDeclarationParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{12}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{33}
 }
 func (m *CreateNameDeclarationParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNameDeclarationParams.Unmarshal(m, b)
@@ -706,7 +2018,7 @@ func (m *CreateNameDeclarationResponse) Reset()         { *m = CreateNameDeclara
 func (m *CreateNameDeclarationResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateNameDeclarationResponse) ProtoMessage()    {}
 func (*CreateNameDeclarationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{13}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{34}
 }
 func (m *CreateNameDeclarationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNameDeclarationResponse.Unmarshal(m, b)
@@ -761,7 +2073,7 @@ func (m *ResolveNameParams) Reset()         { *m = ResolveNameParams{} }
 func (m *ResolveNameParams) String() string { return proto.CompactTextString(m) }
 func (*ResolveNameParams) ProtoMessage()    {}
 func (*ResolveNameParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{14}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{35}
 }
 func (m *ResolveNameParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResolveNameParams.Unmarshal(m, b)
@@ -824,7 +2136,7 @@ func (m *NameDeclaration) Reset()         { *m = NameDeclaration{} }
 func (m *NameDeclaration) String() string { return proto.CompactTextString(m) }
 func (*NameDeclaration) ProtoMessage()    {}
 func (*NameDeclaration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{15}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{36}
 }
 func (m *NameDeclaration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NameDeclaration.Unmarshal(m, b)
@@ -929,10 +2241,14 @@ func (m *NameDeclaration) GetValidity() *NameDeclarationValidity {
 }
 
 type ResolveNameResponse struct {
-	Error                *Error             `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Entity               *Entity            `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
-	Derivation           []*NameDeclaration `protobuf:"bytes,3,rep,name=derivation,proto3" json:"derivation,omitempty"`
-	Location             *Location          `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Error      *Error             `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Entity     *Entity            `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Derivation []*NameDeclaration `protobuf:"bytes,3,rep,name=derivation,proto3" json:"derivation,omitempty"`
+	Location   *Location          `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// If the error is a name conflict or a change from the pinned entity,
+	// the valid declarations for the name that failed. One of them can be
+	// accepted with PinName
+	Candidates           []*NameDeclaration `protobuf:"bytes,5,rep,name=candidates,proto3" json:"candidates,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -942,7 +2258,7 @@ func (m *ResolveNameResponse) Reset()         { *m = ResolveNameResponse{} }
 func (m *ResolveNameResponse) String() string { return proto.CompactTextString(m) }
 func (*ResolveNameResponse) ProtoMessage()    {}
 func (*ResolveNameResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{16}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{37}
 }
 func (m *ResolveNameResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResolveNameResponse.Unmarshal(m, b)
@@ -990,6 +2306,302 @@ func (m *ResolveNameResponse) GetLocation() *Location {
 	return nil
 }
 
+func (m *ResolveNameResponse) GetCandidates() []*NameDeclaration {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+type PinNameParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	// The name declaration to trust. Its name will resolve to its subject
+	// from this perspective, even if the attester declares the name again
+	NameDeclaration []byte `protobuf:"bytes,2,opt,name=nameDeclaration,proto3" json:"nameDeclaration,omitempty"`
+	// If omitted, the default location
+	Location             *Location `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PinNameParams) Reset()         { *m = PinNameParams{} }
+func (m *PinNameParams) String() string { return proto.CompactTextString(m) }
+func (*PinNameParams) ProtoMessage()    {}
+func (*PinNameParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{38}
+}
+func (m *PinNameParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PinNameParams.Unmarshal(m, b)
+}
+func (m *PinNameParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PinNameParams.Marshal(b, m, deterministic)
+}
+func (dst *PinNameParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinNameParams.Merge(dst, src)
+}
+func (m *PinNameParams) XXX_Size() int {
+	return xxx_messageInfo_PinNameParams.Size(m)
+}
+func (m *PinNameParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinNameParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinNameParams proto.InternalMessageInfo
+
+func (m *PinNameParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *PinNameParams) GetNameDeclaration() []byte {
+	if m != nil {
+		return m.NameDeclaration
+	}
+	return nil
+}
+
+func (m *PinNameParams) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+type PinNameResponse struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PinNameResponse) Reset()         { *m = PinNameResponse{} }
+func (m *PinNameResponse) String() string { return proto.CompactTextString(m) }
+func (*PinNameResponse) ProtoMessage()    {}
+func (*PinNameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{39}
+}
+func (m *PinNameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PinNameResponse.Unmarshal(m, b)
+}
+func (m *PinNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PinNameResponse.Marshal(b, m, deterministic)
+}
+func (dst *PinNameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinNameResponse.Merge(dst, src)
+}
+func (m *PinNameResponse) XXX_Size() int {
+	return xxx_messageInfo_PinNameResponse.Size(m)
+}
+func (m *PinNameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinNameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinNameResponse proto.InternalMessageInfo
+
+func (m *PinNameResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type CheckRevocationsParams struct {
+	// Optional
+	Perspective           *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	AttestationHashes     [][]byte     `protobuf:"bytes,2,rep,name=attestationHashes,proto3" json:"attestationHashes,omitempty"`
+	EntityHashes          [][]byte     `protobuf:"bytes,3,rep,name=entityHashes,proto3" json:"entityHashes,omitempty"`
+	NameDeclarationHashes [][]byte     `protobuf:"bytes,4,rep,name=nameDeclarationHashes,proto3" json:"nameDeclarationHashes,omitempty"`
+	// Stored checks older than this (in ms) are refreshed from storage. If 0,
+	// one hour
+	MaxAge               int64    `protobuf:"varint,5,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRevocationsParams) Reset()         { *m = CheckRevocationsParams{} }
+func (m *CheckRevocationsParams) String() string { return proto.CompactTextString(m) }
+func (*CheckRevocationsParams) ProtoMessage()    {}
+func (*CheckRevocationsParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{40}
+}
+func (m *CheckRevocationsParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRevocationsParams.Unmarshal(m, b)
+}
+func (m *CheckRevocationsParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRevocationsParams.Marshal(b, m, deterministic)
+}
+func (dst *CheckRevocationsParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRevocationsParams.Merge(dst, src)
+}
+func (m *CheckRevocationsParams) XXX_Size() int {
+	return xxx_messageInfo_CheckRevocationsParams.Size(m)
+}
+func (m *CheckRevocationsParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRevocationsParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRevocationsParams proto.InternalMessageInfo
+
+func (m *CheckRevocationsParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CheckRevocationsParams) GetAttestationHashes() [][]byte {
+	if m != nil {
+		return m.AttestationHashes
+	}
+	return nil
+}
+
+func (m *CheckRevocationsParams) GetEntityHashes() [][]byte {
+	if m != nil {
+		return m.EntityHashes
+	}
+	return nil
+}
+
+func (m *CheckRevocationsParams) GetNameDeclarationHashes() [][]byte {
+	if m != nil {
+		return m.NameDeclarationHashes
+	}
+	return nil
+}
+
+func (m *CheckRevocationsParams) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+type RevocationStatus struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// One of "valid", "revoked" or "unknown"
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Ms since epoch of the last successful storage check, 0 if never
+	LastChecked int64 `protobuf:"varint,3,opt,name=lastChecked,proto3" json:"lastChecked,omitempty"`
+	// The storage location that gave the answer
+	Location *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	// Why the status is unknown
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevocationStatus) Reset()         { *m = RevocationStatus{} }
+func (m *RevocationStatus) String() string { return proto.CompactTextString(m) }
+func (*RevocationStatus) ProtoMessage()    {}
+func (*RevocationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{41}
+}
+func (m *RevocationStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevocationStatus.Unmarshal(m, b)
+}
+func (m *RevocationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevocationStatus.Marshal(b, m, deterministic)
+}
+func (dst *RevocationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevocationStatus.Merge(dst, src)
+}
+func (m *RevocationStatus) XXX_Size() int {
+	return xxx_messageInfo_RevocationStatus.Size(m)
+}
+func (m *RevocationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevocationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevocationStatus proto.InternalMessageInfo
+
+func (m *RevocationStatus) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RevocationStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RevocationStatus) GetLastChecked() int64 {
+	if m != nil {
+		return m.LastChecked
+	}
+	return 0
+}
+
+func (m *RevocationStatus) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *RevocationStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type CheckRevocationsResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// In the order of attestationHashes, entityHashes then nameDeclarationHashes
+	Statuses             []*RevocationStatus `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *CheckRevocationsResponse) Reset()         { *m = CheckRevocationsResponse{} }
+func (m *CheckRevocationsResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckRevocationsResponse) ProtoMessage()    {}
+func (*CheckRevocationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{42}
+}
+func (m *CheckRevocationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRevocationsResponse.Unmarshal(m, b)
+}
+func (m *CheckRevocationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRevocationsResponse.Marshal(b, m, deterministic)
+}
+func (dst *CheckRevocationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRevocationsResponse.Merge(dst, src)
+}
+func (m *CheckRevocationsResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckRevocationsResponse.Size(m)
+}
+func (m *CheckRevocationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRevocationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRevocationsResponse proto.InternalMessageInfo
+
+func (m *CheckRevocationsResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CheckRevocationsResponse) GetStatuses() []*RevocationStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
 type ResolveHashParams struct {
 	Hash                 []byte       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Perspective          *Perspective `protobuf:"bytes,2,opt,name=perspective,proto3" json:"perspective,omitempty"`
@@ -1002,7 +2614,7 @@ func (m *ResolveHashParams) Reset()         { *m = ResolveHashParams{} }
 func (m *ResolveHashParams) String() string { return proto.CompactTextString(m) }
 func (*ResolveHashParams) ProtoMessage()    {}
 func (*ResolveHashParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{17}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{43}
 }
 func (m *ResolveHashParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResolveHashParams.Unmarshal(m, b)
@@ -1050,7 +2662,7 @@ func (m *ResolveHashResponse) Reset()         { *m = ResolveHashResponse{} }
 func (m *ResolveHashResponse) String() string { return proto.CompactTextString(m) }
 func (*ResolveHashResponse) ProtoMessage()    {}
 func (*ResolveHashResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{18}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{44}
 }
 func (m *ResolveHashResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResolveHashResponse.Unmarshal(m, b)
@@ -1111,7 +2723,7 @@ func (m *InspectParams) Reset()         { *m = InspectParams{} }
 func (m *InspectParams) String() string { return proto.CompactTextString(m) }
 func (*InspectParams) ProtoMessage()    {}
 func (*InspectParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{19}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{45}
 }
 func (m *InspectParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InspectParams.Unmarshal(m, b)
@@ -1153,19 +2765,20 @@ func (m *InspectParams) GetVerifierKey() []byte {
 }
 
 type InspectResponse struct {
-	Error                *Error       `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Entity               *Entity      `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
-	Attestation          *Attestation `protobuf:"bytes,3,opt,name=attestation,proto3" json:"attestation,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Error                *Error             `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Entity               *Entity            `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Attestation          *Attestation       `protobuf:"bytes,3,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	ThresholdProposal    *ThresholdProposal `protobuf:"bytes,4,opt,name=thresholdProposal,proto3" json:"thresholdProposal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *InspectResponse) Reset()         { *m = InspectResponse{} }
 func (m *InspectResponse) String() string { return proto.CompactTextString(m) }
 func (*InspectResponse) ProtoMessage()    {}
 func (*InspectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{20}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{46}
 }
 func (m *InspectResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InspectResponse.Unmarshal(m, b)
@@ -1206,6 +2819,13 @@ func (m *InspectResponse) GetAttestation() *Attestation {
 	return nil
 }
 
+func (m *InspectResponse) GetThresholdProposal() *ThresholdProposal {
+	if m != nil {
+		return m.ThresholdProposal
+	}
+	return nil
+}
+
 type ListLocationsParams struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1216,7 +2836,7 @@ func (m *ListLocationsParams) Reset()         { *m = ListLocationsParams{} }
 func (m *ListLocationsParams) String() string { return proto.CompactTextString(m) }
 func (*ListLocationsParams) ProtoMessage()    {}
 func (*ListLocationsParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{21}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{47}
 }
 func (m *ListLocationsParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListLocationsParams.Unmarshal(m, b)
@@ -1248,7 +2868,7 @@ func (m *ListLocationsResponse) Reset()         { *m = ListLocationsResponse{} }
 func (m *ListLocationsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListLocationsResponse) ProtoMessage()    {}
 func (*ListLocationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{22}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{48}
 }
 func (m *ListLocationsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListLocationsResponse.Unmarshal(m, b)
@@ -1284,20 +2904,25 @@ func (m *ListLocationsResponse) GetAgentLocations() map[string]*Location {
 
 type CreateEntityParams struct {
 	// Milliseconds since the epoch
-	ValidFrom            int64     `protobuf:"varint,1,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
-	ValidUntil           int64     `protobuf:"varint,2,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
-	RevocationLocation   *Location `protobuf:"bytes,3,opt,name=revocationLocation,proto3" json:"revocationLocation,omitempty"`
-	SecretPassphrase     string    `protobuf:"bytes,4,opt,name=SecretPassphrase,proto3" json:"SecretPassphrase,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	ValidFrom          int64     `protobuf:"varint,1,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	ValidUntil         int64     `protobuf:"varint,2,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	RevocationLocation *Location `protobuf:"bytes,3,opt,name=revocationLocation,proto3" json:"revocationLocation,omitempty"`
+	SecretPassphrase   string    `protobuf:"bytes,4,opt,name=SecretPassphrase,proto3" json:"SecretPassphrase,omitempty"`
+	// The signing key scheme. If omitted will default to Ed25519
+	KeyScheme string `protobuf:"bytes,5,opt,name=keyScheme,proto3" json:"keyScheme,omitempty"`
+	// The WR1 partition schedule for the entity as a namespace. If omitted
+	// the default schedule is used
+	PartitionSchedule    *PartitionSchedule `protobuf:"bytes,6,opt,name=partitionSchedule,proto3" json:"partitionSchedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *CreateEntityParams) Reset()         { *m = CreateEntityParams{} }
 func (m *CreateEntityParams) String() string { return proto.CompactTextString(m) }
 func (*CreateEntityParams) ProtoMessage()    {}
 func (*CreateEntityParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{23}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{49}
 }
 func (m *CreateEntityParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateEntityParams.Unmarshal(m, b)
@@ -1326,23 +2951,95 @@ func (m *CreateEntityParams) GetValidFrom() int64 {
 
 func (m *CreateEntityParams) GetValidUntil() int64 {
 	if m != nil {
-		return m.ValidUntil
+		return m.ValidUntil
+	}
+	return 0
+}
+
+func (m *CreateEntityParams) GetRevocationLocation() *Location {
+	if m != nil {
+		return m.RevocationLocation
+	}
+	return nil
+}
+
+func (m *CreateEntityParams) GetSecretPassphrase() string {
+	if m != nil {
+		return m.SecretPassphrase
+	}
+	return ""
+}
+
+func (m *CreateEntityParams) GetKeyScheme() string {
+	if m != nil {
+		return m.KeyScheme
+	}
+	return ""
+}
+
+func (m *CreateEntityParams) GetPartitionSchedule() *PartitionSchedule {
+	if m != nil {
+		return m.PartitionSchedule
+	}
+	return nil
+}
+
+type PartitionSchedule struct {
+	// Tier lengths in nanoseconds, coarsest first. Each tier must be a
+	// multiple of the next
+	Tiers []int64 `protobuf:"varint,1,rep,packed,name=tiers,proto3" json:"tiers,omitempty"`
+	// The number of bytes in each time slot
+	SlotSize int32 `protobuf:"varint,2,opt,name=slotSize,proto3" json:"slotSize,omitempty"`
+	// The longest validity range in nanoseconds
+	MaxRange             int64    `protobuf:"varint,3,opt,name=maxRange,proto3" json:"maxRange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionSchedule) Reset()         { *m = PartitionSchedule{} }
+func (m *PartitionSchedule) String() string { return proto.CompactTextString(m) }
+func (*PartitionSchedule) ProtoMessage()    {}
+func (*PartitionSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{50}
+}
+func (m *PartitionSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionSchedule.Unmarshal(m, b)
+}
+func (m *PartitionSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionSchedule.Marshal(b, m, deterministic)
+}
+func (dst *PartitionSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionSchedule.Merge(dst, src)
+}
+func (m *PartitionSchedule) XXX_Size() int {
+	return xxx_messageInfo_PartitionSchedule.Size(m)
+}
+func (m *PartitionSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionSchedule proto.InternalMessageInfo
+
+func (m *PartitionSchedule) GetTiers() []int64 {
+	if m != nil {
+		return m.Tiers
 	}
-	return 0
+	return nil
 }
 
-func (m *CreateEntityParams) GetRevocationLocation() *Location {
+func (m *PartitionSchedule) GetSlotSize() int32 {
 	if m != nil {
-		return m.RevocationLocation
+		return m.SlotSize
 	}
-	return nil
+	return 0
 }
 
-func (m *CreateEntityParams) GetSecretPassphrase() string {
+func (m *PartitionSchedule) GetMaxRange() int64 {
 	if m != nil {
-		return m.SecretPassphrase
+		return m.MaxRange
 	}
-	return ""
+	return 0
 }
 
 type CreateEntityResponse struct {
@@ -1359,7 +3056,7 @@ func (m *CreateEntityResponse) Reset()         { *m = CreateEntityResponse{} }
 func (m *CreateEntityResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateEntityResponse) ProtoMessage()    {}
 func (*CreateEntityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{24}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{51}
 }
 func (m *CreateEntityResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateEntityResponse.Unmarshal(m, b)
@@ -1421,7 +3118,7 @@ func (m *Entity) Reset()         { *m = Entity{} }
 func (m *Entity) String() string { return proto.CompactTextString(m) }
 func (*Entity) ProtoMessage()    {}
 func (*Entity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{25}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{52}
 }
 func (m *Entity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Entity.Unmarshal(m, b)
@@ -1472,25 +3169,30 @@ func (m *Entity) GetValidity() *EntityValidity {
 type CreateAttestationParams struct {
 	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
 	// If omitted will default to wr1
-	BodyScheme      string    `protobuf:"bytes,2,opt,name=bodyScheme,proto3" json:"bodyScheme,omitempty"`
+	BodyScheme string `protobuf:"bytes,2,opt,name=bodyScheme,proto3" json:"bodyScheme,omitempty"`
+	// The subject hash or a WAVE name, resolved from the perspective
 	SubjectHash     []byte    `protobuf:"bytes,3,opt,name=subjectHash,proto3" json:"subjectHash,omitempty"`
 	SubjectLocation *Location `protobuf:"bytes,4,opt,name=subjectLocation,proto3" json:"subjectLocation,omitempty"`
 	// If 0, will be set to time.Now. Ms since epoch
 	ValidFrom int64 `protobuf:"varint,5,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
 	// If 0, will be set to some arbitrary default. Ms since epoch
-	ValidUntil           int64    `protobuf:"varint,6,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
-	Policy               *Policy  `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`
-	Publish              bool     `protobuf:"varint,8,opt,name=publish,proto3" json:"publish,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ValidUntil int64   `protobuf:"varint,6,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	Policy     *Policy `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`
+	Publish    bool    `protobuf:"varint,8,opt,name=publish,proto3" json:"publish,omitempty"`
+	// Revocation lists that can also revoke this attestation
+	RevocationLists []*RevocationListReference `protobuf:"bytes,9,rep,name=revocationLists,proto3" json:"revocationLists,omitempty"`
+	// Entities other than the attester that can also revoke this attestation
+	Revokers             []*RevokerReference `protobuf:"bytes,10,rep,name=revokers,proto3" json:"revokers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *CreateAttestationParams) Reset()         { *m = CreateAttestationParams{} }
 func (m *CreateAttestationParams) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestationParams) ProtoMessage()    {}
 func (*CreateAttestationParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{26}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{53}
 }
 func (m *CreateAttestationParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateAttestationParams.Unmarshal(m, b)
@@ -1566,6 +3268,67 @@ func (m *CreateAttestationParams) GetPublish() bool {
 	return false
 }
 
+func (m *CreateAttestationParams) GetRevocationLists() []*RevocationListReference {
+	if m != nil {
+		return m.RevocationLists
+	}
+	return nil
+}
+
+func (m *CreateAttestationParams) GetRevokers() []*RevokerReference {
+	if m != nil {
+		return m.Revokers
+	}
+	return nil
+}
+
+type RevokerReference struct {
+	Revoker []byte `protobuf:"bytes,1,opt,name=revoker,proto3" json:"revoker,omitempty"`
+	// If omitted, the default location
+	RevokerLocation      *Location `protobuf:"bytes,2,opt,name=revokerLocation,proto3" json:"revokerLocation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RevokerReference) Reset()         { *m = RevokerReference{} }
+func (m *RevokerReference) String() string { return proto.CompactTextString(m) }
+func (*RevokerReference) ProtoMessage()    {}
+func (*RevokerReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{54}
+}
+func (m *RevokerReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokerReference.Unmarshal(m, b)
+}
+func (m *RevokerReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokerReference.Marshal(b, m, deterministic)
+}
+func (dst *RevokerReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokerReference.Merge(dst, src)
+}
+func (m *RevokerReference) XXX_Size() int {
+	return xxx_messageInfo_RevokerReference.Size(m)
+}
+func (m *RevokerReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokerReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokerReference proto.InternalMessageInfo
+
+func (m *RevokerReference) GetRevoker() []byte {
+	if m != nil {
+		return m.Revoker
+	}
+	return nil
+}
+
+func (m *RevokerReference) GetRevokerLocation() *Location {
+	if m != nil {
+		return m.RevokerLocation
+	}
+	return nil
+}
+
 type ResyncPerspectiveGraphParams struct {
 	Perspective          *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
@@ -1577,7 +3340,7 @@ func (m *ResyncPerspectiveGraphParams) Reset()         { *m = ResyncPerspectiveG
 func (m *ResyncPerspectiveGraphParams) String() string { return proto.CompactTextString(m) }
 func (*ResyncPerspectiveGraphParams) ProtoMessage()    {}
 func (*ResyncPerspectiveGraphParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{27}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{55}
 }
 func (m *ResyncPerspectiveGraphParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResyncPerspectiveGraphParams.Unmarshal(m, b)
@@ -1615,7 +3378,7 @@ func (m *ResyncPerspectiveGraphResponse) Reset()         { *m = ResyncPerspectiv
 func (m *ResyncPerspectiveGraphResponse) String() string { return proto.CompactTextString(m) }
 func (*ResyncPerspectiveGraphResponse) ProtoMessage()    {}
 func (*ResyncPerspectiveGraphResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{28}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{56}
 }
 func (m *ResyncPerspectiveGraphResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResyncPerspectiveGraphResponse.Unmarshal(m, b)
@@ -1653,7 +3416,7 @@ func (m *SyncParams) Reset()         { *m = SyncParams{} }
 func (m *SyncParams) String() string { return proto.CompactTextString(m) }
 func (*SyncParams) ProtoMessage()    {}
 func (*SyncParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{29}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{57}
 }
 func (m *SyncParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SyncParams.Unmarshal(m, b)
@@ -1681,11 +3444,13 @@ func (m *SyncParams) GetPerspective() *Perspective {
 }
 
 type EncryptMessageParams struct {
-	// This is no longer required
-	Unused *Perspective `protobuf:"bytes,1,opt,name=unused,proto3" json:"unused,omitempty"`
+	// Only required if a recipient is given as a WAVE name, which is
+	// resolved from this perspective
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
 	// The payload of the message
 	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	// If present, a direct decryption key will be generated
+	// If present, a direct decryption key will be generated. The hash
+	// fields here and in subjects and namespaces may also be WAVE names
 	SubjectHash     []byte    `protobuf:"bytes,3,opt,name=subjectHash,proto3" json:"subjectHash,omitempty"`
 	SubjectLocation *Location `protobuf:"bytes,4,opt,name=subjectLocation,proto3" json:"subjectLocation,omitempty"`
 	// If present, an OAQUE decryption key will be generated
@@ -1695,17 +3460,20 @@ type EncryptMessageParams struct {
 	// ms since epoch, if zero set to now
 	ValidFrom int64 `protobuf:"varint,8,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
 	// ms since epoch, if zero set to now
-	ValidUntil           int64    `protobuf:"varint,9,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ValidUntil int64 `protobuf:"varint,9,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	// Additional recipients, the content key is encrypted once per recipient
+	Subjects             []*EncryptionSubject   `protobuf:"bytes,10,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	Namespaces           []*EncryptionNamespace `protobuf:"bytes,11,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *EncryptMessageParams) Reset()         { *m = EncryptMessageParams{} }
 func (m *EncryptMessageParams) String() string { return proto.CompactTextString(m) }
 func (*EncryptMessageParams) ProtoMessage()    {}
 func (*EncryptMessageParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{30}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{58}
 }
 func (m *EncryptMessageParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EncryptMessageParams.Unmarshal(m, b)
@@ -1725,9 +3493,9 @@ func (m *EncryptMessageParams) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_EncryptMessageParams proto.InternalMessageInfo
 
-func (m *EncryptMessageParams) GetUnused() *Perspective {
+func (m *EncryptMessageParams) GetPerspective() *Perspective {
 	if m != nil {
-		return m.Unused
+		return m.Perspective
 	}
 	return nil
 }
@@ -1753,83 +3521,425 @@ func (m *EncryptMessageParams) GetSubjectLocation() *Location {
 	return nil
 }
 
-func (m *EncryptMessageParams) GetNamespace() []byte {
+func (m *EncryptMessageParams) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *EncryptMessageParams) GetNamespaceLocation() *Location {
+	if m != nil {
+		return m.NamespaceLocation
+	}
+	return nil
+}
+
+func (m *EncryptMessageParams) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *EncryptMessageParams) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *EncryptMessageParams) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+func (m *EncryptMessageParams) GetSubjects() []*EncryptionSubject {
+	if m != nil {
+		return m.Subjects
+	}
+	return nil
+}
+
+func (m *EncryptMessageParams) GetNamespaces() []*EncryptionNamespace {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+type EncryptionSubject struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// If omitted, the default location
+	Location             *Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *EncryptionSubject) Reset()         { *m = EncryptionSubject{} }
+func (m *EncryptionSubject) String() string { return proto.CompactTextString(m) }
+func (*EncryptionSubject) ProtoMessage()    {}
+func (*EncryptionSubject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{59}
+}
+func (m *EncryptionSubject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptionSubject.Unmarshal(m, b)
+}
+func (m *EncryptionSubject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptionSubject.Marshal(b, m, deterministic)
+}
+func (dst *EncryptionSubject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptionSubject.Merge(dst, src)
+}
+func (m *EncryptionSubject) XXX_Size() int {
+	return xxx_messageInfo_EncryptionSubject.Size(m)
+}
+func (m *EncryptionSubject) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptionSubject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptionSubject proto.InternalMessageInfo
+
+func (m *EncryptionSubject) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *EncryptionSubject) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+type EncryptionNamespace struct {
+	Namespace []byte `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// If omitted, the default location
+	NamespaceLocation *Location `protobuf:"bytes,2,opt,name=namespaceLocation,proto3" json:"namespaceLocation,omitempty"`
+	Resource          string    `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	// ms since epoch, if zero set to now
+	ValidFrom int64 `protobuf:"varint,4,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	// ms since epoch, if zero set to now
+	ValidUntil           int64    `protobuf:"varint,5,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptionNamespace) Reset()         { *m = EncryptionNamespace{} }
+func (m *EncryptionNamespace) String() string { return proto.CompactTextString(m) }
+func (*EncryptionNamespace) ProtoMessage()    {}
+func (*EncryptionNamespace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{60}
+}
+func (m *EncryptionNamespace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptionNamespace.Unmarshal(m, b)
+}
+func (m *EncryptionNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptionNamespace.Marshal(b, m, deterministic)
+}
+func (dst *EncryptionNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptionNamespace.Merge(dst, src)
+}
+func (m *EncryptionNamespace) XXX_Size() int {
+	return xxx_messageInfo_EncryptionNamespace.Size(m)
+}
+func (m *EncryptionNamespace) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptionNamespace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptionNamespace proto.InternalMessageInfo
+
+func (m *EncryptionNamespace) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *EncryptionNamespace) GetNamespaceLocation() *Location {
+	if m != nil {
+		return m.NamespaceLocation
+	}
+	return nil
+}
+
+func (m *EncryptionNamespace) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *EncryptionNamespace) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *EncryptionNamespace) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+type EncryptMessageResponse struct {
+	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Ciphertext           []byte   `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptMessageResponse) Reset()         { *m = EncryptMessageResponse{} }
+func (m *EncryptMessageResponse) String() string { return proto.CompactTextString(m) }
+func (*EncryptMessageResponse) ProtoMessage()    {}
+func (*EncryptMessageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{61}
+}
+func (m *EncryptMessageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptMessageResponse.Unmarshal(m, b)
+}
+func (m *EncryptMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptMessageResponse.Marshal(b, m, deterministic)
+}
+func (dst *EncryptMessageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptMessageResponse.Merge(dst, src)
+}
+func (m *EncryptMessageResponse) XXX_Size() int {
+	return xxx_messageInfo_EncryptMessageResponse.Size(m)
+}
+func (m *EncryptMessageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptMessageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptMessageResponse proto.InternalMessageInfo
+
+func (m *EncryptMessageResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *EncryptMessageResponse) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+type EncryptStreamParams struct {
+	// Only in the first message. Its content is ignored
+	Recipients *EncryptMessageParams `protobuf:"bytes,1,opt,name=recipients,proto3" json:"recipients,omitempty"`
+	// Only in the first message. The plaintext bytes in each segment, if 0
+	// 64KiB
+	SegmentSize int64 `protobuf:"varint,2,opt,name=segmentSize,proto3" json:"segmentSize,omitempty"`
+	// The next piece of the plaintext
+	Content              []byte   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptStreamParams) Reset()         { *m = EncryptStreamParams{} }
+func (m *EncryptStreamParams) String() string { return proto.CompactTextString(m) }
+func (*EncryptStreamParams) ProtoMessage()    {}
+func (*EncryptStreamParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{62}
+}
+func (m *EncryptStreamParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptStreamParams.Unmarshal(m, b)
+}
+func (m *EncryptStreamParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptStreamParams.Marshal(b, m, deterministic)
+}
+func (dst *EncryptStreamParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptStreamParams.Merge(dst, src)
+}
+func (m *EncryptStreamParams) XXX_Size() int {
+	return xxx_messageInfo_EncryptStreamParams.Size(m)
+}
+func (m *EncryptStreamParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptStreamParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptStreamParams proto.InternalMessageInfo
+
+func (m *EncryptStreamParams) GetRecipients() *EncryptMessageParams {
+	if m != nil {
+		return m.Recipients
+	}
+	return nil
+}
+
+func (m *EncryptStreamParams) GetSegmentSize() int64 {
+	if m != nil {
+		return m.SegmentSize
+	}
+	return 0
+}
+
+func (m *EncryptStreamParams) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type EncryptStreamResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The next piece of the ciphertext
+	Ciphertext           []byte   `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptStreamResponse) Reset()         { *m = EncryptStreamResponse{} }
+func (m *EncryptStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*EncryptStreamResponse) ProtoMessage()    {}
+func (*EncryptStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{63}
+}
+func (m *EncryptStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptStreamResponse.Unmarshal(m, b)
+}
+func (m *EncryptStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptStreamResponse.Marshal(b, m, deterministic)
+}
+func (dst *EncryptStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptStreamResponse.Merge(dst, src)
+}
+func (m *EncryptStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_EncryptStreamResponse.Size(m)
+}
+func (m *EncryptStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptStreamResponse proto.InternalMessageInfo
+
+func (m *EncryptStreamResponse) GetError() *Error {
 	if m != nil {
-		return m.Namespace
+		return m.Error
 	}
 	return nil
 }
 
-func (m *EncryptMessageParams) GetNamespaceLocation() *Location {
+func (m *EncryptStreamResponse) GetCiphertext() []byte {
 	if m != nil {
-		return m.NamespaceLocation
+		return m.Ciphertext
 	}
 	return nil
 }
 
-func (m *EncryptMessageParams) GetResource() string {
+type DecryptStreamParams struct {
+	// Only in the first message
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	ResyncFirst bool         `protobuf:"varint,2,opt,name=resyncFirst,proto3" json:"resyncFirst,omitempty"`
+	// The next piece of the ciphertext
+	Ciphertext           []byte   `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecryptStreamParams) Reset()         { *m = DecryptStreamParams{} }
+func (m *DecryptStreamParams) String() string { return proto.CompactTextString(m) }
+func (*DecryptStreamParams) ProtoMessage()    {}
+func (*DecryptStreamParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{64}
+}
+func (m *DecryptStreamParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecryptStreamParams.Unmarshal(m, b)
+}
+func (m *DecryptStreamParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecryptStreamParams.Marshal(b, m, deterministic)
+}
+func (dst *DecryptStreamParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecryptStreamParams.Merge(dst, src)
+}
+func (m *DecryptStreamParams) XXX_Size() int {
+	return xxx_messageInfo_DecryptStreamParams.Size(m)
+}
+func (m *DecryptStreamParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecryptStreamParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecryptStreamParams proto.InternalMessageInfo
+
+func (m *DecryptStreamParams) GetPerspective() *Perspective {
 	if m != nil {
-		return m.Resource
+		return m.Perspective
 	}
-	return ""
+	return nil
 }
 
-func (m *EncryptMessageParams) GetValidFrom() int64 {
+func (m *DecryptStreamParams) GetResyncFirst() bool {
 	if m != nil {
-		return m.ValidFrom
+		return m.ResyncFirst
 	}
-	return 0
+	return false
 }
 
-func (m *EncryptMessageParams) GetValidUntil() int64 {
+func (m *DecryptStreamParams) GetCiphertext() []byte {
 	if m != nil {
-		return m.ValidUntil
+		return m.Ciphertext
 	}
-	return 0
+	return nil
 }
 
-type EncryptMessageResponse struct {
-	Error                *Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Ciphertext           []byte   `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+type DecryptStreamResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The next piece of the plaintext
+	Content              []byte   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EncryptMessageResponse) Reset()         { *m = EncryptMessageResponse{} }
-func (m *EncryptMessageResponse) String() string { return proto.CompactTextString(m) }
-func (*EncryptMessageResponse) ProtoMessage()    {}
-func (*EncryptMessageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{31}
+func (m *DecryptStreamResponse) Reset()         { *m = DecryptStreamResponse{} }
+func (m *DecryptStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*DecryptStreamResponse) ProtoMessage()    {}
+func (*DecryptStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{65}
 }
-func (m *EncryptMessageResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EncryptMessageResponse.Unmarshal(m, b)
+func (m *DecryptStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecryptStreamResponse.Unmarshal(m, b)
 }
-func (m *EncryptMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EncryptMessageResponse.Marshal(b, m, deterministic)
+func (m *DecryptStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecryptStreamResponse.Marshal(b, m, deterministic)
 }
-func (dst *EncryptMessageResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EncryptMessageResponse.Merge(dst, src)
+func (dst *DecryptStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecryptStreamResponse.Merge(dst, src)
 }
-func (m *EncryptMessageResponse) XXX_Size() int {
-	return xxx_messageInfo_EncryptMessageResponse.Size(m)
+func (m *DecryptStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_DecryptStreamResponse.Size(m)
 }
-func (m *EncryptMessageResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EncryptMessageResponse.DiscardUnknown(m)
+func (m *DecryptStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecryptStreamResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EncryptMessageResponse proto.InternalMessageInfo
+var xxx_messageInfo_DecryptStreamResponse proto.InternalMessageInfo
 
-func (m *EncryptMessageResponse) GetError() *Error {
+func (m *DecryptStreamResponse) GetError() *Error {
 	if m != nil {
 		return m.Error
 	}
 	return nil
 }
 
-func (m *EncryptMessageResponse) GetCiphertext() []byte {
+func (m *DecryptStreamResponse) GetContent() []byte {
 	if m != nil {
-		return m.Ciphertext
+		return m.Content
 	}
 	return nil
 }
@@ -1847,7 +3957,7 @@ func (m *DecryptMessageParams) Reset()         { *m = DecryptMessageParams{} }
 func (m *DecryptMessageParams) String() string { return proto.CompactTextString(m) }
 func (*DecryptMessageParams) ProtoMessage()    {}
 func (*DecryptMessageParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{32}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{66}
 }
 func (m *DecryptMessageParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecryptMessageParams.Unmarshal(m, b)
@@ -1900,7 +4010,7 @@ func (m *DecryptMessageResponse) Reset()         { *m = DecryptMessageResponse{}
 func (m *DecryptMessageResponse) String() string { return proto.CompactTextString(m) }
 func (*DecryptMessageResponse) ProtoMessage()    {}
 func (*DecryptMessageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{33}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{67}
 }
 func (m *DecryptMessageResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DecryptMessageResponse.Unmarshal(m, b)
@@ -1934,6 +4044,260 @@ func (m *DecryptMessageResponse) GetContent() []byte {
 	return nil
 }
 
+type ReencryptMessageParams struct {
+	// The decrypting entity, also used to resolve WAVE names
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	Ciphertext  []byte       `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	ResyncFirst bool         `protobuf:"varint,3,opt,name=resyncFirst,proto3" json:"resyncFirst,omitempty"`
+	// The recipients of the new message. If there are none, the namespace
+	// recipients of the original message are kept, moved to newNamespace if
+	// it is given and to the new validity window
+	Subjects   []*EncryptionSubject   `protobuf:"bytes,4,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	Namespaces []*EncryptionNamespace `protobuf:"bytes,5,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// A hash or WAVE name. It can not be combined with subjects or namespaces
+	NewNamespace         []byte    `protobuf:"bytes,6,opt,name=newNamespace,proto3" json:"newNamespace,omitempty"`
+	NewNamespaceLocation *Location `protobuf:"bytes,7,opt,name=newNamespaceLocation,proto3" json:"newNamespaceLocation,omitempty"`
+	// The validity window of the kept namespace recipients. Explicit
+	// namespaces carry their own window
+	// ms since epoch, if zero set to now
+	ValidFrom int64 `protobuf:"varint,8,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	// ms since epoch, if zero set to 30 days after validFrom
+	ValidUntil           int64    `protobuf:"varint,9,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReencryptMessageParams) Reset()         { *m = ReencryptMessageParams{} }
+func (m *ReencryptMessageParams) String() string { return proto.CompactTextString(m) }
+func (*ReencryptMessageParams) ProtoMessage()    {}
+func (*ReencryptMessageParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{68}
+}
+func (m *ReencryptMessageParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReencryptMessageParams.Unmarshal(m, b)
+}
+func (m *ReencryptMessageParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReencryptMessageParams.Marshal(b, m, deterministic)
+}
+func (dst *ReencryptMessageParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReencryptMessageParams.Merge(dst, src)
+}
+func (m *ReencryptMessageParams) XXX_Size() int {
+	return xxx_messageInfo_ReencryptMessageParams.Size(m)
+}
+func (m *ReencryptMessageParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReencryptMessageParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReencryptMessageParams proto.InternalMessageInfo
+
+func (m *ReencryptMessageParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *ReencryptMessageParams) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+func (m *ReencryptMessageParams) GetResyncFirst() bool {
+	if m != nil {
+		return m.ResyncFirst
+	}
+	return false
+}
+
+func (m *ReencryptMessageParams) GetSubjects() []*EncryptionSubject {
+	if m != nil {
+		return m.Subjects
+	}
+	return nil
+}
+
+func (m *ReencryptMessageParams) GetNamespaces() []*EncryptionNamespace {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *ReencryptMessageParams) GetNewNamespace() []byte {
+	if m != nil {
+		return m.NewNamespace
+	}
+	return nil
+}
+
+func (m *ReencryptMessageParams) GetNewNamespaceLocation() *Location {
+	if m != nil {
+		return m.NewNamespaceLocation
+	}
+	return nil
+}
+
+func (m *ReencryptMessageParams) GetValidFrom() int64 {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return 0
+}
+
+func (m *ReencryptMessageParams) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+type MessageRecipient struct {
+	// Direct recipients only have a subject if it is the perspective
+	Direct            bool      `protobuf:"varint,1,opt,name=direct,proto3" json:"direct,omitempty"`
+	Subject           []byte    `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Namespace         []byte    `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	NamespaceLocation *Location `protobuf:"bytes,4,opt,name=namespaceLocation,proto3" json:"namespaceLocation,omitempty"`
+	// Empty if the perspective could not open the envelope
+	Resource             string   `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
+	Partition            [][]byte `protobuf:"bytes,6,rep,name=partition,proto3" json:"partition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MessageRecipient) Reset()         { *m = MessageRecipient{} }
+func (m *MessageRecipient) String() string { return proto.CompactTextString(m) }
+func (*MessageRecipient) ProtoMessage()    {}
+func (*MessageRecipient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{69}
+}
+func (m *MessageRecipient) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageRecipient.Unmarshal(m, b)
+}
+func (m *MessageRecipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageRecipient.Marshal(b, m, deterministic)
+}
+func (dst *MessageRecipient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageRecipient.Merge(dst, src)
+}
+func (m *MessageRecipient) XXX_Size() int {
+	return xxx_messageInfo_MessageRecipient.Size(m)
+}
+func (m *MessageRecipient) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageRecipient.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageRecipient proto.InternalMessageInfo
+
+func (m *MessageRecipient) GetDirect() bool {
+	if m != nil {
+		return m.Direct
+	}
+	return false
+}
+
+func (m *MessageRecipient) GetSubject() []byte {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *MessageRecipient) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *MessageRecipient) GetNamespaceLocation() *Location {
+	if m != nil {
+		return m.NamespaceLocation
+	}
+	return nil
+}
+
+func (m *MessageRecipient) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *MessageRecipient) GetPartition() [][]byte {
+	if m != nil {
+		return m.Partition
+	}
+	return nil
+}
+
+type ReencryptMessageResponse struct {
+	Error      *Error              `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Ciphertext []byte              `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	Recipients []*MessageRecipient `protobuf:"bytes,3,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	// Recipients of the original message that can not decrypt the new one
+	LostAccess           []*MessageRecipient `protobuf:"bytes,4,rep,name=lostAccess,proto3" json:"lostAccess,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ReencryptMessageResponse) Reset()         { *m = ReencryptMessageResponse{} }
+func (m *ReencryptMessageResponse) String() string { return proto.CompactTextString(m) }
+func (*ReencryptMessageResponse) ProtoMessage()    {}
+func (*ReencryptMessageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{70}
+}
+func (m *ReencryptMessageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReencryptMessageResponse.Unmarshal(m, b)
+}
+func (m *ReencryptMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReencryptMessageResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReencryptMessageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReencryptMessageResponse.Merge(dst, src)
+}
+func (m *ReencryptMessageResponse) XXX_Size() int {
+	return xxx_messageInfo_ReencryptMessageResponse.Size(m)
+}
+func (m *ReencryptMessageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReencryptMessageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReencryptMessageResponse proto.InternalMessageInfo
+
+func (m *ReencryptMessageResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ReencryptMessageResponse) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+func (m *ReencryptMessageResponse) GetRecipients() []*MessageRecipient {
+	if m != nil {
+		return m.Recipients
+	}
+	return nil
+}
+
+func (m *ReencryptMessageResponse) GetLostAccess() []*MessageRecipient {
+	if m != nil {
+		return m.LostAccess
+	}
+	return nil
+}
+
 type SyncResponse struct {
 	Error                *Error                          `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	StorageStatus        map[string]*StorageDriverStatus `protobuf:"bytes,2,rep,name=storageStatus,proto3" json:"storageStatus,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -1948,7 +4312,7 @@ func (m *SyncResponse) Reset()         { *m = SyncResponse{} }
 func (m *SyncResponse) String() string { return proto.CompactTextString(m) }
 func (*SyncResponse) ProtoMessage()    {}
 func (*SyncResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{34}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{71}
 }
 func (m *SyncResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SyncResponse.Unmarshal(m, b)
@@ -2008,7 +4372,7 @@ func (m *StorageDriverStatus) Reset()         { *m = StorageDriverStatus{} }
 func (m *StorageDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*StorageDriverStatus) ProtoMessage()    {}
 func (*StorageDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{35}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{72}
 }
 func (m *StorageDriverStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StorageDriverStatus.Unmarshal(m, b)
@@ -2057,7 +4421,7 @@ func (m *CreateAttestationResponse) Reset()         { *m = CreateAttestationResp
 func (m *CreateAttestationResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestationResponse) ProtoMessage()    {}
 func (*CreateAttestationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{36}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{73}
 }
 func (m *CreateAttestationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateAttestationResponse.Unmarshal(m, b)
@@ -2091,23 +4455,135 @@ func (m *CreateAttestationResponse) GetDER() []byte {
 	return nil
 }
 
-func (m *CreateAttestationResponse) GetVerifierKey() []byte {
+func (m *CreateAttestationResponse) GetVerifierKey() []byte {
+	if m != nil {
+		return m.VerifierKey
+	}
+	return nil
+}
+
+func (m *CreateAttestationResponse) GetProverKey() []byte {
+	if m != nil {
+		return m.ProverKey
+	}
+	return nil
+}
+
+func (m *CreateAttestationResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type CreateAttestationsParams struct {
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	// The perspective of each of these is ignored
+	Attestations []*CreateAttestationParams `protobuf:"bytes,2,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	// Resync the perspective graph before creating anything
+	Sync bool `protobuf:"varint,3,opt,name=sync,proto3" json:"sync,omitempty"`
+	// How many attestations to create at a time, if 0 a default is used
+	Concurrency          int32    `protobuf:"varint,4,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAttestationsParams) Reset()         { *m = CreateAttestationsParams{} }
+func (m *CreateAttestationsParams) String() string { return proto.CompactTextString(m) }
+func (*CreateAttestationsParams) ProtoMessage()    {}
+func (*CreateAttestationsParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{74}
+}
+func (m *CreateAttestationsParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAttestationsParams.Unmarshal(m, b)
+}
+func (m *CreateAttestationsParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAttestationsParams.Marshal(b, m, deterministic)
+}
+func (dst *CreateAttestationsParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAttestationsParams.Merge(dst, src)
+}
+func (m *CreateAttestationsParams) XXX_Size() int {
+	return xxx_messageInfo_CreateAttestationsParams.Size(m)
+}
+func (m *CreateAttestationsParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAttestationsParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAttestationsParams proto.InternalMessageInfo
+
+func (m *CreateAttestationsParams) GetPerspective() *Perspective {
+	if m != nil {
+		return m.Perspective
+	}
+	return nil
+}
+
+func (m *CreateAttestationsParams) GetAttestations() []*CreateAttestationParams {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
+func (m *CreateAttestationsParams) GetSync() bool {
+	if m != nil {
+		return m.Sync
+	}
+	return false
+}
+
+func (m *CreateAttestationsParams) GetConcurrency() int32 {
 	if m != nil {
-		return m.VerifierKey
+		return m.Concurrency
 	}
-	return nil
+	return 0
 }
 
-func (m *CreateAttestationResponse) GetProverKey() []byte {
+type CreateAttestationsResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// One per requested attestation, in the same order
+	Results              []*CreateAttestationResponse `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *CreateAttestationsResponse) Reset()         { *m = CreateAttestationsResponse{} }
+func (m *CreateAttestationsResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAttestationsResponse) ProtoMessage()    {}
+func (*CreateAttestationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eapi_bb2472e126594a79, []int{75}
+}
+func (m *CreateAttestationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAttestationsResponse.Unmarshal(m, b)
+}
+func (m *CreateAttestationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAttestationsResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateAttestationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAttestationsResponse.Merge(dst, src)
+}
+func (m *CreateAttestationsResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAttestationsResponse.Size(m)
+}
+func (m *CreateAttestationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAttestationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAttestationsResponse proto.InternalMessageInfo
+
+func (m *CreateAttestationsResponse) GetError() *Error {
 	if m != nil {
-		return m.ProverKey
+		return m.Error
 	}
 	return nil
 }
 
-func (m *CreateAttestationResponse) GetHash() []byte {
+func (m *CreateAttestationsResponse) GetResults() []*CreateAttestationResponse {
 	if m != nil {
-		return m.Hash
+		return m.Results
 	}
 	return nil
 }
@@ -2124,7 +4600,7 @@ func (m *PublishEntityParams) Reset()         { *m = PublishEntityParams{} }
 func (m *PublishEntityParams) String() string { return proto.CompactTextString(m) }
 func (*PublishEntityParams) ProtoMessage()    {}
 func (*PublishEntityParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{37}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{76}
 }
 func (m *PublishEntityParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublishEntityParams.Unmarshal(m, b)
@@ -2170,7 +4646,7 @@ func (m *PublishEntityResponse) Reset()         { *m = PublishEntityResponse{} }
 func (m *PublishEntityResponse) String() string { return proto.CompactTextString(m) }
 func (*PublishEntityResponse) ProtoMessage()    {}
 func (*PublishEntityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{38}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{77}
 }
 func (m *PublishEntityResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublishEntityResponse.Unmarshal(m, b)
@@ -2215,7 +4691,7 @@ func (m *PublishAttestationParams) Reset()         { *m = PublishAttestationPara
 func (m *PublishAttestationParams) String() string { return proto.CompactTextString(m) }
 func (*PublishAttestationParams) ProtoMessage()    {}
 func (*PublishAttestationParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{39}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{78}
 }
 func (m *PublishAttestationParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublishAttestationParams.Unmarshal(m, b)
@@ -2254,7 +4730,7 @@ func (m *PublishAttestationResponse) Reset()         { *m = PublishAttestationRe
 func (m *PublishAttestationResponse) String() string { return proto.CompactTextString(m) }
 func (*PublishAttestationResponse) ProtoMessage()    {}
 func (*PublishAttestationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{40}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{79}
 }
 func (m *PublishAttestationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublishAttestationResponse.Unmarshal(m, b)
@@ -2301,7 +4777,7 @@ func (m *AddAttestationParams) Reset()         { *m = AddAttestationParams{} }
 func (m *AddAttestationParams) String() string { return proto.CompactTextString(m) }
 func (*AddAttestationParams) ProtoMessage()    {}
 func (*AddAttestationParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{41}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{80}
 }
 func (m *AddAttestationParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AddAttestationParams.Unmarshal(m, b)
@@ -2353,7 +4829,7 @@ func (m *AddAttestationResponse) Reset()         { *m = AddAttestationResponse{}
 func (m *AddAttestationResponse) String() string { return proto.CompactTextString(m) }
 func (*AddAttestationResponse) ProtoMessage()    {}
 func (*AddAttestationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{42}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{81}
 }
 func (m *AddAttestationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AddAttestationResponse.Unmarshal(m, b)
@@ -2381,19 +4857,34 @@ func (m *AddAttestationResponse) GetError() *Error {
 }
 
 type LookupAttestationsParams struct {
-	Perspective          *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
-	FromEntity           []byte       `protobuf:"bytes,2,opt,name=fromEntity,proto3" json:"fromEntity,omitempty"`
-	ToEntity             []byte       `protobuf:"bytes,3,opt,name=toEntity,proto3" json:"toEntity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
+	FromEntity  []byte       `protobuf:"bytes,2,opt,name=fromEntity,proto3" json:"fromEntity,omitempty"`
+	ToEntity    []byte       `protobuf:"bytes,3,opt,name=toEntity,proto3" json:"toEntity,omitempty"`
+	// The following filters are optional. The policy filters match an
+	// attestation if any one of its statements matches all of them
+	Namespace      []byte `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PermissionSet  []byte `protobuf:"bytes,5,opt,name=permissionSet,proto3" json:"permissionSet,omitempty"`
+	Permission     string `protobuf:"bytes,6,opt,name=permission,proto3" json:"permission,omitempty"`
+	ResourcePrefix string `protobuf:"bytes,7,opt,name=resourcePrefix,proto3" json:"resourcePrefix,omitempty"`
+	// Only attestations expiring in this window (ms since the epoch)
+	ExpiresAfter   int64 `protobuf:"varint,8,opt,name=expiresAfter,proto3" json:"expiresAfter,omitempty"`
+	ExpiresBefore  int64 `protobuf:"varint,9,opt,name=expiresBefore,proto3" json:"expiresBefore,omitempty"`
+	ExcludeRevoked bool  `protobuf:"varint,10,opt,name=excludeRevoked,proto3" json:"excludeRevoked,omitempty"`
+	OnlyRevoked    bool  `protobuf:"varint,11,opt,name=onlyRevoked,proto3" json:"onlyRevoked,omitempty"`
+	// If pageSize is nonzero, at most that many results are returned and
+	// nextCursor can be passed as cursor to get the next page
+	PageSize             int32    `protobuf:"varint,12,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Cursor               []byte   `protobuf:"bytes,13,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LookupAttestationsParams) Reset()         { *m = LookupAttestationsParams{} }
 func (m *LookupAttestationsParams) String() string { return proto.CompactTextString(m) }
 func (*LookupAttestationsParams) ProtoMessage()    {}
 func (*LookupAttestationsParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{43}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{82}
 }
 func (m *LookupAttestationsParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupAttestationsParams.Unmarshal(m, b)
@@ -2434,19 +4925,91 @@ func (m *LookupAttestationsParams) GetToEntity() []byte {
 	return nil
 }
 
+func (m *LookupAttestationsParams) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *LookupAttestationsParams) GetPermissionSet() []byte {
+	if m != nil {
+		return m.PermissionSet
+	}
+	return nil
+}
+
+func (m *LookupAttestationsParams) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
+}
+
+func (m *LookupAttestationsParams) GetResourcePrefix() string {
+	if m != nil {
+		return m.ResourcePrefix
+	}
+	return ""
+}
+
+func (m *LookupAttestationsParams) GetExpiresAfter() int64 {
+	if m != nil {
+		return m.ExpiresAfter
+	}
+	return 0
+}
+
+func (m *LookupAttestationsParams) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+func (m *LookupAttestationsParams) GetExcludeRevoked() bool {
+	if m != nil {
+		return m.ExcludeRevoked
+	}
+	return false
+}
+
+func (m *LookupAttestationsParams) GetOnlyRevoked() bool {
+	if m != nil {
+		return m.OnlyRevoked
+	}
+	return false
+}
+
+func (m *LookupAttestationsParams) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *LookupAttestationsParams) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 type LookupAttestationsResponse struct {
-	Error                *Error         `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Results              []*Attestation `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Error   *Error         `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Results []*Attestation `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	// Empty if there are no more results
+	NextCursor           []byte   `protobuf:"bytes,3,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LookupAttestationsResponse) Reset()         { *m = LookupAttestationsResponse{} }
 func (m *LookupAttestationsResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupAttestationsResponse) ProtoMessage()    {}
 func (*LookupAttestationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{44}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{83}
 }
 func (m *LookupAttestationsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupAttestationsResponse.Unmarshal(m, b)
@@ -2480,6 +5043,13 @@ func (m *LookupAttestationsResponse) GetResults() []*Attestation {
 	return nil
 }
 
+func (m *LookupAttestationsResponse) GetNextCursor() []byte {
+	if m != nil {
+		return m.NextCursor
+	}
+	return nil
+}
+
 type Error struct {
 	Code                 int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -2492,7 +5062,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{45}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{84}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -2538,7 +5108,7 @@ func (m *Perspective) Reset()         { *m = Perspective{} }
 func (m *Perspective) String() string { return proto.CompactTextString(m) }
 func (*Perspective) ProtoMessage()    {}
 func (*Perspective) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{46}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{85}
 }
 func (m *Perspective) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Perspective.Unmarshal(m, b)
@@ -2586,7 +5156,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{47}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{86}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Location.Unmarshal(m, b)
@@ -2632,7 +5202,7 @@ func (m *LocationURI) Reset()         { *m = LocationURI{} }
 func (m *LocationURI) String() string { return proto.CompactTextString(m) }
 func (*LocationURI) ProtoMessage()    {}
 func (*LocationURI) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{48}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{87}
 }
 func (m *LocationURI) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LocationURI.Unmarshal(m, b)
@@ -2678,7 +5248,7 @@ func (m *EntitySecret) Reset()         { *m = EntitySecret{} }
 func (m *EntitySecret) String() string { return proto.CompactTextString(m) }
 func (*EntitySecret) ProtoMessage()    {}
 func (*EntitySecret) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{49}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{88}
 }
 func (m *EntitySecret) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EntitySecret.Unmarshal(m, b)
@@ -2731,7 +5301,7 @@ func (m *Attestation) Reset()         { *m = Attestation{} }
 func (m *Attestation) String() string { return proto.CompactTextString(m) }
 func (*Attestation) ProtoMessage()    {}
 func (*Attestation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{50}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{89}
 }
 func (m *Attestation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Attestation.Unmarshal(m, b)
@@ -2830,7 +5400,7 @@ func (m *AttestationBody) Reset()         { *m = AttestationBody{} }
 func (m *AttestationBody) String() string { return proto.CompactTextString(m) }
 func (*AttestationBody) ProtoMessage()    {}
 func (*AttestationBody) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{51}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{90}
 }
 func (m *AttestationBody) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationBody.Unmarshal(m, b)
@@ -2911,7 +5481,7 @@ func (m *AttestationValidity) Reset()         { *m = AttestationValidity{} }
 func (m *AttestationValidity) String() string { return proto.CompactTextString(m) }
 func (*AttestationValidity) ProtoMessage()    {}
 func (*AttestationValidity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{52}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{91}
 }
 func (m *AttestationValidity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationValidity.Unmarshal(m, b)
@@ -3010,7 +5580,7 @@ func (m *EntityValidity) Reset()         { *m = EntityValidity{} }
 func (m *EntityValidity) String() string { return proto.CompactTextString(m) }
 func (*EntityValidity) ProtoMessage()    {}
 func (*EntityValidity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{53}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{92}
 }
 func (m *EntityValidity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EntityValidity.Unmarshal(m, b)
@@ -3088,7 +5658,7 @@ func (m *NameDeclarationValidity) Reset()         { *m = NameDeclarationValidity
 func (m *NameDeclarationValidity) String() string { return proto.CompactTextString(m) }
 func (*NameDeclarationValidity) ProtoMessage()    {}
 func (*NameDeclarationValidity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{54}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{93}
 }
 func (m *NameDeclarationValidity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NameDeclarationValidity.Unmarshal(m, b)
@@ -3162,7 +5732,7 @@ func (m *Policy) Reset()         { *m = Policy{} }
 func (m *Policy) String() string { return proto.CompactTextString(m) }
 func (*Policy) ProtoMessage()    {}
 func (*Policy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{55}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{94}
 }
 func (m *Policy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Policy.Unmarshal(m, b)
@@ -3207,7 +5777,7 @@ func (m *TrustLevelPolicy) Reset()         { *m = TrustLevelPolicy{} }
 func (m *TrustLevelPolicy) String() string { return proto.CompactTextString(m) }
 func (*TrustLevelPolicy) ProtoMessage()    {}
 func (*TrustLevelPolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{56}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{95}
 }
 func (m *TrustLevelPolicy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TrustLevelPolicy.Unmarshal(m, b)
@@ -3235,6 +5805,8 @@ func (m *TrustLevelPolicy) GetTrust() int32 {
 }
 
 type RTreePolicy struct {
+	// The namespace hash. When creating attestations this may also be a
+	// WAVE name, resolved from the perspective
 	Namespace            []byte                  `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Indirections         uint32                  `protobuf:"varint,2,opt,name=indirections,proto3" json:"indirections,omitempty"`
 	Statements           []*RTreePolicyStatement `protobuf:"bytes,3,rep,name=statements,proto3" json:"statements,omitempty"`
@@ -3248,7 +5820,7 @@ func (m *RTreePolicy) Reset()         { *m = RTreePolicy{} }
 func (m *RTreePolicy) String() string { return proto.CompactTextString(m) }
 func (*RTreePolicy) ProtoMessage()    {}
 func (*RTreePolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{57}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{96}
 }
 func (m *RTreePolicy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RTreePolicy.Unmarshal(m, b)
@@ -3309,7 +5881,7 @@ func (m *RTreePolicyStatement) Reset()         { *m = RTreePolicyStatement{} }
 func (m *RTreePolicyStatement) String() string { return proto.CompactTextString(m) }
 func (*RTreePolicyStatement) ProtoMessage()    {}
 func (*RTreePolicyStatement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{58}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{97}
 }
 func (m *RTreePolicyStatement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RTreePolicyStatement.Unmarshal(m, b)
@@ -3352,7 +5924,8 @@ func (m *RTreePolicyStatement) GetResource() string {
 
 type BuildRTreeProofParams struct {
 	Perspective *Perspective `protobuf:"bytes,1,opt,name=perspective,proto3" json:"perspective,omitempty"`
-	// If omitted, will default to the perspective entity
+	// If omitted, will default to the perspective entity. Either
+	// field may be a WAVE name, resolved from the perspective
 	SubjectHash          []byte                  `protobuf:"bytes,2,opt,name=subjectHash,proto3" json:"subjectHash,omitempty"`
 	Namespace            []byte                  `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Statements           []*RTreePolicyStatement `protobuf:"bytes,4,rep,name=statements,proto3" json:"statements,omitempty"`
@@ -3366,7 +5939,7 @@ func (m *BuildRTreeProofParams) Reset()         { *m = BuildRTreeProofParams{} }
 func (m *BuildRTreeProofParams) String() string { return proto.CompactTextString(m) }
 func (*BuildRTreeProofParams) ProtoMessage()    {}
 func (*BuildRTreeProofParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{59}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{98}
 }
 func (m *BuildRTreeProofParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BuildRTreeProofParams.Unmarshal(m, b)
@@ -3434,7 +6007,7 @@ func (m *BuildRTreeProofResponse) Reset()         { *m = BuildRTreeProofResponse
 func (m *BuildRTreeProofResponse) String() string { return proto.CompactTextString(m) }
 func (*BuildRTreeProofResponse) ProtoMessage()    {}
 func (*BuildRTreeProofResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{60}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{99}
 }
 func (m *BuildRTreeProofResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BuildRTreeProofResponse.Unmarshal(m, b)
@@ -3488,7 +6061,7 @@ func (m *VerifyProofParams) Reset()         { *m = VerifyProofParams{} }
 func (m *VerifyProofParams) String() string { return proto.CompactTextString(m) }
 func (*VerifyProofParams) ProtoMessage()    {}
 func (*VerifyProofParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{61}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{100}
 }
 func (m *VerifyProofParams) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VerifyProofParams.Unmarshal(m, b)
@@ -3541,7 +6114,7 @@ func (m *VerifyProofResponse) Reset()         { *m = VerifyProofResponse{} }
 func (m *VerifyProofResponse) String() string { return proto.CompactTextString(m) }
 func (*VerifyProofResponse) ProtoMessage()    {}
 func (*VerifyProofResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{62}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{101}
 }
 func (m *VerifyProofResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VerifyProofResponse.Unmarshal(m, b)
@@ -3591,7 +6164,7 @@ func (m *Proof) Reset()         { *m = Proof{} }
 func (m *Proof) String() string { return proto.CompactTextString(m) }
 func (*Proof) ProtoMessage()    {}
 func (*Proof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{63}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{102}
 }
 func (m *Proof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proof.Unmarshal(m, b)
@@ -3664,7 +6237,7 @@ func (m *ProofPath) Reset()         { *m = ProofPath{} }
 func (m *ProofPath) String() string { return proto.CompactTextString(m) }
 func (*ProofPath) ProtoMessage()    {}
 func (*ProofPath) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eapi_1169f3d0de20e30d, []int{64}
+	return fileDescriptor_eapi_bb2472e126594a79, []int{103}
 }
 func (m *ProofPath) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProofPath.Unmarshal(m, b)
@@ -3692,6 +6265,25 @@ func (m *ProofPath) GetElements() []int32 {
 }
 
 func init() {
+	proto.RegisterType((*RevocationListReference)(nil), "pb.RevocationListReference")
+	proto.RegisterType((*UpdateRevocationListParams)(nil), "pb.UpdateRevocationListParams")
+	proto.RegisterType((*UpdateRevocationListResponse)(nil), "pb.UpdateRevocationListResponse")
+	proto.RegisterType((*PrecomputeKeyBundleParams)(nil), "pb.PrecomputeKeyBundleParams")
+	proto.RegisterType((*PrecomputeKeyBundleResponse)(nil), "pb.PrecomputeKeyBundleResponse")
+	proto.RegisterType((*KeyBundleCacheStatsParams)(nil), "pb.KeyBundleCacheStatsParams")
+	proto.RegisterType((*KeyBundleCacheStatsResponse)(nil), "pb.KeyBundleCacheStatsResponse")
+	proto.RegisterType((*CreateSSHCertificateParams)(nil), "pb.CreateSSHCertificateParams")
+	proto.RegisterType((*CreateSSHCertificateResponse)(nil), "pb.CreateSSHCertificateResponse")
+	proto.RegisterType((*CreateX509CertificateParams)(nil), "pb.CreateX509CertificateParams")
+	proto.RegisterType((*CreateX509CertificateResponse)(nil), "pb.CreateX509CertificateResponse")
+	proto.RegisterType((*ThresholdCoSigner)(nil), "pb.ThresholdCoSigner")
+	proto.RegisterType((*CreateThresholdProposalParams)(nil), "pb.CreateThresholdProposalParams")
+	proto.RegisterType((*CoSignThresholdProposalParams)(nil), "pb.CoSignThresholdProposalParams")
+	proto.RegisterType((*ThresholdProposal)(nil), "pb.ThresholdProposal")
+	proto.RegisterType((*ThresholdProposalResponse)(nil), "pb.ThresholdProposalResponse")
+	proto.RegisterType((*CreateThresholdAttestationParams)(nil), "pb.CreateThresholdAttestationParams")
+	proto.RegisterType((*CreateEntitySuccessionParams)(nil), "pb.CreateEntitySuccessionParams")
+	proto.RegisterType((*CreateEntitySuccessionResponse)(nil), "pb.CreateEntitySuccessionResponse")
 	proto.RegisterType((*SignParams)(nil), "pb.SignParams")
 	proto.RegisterType((*SignResponse)(nil), "pb.SignResponse")
 	proto.RegisterType((*VerifySignatureParams)(nil), "pb.VerifySignatureParams")
@@ -3702,6 +6294,8 @@ func init() {
 	proto.RegisterType((*RevokeResponse)(nil), "pb.RevokeResponse")
 	proto.RegisterType((*ResolveReverseNameParams)(nil), "pb.ResolveReverseNameParams")
 	proto.RegisterType((*ResolveReverseNameResponse)(nil), "pb.ResolveReverseNameResponse")
+	proto.RegisterType((*ListNameDeclarationsParams)(nil), "pb.ListNameDeclarationsParams")
+	proto.RegisterType((*ListNameDeclarationsResponse)(nil), "pb.ListNameDeclarationsResponse")
 	proto.RegisterType((*MarkEntityInterestingParams)(nil), "pb.MarkEntityInterestingParams")
 	proto.RegisterType((*MarkEntityInterestingResponse)(nil), "pb.MarkEntityInterestingResponse")
 	proto.RegisterType((*CreateNameDeclarationParams)(nil), "pb.CreateNameDeclarationParams")
@@ -3709,6 +6303,11 @@ func init() {
 	proto.RegisterType((*ResolveNameParams)(nil), "pb.ResolveNameParams")
 	proto.RegisterType((*NameDeclaration)(nil), "pb.NameDeclaration")
 	proto.RegisterType((*ResolveNameResponse)(nil), "pb.ResolveNameResponse")
+	proto.RegisterType((*PinNameParams)(nil), "pb.PinNameParams")
+	proto.RegisterType((*PinNameResponse)(nil), "pb.PinNameResponse")
+	proto.RegisterType((*CheckRevocationsParams)(nil), "pb.CheckRevocationsParams")
+	proto.RegisterType((*RevocationStatus)(nil), "pb.RevocationStatus")
+	proto.RegisterType((*CheckRevocationsResponse)(nil), "pb.CheckRevocationsResponse")
 	proto.RegisterType((*ResolveHashParams)(nil), "pb.ResolveHashParams")
 	proto.RegisterType((*ResolveHashResponse)(nil), "pb.ResolveHashResponse")
 	proto.RegisterType((*InspectParams)(nil), "pb.InspectParams")
@@ -3717,21 +6316,34 @@ func init() {
 	proto.RegisterType((*ListLocationsResponse)(nil), "pb.ListLocationsResponse")
 	proto.RegisterMapType((map[string]*Location)(nil), "pb.ListLocationsResponse.AgentLocationsEntry")
 	proto.RegisterType((*CreateEntityParams)(nil), "pb.CreateEntityParams")
+	proto.RegisterType((*PartitionSchedule)(nil), "pb.PartitionSchedule")
 	proto.RegisterType((*CreateEntityResponse)(nil), "pb.CreateEntityResponse")
 	proto.RegisterType((*Entity)(nil), "pb.Entity")
 	proto.RegisterType((*CreateAttestationParams)(nil), "pb.CreateAttestationParams")
+	proto.RegisterType((*RevokerReference)(nil), "pb.RevokerReference")
 	proto.RegisterType((*ResyncPerspectiveGraphParams)(nil), "pb.ResyncPerspectiveGraphParams")
 	proto.RegisterType((*ResyncPerspectiveGraphResponse)(nil), "pb.ResyncPerspectiveGraphResponse")
 	proto.RegisterType((*SyncParams)(nil), "pb.SyncParams")
 	proto.RegisterType((*EncryptMessageParams)(nil), "pb.EncryptMessageParams")
+	proto.RegisterType((*EncryptionSubject)(nil), "pb.EncryptionSubject")
+	proto.RegisterType((*EncryptionNamespace)(nil), "pb.EncryptionNamespace")
 	proto.RegisterType((*EncryptMessageResponse)(nil), "pb.EncryptMessageResponse")
+	proto.RegisterType((*EncryptStreamParams)(nil), "pb.EncryptStreamParams")
+	proto.RegisterType((*EncryptStreamResponse)(nil), "pb.EncryptStreamResponse")
+	proto.RegisterType((*DecryptStreamParams)(nil), "pb.DecryptStreamParams")
+	proto.RegisterType((*DecryptStreamResponse)(nil), "pb.DecryptStreamResponse")
 	proto.RegisterType((*DecryptMessageParams)(nil), "pb.DecryptMessageParams")
 	proto.RegisterType((*DecryptMessageResponse)(nil), "pb.DecryptMessageResponse")
+	proto.RegisterType((*ReencryptMessageParams)(nil), "pb.ReencryptMessageParams")
+	proto.RegisterType((*MessageRecipient)(nil), "pb.MessageRecipient")
+	proto.RegisterType((*ReencryptMessageResponse)(nil), "pb.ReencryptMessageResponse")
 	proto.RegisterType((*SyncResponse)(nil), "pb.SyncResponse")
 	proto.RegisterMapType((map[string]*StorageDriverStatus)(nil), "pb.SyncResponse.StorageStatusEntry")
 	proto.RegisterType((*StorageDriverStatus)(nil), "pb.StorageDriverStatus")
 	proto.RegisterMapType((map[string]string)(nil), "pb.StorageDriverStatus.InfoEntry")
 	proto.RegisterType((*CreateAttestationResponse)(nil), "pb.CreateAttestationResponse")
+	proto.RegisterType((*CreateAttestationsParams)(nil), "pb.CreateAttestationsParams")
+	proto.RegisterType((*CreateAttestationsResponse)(nil), "pb.CreateAttestationsResponse")
 	proto.RegisterType((*PublishEntityParams)(nil), "pb.PublishEntityParams")
 	proto.RegisterType((*PublishEntityResponse)(nil), "pb.PublishEntityResponse")
 	proto.RegisterType((*PublishAttestationParams)(nil), "pb.PublishAttestationParams")
@@ -3779,6 +6391,9 @@ type WAVEClient interface {
 	// Create a WAVE attestation, both the source and destination entities must
 	// be published
 	CreateAttestation(ctx context.Context, in *CreateAttestationParams, opts ...grpc.CallOption) (*CreateAttestationResponse, error)
+	// Create many attestations from one perspective at once. The perspective
+	// graph is synced once and failures are reported per attestation
+	CreateAttestations(ctx context.Context, in *CreateAttestationsParams, opts ...grpc.CallOption) (*CreateAttestationsResponse, error)
 	// Publish the given entity
 	PublishEntity(ctx context.Context, in *PublishEntityParams, opts ...grpc.CallOption) (*PublishEntityResponse, error)
 	// Publish an attestation
@@ -3786,6 +6401,7 @@ type WAVEClient interface {
 	// Add an attestation to the given perspective graph
 	AddAttestation(ctx context.Context, in *AddAttestationParams, opts ...grpc.CallOption) (*AddAttestationResponse, error)
 	LookupAttestations(ctx context.Context, in *LookupAttestationsParams, opts ...grpc.CallOption) (*LookupAttestationsResponse, error)
+	LookupAttestationsStream(ctx context.Context, in *LookupAttestationsParams, opts ...grpc.CallOption) (WAVE_LookupAttestationsStreamClient, error)
 	ResyncPerspectiveGraph(ctx context.Context, in *ResyncPerspectiveGraphParams, opts ...grpc.CallOption) (*ResyncPerspectiveGraphResponse, error)
 	SyncStatus(ctx context.Context, in *SyncParams, opts ...grpc.CallOption) (*SyncResponse, error)
 	WaitForSyncComplete(ctx context.Context, in *SyncParams, opts ...grpc.CallOption) (WAVE_WaitForSyncCompleteClient, error)
@@ -3794,16 +6410,49 @@ type WAVEClient interface {
 	ListLocations(ctx context.Context, in *ListLocationsParams, opts ...grpc.CallOption) (*ListLocationsResponse, error)
 	Inspect(ctx context.Context, in *InspectParams, opts ...grpc.CallOption) (*InspectResponse, error)
 	ResolveHash(ctx context.Context, in *ResolveHashParams, opts ...grpc.CallOption) (*ResolveHashResponse, error)
+	CheckRevocations(ctx context.Context, in *CheckRevocationsParams, opts ...grpc.CallOption) (*CheckRevocationsResponse, error)
 	EncryptMessage(ctx context.Context, in *EncryptMessageParams, opts ...grpc.CallOption) (*EncryptMessageResponse, error)
 	DecryptMessage(ctx context.Context, in *DecryptMessageParams, opts ...grpc.CallOption) (*DecryptMessageResponse, error)
+	// Decrypt a message and encrypt its content again for new recipients,
+	// reporting the recipients that lose access
+	ReencryptMessage(ctx context.Context, in *ReencryptMessageParams, opts ...grpc.CallOption) (*ReencryptMessageResponse, error)
+	// The streaming forms of EncryptMessage and DecryptMessage, for content
+	// too large to hold in memory. The output is streamed back as it is produced
+	EncryptStream(ctx context.Context, opts ...grpc.CallOption) (WAVE_EncryptStreamClient, error)
+	DecryptStream(ctx context.Context, opts ...grpc.CallOption) (WAVE_DecryptStreamClient, error)
 	CreateNameDeclaration(ctx context.Context, in *CreateNameDeclarationParams, opts ...grpc.CallOption) (*CreateNameDeclarationResponse, error)
 	ResolveName(ctx context.Context, in *ResolveNameParams, opts ...grpc.CallOption) (*ResolveNameResponse, error)
 	MarkEntityInteresting(ctx context.Context, in *MarkEntityInterestingParams, opts ...grpc.CallOption) (*MarkEntityInterestingResponse, error)
 	ResolveReverseName(ctx context.Context, in *ResolveReverseNameParams, opts ...grpc.CallOption) (*ResolveReverseNameResponse, error)
+	ListNameDeclarations(ctx context.Context, in *ListNameDeclarationsParams, opts ...grpc.CallOption) (*ListNameDeclarationsResponse, error)
+	PinName(ctx context.Context, in *PinNameParams, opts ...grpc.CallOption) (*PinNameResponse, error)
 	Revoke(ctx context.Context, in *RevokeParams, opts ...grpc.CallOption) (*RevokeResponse, error)
 	CompactProof(ctx context.Context, in *CompactProofParams, opts ...grpc.CallOption) (*CompactProofResponse, error)
 	Sign(ctx context.Context, in *SignParams, opts ...grpc.CallOption) (*SignResponse, error)
 	VerifySignature(ctx context.Context, in *VerifySignatureParams, opts ...grpc.CallOption) (*VerifySignatureResponse, error)
+	// Name a successor to the perspective entity. Attestations granted to the
+	// perspective are usable by the successor until the grace window ends
+	CreateEntitySuccession(ctx context.Context, in *CreateEntitySuccessionParams, opts ...grpc.CallOption) (*CreateEntitySuccessionResponse, error)
+	// Propose a threshold attestation from the perspective to the subject. It
+	// must be co-signed by threshold of the co-signers before it can be created
+	CreateThresholdProposal(ctx context.Context, in *CreateThresholdProposalParams, opts ...grpc.CallOption) (*ThresholdProposalResponse, error)
+	// Add the perspective's co-signature to a threshold proposal
+	CoSignThresholdProposal(ctx context.Context, in *CoSignThresholdProposalParams, opts ...grpc.CallOption) (*ThresholdProposalResponse, error)
+	// Create a threshold attestation from co-signed copies of a proposal
+	CreateThresholdAttestation(ctx context.Context, in *CreateThresholdAttestationParams, opts ...grpc.CallOption) (*CreateAttestationResponse, error)
+	// Export the perspective entity as a self-signed X.509 certificate and
+	// optionally issue a leaf certificate that embeds a proof
+	CreateX509Certificate(ctx context.Context, in *CreateX509CertificateParams, opts ...grpc.CallOption) (*CreateX509CertificateResponse, error)
+	// Issue an OpenSSH user certificate to the subject of a proof, if this
+	// agent is an SSH certificate authority
+	CreateSSHCertificate(ctx context.Context, in *CreateSSHCertificateParams, opts ...grpc.CallOption) (*CreateSSHCertificateResponse, error)
+	// Publish a new version of one of the perspective's revocation lists
+	UpdateRevocationList(ctx context.Context, in *UpdateRevocationListParams, opts ...grpc.CallOption) (*UpdateRevocationListResponse, error)
+	// Generate and cache the WR1 body keys that attestations with the given
+	// policy and validity would delegate, so that creating them is fast
+	PrecomputeKeyBundle(ctx context.Context, in *PrecomputeKeyBundleParams, opts ...grpc.CallOption) (*PrecomputeKeyBundleResponse, error)
+	// Report how often attestation creation found its body keys in the cache
+	KeyBundleCacheStats(ctx context.Context, in *KeyBundleCacheStatsParams, opts ...grpc.CallOption) (*KeyBundleCacheStatsResponse, error)
 }
 
 type wAVEClient struct {
@@ -3832,6 +6481,15 @@ func (c *wAVEClient) CreateAttestation(ctx context.Context, in *CreateAttestatio
 	return out, nil
 }
 
+func (c *wAVEClient) CreateAttestations(ctx context.Context, in *CreateAttestationsParams, opts ...grpc.CallOption) (*CreateAttestationsResponse, error) {
+	out := new(CreateAttestationsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateAttestations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *wAVEClient) PublishEntity(ctx context.Context, in *PublishEntityParams, opts ...grpc.CallOption) (*PublishEntityResponse, error) {
 	out := new(PublishEntityResponse)
 	err := c.cc.Invoke(ctx, "/pb.WAVE/PublishEntity", in, out, opts...)
@@ -3868,6 +6526,38 @@ func (c *wAVEClient) LookupAttestations(ctx context.Context, in *LookupAttestati
 	return out, nil
 }
 
+func (c *wAVEClient) LookupAttestationsStream(ctx context.Context, in *LookupAttestationsParams, opts ...grpc.CallOption) (WAVE_LookupAttestationsStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WAVE_serviceDesc.Streams[0], "/pb.WAVE/LookupAttestationsStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wAVELookupAttestationsStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WAVE_LookupAttestationsStreamClient interface {
+	Recv() (*LookupAttestationsResponse, error)
+	grpc.ClientStream
+}
+
+type wAVELookupAttestationsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *wAVELookupAttestationsStreamClient) Recv() (*LookupAttestationsResponse, error) {
+	m := new(LookupAttestationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *wAVEClient) ResyncPerspectiveGraph(ctx context.Context, in *ResyncPerspectiveGraphParams, opts ...grpc.CallOption) (*ResyncPerspectiveGraphResponse, error) {
 	out := new(ResyncPerspectiveGraphResponse)
 	err := c.cc.Invoke(ctx, "/pb.WAVE/ResyncPerspectiveGraph", in, out, opts...)
@@ -3887,7 +6577,7 @@ func (c *wAVEClient) SyncStatus(ctx context.Context, in *SyncParams, opts ...grp
 }
 
 func (c *wAVEClient) WaitForSyncComplete(ctx context.Context, in *SyncParams, opts ...grpc.CallOption) (WAVE_WaitForSyncCompleteClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_WAVE_serviceDesc.Streams[0], "/pb.WAVE/WaitForSyncComplete", opts...)
+	stream, err := c.cc.NewStream(ctx, &_WAVE_serviceDesc.Streams[1], "/pb.WAVE/WaitForSyncComplete", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3963,6 +6653,15 @@ func (c *wAVEClient) ResolveHash(ctx context.Context, in *ResolveHashParams, opt
 	return out, nil
 }
 
+func (c *wAVEClient) CheckRevocations(ctx context.Context, in *CheckRevocationsParams, opts ...grpc.CallOption) (*CheckRevocationsResponse, error) {
+	out := new(CheckRevocationsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CheckRevocations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *wAVEClient) EncryptMessage(ctx context.Context, in *EncryptMessageParams, opts ...grpc.CallOption) (*EncryptMessageResponse, error) {
 	out := new(EncryptMessageResponse)
 	err := c.cc.Invoke(ctx, "/pb.WAVE/EncryptMessage", in, out, opts...)
@@ -3981,6 +6680,77 @@ func (c *wAVEClient) DecryptMessage(ctx context.Context, in *DecryptMessageParam
 	return out, nil
 }
 
+func (c *wAVEClient) ReencryptMessage(ctx context.Context, in *ReencryptMessageParams, opts ...grpc.CallOption) (*ReencryptMessageResponse, error) {
+	out := new(ReencryptMessageResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/ReencryptMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) EncryptStream(ctx context.Context, opts ...grpc.CallOption) (WAVE_EncryptStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WAVE_serviceDesc.Streams[2], "/pb.WAVE/EncryptStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wAVEEncryptStreamClient{stream}
+	return x, nil
+}
+
+type WAVE_EncryptStreamClient interface {
+	Send(*EncryptStreamParams) error
+	Recv() (*EncryptStreamResponse, error)
+	grpc.ClientStream
+}
+
+type wAVEEncryptStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *wAVEEncryptStreamClient) Send(m *EncryptStreamParams) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *wAVEEncryptStreamClient) Recv() (*EncryptStreamResponse, error) {
+	m := new(EncryptStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wAVEClient) DecryptStream(ctx context.Context, opts ...grpc.CallOption) (WAVE_DecryptStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WAVE_serviceDesc.Streams[3], "/pb.WAVE/DecryptStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wAVEDecryptStreamClient{stream}
+	return x, nil
+}
+
+type WAVE_DecryptStreamClient interface {
+	Send(*DecryptStreamParams) error
+	Recv() (*DecryptStreamResponse, error)
+	grpc.ClientStream
+}
+
+type wAVEDecryptStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *wAVEDecryptStreamClient) Send(m *DecryptStreamParams) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *wAVEDecryptStreamClient) Recv() (*DecryptStreamResponse, error) {
+	m := new(DecryptStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *wAVEClient) CreateNameDeclaration(ctx context.Context, in *CreateNameDeclarationParams, opts ...grpc.CallOption) (*CreateNameDeclarationResponse, error) {
 	out := new(CreateNameDeclarationResponse)
 	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateNameDeclaration", in, out, opts...)
@@ -4017,6 +6787,24 @@ func (c *wAVEClient) ResolveReverseName(ctx context.Context, in *ResolveReverseN
 	return out, nil
 }
 
+func (c *wAVEClient) ListNameDeclarations(ctx context.Context, in *ListNameDeclarationsParams, opts ...grpc.CallOption) (*ListNameDeclarationsResponse, error) {
+	out := new(ListNameDeclarationsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/ListNameDeclarations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) PinName(ctx context.Context, in *PinNameParams, opts ...grpc.CallOption) (*PinNameResponse, error) {
+	out := new(PinNameResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/PinName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *wAVEClient) Revoke(ctx context.Context, in *RevokeParams, opts ...grpc.CallOption) (*RevokeResponse, error) {
 	out := new(RevokeResponse)
 	err := c.cc.Invoke(ctx, "/pb.WAVE/Revoke", in, out, opts...)
@@ -4053,6 +6841,87 @@ func (c *wAVEClient) VerifySignature(ctx context.Context, in *VerifySignaturePar
 	return out, nil
 }
 
+func (c *wAVEClient) CreateEntitySuccession(ctx context.Context, in *CreateEntitySuccessionParams, opts ...grpc.CallOption) (*CreateEntitySuccessionResponse, error) {
+	out := new(CreateEntitySuccessionResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateEntitySuccession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) CreateThresholdProposal(ctx context.Context, in *CreateThresholdProposalParams, opts ...grpc.CallOption) (*ThresholdProposalResponse, error) {
+	out := new(ThresholdProposalResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateThresholdProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) CoSignThresholdProposal(ctx context.Context, in *CoSignThresholdProposalParams, opts ...grpc.CallOption) (*ThresholdProposalResponse, error) {
+	out := new(ThresholdProposalResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CoSignThresholdProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) CreateThresholdAttestation(ctx context.Context, in *CreateThresholdAttestationParams, opts ...grpc.CallOption) (*CreateAttestationResponse, error) {
+	out := new(CreateAttestationResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateThresholdAttestation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) CreateX509Certificate(ctx context.Context, in *CreateX509CertificateParams, opts ...grpc.CallOption) (*CreateX509CertificateResponse, error) {
+	out := new(CreateX509CertificateResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateX509Certificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) CreateSSHCertificate(ctx context.Context, in *CreateSSHCertificateParams, opts ...grpc.CallOption) (*CreateSSHCertificateResponse, error) {
+	out := new(CreateSSHCertificateResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/CreateSSHCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) UpdateRevocationList(ctx context.Context, in *UpdateRevocationListParams, opts ...grpc.CallOption) (*UpdateRevocationListResponse, error) {
+	out := new(UpdateRevocationListResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/UpdateRevocationList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) PrecomputeKeyBundle(ctx context.Context, in *PrecomputeKeyBundleParams, opts ...grpc.CallOption) (*PrecomputeKeyBundleResponse, error) {
+	out := new(PrecomputeKeyBundleResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/PrecomputeKeyBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wAVEClient) KeyBundleCacheStats(ctx context.Context, in *KeyBundleCacheStatsParams, opts ...grpc.CallOption) (*KeyBundleCacheStatsResponse, error) {
+	out := new(KeyBundleCacheStatsResponse)
+	err := c.cc.Invoke(ctx, "/pb.WAVE/KeyBundleCacheStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WAVEServer is the server API for WAVE service.
 type WAVEServer interface {
 	// Create a new WAVE entity, but do not publish it
@@ -4060,6 +6929,9 @@ type WAVEServer interface {
 	// Create a WAVE attestation, both the source and destination entities must
 	// be published
 	CreateAttestation(context.Context, *CreateAttestationParams) (*CreateAttestationResponse, error)
+	// Create many attestations from one perspective at once. The perspective
+	// graph is synced once and failures are reported per attestation
+	CreateAttestations(context.Context, *CreateAttestationsParams) (*CreateAttestationsResponse, error)
 	// Publish the given entity
 	PublishEntity(context.Context, *PublishEntityParams) (*PublishEntityResponse, error)
 	// Publish an attestation
@@ -4067,6 +6939,7 @@ type WAVEServer interface {
 	// Add an attestation to the given perspective graph
 	AddAttestation(context.Context, *AddAttestationParams) (*AddAttestationResponse, error)
 	LookupAttestations(context.Context, *LookupAttestationsParams) (*LookupAttestationsResponse, error)
+	LookupAttestationsStream(*LookupAttestationsParams, WAVE_LookupAttestationsStreamServer) error
 	ResyncPerspectiveGraph(context.Context, *ResyncPerspectiveGraphParams) (*ResyncPerspectiveGraphResponse, error)
 	SyncStatus(context.Context, *SyncParams) (*SyncResponse, error)
 	WaitForSyncComplete(*SyncParams, WAVE_WaitForSyncCompleteServer) error
@@ -4075,16 +6948,49 @@ type WAVEServer interface {
 	ListLocations(context.Context, *ListLocationsParams) (*ListLocationsResponse, error)
 	Inspect(context.Context, *InspectParams) (*InspectResponse, error)
 	ResolveHash(context.Context, *ResolveHashParams) (*ResolveHashResponse, error)
+	CheckRevocations(context.Context, *CheckRevocationsParams) (*CheckRevocationsResponse, error)
 	EncryptMessage(context.Context, *EncryptMessageParams) (*EncryptMessageResponse, error)
 	DecryptMessage(context.Context, *DecryptMessageParams) (*DecryptMessageResponse, error)
+	// Decrypt a message and encrypt its content again for new recipients,
+	// reporting the recipients that lose access
+	ReencryptMessage(context.Context, *ReencryptMessageParams) (*ReencryptMessageResponse, error)
+	// The streaming forms of EncryptMessage and DecryptMessage, for content
+	// too large to hold in memory. The output is streamed back as it is produced
+	EncryptStream(WAVE_EncryptStreamServer) error
+	DecryptStream(WAVE_DecryptStreamServer) error
 	CreateNameDeclaration(context.Context, *CreateNameDeclarationParams) (*CreateNameDeclarationResponse, error)
 	ResolveName(context.Context, *ResolveNameParams) (*ResolveNameResponse, error)
 	MarkEntityInteresting(context.Context, *MarkEntityInterestingParams) (*MarkEntityInterestingResponse, error)
 	ResolveReverseName(context.Context, *ResolveReverseNameParams) (*ResolveReverseNameResponse, error)
+	ListNameDeclarations(context.Context, *ListNameDeclarationsParams) (*ListNameDeclarationsResponse, error)
+	PinName(context.Context, *PinNameParams) (*PinNameResponse, error)
 	Revoke(context.Context, *RevokeParams) (*RevokeResponse, error)
 	CompactProof(context.Context, *CompactProofParams) (*CompactProofResponse, error)
 	Sign(context.Context, *SignParams) (*SignResponse, error)
 	VerifySignature(context.Context, *VerifySignatureParams) (*VerifySignatureResponse, error)
+	// Name a successor to the perspective entity. Attestations granted to the
+	// perspective are usable by the successor until the grace window ends
+	CreateEntitySuccession(context.Context, *CreateEntitySuccessionParams) (*CreateEntitySuccessionResponse, error)
+	// Propose a threshold attestation from the perspective to the subject. It
+	// must be co-signed by threshold of the co-signers before it can be created
+	CreateThresholdProposal(context.Context, *CreateThresholdProposalParams) (*ThresholdProposalResponse, error)
+	// Add the perspective's co-signature to a threshold proposal
+	CoSignThresholdProposal(context.Context, *CoSignThresholdProposalParams) (*ThresholdProposalResponse, error)
+	// Create a threshold attestation from co-signed copies of a proposal
+	CreateThresholdAttestation(context.Context, *CreateThresholdAttestationParams) (*CreateAttestationResponse, error)
+	// Export the perspective entity as a self-signed X.509 certificate and
+	// optionally issue a leaf certificate that embeds a proof
+	CreateX509Certificate(context.Context, *CreateX509CertificateParams) (*CreateX509CertificateResponse, error)
+	// Issue an OpenSSH user certificate to the subject of a proof, if this
+	// agent is an SSH certificate authority
+	CreateSSHCertificate(context.Context, *CreateSSHCertificateParams) (*CreateSSHCertificateResponse, error)
+	// Publish a new version of one of the perspective's revocation lists
+	UpdateRevocationList(context.Context, *UpdateRevocationListParams) (*UpdateRevocationListResponse, error)
+	// Generate and cache the WR1 body keys that attestations with the given
+	// policy and validity would delegate, so that creating them is fast
+	PrecomputeKeyBundle(context.Context, *PrecomputeKeyBundleParams) (*PrecomputeKeyBundleResponse, error)
+	// Report how often attestation creation found its body keys in the cache
+	KeyBundleCacheStats(context.Context, *KeyBundleCacheStatsParams) (*KeyBundleCacheStatsResponse, error)
 }
 
 func RegisterWAVEServer(s *grpc.Server, srv WAVEServer) {
@@ -4115,14 +7021,32 @@ func _WAVE_CreateAttestation_Handler(srv interface{}, ctx context.Context, dec f
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WAVEServer).CreateAttestation(ctx, in)
+		return srv.(WAVEServer).CreateAttestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CreateAttestation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CreateAttestation(ctx, req.(*CreateAttestationParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_CreateAttestations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttestationsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CreateAttestations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.WAVE/CreateAttestation",
+		FullMethod: "/pb.WAVE/CreateAttestations",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WAVEServer).CreateAttestation(ctx, req.(*CreateAttestationParams))
+		return srv.(WAVEServer).CreateAttestations(ctx, req.(*CreateAttestationsParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -4199,6 +7123,27 @@ func _WAVE_LookupAttestations_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WAVE_LookupAttestationsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LookupAttestationsParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WAVEServer).LookupAttestationsStream(m, &wAVELookupAttestationsStreamServer{stream})
+}
+
+type WAVE_LookupAttestationsStreamServer interface {
+	Send(*LookupAttestationsResponse) error
+	grpc.ServerStream
+}
+
+type wAVELookupAttestationsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *wAVELookupAttestationsStreamServer) Send(m *LookupAttestationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WAVE_ResyncPerspectiveGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResyncPerspectiveGraphParams)
 	if err := dec(in); err != nil {
@@ -4346,6 +7291,24 @@ func _WAVE_ResolveHash_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WAVE_CheckRevocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRevocationsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CheckRevocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CheckRevocations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CheckRevocations(ctx, req.(*CheckRevocationsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WAVE_EncryptMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EncryptMessageParams)
 	if err := dec(in); err != nil {
@@ -4382,6 +7345,76 @@ func _WAVE_DecryptMessage_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WAVE_ReencryptMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReencryptMessageParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).ReencryptMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/ReencryptMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).ReencryptMessage(ctx, req.(*ReencryptMessageParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_EncryptStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WAVEServer).EncryptStream(&wAVEEncryptStreamServer{stream})
+}
+
+type WAVE_EncryptStreamServer interface {
+	Send(*EncryptStreamResponse) error
+	Recv() (*EncryptStreamParams, error)
+	grpc.ServerStream
+}
+
+type wAVEEncryptStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *wAVEEncryptStreamServer) Send(m *EncryptStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *wAVEEncryptStreamServer) Recv() (*EncryptStreamParams, error) {
+	m := new(EncryptStreamParams)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _WAVE_DecryptStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WAVEServer).DecryptStream(&wAVEDecryptStreamServer{stream})
+}
+
+type WAVE_DecryptStreamServer interface {
+	Send(*DecryptStreamResponse) error
+	Recv() (*DecryptStreamParams, error)
+	grpc.ServerStream
+}
+
+type wAVEDecryptStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *wAVEDecryptStreamServer) Send(m *DecryptStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *wAVEDecryptStreamServer) Recv() (*DecryptStreamParams, error) {
+	m := new(DecryptStreamParams)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _WAVE_CreateNameDeclaration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateNameDeclarationParams)
 	if err := dec(in); err != nil {
@@ -4454,6 +7487,42 @@ func _WAVE_ResolveReverseName_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WAVE_ListNameDeclarations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNameDeclarationsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).ListNameDeclarations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/ListNameDeclarations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).ListNameDeclarations(ctx, req.(*ListNameDeclarationsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_PinName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinNameParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).PinName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/PinName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).PinName(ctx, req.(*PinNameParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WAVE_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RevokeParams)
 	if err := dec(in); err != nil {
@@ -4526,6 +7595,168 @@ func _WAVE_VerifySignature_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WAVE_CreateEntitySuccession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEntitySuccessionParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CreateEntitySuccession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CreateEntitySuccession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CreateEntitySuccession(ctx, req.(*CreateEntitySuccessionParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_CreateThresholdProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThresholdProposalParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CreateThresholdProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CreateThresholdProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CreateThresholdProposal(ctx, req.(*CreateThresholdProposalParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_CoSignThresholdProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoSignThresholdProposalParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CoSignThresholdProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CoSignThresholdProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CoSignThresholdProposal(ctx, req.(*CoSignThresholdProposalParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_CreateThresholdAttestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThresholdAttestationParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CreateThresholdAttestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CreateThresholdAttestation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CreateThresholdAttestation(ctx, req.(*CreateThresholdAttestationParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_CreateX509Certificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateX509CertificateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CreateX509Certificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CreateX509Certificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CreateX509Certificate(ctx, req.(*CreateX509CertificateParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_CreateSSHCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSSHCertificateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).CreateSSHCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/CreateSSHCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).CreateSSHCertificate(ctx, req.(*CreateSSHCertificateParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_UpdateRevocationList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRevocationListParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).UpdateRevocationList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/UpdateRevocationList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).UpdateRevocationList(ctx, req.(*UpdateRevocationListParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_PrecomputeKeyBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrecomputeKeyBundleParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).PrecomputeKeyBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/PrecomputeKeyBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).PrecomputeKeyBundle(ctx, req.(*PrecomputeKeyBundleParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WAVE_KeyBundleCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyBundleCacheStatsParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WAVEServer).KeyBundleCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.WAVE/KeyBundleCacheStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WAVEServer).KeyBundleCacheStats(ctx, req.(*KeyBundleCacheStatsParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WAVE_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.WAVE",
 	HandlerType: (*WAVEServer)(nil),
@@ -4538,6 +7769,10 @@ var _WAVE_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateAttestation",
 			Handler:    _WAVE_CreateAttestation_Handler,
 		},
+		{
+			MethodName: "CreateAttestations",
+			Handler:    _WAVE_CreateAttestations_Handler,
+		},
 		{
 			MethodName: "PublishEntity",
 			Handler:    _WAVE_PublishEntity_Handler,
@@ -4582,6 +7817,10 @@ var _WAVE_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ResolveHash",
 			Handler:    _WAVE_ResolveHash_Handler,
 		},
+		{
+			MethodName: "CheckRevocations",
+			Handler:    _WAVE_CheckRevocations_Handler,
+		},
 		{
 			MethodName: "EncryptMessage",
 			Handler:    _WAVE_EncryptMessage_Handler,
@@ -4590,6 +7829,10 @@ var _WAVE_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DecryptMessage",
 			Handler:    _WAVE_DecryptMessage_Handler,
 		},
+		{
+			MethodName: "ReencryptMessage",
+			Handler:    _WAVE_ReencryptMessage_Handler,
+		},
 		{
 			MethodName: "CreateNameDeclaration",
 			Handler:    _WAVE_CreateNameDeclaration_Handler,
@@ -4606,6 +7849,14 @@ var _WAVE_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ResolveReverseName",
 			Handler:    _WAVE_ResolveReverseName_Handler,
 		},
+		{
+			MethodName: "ListNameDeclarations",
+			Handler:    _WAVE_ListNameDeclarations_Handler,
+		},
+		{
+			MethodName: "PinName",
+			Handler:    _WAVE_PinName_Handler,
+		},
 		{
 			MethodName: "Revoke",
 			Handler:    _WAVE_Revoke_Handler,
@@ -4622,200 +7873,366 @@ var _WAVE_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VerifySignature",
 			Handler:    _WAVE_VerifySignature_Handler,
 		},
+		{
+			MethodName: "CreateEntitySuccession",
+			Handler:    _WAVE_CreateEntitySuccession_Handler,
+		},
+		{
+			MethodName: "CreateThresholdProposal",
+			Handler:    _WAVE_CreateThresholdProposal_Handler,
+		},
+		{
+			MethodName: "CoSignThresholdProposal",
+			Handler:    _WAVE_CoSignThresholdProposal_Handler,
+		},
+		{
+			MethodName: "CreateThresholdAttestation",
+			Handler:    _WAVE_CreateThresholdAttestation_Handler,
+		},
+		{
+			MethodName: "CreateX509Certificate",
+			Handler:    _WAVE_CreateX509Certificate_Handler,
+		},
+		{
+			MethodName: "CreateSSHCertificate",
+			Handler:    _WAVE_CreateSSHCertificate_Handler,
+		},
+		{
+			MethodName: "UpdateRevocationList",
+			Handler:    _WAVE_UpdateRevocationList_Handler,
+		},
+		{
+			MethodName: "PrecomputeKeyBundle",
+			Handler:    _WAVE_PrecomputeKeyBundle_Handler,
+		},
+		{
+			MethodName: "KeyBundleCacheStats",
+			Handler:    _WAVE_KeyBundleCacheStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "LookupAttestationsStream",
+			Handler:       _WAVE_LookupAttestationsStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "WaitForSyncComplete",
 			Handler:       _WAVE_WaitForSyncComplete_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "EncryptStream",
+			Handler:       _WAVE_EncryptStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DecryptStream",
+			Handler:       _WAVE_DecryptStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "eapi.proto",
 }
 
-func init() { proto.RegisterFile("eapi.proto", fileDescriptor_eapi_1169f3d0de20e30d) }
-
-var fileDescriptor_eapi_1169f3d0de20e30d = []byte{
-	// 2894 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3a, 0xcb, 0x6f, 0x24, 0x47,
-	0xf9, 0xea, 0x79, 0x79, 0xe6, 0x9b, 0xf1, 0xab, 0xc6, 0x8f, 0xd9, 0xf6, 0xae, 0xe3, 0xad, 0xe4,
-	0x97, 0xf8, 0x17, 0x12, 0x6f, 0x76, 0x93, 0x90, 0x64, 0x05, 0x62, 0x9d, 0x5d, 0x2f, 0x58, 0x78,
-	0xc1, 0xdb, 0x93, 0xdd, 0xc8, 0x48, 0x1c, 0xda, 0x33, 0x65, 0xbb, 0xd9, 0x99, 0xee, 0xde, 0xee,
-	0x9e, 0x51, 0x06, 0x89, 0x03, 0x48, 0x80, 0x02, 0xdc, 0x38, 0x87, 0x03, 0x17, 0x0e, 0x1c, 0xb8,
-	0x20, 0x21, 0x24, 0xb8, 0x70, 0x43, 0x5c, 0x90, 0xf8, 0x07, 0x38, 0x44, 0xe4, 0xc2, 0x3f, 0xc0,
-	0x0d, 0xd5, 0xa3, 0xbb, 0xab, 0xba, 0x6b, 0xc6, 0xbd, 0xf6, 0x12, 0xc4, 0xad, 0xeb, 0xab, 0xaf,
-	0xbf, 0x57, 0x7d, 0xf5, 0x3d, 0xaa, 0x0a, 0x80, 0xd8, 0xbe, 0xb3, 0xe3, 0x07, 0x5e, 0xe4, 0xa1,
-	0x92, 0x7f, 0x6c, 0x5e, 0x3d, 0xf5, 0xbc, 0xd3, 0x01, 0xb9, 0x61, 0xfb, 0xce, 0x0d, 0xdb, 0x75,
-	0xbd, 0xc8, 0x8e, 0x1c, 0xcf, 0x0d, 0x39, 0x06, 0x3e, 0x02, 0xe8, 0x3a, 0xa7, 0xee, 0xa1, 0x1d,
-	0xd8, 0xc3, 0x10, 0xdd, 0x84, 0xa6, 0x4f, 0x82, 0xd0, 0x27, 0xbd, 0xc8, 0x19, 0x93, 0x8e, 0xb1,
-	0x65, 0x6c, 0x37, 0x6f, 0x2d, 0xee, 0xf8, 0xc7, 0x3b, 0x87, 0x29, 0xd8, 0x92, 0x71, 0x50, 0x07,
-	0xe6, 0x7a, 0x9e, 0x1b, 0x11, 0x37, 0xea, 0x94, 0xb6, 0x8c, 0xed, 0x96, 0x15, 0x0f, 0xf1, 0x03,
-	0x68, 0x51, 0xd2, 0x16, 0x09, 0x7d, 0xcf, 0x0d, 0x09, 0x7a, 0x01, 0xaa, 0x24, 0x08, 0xbc, 0x40,
-	0x90, 0x6d, 0x50, 0xb2, 0x7b, 0x14, 0x60, 0x71, 0x38, 0xba, 0x0a, 0x8d, 0xd0, 0x39, 0x75, 0xed,
-	0x68, 0x14, 0x10, 0x41, 0x2c, 0x05, 0xe0, 0x4f, 0x0c, 0x58, 0x7d, 0x4c, 0x02, 0xe7, 0x64, 0xd2,
-	0x8d, 0x61, 0x42, 0xea, 0x35, 0xa8, 0x51, 0x34, 0xc2, 0x29, 0xb7, 0x2c, 0x31, 0x42, 0x6f, 0xc1,
-	0x02, 0xff, 0x3a, 0xf0, 0x7a, 0x4c, 0x69, 0x46, 0xb4, 0x79, 0xab, 0x45, 0x39, 0xc7, 0x30, 0x2b,
-	0x83, 0xa3, 0x4a, 0x51, 0xce, 0x48, 0x21, 0xab, 0x5b, 0x51, 0xd5, 0xbd, 0x0d, 0xeb, 0x19, 0xf1,
-	0x0a, 0x6b, 0x8e, 0x5f, 0x06, 0x74, 0xd7, 0x1b, 0xfa, 0x76, 0x2f, 0x3a, 0x0c, 0x3c, 0xef, 0x44,
-	0xe8, 0xb5, 0x04, 0xe5, 0x7b, 0x7b, 0x96, 0x50, 0x8a, 0x7e, 0xe2, 0x2e, 0xac, 0xc8, 0x78, 0xc5,
-	0x4d, 0x6b, 0x42, 0xdd, 0xa7, 0x7f, 0x50, 0x7a, 0xdc, 0xb2, 0xc9, 0x18, 0xff, 0xc5, 0x80, 0x96,
-	0x45, 0xc6, 0xde, 0x13, 0x72, 0x71, 0x2f, 0xd8, 0x86, 0x45, 0x3b, 0x8a, 0x48, 0xc8, 0x9d, 0xeb,
-	0x6b, 0x76, 0x78, 0x26, 0xd8, 0x64, 0xc1, 0xe8, 0x0d, 0x68, 0xbb, 0xf6, 0x90, 0xdc, 0x23, 0xbd,
-	0x81, 0x1d, 0xa4, 0xd8, 0xdc, 0xd0, 0xba, 0x29, 0xf4, 0x1a, 0x2c, 0x07, 0x5c, 0x3c, 0x49, 0x28,
-	0x6a, 0xfc, 0xba, 0x95, 0x9f, 0xc0, 0x37, 0x61, 0x81, 0x2b, 0x53, 0xdc, 0xfa, 0x36, 0x74, 0x2c,
-	0x12, 0x7a, 0x83, 0x31, 0xb1, 0xc8, 0x98, 0x04, 0x21, 0xf9, 0x86, 0x3d, 0xbc, 0x84, 0x2d, 0x10,
-	0x54, 0xce, 0x52, 0x03, 0xb0, 0x6f, 0xfc, 0x10, 0xcc, 0x3c, 0x8b, 0xe2, 0xcb, 0x87, 0xa0, 0x42,
-	0x2d, 0xc3, 0x48, 0x36, 0x2c, 0xf6, 0x8d, 0x7f, 0x61, 0xc0, 0xc6, 0x03, 0x3b, 0x78, 0xb2, 0xe7,
-	0x46, 0x4e, 0x34, 0xd9, 0x77, 0x23, 0x12, 0x90, 0x30, 0x72, 0xdc, 0xd3, 0x8b, 0x4b, 0xbe, 0x06,
-	0x35, 0xc2, 0xa8, 0x09, 0xd9, 0xc5, 0x88, 0x6e, 0x24, 0xfe, 0x95, 0x6c, 0xa4, 0xb2, 0x6e, 0x23,
-	0xa9, 0x38, 0xf8, 0x0e, 0x5c, 0xd3, 0xca, 0x57, 0x7c, 0x61, 0xfe, 0x59, 0x82, 0x8d, 0xbb, 0x01,
-	0xb1, 0x23, 0x66, 0x2e, 0xc9, 0x2f, 0x2e, 0xb5, 0x38, 0x59, 0x4b, 0xd2, 0x3d, 0x1d, 0x8e, 0x8e,
-	0xbf, 0x43, 0x7a, 0x91, 0x70, 0xc3, 0x78, 0x88, 0xbe, 0x08, 0x8b, 0xe2, 0x33, 0xd1, 0xbc, 0xa2,
-	0xd1, 0x3c, 0x8b, 0x44, 0x63, 0xc8, 0xd8, 0x1e, 0x38, 0xfd, 0xfb, 0x81, 0x37, 0xec, 0x54, 0xb7,
-	0x8c, 0xed, 0xb2, 0x95, 0x02, 0xd0, 0x26, 0x00, 0x1b, 0x3c, 0x72, 0x23, 0x67, 0xd0, 0xa9, 0xb1,
-	0x69, 0x09, 0x42, 0xff, 0xa6, 0x72, 0x85, 0xbe, 0xdd, 0x23, 0x9d, 0x39, 0x1e, 0x81, 0x12, 0x00,
-	0xba, 0x0d, 0xcb, 0xc9, 0x20, 0x91, 0xaa, 0xae, 0x91, 0x2a, 0x8f, 0x46, 0x29, 0xfb, 0x76, 0x10,
-	0x39, 0xec, 0x9f, 0xc6, 0x56, 0x99, 0x52, 0x4e, 0x00, 0xf8, 0x04, 0xae, 0x69, 0xad, 0x5d, 0xdc,
-	0x4f, 0x45, 0xc4, 0x2a, 0x25, 0x11, 0x2b, 0xd9, 0x0c, 0x65, 0x69, 0x33, 0xfc, 0xc8, 0x80, 0x65,
-	0xb1, 0x1b, 0x2e, 0xbd, 0xd3, 0x72, 0x8b, 0xf9, 0x2a, 0x2c, 0x45, 0x9e, 0x7f, 0x40, 0xc6, 0x64,
-	0xb0, 0xcb, 0x42, 0x0f, 0x09, 0x04, 0xf3, 0x1c, 0x1c, 0xff, 0xb5, 0x0c, 0x8b, 0x19, 0x5d, 0x13,
-	0x81, 0x8d, 0x54, 0xe0, 0xcf, 0xc9, 0x69, 0x4c, 0xa8, 0xdb, 0xb1, 0xc4, 0x55, 0x1e, 0xa3, 0xe3,
-	0x31, 0x7a, 0x17, 0x96, 0xe2, 0xef, 0x84, 0x68, 0x4d, 0x43, 0x34, 0x87, 0xa5, 0xba, 0xe2, 0xdc,
-	0x6c, 0x57, 0xac, 0xcf, 0x76, 0xc5, 0x46, 0x21, 0x57, 0x84, 0x0b, 0xb8, 0x62, 0x33, 0xe3, 0x8a,
-	0xe8, 0x1d, 0xa8, 0x33, 0x29, 0x68, 0x2c, 0x6a, 0x31, 0x82, 0x1b, 0x94, 0x60, 0x66, 0xb1, 0x1e,
-	0x0b, 0x14, 0x2b, 0x41, 0xc6, 0xbf, 0x37, 0xa0, 0x2d, 0xf9, 0x56, 0x71, 0xd7, 0xc5, 0x4a, 0xec,
-	0x6b, 0xde, 0x02, 0x86, 0xc1, 0x20, 0x49, 0x1c, 0x7c, 0x13, 0xa0, 0x4f, 0x02, 0x67, 0x1c, 0xc7,
-	0xc0, 0xf2, 0x76, 0xf3, 0x56, 0x5b, 0x23, 0x97, 0x25, 0xa1, 0xa1, 0x6d, 0xa8, 0x0f, 0x66, 0xf9,
-	0x41, 0x32, 0x8b, 0xbf, 0x95, 0x6c, 0x0b, 0x9a, 0xf7, 0xc4, 0xb6, 0xd0, 0xf9, 0x63, 0x66, 0xab,
-	0x94, 0xce, 0xdf, 0x2a, 0xf8, 0x77, 0xa9, 0x5d, 0x28, 0xf1, 0xe2, 0x76, 0x91, 0xc5, 0x2f, 0xcd,
-	0x12, 0x5f, 0xb2, 0x60, 0x79, 0xaa, 0x05, 0x6f, 0x42, 0x53, 0x2a, 0x08, 0x84, 0x3d, 0x98, 0xe4,
-	0xbb, 0x29, 0xd8, 0x92, 0x71, 0xb0, 0x03, 0xf3, 0xfb, 0x2e, 0xd3, 0x43, 0x58, 0x44, 0x2a, 0xc1,
-	0x0c, 0xa5, 0x04, 0x63, 0x3e, 0x15, 0x78, 0x63, 0x12, 0x7c, 0x9d, 0xc4, 0x29, 0x2c, 0x05, 0xa0,
-	0x2d, 0x68, 0x8e, 0x69, 0x81, 0xe6, 0xf0, 0x79, 0xbe, 0x6b, 0x65, 0x10, 0xfe, 0xd8, 0x80, 0x45,
-	0xc1, 0xeb, 0xf9, 0x3a, 0x4e, 0x46, 0xed, 0x72, 0x01, 0xb5, 0x57, 0xa1, 0x7d, 0xe0, 0x84, 0x49,
-	0x74, 0x08, 0xb9, 0xf2, 0xf8, 0xef, 0x06, 0xac, 0x2a, 0xf0, 0xe2, 0x82, 0x3e, 0x82, 0x05, 0xfb,
-	0x94, 0xb8, 0xe9, 0xaf, 0x9d, 0x12, 0xf3, 0xe0, 0xd7, 0xd9, 0x7a, 0xea, 0x68, 0xee, 0xec, 0x2a,
-	0xf8, 0x7b, 0x6e, 0x14, 0x4c, 0xac, 0x0c, 0x11, 0xf3, 0x9b, 0xd0, 0xd6, 0xa0, 0xd1, 0x54, 0xf0,
-	0x84, 0x4c, 0x98, 0x30, 0x0d, 0x8b, 0x7e, 0x22, 0x0c, 0xd5, 0xb1, 0x3d, 0x18, 0x11, 0xad, 0x1b,
-	0xf1, 0xa9, 0xdb, 0xa5, 0x77, 0x0d, 0xfc, 0x07, 0x03, 0x10, 0xcf, 0x43, 0xdc, 0x8a, 0x62, 0xd9,
-	0x95, 0x40, 0x66, 0xcc, 0x0e, 0x64, 0xa5, 0x5c, 0x20, 0xfb, 0x12, 0x20, 0x5a, 0x2b, 0x72, 0x6e,
-	0x33, 0xcb, 0x18, 0x0d, 0x1e, 0x4d, 0x2a, 0x5d, 0xd2, 0x0b, 0x48, 0x74, 0x68, 0x87, 0xa1, 0x7f,
-	0x16, 0xd8, 0x21, 0xaf, 0x40, 0x1b, 0x56, 0x0e, 0x8e, 0x7f, 0x6c, 0xc0, 0x8a, 0x2c, 0xfe, 0x33,
-	0xf5, 0x3f, 0x87, 0xa3, 0xe3, 0x81, 0xd3, 0x4b, 0x73, 0x68, 0x0a, 0xa0, 0xb3, 0x9c, 0x17, 0x9d,
-	0x15, 0x7d, 0x49, 0x02, 0x48, 0xc2, 0x44, 0x45, 0xca, 0xb3, 0x3f, 0x31, 0xa0, 0xc6, 0x65, 0xd0,
-	0x46, 0x11, 0xc5, 0xa0, 0xa5, 0xd9, 0x06, 0x2d, 0xe7, 0x0c, 0xba, 0x23, 0x45, 0x68, 0xbe, 0x8d,
-	0x51, 0xea, 0xf8, 0x9a, 0xc0, 0xfc, 0xa7, 0x12, 0xac, 0x73, 0xb3, 0x48, 0x2e, 0x7f, 0xf1, 0xd4,
-	0xbf, 0x09, 0x70, 0xec, 0xf5, 0x27, 0xdd, 0xde, 0x19, 0x49, 0x12, 0xb3, 0x04, 0xa1, 0x9b, 0x5d,
-	0xe4, 0x57, 0xa9, 0xbd, 0x90, 0x41, 0xff, 0xa5, 0xda, 0x0e, 0x43, 0xcd, 0xf7, 0x06, 0x4e, 0x6f,
-	0xc2, 0x72, 0xb1, 0x88, 0x16, 0x87, 0x0c, 0x62, 0x89, 0x19, 0x1a, 0xe0, 0x7c, 0xba, 0xec, 0xe1,
-	0x19, 0xcb, 0xc8, 0x75, 0x2b, 0x1e, 0xe2, 0x87, 0x70, 0xd5, 0x22, 0xe1, 0xc4, 0xed, 0x49, 0x76,
-	0xf9, 0x6a, 0x60, 0xfb, 0x67, 0x17, 0x36, 0x24, 0xde, 0x85, 0x4d, 0x3d, 0xc9, 0xe2, 0x65, 0xfa,
-	0x57, 0x00, 0xba, 0x94, 0xc0, 0x85, 0x65, 0xf8, 0xac, 0x04, 0x2b, 0x7b, 0x6e, 0x2f, 0x98, 0xf8,
-	0xd1, 0x03, 0x12, 0x86, 0xf6, 0x69, 0x5c, 0x13, 0xbe, 0x02, 0xb5, 0x91, 0x3b, 0x0a, 0x49, 0x7f,
-	0x1a, 0x19, 0x31, 0x3d, 0xfd, 0x14, 0xe2, 0x3f, 0xeb, 0x08, 0x69, 0x6d, 0x54, 0x2d, 0x54, 0x1b,
-	0xd5, 0x8a, 0xd5, 0x46, 0x26, 0xd4, 0x03, 0x12, 0x7a, 0xa3, 0x40, 0xd4, 0xff, 0x0d, 0x2b, 0x19,
-	0xab, 0xee, 0x57, 0x9f, 0xed, 0x7e, 0x8d, 0xac, 0xfb, 0xe1, 0x23, 0x58, 0x53, 0x0d, 0x5d, 0x3c,
-	0x3a, 0x6d, 0x02, 0xf4, 0x1c, 0xff, 0x8c, 0x04, 0x11, 0xf9, 0x28, 0xb6, 0xb2, 0x04, 0xc1, 0x3f,
-	0x35, 0x60, 0xe5, 0x1e, 0xd1, 0x2c, 0xe2, 0xc5, 0x76, 0xf7, 0x2c, 0x5e, 0x74, 0x51, 0x03, 0xe6,
-	0xb4, 0xf7, 0x9d, 0x20, 0xe4, 0x05, 0x78, 0xdd, 0x92, 0x41, 0xb8, 0x0b, 0x6b, 0xaa, 0x30, 0xc5,
-	0x15, 0x9d, 0x7e, 0xa2, 0xf5, 0xeb, 0x12, 0xb4, 0xa8, 0xa7, 0x17, 0xa7, 0xb5, 0x0f, 0xf3, 0x61,
-	0xe4, 0x05, 0xf6, 0x29, 0xe9, 0x46, 0x76, 0x34, 0x8a, 0x53, 0xee, 0x8b, 0x14, 0x51, 0xa6, 0xb4,
-	0xd3, 0x95, 0xb1, 0x78, 0xa2, 0x55, 0xff, 0x44, 0xaf, 0xc1, 0x72, 0xe4, 0x45, 0xf6, 0x80, 0xff,
-	0xf6, 0x74, 0x44, 0xc2, 0x28, 0x14, 0x71, 0x39, 0x3f, 0x81, 0x5e, 0x86, 0x85, 0x9e, 0x37, 0xf4,
-	0x07, 0x24, 0x22, 0x7d, 0x3a, 0x11, 0x32, 0x9f, 0x2e, 0x5b, 0x19, 0xa8, 0x79, 0x04, 0x28, 0xcf,
-	0x5a, 0x93, 0xbc, 0x5f, 0x57, 0x93, 0xf7, 0x3a, 0x53, 0x80, 0xff, 0x78, 0x2f, 0x70, 0xc6, 0x24,
-	0xe0, 0xbf, 0xcb, 0x79, 0xfc, 0x57, 0x06, 0xb4, 0x35, 0x28, 0x74, 0xf1, 0x3c, 0x9f, 0xf0, 0x4a,
-	0xd9, 0x1e, 0x30, 0x26, 0x75, 0x4b, 0x06, 0xa1, 0xb7, 0xa1, 0xe2, 0xb8, 0x27, 0x9e, 0x30, 0xd6,
-	0xf5, 0x29, 0xbc, 0x76, 0xf6, 0xdd, 0x13, 0x8f, 0x9b, 0x8a, 0xa1, 0x9b, 0xef, 0x40, 0x23, 0x01,
-	0x69, 0x54, 0x58, 0x91, 0x55, 0x68, 0xc8, 0x92, 0xfe, 0xd2, 0x80, 0x2b, 0xb9, 0xdc, 0x74, 0x99,
-	0xae, 0xf7, 0xdc, 0x52, 0x53, 0x2d, 0x55, 0x2b, 0xd9, 0x52, 0x35, 0x4e, 0xd7, 0x55, 0xe5, 0x08,
-	0xa9, 0x7d, 0xc8, 0xd3, 0x80, 0x52, 0x16, 0xe5, 0x0e, 0x09, 0x8b, 0x57, 0xec, 0xf8, 0x00, 0x56,
-	0x15, 0x92, 0xcf, 0x74, 0x20, 0x95, 0x3b, 0xe3, 0x7a, 0x0d, 0x3a, 0x82, 0x5a, 0x3e, 0xc3, 0xe7,
-	0x8f, 0x32, 0x1f, 0x82, 0x99, 0xc7, 0xbe, 0x9c, 0x00, 0x13, 0x58, 0xd9, 0xed, 0xf7, 0x9f, 0x4b,
-	0x79, 0x91, 0x5f, 0x52, 0x65, 0xc1, 0xca, 0x99, 0x05, 0xc3, 0xef, 0xc1, 0x9a, 0xca, 0xba, 0x78,
-	0xf6, 0xfc, 0xd8, 0x80, 0xce, 0x81, 0xe7, 0x3d, 0x19, 0xf9, 0xd2, 0xef, 0xe1, 0xa5, 0x62, 0xe7,
-	0x49, 0xe0, 0x0d, 0xf7, 0xe4, 0x83, 0x3c, 0x09, 0x42, 0x93, 0x4b, 0xe4, 0xed, 0xa5, 0x8d, 0x5a,
-	0xcb, 0x4a, 0xc6, 0xf8, 0x0c, 0xcc, 0xbc, 0x28, 0xc5, 0x17, 0xe5, 0xff, 0x61, 0x2e, 0x20, 0xe1,
-	0x68, 0x10, 0xc5, 0x71, 0x2e, 0xd7, 0xe2, 0xc4, 0xf3, 0xf8, 0x6d, 0xa8, 0xee, 0xc5, 0x0b, 0xd9,
-	0xf3, 0xfa, 0x5c, 0xb5, 0xaa, 0xc5, 0xbe, 0x69, 0x04, 0x1e, 0xf2, 0xa8, 0x2d, 0xf6, 0x6a, 0x3c,
-	0xc4, 0x43, 0x68, 0x4a, 0x8a, 0xa3, 0xb7, 0xa0, 0xc5, 0x3b, 0x2c, 0x5e, 0x08, 0x0b, 0xc1, 0x96,
-	0xd2, 0x42, 0x94, 0xc3, 0x2d, 0x05, 0xeb, 0x19, 0x36, 0x48, 0x0f, 0xea, 0x49, 0x52, 0xbe, 0x09,
-	0xcd, 0x18, 0xfe, 0xc8, 0xda, 0x97, 0x97, 0xe2, 0x20, 0x05, 0x5b, 0x32, 0x0e, 0x7a, 0x09, 0xe6,
-	0x95, 0x66, 0x49, 0x68, 0xa3, 0x02, 0xf1, 0x7b, 0xd0, 0x94, 0x28, 0x50, 0xd7, 0x8b, 0xe9, 0x37,
-	0x2c, 0xfa, 0x49, 0xcd, 0x31, 0x26, 0x41, 0x18, 0x13, 0xa8, 0x5a, 0xf1, 0x10, 0xdf, 0x81, 0x96,
-	0xac, 0xa7, 0x26, 0x18, 0x6c, 0x02, 0xf8, 0x69, 0xcf, 0x22, 0xbc, 0x21, 0x85, 0xe0, 0x3f, 0x97,
-	0xa0, 0x29, 0x2d, 0x90, 0x86, 0x82, 0x66, 0xa7, 0xa1, 0x57, 0xa0, 0x42, 0x6b, 0x6d, 0xd1, 0x3f,
-	0xb5, 0x33, 0xab, 0xfc, 0xbe, 0xd7, 0x9f, 0x58, 0x0c, 0x21, 0x1b, 0x08, 0x2b, 0xe7, 0x04, 0xc2,
-	0xaa, 0xa6, 0x67, 0x97, 0xab, 0xb7, 0x5a, 0xa1, 0xea, 0x6d, 0xae, 0x48, 0xf5, 0xf6, 0xa6, 0xd4,
-	0xbf, 0xd4, 0xd3, 0x9c, 0x26, 0xa9, 0x91, 0x6f, 0x62, 0xce, 0x39, 0x3f, 0xfd, 0x97, 0x01, 0x8b,
-	0x19, 0x33, 0xd0, 0x3c, 0x7c, 0x8f, 0x50, 0xa7, 0xee, 0xd3, 0x61, 0x6a, 0xda, 0x0c, 0x14, 0x61,
-	0x68, 0xc5, 0x47, 0x77, 0xd2, 0xed, 0x89, 0x02, 0xd3, 0x1e, 0x02, 0x96, 0x0b, 0x1d, 0x02, 0xa6,
-	0x5d, 0x47, 0x65, 0x6a, 0xd7, 0x71, 0xa9, 0xbe, 0x06, 0x7f, 0x52, 0x82, 0xb6, 0xc6, 0x76, 0x22,
-	0xe9, 0x3a, 0x7d, 0x91, 0xe6, 0xf9, 0x80, 0x7a, 0x34, 0xbf, 0xb9, 0xe9, 0x33, 0x45, 0xeb, 0x56,
-	0x3c, 0xa4, 0x33, 0xe4, 0x23, 0xdf, 0x09, 0x48, 0x5f, 0x54, 0x75, 0xf1, 0x90, 0xca, 0x37, 0xb4,
-	0x07, 0x27, 0x5e, 0x30, 0x24, 0x7d, 0x71, 0xfd, 0x93, 0x02, 0xa8, 0xfd, 0x5c, 0x2f, 0x12, 0x25,
-	0x1f, 0xe9, 0x33, 0x05, 0xea, 0x96, 0x02, 0xa3, 0x3a, 0x84, 0x41, 0x6f, 0xdf, 0xe5, 0x02, 0xd5,
-	0x18, 0x86, 0x04, 0xa1, 0xf3, 0xfd, 0x30, 0x8a, 0xe7, 0xe7, 0xf8, 0x7c, 0x0a, 0x91, 0xc3, 0x52,
-	0x5d, 0x09, 0x4b, 0xd4, 0x4d, 0x5d, 0x2f, 0x62, 0x4a, 0x1f, 0x91, 0x88, 0xd5, 0xdd, 0x75, 0x4b,
-	0x06, 0xe1, 0xdf, 0x1a, 0xb0, 0xa0, 0xf6, 0xc6, 0x9f, 0x9b, 0x69, 0x24, 0xb1, 0xab, 0x33, 0xc5,
-	0xae, 0xe5, 0xc5, 0xfe, 0xa3, 0x01, 0xeb, 0x53, 0x0e, 0x5d, 0xff, 0x27, 0xe4, 0xff, 0x1e, 0xd4,
-	0xb8, 0x9b, 0xa3, 0x3b, 0xb0, 0x14, 0x05, 0xa3, 0x30, 0x62, 0x37, 0x00, 0x1c, 0x26, 0x62, 0xf8,
-	0x0a, 0xdd, 0x0c, 0x1f, 0x64, 0xe6, 0xac, 0x1c, 0x36, 0x4d, 0x00, 0xc1, 0x07, 0x01, 0x21, 0xe2,
-	0x67, 0xe9, 0xd4, 0xd5, 0x4a, 0xc1, 0x96, 0x8c, 0x83, 0xb7, 0x61, 0x29, 0x4b, 0x98, 0x9a, 0x8d,
-	0x91, 0x16, 0x19, 0x8f, 0x0f, 0xf0, 0x6f, 0x0c, 0x68, 0x4a, 0x64, 0xd4, 0xe6, 0xd2, 0xc8, 0x36,
-	0x97, 0x18, 0x5a, 0x8e, 0xdb, 0x77, 0x02, 0x9a, 0x06, 0xf9, 0x41, 0x9e, 0xb1, 0x3d, 0x6f, 0x29,
-	0x30, 0xf4, 0x2e, 0x00, 0xdd, 0x8c, 0x64, 0x48, 0x5c, 0xd6, 0x28, 0xd0, 0x7c, 0xdc, 0xc9, 0x48,
-	0xdb, 0x8d, 0x11, 0x2c, 0x09, 0x97, 0xa6, 0xad, 0xb1, 0x13, 0x3a, 0xc7, 0xce, 0xc0, 0x89, 0x26,
-	0x34, 0x17, 0x55, 0x58, 0xa4, 0x53, 0x81, 0xf8, 0xbb, 0xb0, 0xa2, 0xa3, 0x44, 0xff, 0xf6, 0x49,
-	0x30, 0x74, 0x42, 0x9a, 0xa1, 0xba, 0x24, 0x3e, 0xa4, 0x55, 0x81, 0x74, 0xe9, 0x52, 0x00, 0x2f,
-	0x17, 0x1a, 0x96, 0x0c, 0x52, 0x9a, 0xe0, 0xb2, 0xda, 0x04, 0xe3, 0x7f, 0x18, 0xb0, 0xfa, 0xfe,
-	0xc8, 0x19, 0xf4, 0xb9, 0x04, 0xd2, 0x9d, 0xf9, 0x05, 0x0a, 0xa6, 0x4c, 0x8e, 0x29, 0xe5, 0x73,
-	0x8c, 0xb2, 0x18, 0xe5, 0xec, 0x62, 0xa8, 0x86, 0xae, 0x3c, 0x83, 0xa1, 0x33, 0x6d, 0x6c, 0x35,
-	0xdf, 0xc6, 0x4e, 0x60, 0x3d, 0xa3, 0x67, 0xf1, 0x6a, 0xec, 0x3a, 0xd4, 0x78, 0xb5, 0x25, 0x5c,
-	0x95, 0x61, 0x70, 0x1a, 0x62, 0x42, 0x79, 0x16, 0x50, 0xce, 0x3c, 0x0b, 0xf8, 0x99, 0x01, 0xcb,
-	0xfc, 0x41, 0x83, 0x6c, 0x5f, 0xf9, 0x0f, 0x43, 0xfd, 0x03, 0xed, 0x42, 0x3b, 0x20, 0x4f, 0x47,
-	0x74, 0x4b, 0x5b, 0xe7, 0x6f, 0x14, 0x1d, 0xee, 0xf4, 0x5b, 0x35, 0x7c, 0x04, 0x6d, 0x49, 0x9a,
-	0xe7, 0x69, 0x05, 0xfc, 0x99, 0x01, 0x55, 0x06, 0x41, 0x5f, 0x80, 0x3a, 0x19, 0x88, 0x85, 0x34,
-	0xf4, 0x15, 0x6c, 0x82, 0x80, 0x5e, 0x84, 0xaa, 0x6f, 0x47, 0x67, 0x71, 0xad, 0x3b, 0x9f, 0x10,
-	0x3e, 0xb4, 0xa3, 0x33, 0x8b, 0xcf, 0x49, 0x99, 0xb7, 0x3c, 0x35, 0xf3, 0xae, 0x41, 0x8d, 0x45,
-	0xc2, 0x89, 0xe8, 0xd1, 0xc5, 0x48, 0x36, 0x46, 0xf5, 0xdc, 0x2b, 0xc6, 0x5a, 0x81, 0xa2, 0x07,
-	0xbf, 0x02, 0x8d, 0x44, 0x42, 0xba, 0x94, 0x8a, 0xb2, 0xd5, 0x54, 0xb7, 0x5b, 0x9f, 0x22, 0xa8,
-	0x7c, 0xb8, 0xfb, 0x78, 0x0f, 0x7d, 0x1b, 0x5a, 0xf2, 0x61, 0x36, 0x5a, 0xa3, 0x0c, 0xf2, 0xa7,
-	0xf3, 0x66, 0x27, 0x0b, 0x8f, 0x57, 0x08, 0x6f, 0xfc, 0xe0, 0x6f, 0x9f, 0xfe, 0xbc, 0xb4, 0x8a,
-	0x97, 0x6e, 0x8c, 0x6f, 0xde, 0x90, 0x31, 0x6e, 0x1b, 0xaf, 0xa2, 0xa7, 0xb0, 0x9c, 0x6b, 0xbc,
-	0xd1, 0x46, 0x4a, 0x2b, 0xd7, 0xcc, 0x99, 0xd7, 0xb4, 0x93, 0x09, 0xb7, 0x2d, 0xc6, 0xcd, 0xc4,
-	0xab, 0x29, 0x37, 0x09, 0x8d, 0xb2, 0xb4, 0x61, 0x5e, 0x69, 0x7a, 0x11, 0xab, 0xfb, 0x34, 0xad,
-	0xb5, 0x79, 0x25, 0x37, 0x91, 0xb0, 0xb9, 0xca, 0xd8, 0xac, 0xe1, 0x65, 0xca, 0x46, 0x41, 0xa1,
-	0x2c, 0x46, 0x80, 0xf2, 0xbd, 0x2d, 0xba, 0x2a, 0x91, 0xcb, 0xeb, 0xb5, 0xa9, 0x9f, 0x4d, 0x38,
-	0x5e, 0x67, 0x1c, 0x37, 0xf0, 0x9a, 0xc4, 0x31, 0xa3, 0x19, 0x81, 0x05, 0xb5, 0x09, 0x45, 0x6c,
-	0x55, 0x74, 0x3d, 0xb1, 0x69, 0xe6, 0x67, 0x12, 0x56, 0xd7, 0x18, 0xab, 0x75, 0x8c, 0x28, 0x2b,
-	0x15, 0x87, 0xb2, 0x89, 0x00, 0xe5, 0x9b, 0x44, 0xae, 0xdd, 0xb4, 0x3e, 0x96, 0x6b, 0x37, 0xbd,
-	0xb5, 0x54, 0x97, 0x2d, 0x87, 0x47, 0xb9, 0xfe, 0xd0, 0x80, 0x35, 0xfd, 0x41, 0x35, 0xda, 0x62,
-	0xa1, 0x65, 0xc6, 0xb9, 0xb8, 0x89, 0xa7, 0x63, 0x24, 0x22, 0xfc, 0x1f, 0x13, 0xe1, 0x05, 0x6c,
-	0x52, 0x11, 0xf4, 0xb8, 0x54, 0x8e, 0x7d, 0x7e, 0xd8, 0x2d, 0xce, 0xb2, 0x16, 0xe2, 0x83, 0x3c,
-	0xc1, 0x68, 0x29, 0x7b, 0xb0, 0x87, 0xaf, 0x30, 0xb2, 0x6d, 0xbc, 0x40, 0xc9, 0xa6, 0x7f, 0x52,
-	0x52, 0xef, 0x41, 0xfb, 0x43, 0xdb, 0x89, 0xee, 0x7b, 0x01, 0x85, 0xdf, 0x15, 0x07, 0x73, 0xe7,
-	0xd3, 0x7c, 0xc3, 0x40, 0x0e, 0x2c, 0x66, 0xf2, 0x02, 0x62, 0xde, 0xaa, 0x4d, 0x8a, 0xe6, 0x86,
-	0x66, 0x2a, 0x11, 0x70, 0x93, 0x09, 0xd8, 0xc1, 0x6d, 0x2a, 0x60, 0x06, 0x89, 0x4a, 0x79, 0x04,
-	0x4d, 0x29, 0xf0, 0xa2, 0x55, 0x4a, 0x2b, 0x97, 0x17, 0xcc, 0xf5, 0x0c, 0x38, 0x21, 0x6f, 0x32,
-	0xf2, 0x2b, 0x78, 0x91, 0x92, 0x97, 0x10, 0xc4, 0x56, 0x54, 0xee, 0x1d, 0xf9, 0x56, 0xd4, 0x5c,
-	0x7b, 0xf2, 0xad, 0xa8, 0xbd, 0xa3, 0x54, 0xb7, 0xa2, 0x82, 0xc2, 0x97, 0x6b, 0x4e, 0xdc, 0xe8,
-	0xa2, 0x65, 0x4a, 0x43, 0xb9, 0x4a, 0x36, 0xdb, 0x12, 0x28, 0x21, 0xb8, 0xc6, 0x08, 0x2e, 0xe1,
-	0x26, 0x25, 0x28, 0x26, 0x85, 0x21, 0xa4, 0x1b, 0x74, 0x6e, 0x88, 0xdc, 0x7d, 0x3d, 0x37, 0x84,
-	0xe6, 0xa6, 0x5d, 0x35, 0x84, 0x84, 0x20, 0x76, 0xae, 0x7a, 0x2c, 0xcf, 0x77, 0xae, 0xee, 0x4e,
-	0x84, 0xef, 0x5c, 0xfd, 0x21, 0xbe, 0xba, 0x73, 0x55, 0x1c, 0xc1, 0x46, 0x3d, 0x14, 0xe7, 0x6c,
-	0x74, 0xa7, 0xf6, 0x9c, 0x8d, 0xfe, 0x08, 0x5d, 0x65, 0xa3, 0xe2, 0x50, 0x36, 0xdf, 0x37, 0x60,
-	0x55, 0xfb, 0x90, 0x08, 0xbd, 0x90, 0x06, 0x6f, 0xed, 0x8b, 0x2e, 0xf3, 0xfa, 0x54, 0x84, 0x84,
-	0xf9, 0x4b, 0x8c, 0xf9, 0x26, 0xbe, 0x92, 0x46, 0xf8, 0x0c, 0xaa, 0xba, 0x58, 0x74, 0x52, 0x59,
-	0xac, 0xf4, 0xcd, 0x91, 0xb2, 0x58, 0xf2, 0x73, 0x11, 0xed, 0x62, 0x51, 0x84, 0x58, 0x3d, 0xed,
-	0xc3, 0x36, 0xae, 0xde, 0x8c, 0x37, 0x79, 0x5c, 0xbd, 0x99, 0x8f, 0xe2, 0x54, 0xf5, 0xb4, 0xa8,
-	0x22, 0xc3, 0xe4, 0xdf, 0x13, 0xf2, 0x18, 0x3c, 0xed, 0x29, 0x23, 0x8f, 0xc1, 0xd3, 0x5f, 0x21,
-	0xaa, 0x19, 0x26, 0x8f, 0x47, 0xd9, 0xee, 0x41, 0x8d, 0x3f, 0xae, 0x44, 0x4b, 0x9c, 0x58, 0xfa,
-	0x6a, 0xd4, 0x44, 0x29, 0x24, 0x21, 0xb9, 0xca, 0x48, 0x2e, 0x62, 0xe0, 0x24, 0xe9, 0x1c, 0x25,
-	0x43, 0x8b, 0x0a, 0xe9, 0x19, 0xab, 0x28, 0x2a, 0x72, 0x0f, 0x60, 0x45, 0x51, 0xa1, 0x79, 0xf0,
-	0x9a, 0x29, 0x2a, 0x24, 0x0c, 0x4a, 0xfe, 0xcb, 0x50, 0xe9, 0x3a, 0xa7, 0xae, 0x08, 0xa4, 0xc9,
-	0xeb, 0x66, 0x11, 0x48, 0xa5, 0x27, 0xc9, 0xb8, 0xcd, 0xc8, 0xcc, 0xe3, 0x3a, 0x0b, 0xce, 0xce,
-	0x29, 0x73, 0x1d, 0x07, 0x16, 0x33, 0x0f, 0x79, 0x79, 0x6c, 0xd5, 0x3e, 0x3e, 0xe6, 0xb1, 0x75,
-	0xca, 0xc3, 0x5f, 0x35, 0xb6, 0x66, 0x90, 0x6e, 0x1b, 0xaf, 0x1e, 0xd7, 0xd8, 0x23, 0xec, 0x37,
-	0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x70, 0x84, 0x84, 0x92, 0xb4, 0x2d, 0x00, 0x00,
+func init() { proto.RegisterFile("eapi.proto", fileDescriptor_eapi_bb2472e126594a79) }
+
+var fileDescriptor_eapi_bb2472e126594a79 = []byte{
+	// 4691 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3c, 0x4b, 0x6c, 0x24, 0x49,
+	0x56, 0xca, 0xfa, 0xb9, 0xea, 0x95, 0xdd, 0xb6, 0xb3, 0xfc, 0x29, 0xa7, 0xdd, 0x6e, 0x77, 0xcc,
+	0x30, 0xeb, 0x1d, 0x66, 0x7a, 0xba, 0x7b, 0x7a, 0x98, 0x99, 0x16, 0x68, 0xc7, 0x6d, 0x7b, 0x76,
+	0x5b, 0xdb, 0x33, 0xb8, 0xd3, 0x33, 0xbd, 0xdb, 0x2b, 0x71, 0xc8, 0xae, 0x0a, 0xdb, 0xb9, 0x5d,
+	0x95, 0x59, 0x93, 0x99, 0x65, 0xba, 0x56, 0xda, 0xc3, 0xb2, 0x5a, 0x40, 0xbb, 0x73, 0x40, 0x42,
+	0x42, 0x5c, 0x06, 0x24, 0x40, 0xe2, 0x80, 0xf8, 0x1c, 0x90, 0x38, 0x20, 0x6e, 0x48, 0x68, 0x85,
+	0x84, 0x90, 0xb8, 0x70, 0x19, 0x01, 0x12, 0x82, 0x03, 0x88, 0x3b, 0x37, 0xf4, 0x22, 0x22, 0x33,
+	0x23, 0x22, 0xa3, 0xca, 0xe5, 0xcf, 0x8c, 0xd8, 0x5b, 0xc5, 0x8b, 0x57, 0xef, 0x17, 0x2f, 0xde,
+	0x7b, 0xf1, 0x32, 0x32, 0x01, 0xa8, 0x37, 0xf0, 0x6f, 0x0d, 0xa2, 0x30, 0x09, 0xed, 0xd2, 0xe0,
+	0x99, 0xb3, 0x71, 0x1c, 0x86, 0xc7, 0x3d, 0xfa, 0x86, 0x37, 0xf0, 0xdf, 0xf0, 0x82, 0x20, 0x4c,
+	0xbc, 0xc4, 0x0f, 0x83, 0x98, 0x63, 0x90, 0x1f, 0x5a, 0xb0, 0xea, 0xd2, 0xd3, 0xb0, 0xc3, 0xa0,
+	0x8f, 0xfc, 0x38, 0x71, 0xe9, 0x11, 0x8d, 0x68, 0xd0, 0xa1, 0x76, 0x1b, 0x66, 0x22, 0x7a, 0x1a,
+	0x3e, 0xa7, 0x51, 0xdb, 0xda, 0xb2, 0xb6, 0x67, 0xdd, 0x74, 0x68, 0xff, 0x02, 0xcc, 0x8b, 0x9f,
+	0x8f, 0xc4, 0x3f, 0xdb, 0xa5, 0x2d, 0x6b, 0xbb, 0x79, 0x77, 0xf6, 0xd6, 0xe0, 0xd9, 0xad, 0x14,
+	0xe6, 0xea, 0x48, 0xf6, 0x0a, 0xd4, 0x7a, 0x7e, 0x9c, 0x3c, 0xdc, 0x6b, 0x97, 0x19, 0x41, 0x31,
+	0x22, 0xff, 0x62, 0x81, 0xf3, 0xf1, 0xa0, 0xeb, 0x25, 0x54, 0x95, 0xe5, 0xc0, 0x8b, 0xbc, 0x7e,
+	0x6c, 0xdf, 0x81, 0xe6, 0x80, 0x46, 0xf1, 0x80, 0x76, 0x12, 0xff, 0x94, 0x32, 0x61, 0x9a, 0x77,
+	0xe7, 0x91, 0xd5, 0x41, 0x0e, 0x76, 0x65, 0x1c, 0x89, 0x53, 0x49, 0xe6, 0x84, 0x70, 0x2e, 0x54,
+	0xbb, 0xbc, 0x55, 0x46, 0x38, 0x1f, 0xd9, 0x04, 0x66, 0xbd, 0x24, 0xa1, 0xb1, 0xb0, 0x4e, 0xbb,
+	0xc2, 0x66, 0x15, 0x98, 0xed, 0x40, 0x7d, 0x18, 0x88, 0x7f, 0x57, 0xd9, 0x7c, 0x36, 0xb6, 0x37,
+	0x01, 0x02, 0xfa, 0x22, 0xe1, 0x4a, 0xb4, 0x6b, 0x5b, 0xd6, 0x76, 0xd9, 0x95, 0x20, 0xe4, 0x47,
+	0x16, 0x6c, 0x98, 0x34, 0x74, 0x69, 0x3c, 0x08, 0x83, 0x98, 0xda, 0x37, 0xa0, 0x4a, 0xa3, 0x28,
+	0x8c, 0x84, 0x76, 0x0d, 0xd4, 0x6e, 0x1f, 0x01, 0x2e, 0x87, 0xdb, 0x0b, 0x50, 0xde, 0xdb, 0x77,
+	0x85, 0x3a, 0xf8, 0x13, 0xd7, 0xe7, 0x94, 0x46, 0x31, 0x5a, 0xbf, 0xcc, 0x18, 0xa6, 0xc3, 0x7c,
+	0xe5, 0xba, 0x42, 0x91, 0x74, 0x48, 0xfe, 0xc2, 0x82, 0xb5, 0x83, 0x88, 0x76, 0xc2, 0xfe, 0x60,
+	0x98, 0xd0, 0x6f, 0xd2, 0xd1, 0x83, 0x61, 0xd0, 0xed, 0xd1, 0x8b, 0x1b, 0x9a, 0x40, 0x6d, 0x10,
+	0xf6, 0xfc, 0xce, 0x48, 0x78, 0x00, 0x30, 0x6c, 0x06, 0x71, 0xc5, 0x8c, 0xbd, 0x01, 0x8d, 0x53,
+	0xaf, 0xe7, 0x77, 0xdf, 0x8f, 0xc2, 0xbe, 0x10, 0x35, 0x07, 0xa0, 0xe9, 0xd8, 0xe0, 0xe3, 0x20,
+	0xf1, 0x7b, 0xed, 0x0a, 0x37, 0x5d, 0x0e, 0x21, 0x03, 0x58, 0x37, 0x48, 0x3c, 0xbd, 0xe1, 0x6c,
+	0xa8, 0x3c, 0xa7, 0xa3, 0x98, 0xc9, 0x57, 0x76, 0xd9, 0x6f, 0x94, 0xe8, 0x98, 0x06, 0x34, 0xf2,
+	0x12, 0xda, 0x4d, 0x25, 0xca, 0x00, 0x64, 0x1d, 0xd6, 0x32, 0x3e, 0xbb, 0x5e, 0xe7, 0x84, 0x1e,
+	0x26, 0x5e, 0x12, 0x73, 0x1b, 0x91, 0xef, 0xc2, 0xba, 0x61, 0xf2, 0x5c, 0xe2, 0x9c, 0xf8, 0x09,
+	0x17, 0xa7, 0xe2, 0xb2, 0xdf, 0xe8, 0x95, 0x7d, 0x3f, 0x8e, 0x69, 0xcc, 0x64, 0xa9, 0xb8, 0x62,
+	0x44, 0x7e, 0xcb, 0x02, 0x67, 0x37, 0xa2, 0x5e, 0x42, 0x0f, 0x0f, 0xbf, 0xb1, 0x4b, 0xa3, 0xc4,
+	0x3f, 0xf2, 0x3b, 0x5e, 0x92, 0x2e, 0x97, 0x03, 0xf5, 0x41, 0x14, 0x86, 0x47, 0xe8, 0x17, 0x7c,
+	0x87, 0x66, 0x63, 0xd4, 0x70, 0x30, 0x7c, 0xd6, 0xf3, 0x3b, 0xdf, 0xa4, 0x23, 0xe1, 0x34, 0x39,
+	0x00, 0x67, 0x63, 0xff, 0x38, 0xf0, 0x92, 0x61, 0x44, 0xc5, 0x5e, 0xcc, 0x01, 0x48, 0x97, 0x2f,
+	0x4f, 0x18, 0x89, 0xf5, 0xc8, 0xc6, 0xb8, 0x55, 0x37, 0x4c, 0x22, 0x4d, 0x6f, 0x80, 0x2d, 0x68,
+	0x76, 0xf2, 0xff, 0x09, 0xd9, 0x64, 0x10, 0xc3, 0xf0, 0x0e, 0x32, 0xe9, 0xcb, 0x02, 0x23, 0x07,
+	0xa1, 0xcf, 0x0c, 0x22, 0x3f, 0xe8, 0xf8, 0x03, 0xaf, 0xc7, 0x37, 0x6b, 0xc3, 0x95, 0x20, 0xb8,
+	0x01, 0xe2, 0xe1, 0xb3, 0xef, 0xd2, 0x4e, 0xd2, 0xae, 0xf2, 0xd0, 0x25, 0x86, 0x48, 0x9b, 0xe9,
+	0xf2, 0x80, 0x1e, 0x85, 0x51, 0xba, 0x53, 0x65, 0x10, 0xf9, 0x33, 0x0b, 0xd6, 0xb9, 0x86, 0xdf,
+	0x7e, 0xeb, 0xf6, 0xbb, 0x45, 0xab, 0x5f, 0x60, 0x93, 0xc8, 0x0b, 0x55, 0xd2, 0x16, 0xea, 0x65,
+	0x98, 0xeb, 0x51, 0xef, 0x48, 0x57, 0x57, 0x05, 0x4e, 0x5c, 0x92, 0xdf, 0xb5, 0xe0, 0xba, 0x51,
+	0xe0, 0xe9, 0xd7, 0xe4, 0x35, 0x58, 0xa4, 0x41, 0xe2, 0x27, 0xa3, 0xdd, 0xc2, 0xca, 0x14, 0x27,
+	0xec, 0x6d, 0x98, 0x47, 0xe9, 0x64, 0x5c, 0x2e, 0xb4, 0x0e, 0x26, 0x8f, 0x61, 0xf1, 0xa3, 0x93,
+	0x88, 0xc6, 0x27, 0x61, 0xaf, 0xbb, 0x1b, 0x1e, 0xfa, 0xc7, 0x01, 0xe5, 0x3b, 0xc0, 0x8b, 0x4f,
+	0x84, 0xcb, 0xb2, 0xdf, 0xf6, 0x36, 0xd4, 0x7b, 0x93, 0x52, 0x49, 0x36, 0x4b, 0xfe, 0xad, 0x94,
+	0x6a, 0x9b, 0x51, 0x3e, 0x88, 0xc2, 0x41, 0x18, 0x7b, 0xbd, 0x8b, 0x2f, 0xd0, 0x16, 0x34, 0x85,
+	0x83, 0x7c, 0x03, 0x25, 0x13, 0x3e, 0x29, 0x81, 0x30, 0xe5, 0x89, 0x61, 0x96, 0xf2, 0xca, 0xa6,
+	0x94, 0xa7, 0x21, 0xa9, 0xb1, 0xaf, 0x32, 0x39, 0xf6, 0x55, 0xf5, 0xd8, 0x27, 0x45, 0xd7, 0xda,
+	0xa4, 0xe8, 0x9a, 0xa4, 0x96, 0x68, 0xcf, 0x70, 0x0e, 0x19, 0xc0, 0x7e, 0x13, 0x1a, 0x1d, 0x61,
+	0xf8, 0xb8, 0x5d, 0xdf, 0x2a, 0x6f, 0x37, 0xef, 0x2e, 0x23, 0x91, 0xc2, 0xb2, 0xb8, 0x39, 0x1e,
+	0xe9, 0xc2, 0x75, 0x0e, 0xbe, 0x42, 0x13, 0x17, 0xf2, 0x17, 0xf9, 0x61, 0x09, 0x16, 0x0b, 0x0c,
+	0xd0, 0xd3, 0x79, 0xd6, 0xcd, 0xca, 0x8e, 0x6c, 0x2c, 0x6f, 0xeb, 0x92, 0xba, 0xad, 0x2f, 0x95,
+	0x62, 0x24, 0x33, 0x57, 0xa7, 0x33, 0x73, 0x4d, 0x37, 0xf3, 0x86, 0x6c, 0xe6, 0x19, 0x96, 0x73,
+	0x73, 0x00, 0xea, 0x84, 0xd1, 0x95, 0x76, 0x1f, 0x8c, 0xd8, 0x1a, 0xcc, 0xba, 0xd9, 0x98, 0xfc,
+	0xc0, 0x82, 0xb5, 0x82, 0x15, 0x2e, 0x53, 0x16, 0xdc, 0x61, 0xc1, 0x86, 0x91, 0x11, 0x2e, 0xaa,
+	0x2e, 0x78, 0xc6, 0x23, 0x43, 0x23, 0x7f, 0x6a, 0xc1, 0x96, 0xb6, 0xa7, 0x76, 0xf2, 0xca, 0xe7,
+	0xe2, 0x6b, 0x8e, 0x49, 0x48, 0xf0, 0xc0, 0x84, 0xc7, 0xac, 0x92, 0x01, 0x70, 0x55, 0x9e, 0x85,
+	0xdd, 0xd1, 0x61, 0xe7, 0x84, 0xf6, 0x79, 0x04, 0x69, 0xb8, 0x12, 0x04, 0x57, 0x9b, 0x65, 0xac,
+	0xf8, 0x84, 0x2d, 0x59, 0xdd, 0x4d, 0x87, 0xe4, 0xef, 0xb3, 0x24, 0xb4, 0xcf, 0x82, 0xd3, 0xe1,
+	0xb0, 0xd3, 0xa1, 0x71, 0x7c, 0x59, 0x59, 0x63, 0x4e, 0x26, 0x8c, 0xd2, 0x84, 0x99, 0x01, 0xec,
+	0xfb, 0xb0, 0x98, 0x0d, 0x26, 0x06, 0x80, 0x22, 0x1a, 0xea, 0x79, 0x1c, 0x79, 0x1d, 0xaa, 0x78,
+	0x5f, 0x0e, 0x21, 0xbf, 0x61, 0xc1, 0xa6, 0x59, 0x9b, 0xcb, 0xb8, 0x41, 0x1a, 0x65, 0xcb, 0x52,
+	0x94, 0x3d, 0x4b, 0x92, 0xa7, 0x00, 0xe8, 0xb2, 0x17, 0x37, 0x62, 0x1b, 0x66, 0x3a, 0x61, 0x90,
+	0xd0, 0x20, 0xdb, 0xa0, 0x62, 0x48, 0x3e, 0x80, 0x59, 0x24, 0x3d, 0xbd, 0x46, 0x4a, 0x89, 0x52,
+	0xd2, 0x4a, 0x14, 0xf2, 0x99, 0x05, 0xcb, 0x4f, 0x68, 0xe4, 0x1f, 0x8d, 0x0e, 0x53, 0x98, 0x90,
+	0x7a, 0x05, 0x6a, 0x31, 0xdb, 0x76, 0x22, 0x7a, 0x88, 0x91, 0x7d, 0x0f, 0xae, 0xf1, 0x5f, 0x13,
+	0x8f, 0x2c, 0x1a, 0xce, 0x19, 0x85, 0x92, 0xa4, 0x6e, 0x45, 0x55, 0xf7, 0x3e, 0xac, 0x6a, 0xe2,
+	0x4d, 0xad, 0x39, 0x79, 0x05, 0xec, 0xdd, 0xb0, 0x3f, 0xf0, 0x3a, 0xc9, 0x01, 0x16, 0x09, 0x42,
+	0x2f, 0xb1, 0xc2, 0x56, 0x1e, 0x3f, 0x0f, 0x61, 0x49, 0xc6, 0x9b, 0xde, 0xb4, 0x13, 0xca, 0x11,
+	0xdc, 0x5a, 0xb3, 0x2e, 0x3b, 0x2c, 0x5c, 0xdc, 0x0b, 0xb6, 0x61, 0x5e, 0x3a, 0x38, 0x49, 0x19,
+	0x55, 0x07, 0xdb, 0xb7, 0xa1, 0x15, 0x78, 0x7d, 0xba, 0x47, 0x3b, 0x3d, 0x2f, 0xca, 0xb1, 0xb9,
+	0xa1, 0x4d, 0x53, 0x58, 0xa9, 0xf0, 0xb3, 0x8c, 0xc4, 0x5d, 0x84, 0x87, 0xe2, 0x04, 0xb9, 0x03,
+	0xd7, 0xb8, 0x32, 0xd3, 0x5b, 0xdf, 0x83, 0xb6, 0x4b, 0xe3, 0xb0, 0x77, 0x8a, 0x27, 0x35, 0x1a,
+	0xc5, 0xf4, 0x43, 0xaf, 0x7f, 0x09, 0x5b, 0xa4, 0xdb, 0xb0, 0x94, 0x6f, 0x43, 0xf2, 0x18, 0x9c,
+	0x22, 0x8b, 0x73, 0x9d, 0x20, 0xd0, 0x32, 0x8c, 0x64, 0xc3, 0x65, 0xbf, 0xc9, 0x1f, 0x5a, 0xe0,
+	0xe0, 0x79, 0xf2, 0x43, 0xd5, 0x64, 0xf1, 0xa5, 0x6a, 0xd6, 0x2c, 0x0f, 0x97, 0xc6, 0xe7, 0xe1,
+	0x72, 0x21, 0x0f, 0x87, 0x41, 0x6f, 0xf4, 0x04, 0x73, 0xab, 0x58, 0x96, 0x1c, 0x40, 0x02, 0xd8,
+	0x30, 0x09, 0x39, 0xbd, 0xea, 0xaf, 0xe3, 0xc1, 0x36, 0x1e, 0xf6, 0x12, 0x9e, 0x4e, 0x9a, 0x77,
+	0x5b, 0x88, 0xa2, 0xd1, 0x73, 0x53, 0x1c, 0xf2, 0x7b, 0x16, 0xac, 0x7f, 0xe0, 0x45, 0xcf, 0x79,
+	0x5c, 0x7d, 0x18, 0x24, 0x34, 0xa2, 0x71, 0xe2, 0x07, 0xc7, 0x97, 0x6a, 0x2c, 0xf0, 0x82, 0x38,
+	0x6d, 0x2c, 0xf0, 0x11, 0x86, 0x17, 0xfe, 0x6b, 0x62, 0x76, 0xd0, 0x70, 0xc8, 0x7b, 0x70, 0xdd,
+	0x28, 0xdf, 0xf4, 0xee, 0xfa, 0xdf, 0xa5, 0xf4, 0xb4, 0xa2, 0x59, 0xe1, 0x52, 0x2e, 0xab, 0xfb,
+	0xd7, 0x84, 0x15, 0x37, 0x14, 0xc6, 0x95, 0x73, 0x17, 0xc6, 0xd5, 0xc9, 0x15, 0x5b, 0xad, 0x50,
+	0xb1, 0x6d, 0x40, 0x03, 0xe5, 0x8a, 0x07, 0x5e, 0x87, 0xb2, 0xa2, 0x77, 0xd6, 0xcd, 0x01, 0x98,
+	0xad, 0xb3, 0x41, 0x26, 0x55, 0xdd, 0x94, 0xad, 0x0b, 0x68, 0x48, 0x79, 0xe0, 0x45, 0x89, 0xcf,
+	0xfe, 0xd3, 0x10, 0x35, 0x4b, 0x0a, 0x20, 0x47, 0x70, 0xdd, 0x68, 0xed, 0x2b, 0xce, 0xd4, 0xe4,
+	0xd7, 0x2d, 0x58, 0x14, 0x31, 0xe2, 0xd2, 0xf1, 0xa7, 0xb0, 0x98, 0xaf, 0xc2, 0x42, 0x12, 0x0e,
+	0x1e, 0xd1, 0x53, 0xda, 0xdb, 0x49, 0xb7, 0x38, 0x67, 0x5e, 0x80, 0x93, 0x7f, 0x2c, 0xc3, 0xbc,
+	0xa6, 0xab, 0xf1, 0x00, 0xf7, 0xe5, 0x38, 0x8d, 0x1c, 0x94, 0xaa, 0x5a, 0x50, 0x7a, 0x07, 0x16,
+	0xd2, 0xdf, 0x19, 0xd1, 0x9a, 0x81, 0x68, 0x01, 0x4b, 0x75, 0xc5, 0x99, 0xc9, 0xae, 0x58, 0x9f,
+	0xec, 0x8a, 0x8d, 0xa9, 0x5c, 0x11, 0x2e, 0xe0, 0x8a, 0x4d, 0xcd, 0x15, 0xed, 0xb7, 0x45, 0x4b,
+	0x00, 0x63, 0xd1, 0x2c, 0x23, 0xb8, 0x6e, 0x08, 0x86, 0x4f, 0x04, 0x8a, 0x9b, 0x21, 0x93, 0xff,
+	0xb1, 0xa0, 0x25, 0xf9, 0xd6, 0xf4, 0xae, 0x4b, 0x94, 0xd8, 0x27, 0x8e, 0x49, 0x3c, 0x76, 0x65,
+	0x71, 0xf0, 0x4d, 0x80, 0x2e, 0x8d, 0xfc, 0xd3, 0x34, 0x06, 0x8e, 0x0d, 0xd2, 0x12, 0x9a, 0x72,
+	0xfa, 0xaf, 0x4c, 0x3a, 0xfd, 0x23, 0xf9, 0x8e, 0x17, 0x74, 0x7d, 0xec, 0xa4, 0xc6, 0xed, 0xea,
+	0x04, 0xf2, 0x39, 0x1a, 0xf9, 0x1d, 0x0b, 0xe6, 0x0e, 0xfc, 0xe0, 0x72, 0x1b, 0x69, 0x1b, 0xe6,
+	0xb5, 0x7a, 0x24, 0x2d, 0x6a, 0x34, 0xb0, 0xa2, 0x4d, 0x79, 0x62, 0x2f, 0xe3, 0x2e, 0xcc, 0x0b,
+	0xb9, 0xa6, 0x0f, 0xf8, 0xff, 0x65, 0xc1, 0xca, 0xee, 0x09, 0xed, 0x3c, 0xcf, 0x1b, 0xc9, 0x97,
+	0xc8, 0xf2, 0xaf, 0xc1, 0xa2, 0x56, 0x93, 0xd1, 0xf4, 0xa4, 0x56, 0x9c, 0xc0, 0x2e, 0x39, 0x5f,
+	0x66, 0x81, 0xc8, 0x7b, 0xe8, 0x0a, 0xcc, 0xbe, 0x07, 0xcb, 0x86, 0xba, 0x8d, 0xa6, 0x2d, 0x75,
+	0xf3, 0x24, 0xeb, 0x80, 0x7a, 0x2f, 0x76, 0x8e, 0xa9, 0x48, 0x05, 0x62, 0x44, 0xfe, 0xc8, 0x82,
+	0x85, 0x5c, 0x51, 0x6c, 0xb5, 0x0e, 0x63, 0x63, 0xfc, 0xc1, 0xb2, 0x9f, 0xcd, 0x8a, 0x08, 0x24,
+	0x46, 0xd8, 0xd9, 0xe9, 0x79, 0x71, 0xc2, 0x2c, 0x96, 0xf5, 0x7a, 0x65, 0xd0, 0x39, 0x9c, 0xaf,
+	0x0d, 0x33, 0x7d, 0x1a, 0xc7, 0x9e, 0x90, 0xb2, 0xe1, 0xa6, 0x43, 0xd2, 0x87, 0xb6, 0xbe, 0x26,
+	0xd3, 0x6f, 0xab, 0xdb, 0x50, 0xe7, 0xc2, 0xd2, 0xb4, 0xaa, 0x59, 0x42, 0x1c, 0x5d, 0x6d, 0x37,
+	0xc3, 0x22, 0xdf, 0xc9, 0x92, 0x03, 0x9a, 0x4f, 0xac, 0xbe, 0xc9, 0x2a, 0x9a, 0x47, 0x94, 0xce,
+	0xf6, 0x08, 0xf2, 0x57, 0x79, 0x74, 0x40, 0xe2, 0xd3, 0xab, 0x31, 0x75, 0x0b, 0x4f, 0x8a, 0x23,
+	0xe5, 0xb1, 0x71, 0xe4, 0x0e, 0x34, 0x25, 0xff, 0x6b, 0x57, 0x72, 0xc9, 0xa5, 0xce, 0x84, 0x2b,
+	0xe3, 0x10, 0x1f, 0xe6, 0x1e, 0x06, 0x4c, 0x0f, 0x61, 0x11, 0xe9, 0x78, 0x66, 0x29, 0xc7, 0x33,
+	0xd1, 0x98, 0x38, 0xa5, 0x91, 0xdc, 0x1d, 0x4f, 0x01, 0xac, 0x47, 0x8c, 0x87, 0x37, 0x9f, 0xcf,
+	0x8b, 0xfe, 0xb3, 0x04, 0x22, 0xff, 0x60, 0xc1, 0xbc, 0xe0, 0x75, 0xb5, 0xe1, 0x53, 0x53, 0xbb,
+	0x7c, 0xb6, 0xda, 0xf6, 0x2e, 0x2c, 0x26, 0x7a, 0x6f, 0xa7, 0x5d, 0x99, 0xd4, 0xf8, 0x29, 0xe2,
+	0x93, 0x65, 0x68, 0x61, 0x65, 0xfe, 0x48, 0x8d, 0x28, 0xe4, 0x5f, 0x2d, 0x58, 0x56, 0xe0, 0xd3,
+	0x6b, 0xfb, 0x31, 0x5c, 0xf3, 0x8e, 0x69, 0x90, 0xff, 0x55, 0xf8, 0xf6, 0xeb, 0xcc, 0x29, 0x4c,
+	0x34, 0x6f, 0xed, 0x28, 0xf8, 0xfb, 0x41, 0x12, 0x8d, 0x5c, 0x8d, 0x88, 0xf3, 0xcb, 0xd0, 0x32,
+	0xa0, 0x61, 0x55, 0xf5, 0x9c, 0x8e, 0x98, 0x30, 0x0d, 0x17, 0x7f, 0xda, 0x04, 0xaa, 0xa7, 0x5e,
+	0x6f, 0x48, 0x8d, 0xbe, 0xc8, 0xa7, 0xee, 0x97, 0xde, 0xb1, 0xc8, 0xef, 0x97, 0xc0, 0x96, 0xbb,
+	0x2f, 0xc2, 0x77, 0x94, 0x9a, 0xc0, 0x9a, 0x5c, 0x13, 0x94, 0x0a, 0x35, 0xc1, 0x2f, 0x82, 0x1d,
+	0xe5, 0xcf, 0xf9, 0x26, 0x25, 0x03, 0x03, 0x1e, 0xd6, 0x67, 0x87, 0xb4, 0x13, 0xd1, 0xe4, 0xc0,
+	0x8b, 0xe3, 0xc1, 0x49, 0xe4, 0xc5, 0xfc, 0x88, 0xdb, 0x70, 0x0b, 0x70, 0x94, 0xf3, 0x39, 0x4d,
+	0x7b, 0x68, 0x3c, 0x2a, 0xe5, 0x00, 0xf4, 0x8d, 0xac, 0x60, 0x40, 0x50, 0x77, 0xd8, 0xa3, 0xed,
+	0x5a, 0xee, 0x1b, 0x07, 0xfa, 0xa4, 0x5b, 0xc4, 0x27, 0x1e, 0x2c, 0x16, 0xf0, 0xec, 0x25, 0xa8,
+	0x26, 0x3e, 0x8d, 0xe2, 0xb6, 0xb5, 0x55, 0xde, 0x2e, 0xbb, 0x7c, 0xc0, 0x1a, 0x9d, 0xbd, 0x30,
+	0x39, 0xf4, 0xbf, 0xc7, 0xed, 0x5e, 0x75, 0xb3, 0x31, 0xce, 0xf5, 0xbd, 0x17, 0xae, 0x17, 0x1c,
+	0x53, 0x11, 0x86, 0xb3, 0x31, 0xb6, 0xc0, 0x96, 0xe4, 0x45, 0x38, 0x57, 0x9b, 0x88, 0x3f, 0x25,
+	0xc9, 0x8b, 0xea, 0x1c, 0x80, 0xb3, 0xdc, 0x62, 0x38, 0x2b, 0xda, 0x37, 0x19, 0x20, 0x8b, 0x98,
+	0x15, 0xa9, 0xf0, 0xfe, 0xb1, 0x05, 0x35, 0x2e, 0x83, 0x31, 0xa0, 0x2a, 0x6e, 0x51, 0x9a, 0xec,
+	0x16, 0xe5, 0x82, 0x5b, 0xdc, 0x92, 0x4a, 0x36, 0xbe, 0x43, 0xed, 0x3c, 0x06, 0x18, 0x2a, 0xb5,
+	0xbf, 0x2e, 0xc3, 0x2a, 0x37, 0xcb, 0x95, 0xb4, 0x63, 0xd5, 0x86, 0x6b, 0xa9, 0xd0, 0x70, 0xd5,
+	0x9e, 0x82, 0x94, 0xa7, 0x7a, 0x0a, 0xf2, 0x25, 0x1c, 0xf6, 0xf2, 0xf6, 0xfc, 0xcc, 0xd8, 0xf6,
+	0xbc, 0xd4, 0x2c, 0xae, 0x2b, 0xcd, 0x62, 0x7b, 0x9f, 0x5f, 0x56, 0xc8, 0x9f, 0xb9, 0xc7, 0xec,
+	0x58, 0x27, 0xca, 0xe5, 0x31, 0x97, 0x1f, 0x5c, 0xfd, 0x3f, 0x98, 0xa5, 0xc5, 0x75, 0x86, 0xb8,
+	0x0d, 0x6a, 0x96, 0x7e, 0x4e, 0xa3, 0xfc, 0x8f, 0x19, 0x16, 0xe9, 0xc2, 0x82, 0x3e, 0x7b, 0xf5,
+	0x77, 0x2a, 0xc8, 0x63, 0xd8, 0x70, 0x69, 0x3c, 0x0a, 0x3a, 0xd2, 0xb2, 0x7f, 0x3d, 0xf2, 0x06,
+	0x27, 0x17, 0xf6, 0x13, 0xb2, 0x03, 0x9b, 0x66, 0x92, 0xd3, 0x57, 0xa9, 0x5f, 0x03, 0x38, 0x44,
+	0x02, 0x17, 0x96, 0xe1, 0xf3, 0x32, 0x2c, 0xed, 0x07, 0x9d, 0x68, 0x34, 0x48, 0x3e, 0xe0, 0x45,
+	0xd6, 0x17, 0xd0, 0x95, 0xfe, 0x62, 0x3d, 0x3e, 0x3f, 0x15, 0x56, 0xa7, 0x3a, 0x15, 0xd6, 0xa6,
+	0x3b, 0x15, 0x3a, 0xe8, 0x88, 0x71, 0x38, 0x8c, 0x44, 0xe7, 0xa3, 0xe1, 0x66, 0x63, 0x75, 0x9f,
+	0xd5, 0x27, 0xef, 0xb3, 0x46, 0x61, 0x9f, 0xdd, 0x81, 0xba, 0x50, 0x23, 0x75, 0xf1, 0x65, 0x1e,
+	0x9e, 0xd8, 0x32, 0x60, 0xf4, 0xe7, 0xb3, 0x6e, 0x86, 0x66, 0xbf, 0x0d, 0x90, 0x49, 0x18, 0xb3,
+	0x33, 0x6a, 0xf3, 0xee, 0xaa, 0xfa, 0xa7, 0x0f, 0xd3, 0x79, 0x57, 0x42, 0xc5, 0x27, 0xc3, 0x05,
+	0xba, 0x97, 0x7c, 0x32, 0xfc, 0x53, 0x0b, 0x5a, 0x06, 0xb6, 0xea, 0x52, 0x58, 0x53, 0x2d, 0x45,
+	0xe9, 0xfc, 0x4b, 0x51, 0x9e, 0xb4, 0x14, 0xe7, 0x7d, 0xf0, 0x4b, 0x9e, 0xc2, 0x8a, 0xea, 0xfd,
+	0xd3, 0x67, 0xc4, 0x4d, 0x80, 0x8e, 0x3f, 0x38, 0xa1, 0x51, 0x42, 0x5f, 0xa4, 0x0e, 0x2f, 0x41,
+	0xc8, 0x4f, 0x72, 0x33, 0x1d, 0x26, 0x11, 0xf5, 0xfa, 0x62, 0x63, 0xbd, 0x03, 0x10, 0xd1, 0x8e,
+	0x3f, 0xf0, 0x69, 0x90, 0xc4, 0x82, 0x7a, 0x5b, 0x5a, 0x4a, 0x65, 0x1b, 0xba, 0x12, 0x2e, 0xdb,
+	0x45, 0xf4, 0xb8, 0x4f, 0x83, 0x3c, 0xf1, 0x97, 0x5d, 0x19, 0x24, 0xef, 0xc0, 0xb2, 0xfa, 0xa0,
+	0xe4, 0xdb, 0xb0, 0xac, 0x08, 0x73, 0x75, 0x7a, 0xfe, 0xd8, 0x82, 0xd6, 0x1e, 0x2d, 0xea, 0x79,
+	0xb1, 0xeb, 0x01, 0x11, 0x0b, 0x88, 0xef, 0xfb, 0x51, 0xcc, 0x79, 0xd5, 0x5d, 0x19, 0xa4, 0x09,
+	0x53, 0x2e, 0x08, 0xe3, 0xc2, 0xf2, 0x1e, 0xbd, 0x90, 0x9a, 0xe3, 0x1f, 0xa9, 0xfd, 0xc4, 0x82,
+	0xa5, 0x3d, 0x5a, 0x5c, 0x9b, 0x0b, 0x96, 0x06, 0x93, 0x8c, 0xa9, 0x5b, 0xa0, 0x5c, 0xb0, 0x00,
+	0x39, 0x84, 0x15, 0x55, 0x98, 0xab, 0x50, 0xf1, 0xb3, 0x32, 0xac, 0xb8, 0x94, 0x06, 0xff, 0x4f,
+	0x94, 0x54, 0x22, 0x64, 0xe5, 0x22, 0x11, 0xb2, 0x3a, 0x75, 0x84, 0xc4, 0x66, 0x4b, 0x40, 0x7f,
+	0x35, 0x9b, 0x63, 0xe9, 0x61, 0xd6, 0x55, 0x60, 0xf6, 0x7b, 0xb0, 0x24, 0x8f, 0xb3, 0xf8, 0x35,
+	0x63, 0x88, 0x5f, 0x46, 0xcc, 0xcb, 0x65, 0x0c, 0xf2, 0xcf, 0x16, 0x2c, 0x64, 0xcb, 0x2d, 0xe2,
+	0x01, 0xb6, 0x62, 0xba, 0x7e, 0x84, 0x5d, 0x5f, 0x8b, 0x59, 0x50, 0x8c, 0x26, 0xdf, 0xde, 0xc8,
+	0x23, 0x74, 0x79, 0xaa, 0x08, 0x5d, 0x39, 0x7f, 0x84, 0xae, 0x16, 0x23, 0x74, 0xde, 0x5e, 0xad,
+	0xe9, 0x9d, 0xfe, 0xbf, 0xb3, 0xa0, 0xad, 0xbb, 0xde, 0x95, 0x05, 0x27, 0xfb, 0x9e, 0x12, 0x6c,
+	0xcb, 0x79, 0x3d, 0xa9, 0x5b, 0x53, 0x09, 0xb4, 0xf7, 0x00, 0x7a, 0x61, 0x9c, 0xec, 0xb0, 0xfb,
+	0x01, 0xed, 0xca, 0xa4, 0x7f, 0xe5, 0x78, 0xe4, 0x4f, 0x4a, 0x30, 0x8b, 0xc5, 0xd8, 0xf4, 0xd2,
+	0x3f, 0x84, 0xb9, 0x38, 0x09, 0x23, 0xef, 0x98, 0x1e, 0xa6, 0x3d, 0x35, 0x64, 0xf5, 0x12, 0x22,
+	0xca, 0x94, 0x6e, 0x1d, 0xca, 0x58, 0xfc, 0xc0, 0xae, 0xfe, 0x13, 0x1b, 0x8c, 0x49, 0x98, 0x78,
+	0x3d, 0xfe, 0xb7, 0x4f, 0x86, 0x34, 0x4e, 0x62, 0x71, 0x32, 0x2a, 0x4e, 0xd8, 0xaf, 0xc0, 0x35,
+	0xbc, 0xe8, 0xd9, 0xa3, 0x09, 0xed, 0xe2, 0x44, 0x2c, 0x32, 0xa7, 0x06, 0x75, 0x9e, 0x82, 0x5d,
+	0x64, 0x6d, 0x68, 0x02, 0xbc, 0xae, 0x36, 0x01, 0xd8, 0xbe, 0x13, 0x7f, 0xdc, 0x8b, 0xfc, 0x53,
+	0x1a, 0xf1, 0xbf, 0xcb, 0xfd, 0x80, 0x3f, 0xb6, 0xa0, 0x65, 0x40, 0xc1, 0xe0, 0x10, 0x0e, 0x28,
+	0x6f, 0x5a, 0x7a, 0x3d, 0xe1, 0xda, 0x32, 0xc8, 0x7e, 0x0b, 0x2a, 0x7e, 0x70, 0x14, 0x0a, 0x63,
+	0xdd, 0x1c, 0xc3, 0xeb, 0xd6, 0xc3, 0xe0, 0x28, 0xe4, 0xa6, 0x62, 0xe8, 0xce, 0xdb, 0xd0, 0xc8,
+	0x40, 0x06, 0x15, 0x96, 0x64, 0x15, 0x1a, 0xb2, 0xa4, 0x7f, 0x60, 0xc1, 0x5a, 0xe1, 0x74, 0x78,
+	0x99, 0x07, 0x51, 0x67, 0xf6, 0xbd, 0xd4, 0xbe, 0x59, 0x45, 0xef, 0x9b, 0xa5, 0xe5, 0x5b, 0x55,
+	0x3a, 0x4f, 0xff, 0xad, 0x05, 0xed, 0x82, 0x90, 0x97, 0x68, 0x58, 0x7f, 0x4d, 0xbb, 0xa8, 0x5d,
+	0xca, 0x8f, 0x72, 0x63, 0x4e, 0xca, 0xda, 0x2d, 0x6e, 0x1b, 0x2a, 0x18, 0xcf, 0x45, 0x74, 0x67,
+	0xbf, 0x51, 0xf1, 0x4e, 0x18, 0x74, 0x86, 0x11, 0x1e, 0xd2, 0xb8, 0x62, 0x55, 0x57, 0x06, 0x91,
+	0x53, 0x70, 0x0a, 0xe4, 0xcf, 0xd1, 0x0c, 0x7b, 0x5b, 0x7f, 0x6e, 0x7d, 0xdd, 0x28, 0x70, 0x4a,
+	0x30, 0x7f, 0x82, 0xfd, 0x18, 0x5a, 0x07, 0xfc, 0x1c, 0xab, 0x74, 0xa7, 0x0a, 0x97, 0x41, 0xce,
+	0x51, 0x26, 0x3f, 0x82, 0x65, 0x85, 0xe4, 0xf9, 0xae, 0x2e, 0xeb, 0x77, 0x19, 0x5e, 0x83, 0xb6,
+	0xa0, 0x56, 0x6c, 0x51, 0x14, 0xaf, 0xac, 0x3c, 0x06, 0xa7, 0x88, 0x7d, 0x39, 0x01, 0x46, 0xb0,
+	0xb4, 0xd3, 0xbd, 0x9a, 0xeb, 0x6a, 0xc5, 0x1d, 0xa1, 0xf8, 0x7b, 0x59, 0xf3, 0x77, 0xf2, 0x2e,
+	0xac, 0xa8, 0xac, 0xa7, 0x3f, 0x1f, 0x7f, 0x5e, 0x86, 0xf6, 0xa3, 0x30, 0x7c, 0x3e, 0x1c, 0x5c,
+	0xcd, 0xb6, 0xd8, 0x04, 0x38, 0x8a, 0xc2, 0xfe, 0xbe, 0x7c, 0x35, 0x41, 0x82, 0x60, 0x1e, 0x4c,
+	0xc2, 0xfd, 0xbc, 0xe9, 0x3e, 0xeb, 0x66, 0x63, 0x35, 0xfb, 0x56, 0xf4, 0xec, 0xfb, 0x32, 0xcc,
+	0x0d, 0x68, 0xd4, 0xf7, 0xd9, 0x85, 0xb4, 0x43, 0x9a, 0x5e, 0xa8, 0x56, 0x81, 0xc8, 0x3f, 0x07,
+	0xb0, 0x52, 0xa5, 0xe1, 0x4a, 0x10, 0x0c, 0xec, 0x69, 0xde, 0x3d, 0x88, 0xe8, 0x91, 0xff, 0x42,
+	0x1c, 0x5d, 0x35, 0x28, 0x7b, 0xc2, 0xf4, 0x62, 0xe0, 0x47, 0x34, 0xde, 0x39, 0x4a, 0x68, 0x24,
+	0x2a, 0x12, 0x05, 0x86, 0x12, 0x89, 0xb1, 0xb8, 0xc4, 0xcd, 0xeb, 0x12, 0x15, 0x88, 0x1c, 0xe9,
+	0x8b, 0x4e, 0x6f, 0xd8, 0xa5, 0xae, 0x78, 0x15, 0x02, 0xd8, 0x8e, 0xd7, 0xa0, 0x2c, 0xae, 0x07,
+	0xbd, 0x51, 0x8a, 0xd4, 0x14, 0x71, 0x3d, 0x07, 0xa1, 0xed, 0x06, 0x98, 0x69, 0xf0, 0x6c, 0x33,
+	0xcb, 0x9b, 0x9a, 0xe9, 0x18, 0x6b, 0x9d, 0xce, 0x30, 0x8a, 0xc3, 0xa8, 0x3d, 0xc7, 0xaf, 0x83,
+	0xf0, 0x11, 0xf9, 0x4d, 0xbc, 0x8f, 0x53, 0x58, 0xdf, 0xe9, 0x3d, 0xfd, 0xab, 0x7a, 0xc0, 0x28,
+	0x3c, 0x03, 0x48, 0xe7, 0xd3, 0x57, 0x4f, 0x76, 0xb9, 0x18, 0xe2, 0xe8, 0x91, 0x43, 0xc8, 0x5b,
+	0x50, 0xdd, 0x4f, 0x77, 0x4f, 0x27, 0xec, 0x72, 0x7f, 0xaa, 0xba, 0xec, 0xb7, 0xfc, 0x48, 0xab,
+	0xa4, 0x3f, 0xd2, 0x6a, 0x4a, 0xde, 0x66, 0xdf, 0x4b, 0x1f, 0xfd, 0xf1, 0xf6, 0xa9, 0x10, 0x7c,
+	0x21, 0x6f, 0x5f, 0x72, 0xb8, 0xab, 0x60, 0x9d, 0x23, 0x2a, 0x75, 0xa0, 0x9e, 0x42, 0xd1, 0xff,
+	0x53, 0xf8, 0xc7, 0xee, 0x43, 0xd9, 0xff, 0x1f, 0xe5, 0x60, 0x57, 0xc6, 0x41, 0x9f, 0x50, 0x1e,
+	0x14, 0x08, 0x6d, 0x54, 0x20, 0x79, 0x17, 0x9a, 0x12, 0x05, 0xdc, 0xef, 0x29, 0xfd, 0x86, 0x8b,
+	0x3f, 0xe5, 0x57, 0x6a, 0x78, 0xfb, 0x3a, 0x1d, 0x92, 0xf7, 0x60, 0x56, 0xd6, 0xd3, 0x10, 0x81,
+	0x71, 0x0b, 0xe4, 0xfd, 0x7a, 0xb1, 0x05, 0x73, 0x08, 0xf9, 0x69, 0x09, 0x9a, 0xd2, 0x02, 0x1a,
+	0x28, 0x18, 0xc2, 0x9b, 0xfd, 0x15, 0xa8, 0x60, 0x87, 0x56, 0x3c, 0x3b, 0x68, 0x69, 0x5e, 0xf0,
+	0x20, 0xec, 0x8e, 0x5c, 0x86, 0xa0, 0x27, 0xef, 0xca, 0x19, 0xc9, 0xbb, 0x6a, 0x78, 0xe8, 0x25,
+	0xb7, 0xc2, 0x6a, 0x53, 0xb5, 0xc2, 0x66, 0xa6, 0x69, 0x85, 0xbd, 0x29, 0x75, 0xbd, 0xeb, 0x79,
+	0x1d, 0x26, 0xa9, 0x51, 0x6c, 0x7d, 0x9f, 0x71, 0x0d, 0xe7, 0x7f, 0x2d, 0x98, 0xd7, 0xcc, 0x80,
+	0x1b, 0x7e, 0x8f, 0xa2, 0x53, 0x77, 0x71, 0x98, 0x9b, 0x56, 0x83, 0xe6, 0xaf, 0x7a, 0xd1, 0x48,
+	0xba, 0x9a, 0xa8, 0xc0, 0x8c, 0x77, 0x49, 0xca, 0x53, 0xdd, 0x25, 0xc9, 0x7b, 0xd5, 0x95, 0xe9,
+	0xde, 0x87, 0x3a, 0x6f, 0x37, 0x9c, 0x7c, 0x56, 0x82, 0x96, 0xc1, 0x76, 0xa2, 0x50, 0xf4, 0xbb,
+	0xa2, 0x34, 0xe5, 0x03, 0xf9, 0x55, 0x30, 0xde, 0xb6, 0x48, 0x87, 0x38, 0xc3, 0x23, 0x66, 0x57,
+	0xd4, 0x42, 0xe9, 0x10, 0xe5, 0xeb, 0x7b, 0xbd, 0xa3, 0x30, 0xea, 0xd3, 0xec, 0x12, 0x5f, 0x06,
+	0x60, 0xe7, 0xd2, 0x30, 0x11, 0x67, 0x7d, 0xda, 0x65, 0x0a, 0xd4, 0x5d, 0x05, 0x86, 0x3a, 0xc4,
+	0x51, 0xe7, 0x61, 0xc0, 0x05, 0xaa, 0x31, 0x0c, 0x09, 0x82, 0xf3, 0xdd, 0x38, 0x49, 0xe7, 0x67,
+	0xf8, 0x7c, 0x0e, 0x91, 0xc3, 0x52, 0x5d, 0x09, 0x4b, 0xe8, 0xa6, 0x41, 0x98, 0x30, 0xa5, 0x9f,
+	0xd2, 0x84, 0x85, 0xfe, 0xba, 0x2b, 0x83, 0xc8, 0x5f, 0x5a, 0x70, 0x4d, 0x7d, 0xa2, 0xf2, 0xa5,
+	0x99, 0x66, 0xec, 0x05, 0x01, 0x5d, 0xec, 0x5a, 0x51, 0xec, 0xbf, 0xb1, 0x60, 0x75, 0xcc, 0xdd,
+	0x9d, 0x9f, 0x09, 0xf9, 0xbf, 0x0f, 0x35, 0xee, 0xe6, 0xf6, 0x7b, 0xb0, 0x90, 0x44, 0xc3, 0x38,
+	0x61, 0x17, 0xc9, 0x38, 0x4c, 0xc4, 0x70, 0x76, 0x56, 0xfd, 0x48, 0x9b, 0x73, 0x0b, 0xd8, 0x98,
+	0x00, 0xa2, 0x8f, 0x22, 0x4a, 0x0f, 0xe4, 0x37, 0x0b, 0x59, 0x02, 0x70, 0x73, 0xb0, 0x2b, 0xe3,
+	0x90, 0x6d, 0x58, 0xd0, 0x09, 0xa3, 0xd9, 0x18, 0x69, 0x91, 0xf1, 0xf8, 0x80, 0xfc, 0xb9, 0x05,
+	0x4d, 0x89, 0xcc, 0x19, 0xed, 0x61, 0x02, 0xb3, 0x7e, 0xc0, 0x1b, 0x18, 0xe2, 0xbc, 0x61, 0x6d,
+	0xcf, 0xb9, 0x0a, 0x0c, 0x3b, 0xa7, 0xb8, 0x19, 0x69, 0x5f, 0x3a, 0xcc, 0xb7, 0x35, 0x69, 0x0f,
+	0x53, 0x04, 0x57, 0xc2, 0xc5, 0xb4, 0x75, 0xea, 0xc7, 0xfe, 0x33, 0xbf, 0xe7, 0x27, 0x23, 0xcc,
+	0x45, 0xfc, 0x92, 0x8c, 0x0a, 0x24, 0xdf, 0x83, 0x25, 0x13, 0xa5, 0x62, 0x69, 0x66, 0x99, 0x4a,
+	0xb3, 0x2d, 0x68, 0xe6, 0x00, 0x5e, 0x4e, 0x34, 0x5c, 0x19, 0x34, 0xa9, 0x8d, 0x4d, 0xfe, 0xc3,
+	0x82, 0xe5, 0x07, 0x43, 0xbf, 0xd7, 0xe5, 0x12, 0x48, 0x17, 0xd2, 0xbf, 0x90, 0xd7, 0xac, 0x26,
+	0x77, 0x82, 0x54, 0x43, 0x57, 0xce, 0x61, 0x68, 0xad, 0xb5, 0x57, 0x2d, 0xf6, 0x2f, 0x47, 0xb0,
+	0xaa, 0xe9, 0x39, 0x7d, 0xb5, 0x76, 0x13, 0x6a, 0xbc, 0x1a, 0x6b, 0x97, 0x72, 0x0c, 0x4e, 0x43,
+	0x4c, 0x28, 0x77, 0xee, 0xcb, 0xda, 0x9d, 0xfb, 0x4f, 0x2d, 0x58, 0xe4, 0x6f, 0x0b, 0xc8, 0xf6,
+	0x9d, 0xf4, 0x76, 0xe7, 0x0e, 0xb4, 0x22, 0xfa, 0xc9, 0x10, 0xb7, 0xb4, 0x7b, 0xf6, 0x46, 0x31,
+	0xe1, 0x8e, 0xbf, 0x9c, 0x49, 0x9e, 0x42, 0x4b, 0x92, 0xe6, 0x2a, 0xad, 0x40, 0xfe, 0xd3, 0x82,
+	0x2a, 0x83, 0xd8, 0x3f, 0x0f, 0x75, 0xda, 0x13, 0x0b, 0x69, 0x99, 0x2b, 0xdc, 0x0c, 0xc1, 0x7e,
+	0x09, 0xaa, 0x03, 0x2f, 0x39, 0x49, 0x6b, 0xe1, 0xb9, 0x8c, 0xf0, 0x81, 0x97, 0x9c, 0xb8, 0x7c,
+	0x4e, 0xca, 0xbc, 0xe5, 0xb1, 0x99, 0x17, 0x6f, 0x6f, 0x63, 0x24, 0x1c, 0x89, 0xbe, 0x92, 0x18,
+	0x4d, 0x78, 0x5f, 0xd4, 0x50, 0xf4, 0xd4, 0xa6, 0x28, 0x7a, 0xc8, 0x57, 0xa0, 0x91, 0x49, 0x88,
+	0x4b, 0xa9, 0x28, 0x5b, 0xcd, 0x75, 0xbb, 0xfb, 0x29, 0x81, 0xca, 0xb7, 0x76, 0x9e, 0xec, 0xdb,
+	0xbf, 0x02, 0xb3, 0xf2, 0x15, 0x08, 0x7b, 0x25, 0x6f, 0x11, 0xc8, 0x67, 0x7f, 0xa7, 0xad, 0xc3,
+	0xd3, 0x15, 0x22, 0xeb, 0xbf, 0xf6, 0x4f, 0xff, 0xfe, 0xdb, 0xa5, 0x65, 0xb2, 0xf0, 0xc6, 0xe9,
+	0x9d, 0x37, 0x64, 0x8c, 0xfb, 0xd6, 0xab, 0xf6, 0x27, 0xb0, 0x58, 0xe8, 0x37, 0xd8, 0x93, 0xfa,
+	0x26, 0xce, 0xe4, 0x1e, 0x05, 0xd9, 0x62, 0xdc, 0x1c, 0xb2, 0x9c, 0x73, 0x93, 0xd0, 0x90, 0xe5,
+	0x10, 0xec, 0x02, 0x3c, 0xb6, 0x37, 0x8c, 0x64, 0xc5, 0xd9, 0xd7, 0xd9, 0x34, 0xcf, 0x66, 0x5c,
+	0x6f, 0x32, 0xae, 0xeb, 0x64, 0xc5, 0xc8, 0x35, 0x46, 0xb6, 0x1e, 0xcc, 0x29, 0x0d, 0x0e, 0x9b,
+	0x95, 0x9b, 0x86, 0x36, 0x8a, 0xb3, 0x56, 0x98, 0xc8, 0xf8, 0x6c, 0x30, 0x3e, 0x2b, 0x64, 0x11,
+	0xf9, 0x28, 0x28, 0x42, 0xb3, 0x62, 0x1f, 0x83, 0x6b, 0x36, 0xae, 0x1b, 0xe2, 0x6c, 0x9a, 0x67,
+	0xcd, 0x9a, 0x15, 0xf1, 0x90, 0x2d, 0x85, 0x6b, 0x6a, 0xc3, 0xc1, 0x66, 0xce, 0x60, 0xea, 0x7f,
+	0x38, 0x4e, 0x71, 0x26, 0x63, 0x75, 0x9d, 0xb1, 0x5a, 0x25, 0x36, 0xb2, 0x52, 0x71, 0x90, 0x4d,
+	0x02, 0x76, 0xf1, 0xec, 0xca, 0xb5, 0x1b, 0xd7, 0xb3, 0x70, 0x36, 0xcd, 0xb3, 0x66, 0x6f, 0x29,
+	0xe0, 0x21, 0xd7, 0xef, 0x98, 0x3a, 0x22, 0xfc, 0x69, 0xd9, 0xe5, 0x78, 0xdf, 0xb6, 0xec, 0x1f,
+	0x59, 0xf8, 0x1c, 0xc9, 0x74, 0xa5, 0xc1, 0xde, 0xe2, 0xb7, 0x38, 0xc6, 0xdf, 0xa0, 0x70, 0xc8,
+	0x78, 0x8c, 0x4c, 0xbd, 0x9f, 0x63, 0xea, 0xdd, 0x20, 0x0e, 0xaa, 0x67, 0xc6, 0x45, 0x1d, 0x1f,
+	0xf2, 0x6b, 0x11, 0xa2, 0xa5, 0x7c, 0x2d, 0xed, 0xa7, 0x0b, 0x46, 0x0b, 0x7a, 0x7f, 0x9d, 0xac,
+	0x31, 0xb2, 0x2d, 0x72, 0x0d, 0xc9, 0xe6, 0xff, 0x44, 0x52, 0xef, 0x42, 0xeb, 0x5b, 0x9e, 0x9f,
+	0xbc, 0x1f, 0x46, 0x08, 0xdf, 0x15, 0xfd, 0xf1, 0xb3, 0x69, 0xde, 0xb6, 0x6c, 0x1f, 0xe6, 0xb5,
+	0x54, 0x67, 0xb3, 0x9d, 0x60, 0xcc, 0xf3, 0xce, 0xba, 0x61, 0x2a, 0x13, 0x70, 0x93, 0x09, 0xd8,
+	0x26, 0x2d, 0x14, 0x50, 0x43, 0x42, 0x29, 0x9f, 0x42, 0x53, 0xca, 0x25, 0x36, 0x7b, 0x5a, 0x56,
+	0x48, 0x75, 0xce, 0xaa, 0x06, 0xce, 0xc8, 0x3b, 0x8c, 0xfc, 0x12, 0x99, 0x47, 0xf2, 0x12, 0x82,
+	0xd8, 0xe6, 0xca, 0x35, 0x42, 0xbe, 0xcd, 0x0d, 0xb7, 0x18, 0x9d, 0xb5, 0xc2, 0x84, 0x79, 0x9b,
+	0x2b, 0x28, 0x7c, 0xb9, 0x66, 0xc4, 0x2d, 0x4f, 0x7b, 0x11, 0x69, 0x28, 0xd7, 0x4b, 0x9d, 0x96,
+	0x04, 0xca, 0x08, 0xae, 0x30, 0x82, 0x0b, 0xa4, 0x89, 0x04, 0xc5, 0xa4, 0x30, 0x84, 0x74, 0xab,
+	0x96, 0x1b, 0xa2, 0x70, 0x87, 0xd7, 0x59, 0xd5, 0xc0, 0x66, 0x43, 0x48, 0x08, 0x48, 0xba, 0x0f,
+	0x0b, 0xfa, 0xe5, 0x63, 0x9b, 0xed, 0x7e, 0xf3, 0x35, 0x71, 0x67, 0xc3, 0x34, 0x97, 0x71, 0xba,
+	0xc1, 0x38, 0xad, 0x91, 0x25, 0x16, 0x60, 0x35, 0x2c, 0x11, 0x84, 0xd4, 0x1b, 0x01, 0xf6, 0xd8,
+	0x5b, 0x02, 0x8e, 0x53, 0x9c, 0x31, 0x07, 0x21, 0x15, 0x47, 0xb0, 0xd9, 0xa3, 0x45, 0x36, 0x7b,
+	0x74, 0x1c, 0x9b, 0x3d, 0x7a, 0x36, 0x9b, 0x3d, 0xaa, 0xb3, 0xe9, 0xc3, 0x82, 0xfe, 0x94, 0x8f,
+	0x1b, 0xcf, 0xfc, 0xd8, 0xd9, 0xd9, 0x30, 0xcd, 0x99, 0x8d, 0xa7, 0x63, 0x21, 0xbb, 0xaf, 0xc3,
+	0x9c, 0x72, 0xdd, 0xc1, 0x96, 0x1f, 0x05, 0xcb, 0xd7, 0x14, 0x9c, 0xb5, 0xc2, 0x44, 0xca, 0x65,
+	0xdb, 0xba, 0x6d, 0x21, 0xa1, 0x3d, 0x5a, 0x20, 0xb4, 0x47, 0xc7, 0x10, 0xda, 0xa3, 0xe3, 0x08,
+	0xfd, 0xc0, 0x82, 0x65, 0xe3, 0x2b, 0x4d, 0xf6, 0x8d, 0x3c, 0x15, 0x1b, 0xdf, 0x2d, 0x73, 0x6e,
+	0x8e, 0x45, 0xc8, 0x0c, 0xf2, 0x32, 0x33, 0xc8, 0x26, 0x59, 0xcb, 0xd3, 0xb5, 0x86, 0xaa, 0x6e,
+	0x0e, 0x9c, 0x54, 0x36, 0x47, 0xfe, 0xd2, 0x86, 0xb3, 0xaa, 0x81, 0x27, 0x6e, 0x0e, 0x44, 0x40,
+	0xd2, 0xa8, 0x9e, 0xf1, 0x15, 0x3b, 0xae, 0xde, 0x84, 0xb7, 0x03, 0x9d, 0x9b, 0x63, 0x11, 0xcc,
+	0xea, 0x19, 0x51, 0x45, 0xb5, 0x50, 0x7c, 0xdf, 0xd3, 0xde, 0x90, 0xd4, 0x29, 0xbc, 0x6a, 0xea,
+	0x6c, 0x9a, 0x67, 0xcd, 0xd5, 0x42, 0x11, 0x0f, 0xd9, 0x7e, 0x1f, 0x96, 0x4c, 0x6f, 0x5b, 0xda,
+	0x9b, 0x69, 0x38, 0x34, 0xbf, 0x2c, 0xea, 0x6c, 0x8d, 0x9b, 0xcf, 0x98, 0xbf, 0xc4, 0x98, 0x5f,
+	0x27, 0xed, 0x34, 0x6a, 0xea, 0x98, 0x22, 0x78, 0x8a, 0x97, 0x5b, 0x78, 0xf0, 0x54, 0xde, 0xc0,
+	0x71, 0x5a, 0x12, 0xc8, 0x1c, 0x3c, 0xc5, 0x24, 0x92, 0xda, 0x87, 0x1a, 0x6f, 0xc6, 0xdb, 0x0b,
+	0xf9, 0x9d, 0x4b, 0x41, 0xc8, 0xce, 0x21, 0x19, 0x9d, 0x65, 0x46, 0x67, 0x9e, 0x00, 0x37, 0x0e,
+	0xce, 0x21, 0x19, 0xac, 0xb0, 0xa5, 0x17, 0xa6, 0x45, 0x85, 0x5d, 0x78, 0xd5, 0xda, 0x69, 0xeb,
+	0xf0, 0x31, 0x15, 0xb6, 0x84, 0x81, 0xe4, 0x7f, 0x09, 0x2a, 0xf8, 0xb6, 0xb7, 0x48, 0xc1, 0xd9,
+	0x7b, 0xf4, 0x22, 0x05, 0x4b, 0x2f, 0xbf, 0x93, 0x16, 0x23, 0x33, 0x47, 0xea, 0x2c, 0xad, 0xfb,
+	0xc7, 0x6c, 0x13, 0xf8, 0x30, 0xaf, 0xbd, 0x32, 0xce, 0xb3, 0xb2, 0xf1, 0x35, 0x77, 0x67, 0xdd,
+	0x30, 0x65, 0xce, 0xca, 0x1a, 0x12, 0xb2, 0xc2, 0x72, 0xc8, 0xfc, 0xc5, 0x01, 0x5e, 0x0e, 0x4d,
+	0xfa, 0xb6, 0x82, 0x43, 0xc6, 0x63, 0x98, 0xcb, 0x21, 0x33, 0x2e, 0xca, 0x81, 0x5f, 0x1f, 0x1b,
+	0xf3, 0x2d, 0x17, 0x5b, 0x0a, 0x2e, 0x63, 0xbe, 0x42, 0xc2, 0x0f, 0x28, 0x63, 0xbf, 0x9d, 0x41,
+	0x5e, 0x61, 0x42, 0x6c, 0x91, 0xf5, 0x5c, 0x88, 0x02, 0x72, 0x26, 0x85, 0xf9, 0x73, 0x27, 0x42,
+	0x8a, 0x49, 0xdf, 0x42, 0x39, 0x9f, 0x14, 0x66, 0x4a, 0x28, 0xc5, 0xa7, 0xd9, 0xb7, 0x9e, 0x4c,
+	0xdf, 0xe0, 0xb0, 0x5f, 0x36, 0x98, 0xe3, 0xdc, 0x47, 0xb6, 0xaf, 0x32, 0x59, 0x5e, 0x22, 0x9b,
+	0x06, 0x8b, 0x68, 0xd5, 0x78, 0x9e, 0x16, 0xb4, 0x8f, 0x0a, 0xc9, 0x69, 0xc1, 0xf8, 0x81, 0x24,
+	0xe7, 0xe6, 0x58, 0x84, 0x49, 0x69, 0x41, 0x43, 0x15, 0x01, 0xcc, 0xf4, 0xa9, 0x29, 0x5b, 0x3a,
+	0x23, 0x9a, 0xbe, 0x8b, 0xe5, 0x6c, 0x8d, 0x9b, 0x37, 0x07, 0x30, 0x13, 0xa6, 0x60, 0x6f, 0xfa,
+	0x64, 0x1b, 0x67, 0x3f, 0xfe, 0x73, 0x75, 0xce, 0xd6, 0xb8, 0x79, 0x33, 0x7b, 0x13, 0x26, 0xb2,
+	0x1f, 0x41, 0xcb, 0xf0, 0xdd, 0x33, 0xfb, 0x3a, 0x6f, 0x7e, 0x8c, 0xf9, 0x84, 0x9b, 0x73, 0x63,
+	0xcc, 0x74, 0xc6, 0x9b, 0x30, 0xde, 0x1b, 0x64, 0x95, 0xc5, 0xd8, 0x22, 0xa2, 0x60, 0x6d, 0xf8,
+	0xc6, 0x19, 0x67, 0x3d, 0xf6, 0xcb, 0x68, 0xce, 0x8d, 0x31, 0xd3, 0x66, 0xd6, 0x06, 0xc4, 0xfb,
+	0xd6, 0xab, 0xcf, 0x6a, 0xec, 0xbb, 0x84, 0x6f, 0xfe, 0xdf, 0x00, 0x05, 0x00, 0x28, 0xa8, 0xc7,
+	0x50, 0x00, 0x00,
 }
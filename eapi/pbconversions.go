@@ -110,6 +110,26 @@ func ToPbLocation(in iapi.LocationSchemeInstance) *pb.Location {
 		AgentLocation: name,
 	}
 }
+func ToPbThresholdProposal(tp *iapi.ThresholdProposal) *pb.ThresholdProposal {
+	rv := &pb.ThresholdProposal{
+		Attester:   tp.Attester.Multihash(),
+		Subject:    tp.Subject.Multihash(),
+		ValidFrom:  tp.ValidFrom().UnixNano() / 1e6,
+		ValidUntil: tp.ValidUntil().UnixNano() / 1e6,
+		Threshold:  int64(tp.Threshold()),
+	}
+	pol, err := iapi.PolicySchemeInstanceFor(&tp.CanonicalForm.TBS.Policy)
+	if err == nil {
+		rv.Policy = ToPbPolicy(pol)
+	}
+	for _, cs := range tp.CoSigners {
+		rv.CoSigners = append(rv.CoSigners, cs.Multihash())
+	}
+	for _, cs := range tp.SignedBy() {
+		rv.SignedBy = append(rv.SignedBy, cs.Multihash())
+	}
+	return rv
+}
 func ToPbPolicy(in iapi.PolicySchemeInstance) *pb.Policy {
 	if tl, ok := in.(*iapi.TrustLevelPolicy); ok {
 		return &pb.Policy{
@@ -334,7 +354,7 @@ func ConvertBodyScheme(in string) iapi.AttestationBodyScheme {
 	if in == "plaintext" {
 		return &iapi.PlaintextBodyScheme{}
 	}
-	if in == serdes.WR1BodyOID.String() {
+	if in == serdes.WR1BodyOID.String() || in == serdes.WR1BodyV2OID.String() {
 		return &iapi.WR1BodyScheme{}
 	}
 	if in == "wr1" {
@@ -362,3 +382,19 @@ func ConvertEntitySecret(ctx context.Context, in *pb.EntitySecret) (*iapi.Entity
 	}
 	return ppae.EntitySecrets, nil
 }
+
+func ConvertMessageRecipient(r *iapi.MessageRecipient) *pb.MessageRecipient {
+	rv := &pb.MessageRecipient{
+		Direct:    r.Direct,
+		Resource:  r.Resource,
+		Partition: r.Partition,
+	}
+	if r.Subject != nil {
+		rv.Subject = r.Subject.Multihash()
+	}
+	if r.Namespace != nil {
+		rv.Namespace = r.Namespace.Multihash()
+		rv.NamespaceLocation = ToPbLocation(r.NamespaceLocation)
+	}
+	return rv
+}
@@ -0,0 +1,36 @@
+// +build linux
+
+package eapi
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+func peerCredentials(conn net.Conn) (UnixPeerInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return UnixPeerInfo{}, fmt.Errorf("not a unix socket connection")
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return UnixPeerInfo{}, err
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return UnixPeerInfo{}, err
+	}
+	if credErr != nil {
+		return UnixPeerInfo{}, credErr
+	}
+	return UnixPeerInfo{
+		UID: int(cred.Uid),
+		GID: int(cred.Gid),
+		PID: int(cred.Pid),
+	}, nil
+}
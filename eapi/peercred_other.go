@@ -0,0 +1,12 @@
+// +build !linux
+
+package eapi
+
+import (
+	"fmt"
+	"net"
+)
+
+func peerCredentials(conn net.Conn) (UnixPeerInfo, error) {
+	return UnixPeerInfo{}, fmt.Errorf("peer credentials are not supported on this platform")
+}
@@ -0,0 +1,86 @@
+package eapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/iapi"
+	"golang.org/x/crypto/ssh"
+)
+
+//SSHConfig makes the agent an SSH certificate authority that issues user
+//certificates to the holders of a permission. It is normally loaded from
+//the [ssh] section of wave.toml
+type SSHConfig struct {
+	//The CA private key, as written by ssh-keygen
+	CAKeyFile string
+	//The permission that must be proven, as
+	//permset:permission@namespace/resource where the permission set and
+	//namespace are entity hashes and the resource is a path.Match pattern
+	//e.g. <hash>:login@<hash>/hosts/web*
+	Permission string
+	//The longest lived certificate that is issued e.g. "8h". Certificates
+	//never outlive the proof. Empty means the proof expiry is the only limit
+	MaxValidity string
+}
+
+//Enabled returns true if the agent should issue SSH certificates
+func (s *SSHConfig) Enabled() bool {
+	return s != nil && s.CAKeyFile != ""
+}
+
+type sshCA struct {
+	signer      ssh.Signer
+	permission  *iapi.SSHPermission
+	maxValidity time.Duration
+}
+
+func parseSSHPermission(s string) (*iapi.SSHPermission, error) {
+	atsplit := strings.SplitN(s, "@", 2)
+	if len(atsplit) != 2 {
+		return nil, fmt.Errorf("expected permset:permission@namespace/resource")
+	}
+	psplit := strings.SplitN(atsplit[0], ":", 2)
+	nssplit := strings.SplitN(atsplit[1], "/", 2)
+	if len(psplit) != 2 || len(nssplit) != 2 {
+		return nil, fmt.Errorf("expected permset:permission@namespace/resource")
+	}
+	rv := &iapi.SSHPermission{
+		PermissionSet: iapi.HashSchemeInstanceFromMultihash([]byte(psplit[0])),
+		Permission:    psplit[1],
+		Namespace:     iapi.HashSchemeInstanceFromMultihash([]byte(nssplit[0])),
+		Resource:      nssplit[1],
+	}
+	if !rv.PermissionSet.Supported() || !rv.Namespace.Supported() {
+		return nil, fmt.Errorf("permission set and namespace must be entity hashes")
+	}
+	return rv, nil
+}
+
+func newSSHCA(cfg *SSHConfig) (*sshCA, error) {
+	pem, err := ioutil.ReadFile(cfg.CAKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read SSH CA key: %v", err)
+	}
+	signer, err := ssh.ParsePrivateKey(pem)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SSH CA key: %v", err)
+	}
+	perm, err := parseSSHPermission(cfg.Permission)
+	if err != nil {
+		return nil, fmt.Errorf("bad SSH permission %q: %v", cfg.Permission, err)
+	}
+	rv := &sshCA{
+		signer:     signer,
+		permission: perm,
+	}
+	if cfg.MaxValidity != "" {
+		rv.maxValidity, err = time.ParseDuration(cfg.MaxValidity)
+		if err != nil {
+			return nil, fmt.Errorf("bad SSH max validity: %v", err)
+		}
+	}
+	return rv, nil
+}
@@ -0,0 +1,223 @@
+package eapi
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+//TLSConfig enables TLS on the agent's TCP listeners. It is normally loaded
+//from the [tls] section of wave.toml
+type TLSConfig struct {
+	//PEM encoded server certificate (chain) and key
+	CertFile string
+	KeyFile  string
+	//If given, client certificates signed by this CA are accepted and
+	//clients are known as cert:<common name>
+	ClientCAFile string
+	//Reject clients that do not present a certificate
+	RequireClientCert bool
+}
+
+//Enabled returns true if TLS should be used
+func (t *TLSConfig) Enabled() bool {
+	return t != nil && t.CertFile != ""
+}
+
+//How often we check if the certificate files have changed
+var TLSReloadInterval = 5 * time.Second
+
+//certReloader keeps the server certificate and client CA pool in sync with
+//the files on disk so that certificates can be rotated without restarting
+type certReloader struct {
+	cfg *TLSConfig
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modtimes  [3]time.Time
+	lastcheck time.Time
+}
+
+func newCertReloader(cfg *TLSConfig) (*certReloader, error) {
+	if cfg.KeyFile == "" {
+		return nil, fmt.Errorf("TLS key file not specified")
+	}
+	if cfg.RequireClientCert && cfg.ClientCAFile == "" {
+		//Without a CA we could not check the certificates we require
+		return nil, fmt.Errorf("TLS requireClientCert needs a client CA file")
+	}
+	rv := &certReloader{cfg: cfg}
+	if err := rv.load(); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (r *certReloader) files() [3]string {
+	return [3]string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile}
+}
+
+//load must be called with the mutex held (or before the reloader is shared)
+func (r *certReloader) load() error {
+	var modtimes [3]time.Time
+	for idx, f := range r.files() {
+		if f == "" {
+			continue
+		}
+		st, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+		modtimes[idx] = st.ModTime()
+	}
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("could not load TLS certificate: %v", err)
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("could not parse TLS certificate: %v", err)
+	}
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		capem, err := ioutil.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("could not load client CA: %v", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(capem) {
+			return fmt.Errorf("client CA file contains no certificates")
+		}
+	}
+	r.cert = &cert
+	r.clientCAs = pool
+	r.modtimes = modtimes
+	return nil
+}
+
+//refresh reloads the files if they have changed. If the new files are
+//broken (e.g. half written) we keep serving the old certificate
+func (r *certReloader) refresh() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.lastcheck) < TLSReloadInterval {
+		return
+	}
+	r.lastcheck = time.Now()
+	changed := false
+	for idx, f := range r.files() {
+		if f == "" {
+			continue
+		}
+		st, err := os.Stat(f)
+		if err != nil {
+			return
+		}
+		if !st.ModTime().Equal(r.modtimes[idx]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := r.load(); err != nil {
+		fmt.Printf("could not reload TLS certificates, keeping the old ones: %v\n", err)
+		return
+	}
+	fmt.Printf("reloaded TLS certificates\n")
+}
+
+func (r *certReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.refresh()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, r.clientCAs
+}
+
+//isSelf returns true if the given certificate is our own server
+//certificate. The HTTP gateway presents it when it connects to the gRPC
+//server
+func (r *certReloader) isSelf(raw []byte) bool {
+	cert, _ := r.current()
+	return bytes.Equal(cert.Certificate[0], raw)
+}
+
+func (r *certReloader) verifyClient(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		//Whether a certificate is required is enforced by ClientAuth
+		return nil
+	}
+	if r.isSelf(rawCerts[0]) {
+		return nil
+	}
+	_, pool := r.current()
+	if pool == nil {
+		return fmt.Errorf("client certificates are not accepted")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for idx, raw := range rawCerts {
+		c, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("bad client certificate: %v", err)
+		}
+		certs[idx] = c
+	}
+	opts := x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, c := range certs[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+//serverConfig returns the TLS configuration for the gRPC and HTTP
+//listeners
+func (r *certReloader) serverConfig() *tls.Config {
+	rv := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+	}
+	if r.cfg.ClientCAFile != "" {
+		//We verify client certificates ourselves so that the CA can be
+		//reloaded and the gateway can identify itself
+		rv.ClientAuth = tls.RequestClientCert
+		if r.cfg.RequireClientCert {
+			rv.ClientAuth = tls.RequireAnyClientCert
+		}
+		rv.VerifyPeerCertificate = r.verifyClient
+	}
+	return rv
+}
+
+//gatewayConfig returns the TLS configuration that the HTTP gateway uses to
+//connect to the gRPC server. The server certificate is pinned rather than
+//verified against a CA, as it is our own
+func (r *certReloader) gatewayConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !r.isSelf(rawCerts[0]) {
+				return fmt.Errorf("gateway connected to an unexpected server")
+			}
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+	}
+}
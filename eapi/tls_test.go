@@ -0,0 +1,190 @@
+package eapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/immesys/wave/eapi/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+//makeCert creates a certificate signed by parent, or a self signed CA if
+//parent is nil
+func makeCert(t *testing.T, cn string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer := &testCert{cert: tmpl, key: key}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer = parent
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer.cert, &key.PublicKey, signer.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) write(t *testing.T, dir string, name string) (certfile string, keyfile string) {
+	certfile = filepath.Join(dir, name+".crt")
+	keyfile = filepath.Join(dir, name+".key")
+	err := ioutil.WriteFile(certfile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600)
+	require.NoError(t, err)
+	kder, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(keyfile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kder}), 0600)
+	require.NoError(t, err)
+	return
+}
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func TestTLSClientCert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wavetls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca := makeCert(t, "test ca", nil, x509.ExtKeyUsageAny)
+	cafile, _ := ca.write(t, dir, "ca")
+	server := makeCert(t, "agent", ca, x509.ExtKeyUsageServerAuth)
+	servercert, serverkey := server.write(t, dir, "server")
+	client := makeCert(t, "alice", ca, x509.ExtKeyUsageClientAuth)
+
+	addr := freeAddr(t)
+	api := NewEAPI(eapi.state)
+	api.StartServerWithConfig(&ServerConfig{
+		ListenIP: addr,
+		Auth: &AuthConfig{
+			Rule: []AuthRule{
+				{
+					Clients:  []string{"cert:alice"},
+					Entities: []string{"*"},
+				},
+			},
+		},
+		TLS: &TLSConfig{
+			CertFile:     servercert,
+			KeyFile:      serverkey,
+			ClientCAFile: cafile,
+		},
+	})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	dial := func(certs []tls.Certificate) pb.WAVEClient {
+		creds := credentials.NewTLS(&tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+		})
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds), grpc.WithBlock(), grpc.WithTimeout(5*time.Second))
+		require.NoError(t, err)
+		return pb.NewWAVEClient(conn)
+	}
+
+	//A client with a certificate is known by its common name
+	conn := dial([]tls.Certificate{{
+		Certificate: [][]byte{client.cert.Raw},
+		PrivateKey:  client.key,
+	}})
+	resp, err := conn.ListLocations(context.Background(), &pb.ListLocationsParams{})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+
+	//A client without a certificate matches no rule
+	conn = dial(nil)
+	_, err = conn.ListLocations(context.Background(), &pb.ListLocationsParams{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestTLSReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wavetls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca := makeCert(t, "test ca", nil, x509.ExtKeyUsageAny)
+	first := makeCert(t, "agent", ca, x509.ExtKeyUsageServerAuth)
+	certfile, keyfile := first.write(t, dir, "server")
+
+	oldInterval := TLSReloadInterval
+	TLSReloadInterval = 0
+	defer func() { TLSReloadInterval = oldInterval }()
+
+	r, err := newCertReloader(&TLSConfig{
+		CertFile: certfile,
+		KeyFile:  keyfile,
+	})
+	require.NoError(t, err)
+	cert, _ := r.current()
+	require.Equal(t, first.cert.Raw, cert.Certificate[0])
+
+	//Make sure the modification time changes
+	time.Sleep(10 * time.Millisecond)
+	second := makeCert(t, "agent", ca, x509.ExtKeyUsageServerAuth)
+	second.write(t, dir, "server")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certfile, future, future))
+	require.NoError(t, os.Chtimes(keyfile, future, future))
+	cert, _ = r.current()
+	require.Equal(t, second.cert.Raw, cert.Certificate[0])
+
+	//A broken certificate leaves the old one in place
+	require.NoError(t, ioutil.WriteFile(certfile, []byte("garbage"), 0600))
+	future = future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(certfile, future, future))
+	cert, _ = r.current()
+	require.Equal(t, second.cert.Raw, cert.Certificate[0])
+}
+
+func TestTLSRequireClientCertWithoutCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wavetls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca := makeCert(t, "test ca", nil, x509.ExtKeyUsageAny)
+	server := makeCert(t, "agent", ca, x509.ExtKeyUsageServerAuth)
+	certfile, keyfile := server.write(t, dir, "server")
+	_, err = newCertReloader(&TLSConfig{
+		CertFile:          certfile,
+		KeyFile:           keyfile,
+		RequireClientCert: true,
+	})
+	require.Error(t, err)
+}
@@ -63,6 +63,11 @@ func cacheRevocationCheck(id string) {
 		cachemu.Unlock()
 	}
 }
+func forgetRevocationCheck(id string) {
+	cachemu.Lock()
+	delete(rvkCache, id)
+	cachemu.Unlock()
+}
 func isCachedRevocationCheck(id string) bool {
 	cachemu.RLock()
 	ts, ok := rvkCache[id]
@@ -18,6 +18,7 @@ type EngineDecryptionContext struct {
 }
 
 var _ iapi.WR1DecryptionContext = &EngineDecryptionContext{}
+var _ iapi.WR1BodyKeyCache = &EngineDecryptionContext{}
 
 //The map is just for IBE keys decrypting the partition. The OAQUE keys must come from E
 func NewEngineDecryptionContext(e *Engine) *EngineDecryptionContext {
@@ -65,7 +66,7 @@ func (dctx *EngineDecryptionContext) SetProverKey(k []byte) {
 }
 func (dctx *EngineDecryptionContext) WR1EntityFromHash(ctx context.Context, hi iapi.HashSchemeInstance, loc iapi.LocationSchemeInstance) (*iapi.Entity, error) {
 	ent, val, err := dctx.e.LookupEntity(dctx.e.ctx, hi, loc)
-	if err != nil {
+	if err != nil || ent == nil {
 		return nil, err
 	}
 	if !val.Valid {
@@ -91,6 +92,18 @@ func (dctx *EngineDecryptionContext) WR1OAQUEKeysForContent(ctx context.Context,
 	}
 	return dctx.e.ws.WR1KeysForP(dctx.e.ctx, dst, slots, onResult)
 }
+func (dctx *EngineDecryptionContext) WR1CachedBodyKey(ctx context.Context, attester iapi.HashSchemeInstance, partition [][]byte) (iapi.SlottedSecretKey, error) {
+	if dctx.e == nil {
+		return nil, nil
+	}
+	return dctx.e.ws.WR1BundleKeyP(dctx.e.ctx, attester, partition)
+}
+func (dctx *EngineDecryptionContext) WR1CacheBodyKey(ctx context.Context, attester iapi.HashSchemeInstance, key iapi.SlottedSecretKey) error {
+	if dctx.e == nil {
+		return nil
+	}
+	return dctx.e.ws.InsertWR1BundleKeyP(dctx.e.ctx, attester, key)
+}
 func (dctx *EngineDecryptionContext) WR1IBEKeysForPartitionLabel(ctx context.Context, dst iapi.HashSchemeInstance, onResult func(k iapi.EntitySecretKeySchemeInstance) bool) error {
 	if dctx.autopopulate {
 		if !dctx.populated[dst.MultihashString()] {
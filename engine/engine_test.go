@@ -14,6 +14,7 @@ import (
 	"github.com/immesys/wave/serdes"
 	"github.com/immesys/wave/storage/memoryserver"
 	"github.com/immesys/wave/storage/overlay"
+	"github.com/immesys/wave/wve"
 	"github.com/stretchr/testify/require"
 )
 
@@ -83,6 +84,62 @@ func TestNameDeclNoEncryption(t *testing.T) {
 	require.Nil(t, lrv)
 }
 
+func TestNameDeclConflictAndPin(t *testing.T) {
+	ctx := context.Background()
+	a, _ := iapi.NewParsedEntitySecrets(ctx, &iapi.PNewEntity{})
+	b, _ := iapi.NewParsedEntitySecrets(ctx, &iapi.PNewEntity{})
+	c, _ := iapi.NewParsedEntitySecrets(ctx, &iapi.PNewEntity{})
+	iapi.SI().PutEntity(ctx, inmem, a.Entity)
+	iapi.SI().PutEntity(ctx, inmem, b.Entity)
+	iapi.SI().PutEntity(ctx, inmem, c.Entity)
+
+	nds := []*iapi.NameDeclaration{}
+	for _, subj := range []*iapi.Entity{b.Entity, c.Entity} {
+		rv, werr := iapi.CreateNameDeclaration(ctx, &iapi.PCreateNameDeclaration{
+			Attester:         a.EntitySecrets,
+			AttesterLocation: inmem,
+			Subject:          subj,
+			SubjectLocation:  inmem,
+			Name:             "foo",
+		})
+		require.NoError(t, werr)
+		ndhash, err := iapi.SI().PutNameDeclaration(ctx, inmem, rv.NameDeclaration)
+		require.NoError(t, err)
+		err = iapi.SI().Enqueue(ctx, inmem, a.Entity.Keccak256HI(), ndhash)
+		require.NoError(t, err)
+		nds = append(nds, rv.NameDeclaration)
+	}
+
+	eng, err := NewEngine(ctx, ws, iapi.SI(), b.EntitySecrets, inmem)
+	require.NoError(t, err)
+	err = eng.MarkEntityInterestingAndQueueForSync(a.Entity, inmem)
+	require.NoError(t, err)
+	err = eng.ResyncEntireGraph(ctx)
+	require.NoError(t, err)
+	select {
+	case <-eng.WaitForEmptySyncQueue():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for empty sync")
+	}
+
+	//Two subjects for the same name is a conflict listing both
+	lrv, werr := eng.LookupName(ctx, a.Entity.Keccak256HI(), "foo")
+	require.Nil(t, lrv)
+	require.NotNil(t, werr)
+	require.EqualValues(t, wve.NameConflict, werr.Code())
+	ce, ok := werr.(*NameCandidatesError)
+	require.True(t, ok)
+	require.Len(t, ce.Candidates, 2)
+
+	//After pinning the name resolves to the pinned subject
+	werr = eng.PinNameDeclaration(ctx, nds[1])
+	require.NoError(t, werr)
+	lrv, werr = eng.LookupName(ctx, a.Entity.Keccak256HI(), "foo")
+	require.NoError(t, werr)
+	require.NotNil(t, lrv)
+	require.Equal(t, c.Entity.Keccak256HI().Multihash(), lrv.Subject.Multihash())
+}
+
 func TestNameDeclOneHop(t *testing.T) {
 	ctx := context.Background()
 	a, _ := iapi.NewParsedEntitySecrets(ctx, &iapi.PNewEntity{})
@@ -858,6 +915,7 @@ func TestAttestationTwoHopRTree(t *testing.T) {
 	require.NoError(t, err)
 	bodyscheme := &iapi.WR1BodyScheme{}
 	kpdc := iapi.NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(NS.Entity)
 	rv, err := iapi.CreateAttestation(context.Background(), &iapi.PCreateAttestation{
 		Policy: pol,
 		//TODO test with this, it fails right now
@@ -987,6 +1045,7 @@ func TestAttestationTwoHopRTreeNoVis(t *testing.T) {
 	require.NoError(t, err)
 	bodyscheme := &iapi.WR1BodyScheme{}
 	kpdc := iapi.NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(NS.Entity)
 	rv, err := iapi.CreateAttestation(context.Background(), &iapi.PCreateAttestation{
 		Policy: pol,
 		//TODO test with this, it fails right now
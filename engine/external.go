@@ -85,6 +85,9 @@ func (e *Engine) LookupAttestationsFrom(ctx context.Context, entityHash iapi.Has
 					return fin(err)
 				}
 			}
+			if filter.Revoked != nil && validity.Revoked != *filter.Revoked {
+				continue
+			}
 			_, subloc := res.Attestation.Subject()
 			select {
 			case rv <- &LookupResult{
@@ -167,6 +170,9 @@ func (e *Engine) LookupAttestationsTo(ctx context.Context, entityHash iapi.HashS
 					return fin(err)
 				}
 			}
+			if filter.Revoked != nil && validity.Revoked != *filter.Revoked {
+				continue
+			}
 			_, subloc := res.Attestation.Subject()
 			select {
 			case rv <- &LookupResult{
@@ -407,6 +413,15 @@ func (e *Engine) CheckAttestation(ctx context.Context, d *iapi.Attestation) (*Va
 			Message:    fmt.Sprintf("Attester invalid: %s", srcvalid.Message),
 		}, nil
 	}
+	if tp := d.ThresholdProposal(); tp != nil {
+		valid := e.ValidCoSigners(ctx, tp)
+		if len(valid) < tp.Threshold() {
+			return &Validity{
+				SrcInvalid: true,
+				Message:    fmt.Sprintf("Only %d of %d required co-signers are valid", len(valid), tp.Threshold()),
+			}, nil
+		}
+	}
 	exp, err := d.Expired()
 	if err != nil {
 		return &Validity{
@@ -430,6 +445,35 @@ func (e *Engine) CheckAttestation(ctx context.Context, d *iapi.Attestation) (*Va
 		Valid: true,
 	}, nil
 }
+//validEntityContext resolves only entities that are currently valid, so
+//that revoked or expired co-signers do not count towards a threshold
+type validEntityContext struct {
+	e *Engine
+}
+
+func (vc *validEntityContext) EntityByHashLoc(ctx context.Context, h iapi.HashSchemeInstance, loc iapi.LocationSchemeInstance) (*iapi.Entity, wve.WVE) {
+	ent, validity, err := vc.e.LookupEntity(ctx, h, loc)
+	if err != nil {
+		return nil, wve.ErrW(wve.LookupFailure, "could not resolve entity", err)
+	}
+	if ent == nil || !validity.Valid {
+		return nil, nil
+	}
+	return ent, nil
+}
+
+//ValidEntityContext returns a context that resolves entities from the
+//perspective of this engine, skipping any that are not valid
+func (e *Engine) ValidEntityContext() iapi.BodyDecryptionContext {
+	return &validEntityContext{e: e}
+}
+
+//ValidCoSigners returns the co-signers of a threshold attestation that are
+//valid and have signed it
+func (e *Engine) ValidCoSigners(ctx context.Context, tp *iapi.ThresholdProposal) []*iapi.Entity {
+	return tp.ValidCoSigners(ctx, e.ValidEntityContext())
+}
+
 func (e *Engine) CheckEntity(ctx context.Context, ent *iapi.Entity) (*Validity, error) {
 	if ent.Expired() {
 		return &Validity{Valid: false, Expired: true, Message: "Entity expired"}, nil
@@ -491,13 +535,44 @@ func (e *Engine) LookupEntity(ctx context.Context, hash iapi.HashSchemeInstance,
 	return ent, val, err
 }
 
+//NameCandidatesError is returned when a name can not be resolved without
+//the user choosing between declarations: either the attester has valid
+//declarations of the name for more than one entity, or it no longer has one
+//for the entity that was pinned. One of the candidates can be accepted with
+//PinNameDeclaration
+type NameCandidatesError struct {
+	wve.WVE
+	Attester   iapi.HashSchemeInstance
+	Name       string
+	Candidates []*iapi.NameDeclaration
+}
+
+func newNameCandidatesError(code int, reason string, attester iapi.HashSchemeInstance, name string, candidates []*iapi.NameDeclaration) *NameCandidatesError {
+	subjects := []string{}
+	for _, nd := range candidates {
+		subjects = append(subjects, nd.Subject.MultihashString())
+	}
+	return &NameCandidatesError{
+		WVE:        wve.Err(code, fmt.Sprintf("%s, candidates are %s", reason, strings.Join(subjects, ", "))),
+		Attester:   attester,
+		Name:       name,
+		Candidates: candidates,
+	}
+}
+
+//LookupName resolves a name declared by the attester. The first time a name
+//resolves, the entity is pinned, and afterwards the name only resolves to
+//that entity until a different declaration is accepted with
+//PinNameDeclaration. An ambiguous or changed name returns a
+//NameCandidatesError
 func (e *Engine) LookupName(ctx context.Context, attester iapi.HashSchemeInstance, name string) (*iapi.NameDeclaration, wve.WVE) {
 	ctx, cancel := context.WithCancel(e.ctx)
 	defer cancel()
 	if !iapi.IsNameDeclarationValid(name) {
 		return nil, wve.Err(wve.InvalidParameter, "names must match [a-z0-9_-]{1,63}")
 	}
-	var rv *iapi.NameDeclaration
+	//The latest valid declaration for each subject
+	candidates := []*iapi.NameDeclaration{}
 	for res := range e.ws.ResolveNameDeclarationsP(ctx, attester, name) {
 		if ctx.Err() != nil {
 			return nil, wve.CtxE(ctx)
@@ -524,18 +599,124 @@ func (e *Engine) LookupName(ctx context.Context, attester iapi.HashSchemeInstanc
 			}
 			continue
 		}
-		if validity.Valid {
-			rv = nd
+		if !validity.Valid {
+			continue
+		}
+		replaced := false
+		for idx, c := range candidates {
+			if c.Subject.MultihashString() == nd.Subject.MultihashString() {
+				candidates[idx] = nd
+				replaced = true
+			}
+		}
+		if !replaced {
+			candidates = append(candidates, nd)
+		}
+	}
+	//Our own names are not pinned, the perspective can change them directly
+	own := attester.MultihashString() == e.Perspective().Entity.Keccak256HI().MultihashString()
+	var pin iapi.HashSchemeInstance
+	if !own {
+		var err error
+		pin, err = e.ws.GetNamePinP(ctx, attester, name)
+		if err != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not load name pin", err)
+		}
+	}
+	var rv *iapi.NameDeclaration
+	if pin != nil {
+		for _, nd := range candidates {
+			if nd.Subject.MultihashString() == pin.MultihashString() {
+				rv = nd
+			}
+		}
+		if rv == nil && len(candidates) != 0 {
+			return nil, newNameCandidatesError(wve.NamePinMismatch, fmt.Sprintf("name %q no longer refers to the pinned entity %s", name, pin.MultihashString()), attester, name, candidates)
+		}
+	} else {
+		if len(candidates) > 1 {
+			return nil, newNameCandidatesError(wve.NameConflict, fmt.Sprintf("name %q is declared for more than one entity", name), attester, name, candidates)
+		}
+		if len(candidates) == 1 {
+			rv = candidates[0]
+			if !own {
+				err := e.ws.SetNamePinP(ctx, attester, name, rv.Subject)
+				if err != nil {
+					return nil, wve.ErrW(wve.InternalError, "could not store name pin", err)
+				}
+			}
 		}
 	}
 	//If we are the attester here, store a reverse name
-	if rv != nil && attester.MultihashString() == e.Perspective().Entity.Keccak256HI().MultihashString() {
+	if rv != nil && own {
 		err := e.ws.InsertReverseName(e.ctx, rv.Name, rv.Subject)
 		if err != nil {
 			return nil, wve.ErrW(wve.InternalError, "could not modify reverse name state", err)
 		}
 	}
-	//Possibly nil, otherwise latest sorted by creation date
+	//Possibly nil, otherwise the latest declaration for the entity
+	return rv, nil
+}
+
+//PinNameDeclaration makes the name in a valid declaration resolve to its
+//subject, replacing any entity that was pinned for the name before
+func (e *Engine) PinNameDeclaration(ctx context.Context, nd *iapi.NameDeclaration) wve.WVE {
+	if !nd.Decoded() {
+		return wve.Err(wve.InvalidParameter, "name declaration is not decrypted")
+	}
+	validity, err := e.CheckNameDeclaration(e.ctx, nd)
+	if err != nil {
+		return wve.ErrW(wve.InternalError, "could not check ND", err)
+	}
+	if !validity.Valid {
+		return wve.Err(wve.InvalidParameter, "name declaration is not valid")
+	}
+	err = e.ws.SetNamePinP(e.ctx, nd.Attester, nd.Name, nd.Subject)
+	if err != nil {
+		return wve.ErrW(wve.InternalError, "could not store name pin", err)
+	}
+	return nil
+}
+
+//NameDeclarationResult is a listed name declaration and its validity
+type NameDeclarationResult struct {
+	NameDeclaration *iapi.NameDeclaration
+	Validity        *Validity
+}
+
+//ListNameDeclarations returns the name declarations made by the attester
+//or, if the attester is nil, those naming the subject. Declarations that
+//have expired or been revoked are included with their validity
+func (e *Engine) ListNameDeclarations(ctx context.Context, attester iapi.HashSchemeInstance, subject iapi.HashSchemeInstance) ([]*NameDeclarationResult, wve.WVE) {
+	subctx, cancel := context.WithCancel(context.WithValue(ctx, consts.PerspectiveKey, e.perspective))
+	defer cancel()
+	var results chan iapi.ResolveResult
+	if attester != nil {
+		results = e.ws.ListNameDeclarationsByAttesterP(subctx, attester)
+	} else {
+		results = e.ws.ListNameDeclarationsBySubjectP(subctx, subject)
+	}
+	rv := []*NameDeclarationResult{}
+	for res := range results {
+		if res.Err != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not list name declarations", res.Err)
+		}
+		nd := res.NameDeclaration
+		validity, err := e.CheckNameDeclaration(subctx, nd)
+		if err != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not check ND", err)
+		}
+		if !validity.Valid {
+			_, err := e.checkNameDeclarationAndSave(subctx, nd, validity)
+			if err != nil {
+				return nil, wve.ErrW(wve.InternalError, "could not check ND", err)
+			}
+		}
+		rv = append(rv, &NameDeclarationResult{
+			NameDeclaration: nd,
+			Validity:        validity,
+		})
+	}
 	return rv, nil
 }
 
@@ -584,6 +765,65 @@ func (e *Engine) LookupFullName(ctx context.Context, attester iapi.HashSchemeIns
 	return rv, nil
 }
 
+//InsertSuccession checks a succession against its predecessor and, if it
+//is valid, stores it in this perspective
+func (e *Engine) InsertSuccession(ctx context.Context, s *iapi.EntitySuccession) wve.WVE {
+	pred, val, err := e.LookupEntity(ctx, s.Predecessor, s.PredecessorLocation)
+	if err != nil {
+		return wve.ErrW(wve.LookupFailure, "could not resolve predecessor", err)
+	}
+	if pred == nil {
+		return wve.Err(wve.LookupFailure, "could not resolve predecessor")
+	}
+	if !val.Valid {
+		return wve.Err(wve.InvalidParameter, "predecessor entity is not valid")
+	}
+	if werr := s.Verify(ctx, pred); werr != nil {
+		return werr
+	}
+	err = e.ws.InsertSuccessionP(e.ctx, s)
+	if err != nil {
+		return wve.ErrW(wve.InternalError, "could not store succession", err)
+	}
+	return nil
+}
+
+//LookupSuccessionsTo returns the successions naming the given entity as the
+//successor that are still within their grace window. A succession ends early
+//if its predecessor or successor is revoked or expires
+func (e *Engine) LookupSuccessionsTo(ctx context.Context, successor iapi.HashSchemeInstance) ([]*iapi.EntitySuccession, wve.WVE) {
+	all, err := e.ws.GetSuccessionsToP(e.ctx, successor)
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "storage problem", err)
+	}
+	now := time.Now()
+	rv := []*iapi.EntitySuccession{}
+	for _, s := range all {
+		if !s.InGrace(now) {
+			continue
+		}
+		_, val, err := e.LookupEntity(ctx, s.Predecessor, s.PredecessorLocation)
+		if err != nil || val == nil || !val.Valid {
+			continue
+		}
+		_, val, err = e.LookupEntity(ctx, s.Successor, s.SuccessorLocation)
+		if err != nil || val == nil || !val.Valid {
+			continue
+		}
+		rv = append(rv, s)
+	}
+	return rv, nil
+}
+
+//LookupSuccessionsFrom returns all known successions of the given entity
+func (e *Engine) LookupSuccessionsFrom(ctx context.Context, predecessor iapi.HashSchemeInstance) ([]*iapi.EntitySuccession, wve.WVE) {
+	rv, err := e.ws.GetSuccessionsFromP(e.ctx, predecessor)
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "storage problem", err)
+	}
+	return rv, nil
+}
+
 func (e *Engine) Perspective() *iapi.EntitySecrets {
 	return e.perspective
 }
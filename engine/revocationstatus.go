@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/immesys/wave/iapi"
+)
+
+//The results of CheckRevocations
+const (
+	RevocationValid   = "valid"
+	RevocationRevoked = "revoked"
+	RevocationUnknown = "unknown"
+)
+
+//How old a stored revocation check can be before CheckRevocations goes to
+//storage, if the caller does not say
+const DefaultRevocationCheckMaxAge = time.Hour
+
+//RevocationStatus is the combined status of an object's revocation options
+type RevocationStatus struct {
+	//One of RevocationValid, RevocationRevoked or RevocationUnknown
+	Status string
+	//The time of the last successful storage check. For valid objects with
+	//several options this is the oldest of them. Zero if never checked
+	LastChecked time.Time
+	//The storage location that gave the answer. Nil if no location did
+	Location iapi.LocationSchemeInstance
+	//Why the status is unknown
+	Message string
+}
+
+//CheckRevocations gets the revocation status of an object's revocation
+//options. Stored checks younger than maxAge are used as is, older ones are
+//refreshed from storage
+func (e *Engine) CheckRevocations(ctx context.Context, revocations []iapi.RevocationSchemeInstance, maxAge time.Duration) (*RevocationStatus, error) {
+	if maxAge <= 0 {
+		maxAge = DefaultRevocationCheckMaxAge
+	}
+	var unknown, valid *RevocationStatus
+	for _, r := range revocations {
+		st, err := e.checkRevocation(ctx, r, maxAge)
+		if err != nil {
+			return nil, err
+		}
+		if st == nil {
+			continue
+		}
+		switch st.Status {
+		case RevocationRevoked:
+			return st, nil
+		case RevocationUnknown:
+			if unknown == nil {
+				unknown = st
+			}
+		default:
+			if valid == nil || st.LastChecked.Before(valid.LastChecked) {
+				valid = st
+			}
+		}
+	}
+	if unknown != nil {
+		return unknown, nil
+	}
+	if valid == nil {
+		return &RevocationStatus{
+			Status:  RevocationValid,
+			Message: "no revocation options",
+		}, nil
+	}
+	return valid, nil
+}
+
+//checkRevocation returns nil for options that do not affect the object
+func (e *Engine) checkRevocation(ctx context.Context, r iapi.RevocationSchemeInstance, maxAge time.Duration) (*RevocationStatus, error) {
+	if !r.Supported() {
+		if r.Critical() {
+			return &RevocationStatus{
+				Status:  RevocationRevoked,
+				Message: "unsupported critical revocation option",
+			}, nil
+		}
+		return nil, nil
+	}
+	rv := &RevocationStatus{
+		Location: iapi.RevocationSchemeLocation(r),
+	}
+	ts, err := e.ws.GetRevocationCheck(ctx, r.Id())
+	if err != nil {
+		return nil, err
+	}
+	if ts != nil {
+		rv.LastChecked = time.Unix(0, *ts)
+		if time.Now().Sub(rv.LastChecked) < maxAge && rv.LastChecked.After(rvkResetTime) {
+			rv.Status = RevocationValid
+			return rv, nil
+		}
+	}
+	isRevoked, err := r.IsRevoked(ctx, iapi.SI())
+	if err != nil {
+		rv.Status = RevocationUnknown
+		rv.Message = err.Error()
+		return rv, nil
+	}
+	now := time.Now()
+	rv.LastChecked = now
+	if isRevoked {
+		//Make sure the engine does not keep using an earlier result
+		forgetRevocationCheck(r.Id())
+		rv.Status = RevocationRevoked
+		return rv, nil
+	}
+	cacheRevocationCheck(r.Id())
+	if err := e.ws.AddRevocationCheck(ctx, r.Id(), now.UnixNano()); err != nil {
+		return nil, err
+	}
+	rv.Status = RevocationValid
+	return rv, nil
+}
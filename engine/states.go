@@ -90,6 +90,15 @@ nextlocation:
 					skip = true
 				}
 			}
+			if !skip {
+				foundSuccession, err := e.ws.GetSuccessionP(e.ctx, object)
+				if err != nil {
+					return 0, err
+				}
+				if foundSuccession != nil {
+					skip = true
+				}
+			}
 
 			if !skip {
 				//The object is probably an attestation or name declaration
@@ -133,6 +142,13 @@ nextlocation:
 						}
 						changes++
 					}
+					if storageResult.Succession != nil {
+						//Successions that do not verify are ignored
+						werr := e.InsertSuccession(e.ctx, storageResult.Succession)
+						if werr == nil {
+							changes++
+						}
+					}
 				}
 			}
 		settoken:
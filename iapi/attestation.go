@@ -29,6 +29,11 @@ type PCreateAttestation struct {
 	ValidFrom *time.Time
 	//If not specified defaults to Now+30 days
 	ValidUntil *time.Time
+
+	//Revocation options in addition to the attester's commitment, e.g. a
+	//RevocationListSchemeInstance naming the namespace's revocation list or
+	//a DelegatedRevocationSchemeInstance naming another revoker
+	Revocations []RevocationSchemeInstance
 }
 type RCreateAttestation struct {
 	DER         []byte
@@ -37,6 +42,17 @@ type RCreateAttestation struct {
 }
 
 func CreateAttestation(ctx context.Context, p *PCreateAttestation) (*RCreateAttestation, wve.WVE) {
+	return createAttestation(ctx, p, nil)
+}
+
+//thresholdBinding replaces the usual signed outer key when creating a
+//threshold attestation
+type thresholdBinding struct {
+	outerKey EntitySecretKeySchemeInstance
+	binding  serdes.ThresholdOuterKey
+}
+
+func createAttestation(ctx context.Context, p *PCreateAttestation, threshold *thresholdBinding) (*RCreateAttestation, wve.WVE) {
 	if p.Policy == nil || p.Attester == nil || p.Subject == nil || p.BodyScheme == nil || p.SubjectLocation == nil || p.AttesterLocation == nil {
 		return nil, wve.Err(wve.MissingParameter, "missing required parameters")
 	}
@@ -88,7 +104,10 @@ func CreateAttestation(ctx context.Context, p *PCreateAttestation) (*RCreateAtte
 	if body.VerifierBody.Validity.NotAfter.Before(body.VerifierBody.Validity.NotBefore) {
 		return nil, wve.Err(wve.InvalidParameter, "invalid validity times")
 	}
-	if body.VerifierBody.Validity.NotAfter.Add(-3 * 365 * 24 * time.Hour).After(body.VerifierBody.Validity.NotBefore) {
+	//The WR1 body scheme limits the range using the partition schedule of
+	//the namespace
+	_, isWR1 := p.BodyScheme.(*WR1BodyScheme)
+	if !isWR1 && body.VerifierBody.Validity.NotAfter.Add(-3*365*24*time.Hour).After(body.VerifierBody.Validity.NotBefore) {
 		return nil, wve.Err(wve.InvalidParameter, "valid range cannot exceed roughly 3 years")
 	}
 	attesterExpiry := p.Attester.Entity.CanonicalForm.TBS.Validity.NotAfter
@@ -103,34 +122,46 @@ func CreateAttestation(ctx context.Context, p *PCreateAttestation) (*RCreateAtte
 	body.VerifierBody.Policy = *externalPolicy
 
 	//Create the ephemeral key for signing
-	eks, err := NewEntityKeySchemeInstance(serdes.EntityEd25519OID, CapAttestation)
-	if err != nil {
-		panic(err)
+	var eks EntitySecretKeySchemeInstance
+	if threshold != nil {
+		eks = threshold.outerKey
+	} else {
+		eks, err = NewEntityKeySchemeInstance(serdes.EntityEd25519OID, CapAttestation)
+		if err != nil {
+			panic(err)
+		}
 	}
 	ekpub := eks.Public()
-	rsecret := p.Attester.Keyring[0].SecretCanonicalForm().Private.Content.(serdes.EntitySecretEd25519)
+	rsecret := p.Attester.primarySecret()
 	rsecret2 := ekpub.CanonicalForm().Key.Content.(serdes.EntityPublicEd25519)
 
 	ro := NewCommitmentRevocationSchemeInstance(p.SubjectLocation, true, rsecret, rsecret2)
 	att.TBS.Revocations = append(att.TBS.Revocations, ro.CanonicalForm())
+	for _, r := range p.Revocations {
+		att.TBS.Revocations = append(att.TBS.Revocations, r.CanonicalForm())
+	}
 
 	outersig := serdes.Ed25519OuterSignature{}
 	outersig.VerifyingKey = []byte(ekpub.(*EntityKey_Ed25519).PublicKey)
 
-	binding := serdes.SignedOuterKey{}
-	binding.TBS.OuterSignatureScheme = serdes.EphemeralEd25519OID
-	binding.TBS.VerifyingKey = outersig.VerifyingKey
-	bindingDER, err := asn1.Marshal(binding.TBS)
-	if err != nil {
-		panic(err)
-	}
-	sig, err := p.Attester.PrimarySigningKey().SignCertify(ctx, bindingDER)
-	if err != nil {
-		panic(err)
+	if threshold != nil {
+		//The attester and co-signers have already signed the binding
+		body.VerifierBody.OuterSignatureBinding = asn1.NewExternal(threshold.binding)
+	} else {
+		binding := serdes.SignedOuterKey{}
+		binding.TBS.OuterSignatureScheme = serdes.EphemeralEd25519OID
+		binding.TBS.VerifyingKey = outersig.VerifyingKey
+		bindingDER, err := asn1.Marshal(binding.TBS)
+		if err != nil {
+			panic(err)
+		}
+		sig, err := p.Attester.PrimarySigningKey().SignCertify(ctx, bindingDER)
+		if err != nil {
+			panic(err)
+		}
+		binding.Signature = sig
+		body.VerifierBody.OuterSignatureBinding = asn1.NewExternal(binding)
 	}
-	binding.Signature = sig
-
-	body.VerifierBody.OuterSignatureBinding = asn1.NewExternal(binding)
 	//This is just an intermediate form
 	att.TBS.Body = asn1.NewExternal(body)
 
@@ -0,0 +1,298 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+)
+
+type PReencryptEntitySecrets struct {
+	DER []byte
+	//The passphrase the keyring is currently encrypted with, if any
+	Passphrase *string
+	//If nil, the keyring is stored in plaintext
+	NewPassphrase *string
+}
+type RReencryptEntitySecrets struct {
+	DER []byte
+	//The keyring scheme the secret used before
+	PreviousScheme asn1.ObjectIdentifier
+}
+
+//ReencryptEntitySecrets decrypts the keyring of an entity secret and
+//encrypts it again under a new passphrase, always using the Argon2id
+//keyring scheme. The entity itself is unchanged
+func ReencryptEntitySecrets(ctx context.Context, p *PReencryptEntitySecrets) (*RReencryptEntitySecrets, wve.WVE) {
+	//This checks the entity and that every key in the keyring is usable
+	if _, werr := ParseEntitySecrets(ctx, &PParseEntitySecrets{
+		DER:        p.DER,
+		Passphrase: p.Passphrase,
+	}); werr != nil {
+		return nil, werr
+	}
+	wo := serdes.WaveWireObject{}
+	if _, err := asn1.Unmarshal(p.DER, &wo.Content); err != nil {
+		return nil, wve.ErrW(wve.MalformedDER, "could not decode", err)
+	}
+	es := wo.Content.Content.(serdes.WaveEntitySecret)
+	krscheme, err := EntityKeyringSchemeInstanceFor(es.Keyring)
+	if err != nil {
+		return nil, wve.ErrW(wve.UnsupportedKeyScheme, "keyring scheme is malformed", err)
+	}
+	previous := es.Keyring.OID
+	kr, err := krscheme.DecryptKeyring(ctx, p.Passphrase)
+	if err != nil {
+		return nil, wve.ErrW(wve.KeyringDecryptFailed, "could not decrypt entity secrets", err)
+	}
+	if p.NewPassphrase == nil {
+		es.Keyring = asn1.NewExternal(*kr)
+	} else {
+		krs, err := NewEntityKeyringSchemeInstance(serdes.KeyringAES256_GCM_Argon2idOID)
+		if err != nil {
+			panic(err)
+		}
+		ex, err := krs.EncryptKeyring(ctx, kr, *p.NewPassphrase)
+		if err != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not encrypt keyring", err)
+		}
+		es.Keyring = *ex
+	}
+	wo.Content = asn1.NewExternal(es)
+	der, err := asn1.Marshal(wo.Content)
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not marshal entity secret", err)
+	}
+	return &RReencryptEntitySecrets{
+		DER:            der,
+		PreviousScheme: previous,
+	}, nil
+}
+
+//EntitySecretShare is a parsed share of a split entity secret
+type EntitySecretShare struct {
+	CanonicalForm *serdes.EntitySecretShare
+	//The entity the secret belongs to
+	Entity HashSchemeInstance
+	//The hash of the plaintext secret DER
+	SecretHash HashSchemeInstance
+}
+
+func (s *EntitySecretShare) Threshold() int {
+	return s.CanonicalForm.TBS.Threshold
+}
+func (s *EntitySecretShare) Total() int {
+	return s.CanonicalForm.TBS.Total
+}
+func (s *EntitySecretShare) Index() int {
+	return s.CanonicalForm.TBS.Index
+}
+
+type PSplitEntitySecrets struct {
+	DER        []byte
+	Passphrase *string
+	//The number of shares needed to recover the secret
+	Threshold int
+	//The number of shares to create, at most 255
+	Total int
+}
+type RSplitEntitySecrets struct {
+	Shares []*EntitySecretShare
+	//The DER of each share, in the same order
+	DERs [][]byte
+}
+
+//SplitEntitySecrets splits an entity secret into shares, any Threshold of
+//which can recover it. The shares contain the keyring in plaintext, so each
+//one must be stored as carefully as the entity secret itself
+func SplitEntitySecrets(ctx context.Context, p *PSplitEntitySecrets) (*RSplitEntitySecrets, wve.WVE) {
+	if p.Threshold < 2 {
+		return nil, wve.Err(wve.InvalidParameter, "threshold must be at least two")
+	}
+	if p.Total < p.Threshold || p.Total > 255 {
+		return nil, wve.Err(wve.InvalidParameter, "total shares must be between the threshold and 255")
+	}
+	plain, werr := ReencryptEntitySecrets(ctx, &PReencryptEntitySecrets{
+		DER:        p.DER,
+		Passphrase: p.Passphrase,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	ent, werr := ParseEntity(ctx, &PParseEntity{DER: plain.DER})
+	if werr != nil {
+		return nil, werr
+	}
+	values, err := shamirSplit(plain.DER, p.Threshold, p.Total)
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not split secret", err)
+	}
+	setID := make([]byte, 16)
+	if _, err := rand.Read(setID); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not generate set ID", err)
+	}
+	secretHash := SHA3.Instance(plain.DER)
+	rv := &RSplitEntitySecrets{}
+	for idx, value := range values {
+		cf := serdes.EntitySecretShare{}
+		cf.TBS.Entity = *ent.Entity.Keccak256HI().CanonicalForm()
+		cf.TBS.SetID = setID
+		cf.TBS.Threshold = p.Threshold
+		cf.TBS.Total = p.Total
+		cf.TBS.Index = idx + 1
+		cf.TBS.Value = value
+		cf.TBS.SecretHash = *secretHash.CanonicalForm()
+		tbsDER, err := asn1.Marshal(cf.TBS)
+		if err != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not marshal share", err)
+		}
+		cf.Checksum = *SHA3.Instance(tbsDER).CanonicalForm()
+		wo := serdes.WaveWireObject{
+			Content: asn1.NewExternal(cf),
+		}
+		der, err := asn1.Marshal(wo.Content)
+		if err != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not marshal share", err)
+		}
+		rv.Shares = append(rv.Shares, &EntitySecretShare{
+			CanonicalForm: &cf,
+			Entity:        ent.Entity.Keccak256HI(),
+			SecretHash:    secretHash,
+		})
+		rv.DERs = append(rv.DERs, der)
+	}
+	return rv, nil
+}
+
+type PParseEntitySecretShare struct {
+	DER []byte
+}
+type RParseEntitySecretShare struct {
+	Share *EntitySecretShare
+}
+
+//ParseEntitySecretShare decodes a share and checks that it is not damaged
+func ParseEntitySecretShare(ctx context.Context, p *PParseEntitySecretShare) (*RParseEntitySecretShare, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	trailing, err := asn1.Unmarshal(p.DER, &wo.Content)
+	if err != nil {
+		return nil, wve.ErrW(wve.MalformedDER, "could not decode share", err)
+	}
+	if len(trailing) != 0 {
+		return nil, wve.Err(wve.MalformedDER, "could not decode share: trailing bytes")
+	}
+	cf, ok := wo.Content.Content.(serdes.EntitySecretShare)
+	if !ok {
+		return nil, wve.Err(wve.UnexpectedObject, "object is not an entity secret share")
+	}
+	tbsDER, err := asn1.Marshal(cf.TBS)
+	if err != nil {
+		return nil, wve.ErrW(wve.MalformedObject, "could not marshal share", err)
+	}
+	checksum := HashSchemeInstanceFor(&cf.Checksum)
+	if !checksum.Supported() {
+		return nil, wve.Err(wve.MalformedObject, "share checksum uses an unsupported hash")
+	}
+	if !HashSchemeInstanceEqual(checksum, HashSchemeFor(cf.Checksum).Instance(tbsDER)) {
+		return nil, wve.Err(wve.MalformedObject, "share is damaged: checksum mismatch")
+	}
+	tbs := &cf.TBS
+	if tbs.Threshold < 2 || tbs.Total < tbs.Threshold || tbs.Total > 255 || tbs.Index < 1 || tbs.Index > tbs.Total {
+		return nil, wve.Err(wve.MalformedObject, "share has invalid parameters")
+	}
+	entity := HashSchemeInstanceFor(&tbs.Entity)
+	secretHash := HashSchemeInstanceFor(&tbs.SecretHash)
+	if !entity.Supported() || !secretHash.Supported() {
+		return nil, wve.Err(wve.MalformedObject, "share uses an unsupported hash")
+	}
+	return &RParseEntitySecretShare{
+		Share: &EntitySecretShare{
+			CanonicalForm: &cf,
+			Entity:        entity,
+			SecretHash:    secretHash,
+		},
+	}, nil
+}
+
+type PRecoverEntitySecrets struct {
+	//The DER of at least Threshold shares from the same split
+	Shares [][]byte
+	//If nil, the recovered keyring is stored in plaintext
+	NewPassphrase *string
+}
+type RRecoverEntitySecrets struct {
+	DER           []byte
+	EntitySecrets *EntitySecrets
+}
+
+//RecoverEntitySecrets combines shares created by SplitEntitySecrets and
+//re-encrypts the recovered entity secret under a new passphrase
+func RecoverEntitySecrets(ctx context.Context, p *PRecoverEntitySecrets) (*RRecoverEntitySecrets, wve.WVE) {
+	if len(p.Shares) == 0 {
+		return nil, wve.Err(wve.MissingParameter, "no shares given")
+	}
+	var first *EntitySecretShare
+	xs := []byte{}
+	ys := [][]byte{}
+	for _, der := range p.Shares {
+		rv, werr := ParseEntitySecretShare(ctx, &PParseEntitySecretShare{DER: der})
+		if werr != nil {
+			return nil, werr
+		}
+		s := rv.Share
+		if first == nil {
+			first = s
+		} else if !bytes.Equal(s.CanonicalForm.TBS.SetID, first.CanonicalForm.TBS.SetID) ||
+			!HashSchemeInstanceEqual(s.Entity, first.Entity) ||
+			!HashSchemeInstanceEqual(s.SecretHash, first.SecretHash) ||
+			s.Threshold() != first.Threshold() || s.Total() != first.Total() {
+			return nil, wve.Err(wve.InvalidParameter, "shares are not all from the same split")
+		}
+		duplicate := false
+		for _, x := range xs {
+			if x == byte(s.Index()) {
+				duplicate = true
+			}
+		}
+		if duplicate {
+			continue
+		}
+		xs = append(xs, byte(s.Index()))
+		ys = append(ys, s.CanonicalForm.TBS.Value)
+	}
+	if len(xs) < first.Threshold() {
+		return nil, wve.Err(wve.InvalidParameter, "not enough distinct shares to recover the secret")
+	}
+	plain, err := shamirCombine(xs, ys)
+	if err != nil {
+		return nil, wve.ErrW(wve.MalformedObject, "could not combine shares", err)
+	}
+	if !HashSchemeInstanceEqual(first.SecretHash, HashSchemeFor(*first.SecretHash.CanonicalForm()).Instance(plain)) {
+		return nil, wve.Err(wve.MalformedObject, "recovered secret failed integrity check")
+	}
+	es, werr := ParseEntitySecrets(ctx, &PParseEntitySecrets{DER: plain})
+	if werr != nil {
+		return nil, werr
+	}
+	if !HashSchemeInstanceEqual(es.Entity.Keccak256HI(), first.Entity) {
+		return nil, wve.Err(wve.MalformedObject, "recovered secret is for a different entity")
+	}
+	der := plain
+	if p.NewPassphrase != nil {
+		rv, werr := ReencryptEntitySecrets(ctx, &PReencryptEntitySecrets{
+			DER:           plain,
+			NewPassphrase: p.NewPassphrase,
+		})
+		if werr != nil {
+			return nil, werr
+		}
+		der = rv.DER
+	}
+	return &RRecoverEntitySecrets{
+		DER:           der,
+		EntitySecrets: es.EntitySecrets,
+	}, nil
+}
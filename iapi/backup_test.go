@@ -0,0 +1,168 @@
+package iapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRecoverEntitySecrets(t *testing.T) {
+	ctx := context.Background()
+	pass := "password"
+	ent, werr := NewEntity(ctx, &PNewEntity{Passphrase: &pass})
+	require.NoError(t, werr)
+
+	_, werr = SplitEntitySecrets(ctx, &PSplitEntitySecrets{
+		DER:       ent.SecretDER,
+		Threshold: 3,
+		Total:     5,
+	})
+	require.Equal(t, wve.PassphraseRequired, werr.Code())
+
+	split, werr := SplitEntitySecrets(ctx, &PSplitEntitySecrets{
+		DER:        ent.SecretDER,
+		Passphrase: &pass,
+		Threshold:  3,
+		Total:      5,
+	})
+	require.NoError(t, werr)
+	require.Equal(t, 5, len(split.DERs))
+
+	//Any three shares recover the secret
+	newpass := "newpassword"
+	for _, set := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+		shares := [][]byte{}
+		for _, idx := range set {
+			shares = append(shares, split.DERs[idx])
+		}
+		rv, werr := RecoverEntitySecrets(ctx, &PRecoverEntitySecrets{
+			Shares:        shares,
+			NewPassphrase: &newpass,
+		})
+		require.NoError(t, werr)
+		_, werr = ParseEntitySecrets(ctx, &PParseEntitySecrets{
+			DER:        rv.DER,
+			Passphrase: &pass,
+		})
+		require.Error(t, werr)
+		es, werr := ParseEntitySecrets(ctx, &PParseEntitySecrets{
+			DER:        rv.DER,
+			Passphrase: &newpass,
+		})
+		require.NoError(t, werr)
+		require.Equal(t, split.Shares[0].Entity.Multihash(), es.Entity.Keccak256HI().Multihash())
+	}
+
+	//Two shares are not enough, even if one is repeated
+	_, werr = RecoverEntitySecrets(ctx, &PRecoverEntitySecrets{
+		Shares: [][]byte{split.DERs[0], split.DERs[1], split.DERs[1]},
+	})
+	require.Error(t, werr)
+}
+
+func TestEntitySecretShareIntegrity(t *testing.T) {
+	ctx := context.Background()
+	ent, werr := NewEntity(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	split, werr := SplitEntitySecrets(ctx, &PSplitEntitySecrets{
+		DER:       ent.SecretDER,
+		Threshold: 2,
+		Total:     3,
+	})
+	require.NoError(t, werr)
+	other, werr := SplitEntitySecrets(ctx, &PSplitEntitySecrets{
+		DER:       ent.SecretDER,
+		Threshold: 2,
+		Total:     3,
+	})
+	require.NoError(t, werr)
+
+	//Shares from different splits cannot be mixed
+	_, werr = RecoverEntitySecrets(ctx, &PRecoverEntitySecrets{
+		Shares: [][]byte{split.DERs[0], other.DERs[1]},
+	})
+	require.Equal(t, wve.InvalidParameter, werr.Code())
+
+	//A damaged share is detected
+	damaged := make([]byte, len(split.DERs[1]))
+	copy(damaged, split.DERs[1])
+	damaged[len(damaged)/2] ^= 0x01
+	_, werr = ParseEntitySecretShare(ctx, &PParseEntitySecretShare{DER: damaged})
+	require.Error(t, werr)
+
+	rv, werr := RecoverEntitySecrets(ctx, &PRecoverEntitySecrets{
+		Shares: [][]byte{split.DERs[2], split.DERs[0]},
+	})
+	require.NoError(t, werr)
+	require.Equal(t, ent.SecretDER, rv.DER)
+}
+
+func TestReencryptEntitySecretsFromPBKDF2(t *testing.T) {
+	ctx := context.Background()
+	ent, werr := NewEntity(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+
+	//Build a secret with the old PBKDF2 keyring
+	pass := "password"
+	wo := serdes.WaveWireObject{}
+	_, err := asn1.Unmarshal(ent.SecretDER, &wo.Content)
+	require.NoError(t, err)
+	es := wo.Content.Content.(serdes.WaveEntitySecret)
+	kr := es.Keyring.Content.(serdes.EntityKeyring)
+	ex, err := (&AESKeyring{}).EncryptKeyring(ctx, &kr, pass)
+	require.NoError(t, err)
+	es.Keyring = *ex
+	wo.Content = asn1.NewExternal(es)
+	oldDER, err := asn1.Marshal(wo.Content)
+	require.NoError(t, err)
+
+	newpass := "newpassword"
+	rv, werr := ReencryptEntitySecrets(ctx, &PReencryptEntitySecrets{
+		DER:           oldDER,
+		Passphrase:    &pass,
+		NewPassphrase: &newpass,
+	})
+	require.NoError(t, werr)
+	require.True(t, rv.PreviousScheme.Equal(serdes.KeyringAES128_GCM_PBKDF2OID))
+
+	_, werr = ParseEntitySecrets(ctx, &PParseEntitySecrets{DER: rv.DER})
+	require.Equal(t, wve.PassphraseRequired, werr.Code())
+	_, werr = ParseEntitySecrets(ctx, &PParseEntitySecrets{DER: rv.DER, Passphrase: &pass})
+	require.Equal(t, wve.KeyringDecryptFailed, werr.Code())
+	pes, werr := ParseEntitySecrets(ctx, &PParseEntitySecrets{DER: rv.DER, Passphrase: &newpass})
+	require.NoError(t, werr)
+	require.Equal(t, len(kr.Keys), len(pes.EntitySecrets.Keyring))
+
+	wo = serdes.WaveWireObject{}
+	_, err = asn1.Unmarshal(rv.DER, &wo.Content)
+	require.NoError(t, err)
+	require.True(t, wo.Content.Content.(serdes.WaveEntitySecret).Keyring.OID.Equal(serdes.KeyringAES256_GCM_Argon2idOID))
+}
+
+func TestArgon2idKeyringLimits(t *testing.T) {
+	ctx := context.Background()
+	pass := "password"
+	ex, err := (&Argon2idKeyring{}).EncryptKeyring(ctx, &serdes.EntityKeyring{}, pass)
+	require.NoError(t, err)
+	ct := ex.Content.(serdes.KeyringArgon2idCiphertext)
+
+	decrypt := func(ct serdes.KeyringArgon2idCiphertext) error {
+		kr, err := EntityKeyringSchemeInstanceFor(asn1.NewExternal(ct))
+		require.NoError(t, err)
+		_, err = kr.DecryptKeyring(ctx, &pass)
+		return err
+	}
+	require.NoError(t, decrypt(ct))
+
+	//Parameters above the caps are rejected before any work is done
+	over := ct
+	over.Memory = maxArgon2idMemory + 1
+	require.Error(t, decrypt(over))
+	over = ct
+	over.Time = maxArgon2idTime + 1
+	require.Error(t, decrypt(over))
+}
@@ -22,7 +22,7 @@ func AttestationBodySchemeFor(ex *asn1.External) AttestationBodyScheme {
 	if ex.OID.Equal(serdes.UnencryptedBodyOID) {
 		return &PlaintextBodyScheme{}
 	}
-	if ex.OID.Equal(serdes.WR1BodyOID) {
+	if ex.OID.Equal(serdes.WR1BodyOID) || ex.OID.Equal(serdes.WR1BodyV2OID) {
 		return &WR1BodyScheme{}
 	}
 	return &UnsupportedBodyScheme{}
@@ -86,6 +86,8 @@ type WR1Extra struct {
 	ProverBodyKey   []byte
 
 	EnvelopeKey []byte
+	//The partition schedule of the namespace
+	Schedule *WR1PartitionSchedule
 	//For NameDecl only
 	Namespace         HashSchemeInstance
 	NamespaceLocation LocationSchemeInstance
@@ -107,10 +109,20 @@ func (w *WR1BodyScheme) DecryptBody(ctx context.Context, dc BodyDecryptionContex
 	if !ok {
 		incomingExtra = nil
 	}
+	schedule := DefaultWR1PartitionSchedule
 	wr1body, ok := canonicalForm.TBS.Body.Content.(serdes.WR1BodyCiphertext)
 	if !ok {
-		//fmt.Printf("dc A1\n")
-		return nil, nil, ErrDecryptBodyMalformed
+		wr1bodyV2, ok := canonicalForm.TBS.Body.Content.(serdes.WR1BodyCiphertextV2)
+		if !ok {
+			//fmt.Printf("dc A1\n")
+			return nil, nil, ErrDecryptBodyMalformed
+		}
+		var werr wve.WVE
+		schedule, werr = NewWR1PartitionSchedule(&wr1bodyV2.Schedule)
+		if werr != nil {
+			return nil, nil, ErrDecryptBodyMalformed
+		}
+		wr1body = wr1bodyV2.Ciphertext
 	}
 	wr1dctx, ok := dc.(WR1DecryptionContext)
 	if !ok {
@@ -244,7 +256,7 @@ func (w *WR1BodyScheme) DecryptBody(ctx context.Context, dc BodyDecryptionContex
 
 	//fmt.Printf("dc2 1\n")
 	//We know the partition labels now
-	rvextra := &WR1Extra{Partition: realpartition, EnvelopeKey: envelopeKey}
+	rvextra := &WR1Extra{Partition: realpartition, EnvelopeKey: envelopeKey, Schedule: schedule}
 	extra = rvextra
 	if explicitProverBodyKey != nil {
 		bodyKeys = explicitProverBodyKey[28:]
@@ -343,6 +355,28 @@ func isPolicyE2EE(p PolicySchemeInstance) (HashSchemeInstance, LocationSchemeIns
 	return nil, nil, false
 }
 
+//policySchedule returns the partition schedule of the namespace that the
+//policy concerns. Keys for a namespace must be partitioned with its own
+//schedule, so it is an error if the namespace cannot be resolved
+func policySchedule(ctx context.Context, ec WR1BodyEncryptionContext, attester *Entity, policy PolicySchemeInstance) (*WR1PartitionSchedule, wve.WVE) {
+	rtree, ok := policy.(*RTreePolicy)
+	if !ok {
+		return DefaultWR1PartitionSchedule, nil
+	}
+	if attester.Keccak256HI().MultihashString() == rtree.WR1DomainEntity().MultihashString() {
+		return attester.WR1_PartitionSchedule(), nil
+	}
+	loc := LocationSchemeInstanceFor(&rtree.SerdesForm.NamespaceLocation)
+	ns, err := ec.WR1EntityFromHash(ctx, rtree.WR1DomainEntity(), loc)
+	if err != nil {
+		return nil, wve.ErrW(wve.LookupFailure, "could not resolve policy namespace", err)
+	}
+	if ns == nil {
+		return nil, wve.Err(wve.LookupFailure, "could not resolve policy namespace")
+	}
+	return ns.WR1_PartitionSchedule(), nil
+}
+
 func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionContext, attester *EntitySecrets, subject *Entity, intermediateForm *serdes.WaveAttestation, policy PolicySchemeInstance) (encryptedForm *serdes.WaveAttestation, extra interface{}, err error) {
 	//fmt.Printf("encrypt body called\n")
 	ec := ecp.(WR1BodyEncryptionContext)
@@ -363,9 +397,13 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 	if visibilityEntity != nil {
 		visibilityID = visibilityEntity.MultihashString()
 	}
+	schedule, werr := policySchedule(ctx, ec, attester.Entity, policy)
+	if werr != nil {
+		return nil, nil, werr
+	}
 	bodySlots, err := CalculateWR1Partition(plaintextBody.VerifierBody.Validity.NotBefore,
 		plaintextBody.VerifierBody.Validity.NotAfter,
-		policy.WR1PartitionPrefix(false))
+		policy.WR1PartitionPrefix(false), schedule)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -406,7 +444,7 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 	//Get the bundle, but it is empty (no keys)
 	partitions, delegatedBundle, err := CalculateEmptyKeyBundleEntries(plaintextBody.VerifierBody.Validity.NotBefore,
 		plaintextBody.VerifierBody.Validity.NotAfter,
-		policy.WR1PartitionPrefix(false))
+		policy.WR1PartitionPrefix(false), schedule)
 	if err != nil {
 		fmt.Printf("K 2\n")
 		return nil, nil, err
@@ -418,7 +456,7 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 		var err error
 		e2ePartitions, e2eDelegatedBundle, err = CalculateEmptyKeyBundleEntries(plaintextBody.VerifierBody.Validity.NotBefore,
 			plaintextBody.VerifierBody.Validity.NotAfter,
-			policy.WR1PartitionPrefix(true))
+			policy.WR1PartitionPrefix(true), schedule)
 		if err != nil {
 			fmt.Printf("K 1\n")
 			return nil, nil, err
@@ -427,7 +465,8 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 	e2eKeyOkay := make([]bool, len(e2eDelegatedBundle))
 
 	//Generating these delegated keys is a bit of a pain. Sequentially its about 1.5s on my machine.
-	//If we split it over multiple cores it goes down to about 250 ms
+	//If we split it over multiple cores it goes down to about 250 ms. If the context
+	//is a WR1BodyKeyCache, keys from earlier attestations are reused
 	then := time.Now()
 	if true {
 		workers := runtime.NumCPU()
@@ -462,7 +501,7 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 				for _, k := range batches[i] {
 					batchpartitions = append(batchpartitions, partitions[k])
 				}
-				kz, err := attester.CalculateWR1Batch(batchpartitions, true)
+				kz, _, err := wr1BundleKeys(ctx, ecp, attester, batchpartitions)
 				if err != nil {
 					panic(err)
 				}
@@ -671,8 +710,16 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 		return nil, nil, err
 	}
 	ciphertext.EnvelopeCiphertext = aesGCMEncrypt(envelopeSymKey[:16], envelopeDER, envelopeSymKey[16:])
-	//Return the intermediate form with the body replaced
-	intermediateForm.TBS.Body = asn1.NewExternal(*ciphertext)
+	//Return the intermediate form with the body replaced. Namespaces with
+	//their own partition schedule need the second version of the body
+	if schedule.IsDefault() {
+		intermediateForm.TBS.Body = asn1.NewExternal(*ciphertext)
+	} else {
+		intermediateForm.TBS.Body = asn1.NewExternal(serdes.WR1BodyCiphertextV2{
+			Schedule:   *schedule.CanonicalForm(),
+			Ciphertext: *ciphertext,
+		})
+	}
 	explicitProverBodyKey := make([]byte, 28*3)
 	copy(explicitProverBodyKey[0:28], envelopeSymKey)
 	copy(explicitProverBodyKey[28:], bodyKeys)
@@ -681,6 +728,7 @@ func (w *WR1BodyScheme) EncryptBody(ctx context.Context, ecp BodyEncryptionConte
 		Partition:       bodySlots,
 		VerifierBodyKey: bodyKeys[28:56],
 		ProverBodyKey:   explicitProverBodyKey,
+		Schedule:        schedule,
 	}
 	return intermediateForm, rvextra, nil
 }
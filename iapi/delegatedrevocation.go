@@ -0,0 +1,317 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+)
+
+//DelegatedRevocationQueue returns the storage queue that revocation
+//statements for an ID are published to
+func DelegatedRevocationQueue(scheme HashScheme, revoker HashSchemeInstance, id []byte) HashSchemeInstance {
+	content := []byte("delegatedrevocation")
+	content = append(content, revoker.Multihash()...)
+	content = append(content, id...)
+	return scheme.Instance(content)
+}
+
+type DelegatedRevocationSchemeInstance struct {
+	SerdesForm *serdes.RevocationOption
+	DRBody     *serdes.DelegatedRevocation
+}
+
+//NewDelegatedRevocationSchemeInstance creates a revocation option with a
+//new random ID that the given revoker can trigger
+func NewDelegatedRevocationSchemeInstance(revoker HashSchemeInstance, revokerLocation LocationSchemeInstance, critical bool) *DelegatedRevocationSchemeInstance {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	DB := serdes.DelegatedRevocation{
+		Revoker:         *revoker.CanonicalForm(),
+		RevokerLocation: *revokerLocation.CanonicalForm(),
+		ID:              id,
+	}
+	SDF := serdes.RevocationOption{
+		Critical: critical,
+		Scheme:   asn1.NewExternal(DB),
+	}
+	return &DelegatedRevocationSchemeInstance{
+		SerdesForm: &SDF,
+		DRBody:     &DB,
+	}
+}
+
+func (rs *DelegatedRevocationSchemeInstance) Supported() bool {
+	return true
+}
+
+func (rs *DelegatedRevocationSchemeInstance) Critical() bool {
+	return rs.SerdesForm.Critical
+}
+
+func (rs *DelegatedRevocationSchemeInstance) CanonicalForm() serdes.RevocationOption {
+	return *rs.SerdesForm
+}
+
+func (rs *DelegatedRevocationSchemeInstance) Id() string {
+	return "dr:" + base64.URLEncoding.EncodeToString(rs.DRBody.ID)
+}
+
+func (rs *DelegatedRevocationSchemeInstance) Revoker() HashSchemeInstance {
+	return HashSchemeInstanceFor(&rs.DRBody.Revoker)
+}
+
+func (rs *DelegatedRevocationSchemeInstance) RevokerLocation() LocationSchemeInstance {
+	return LocationSchemeInstanceFor(&rs.DRBody.RevokerLocation)
+}
+
+//How long a check that found no revocation statement is relied on before
+//the queue is read again
+var DelegatedRevocationCacheTime = 5 * time.Minute
+
+type delegatedRevocationCacheEntry struct {
+	//Once a statement is found the option stays revoked, as long as the
+	//revoker is still valid
+	revoked bool
+	fetched time.Time
+	//The queue is append only, so a refresh resumes from here
+	token         string
+	revokerEntity *Entity
+}
+
+var delegatedRevocationCache = make(map[string]*delegatedRevocationCacheEntry)
+var delegatedRevocationCacheMu sync.Mutex
+
+//ForgetDelegatedRevocation makes the next check of a cached option go to
+//storage, used after publishing a revocation statement
+func ForgetDelegatedRevocation(queue HashSchemeInstance, loc LocationSchemeInstance) {
+	key := delegatedRevocationCacheKey(queue, loc)
+	delegatedRevocationCacheMu.Lock()
+	if cached, ok := delegatedRevocationCache[key]; ok {
+		cached.fetched = time.Time{}
+	}
+	delegatedRevocationCacheMu.Unlock()
+}
+
+func delegatedRevocationCacheKey(queue HashSchemeInstance, loc LocationSchemeInstance) string {
+	lochash := loc.IdHash()
+	return queue.MultihashString() + "/" + base64.URLEncoding.EncodeToString(lochash[:])
+}
+
+func cacheDelegatedRevocation(key string, entry *delegatedRevocationCacheEntry) {
+	delegatedRevocationCacheMu.Lock()
+	defer delegatedRevocationCacheMu.Unlock()
+	if _, ok := delegatedRevocationCache[key]; !ok && len(delegatedRevocationCache) >= revocationListCacheSize {
+		oldest := ""
+		for k, e := range delegatedRevocationCache {
+			if oldest == "" || e.fetched.Before(delegatedRevocationCache[oldest].fetched) {
+				oldest = k
+			}
+		}
+		delete(delegatedRevocationCache, oldest)
+	}
+	delegatedRevocationCache[key] = entry
+}
+
+//IsRevoked looks for a statement from the revoker in the option's queue.
+//Results are cached like revocation lists. It is an error if the revoker
+//of a found statement is no longer valid
+func (rs *DelegatedRevocationSchemeInstance) IsRevoked(ctx context.Context, s StorageInterface) (bool, wve.WVE) {
+	revoker := rs.Revoker()
+	loc := rs.RevokerLocation()
+	if !revoker.Supported() || !loc.Supported() {
+		return rs.Critical(), nil
+	}
+	scheme, err := s.HashSchemeFor(loc)
+	if err != nil {
+		return false, wve.ErrW(wve.StorageError, "could not get revoker location hash scheme", err)
+	}
+	queue := DelegatedRevocationQueue(scheme, revoker, rs.DRBody.ID)
+	key := delegatedRevocationCacheKey(queue, loc)
+	delegatedRevocationCacheMu.Lock()
+	cached, ok := delegatedRevocationCache[key]
+	entry := &delegatedRevocationCacheEntry{}
+	if ok {
+		*entry = *cached
+	}
+	delegatedRevocationCacheMu.Unlock()
+	if !entry.revoked && !(ok && time.Now().Sub(entry.fetched) < DelegatedRevocationCacheTime) {
+		for !entry.revoked {
+			object, nextToken, err := s.IterateQeueue(ctx, loc, queue, entry.token)
+			if err == ErrNoMore || (err == nil && object == nil) {
+				break
+			}
+			if err != nil {
+				return false, wve.ErrW(wve.StorageError, "could not read revocation statement queue", err)
+			}
+			entry.token = nextToken
+			der, err := s.GetBlob(ctx, loc, object)
+			if err != nil {
+				return false, wve.ErrW(wve.StorageError, "could not get revocation statement", err)
+			}
+			rv, werr := ParseRevocationStatement(ctx, &PParseRevocationStatement{DER: der})
+			if werr != nil {
+				//Anyone can add to the queue, so junk is skipped
+				continue
+			}
+			st := rv.Statement
+			if !HashSchemeInstanceEqual(st.Revoker, revoker) || !bytes.Equal(st.ID(), rs.DRBody.ID) {
+				continue
+			}
+			if entry.revokerEntity == nil {
+				entry.revokerEntity, err = s.GetEntity(ctx, loc, revoker)
+				if err != nil {
+					return false, wve.ErrW(wve.StorageError, "could not get revoker entity", err)
+				}
+				if entry.revokerEntity == nil {
+					return false, wve.Err(wve.LookupFailure, "could not find revoker entity")
+				}
+			}
+			entry.revoked = st.Verify(ctx, entry.revokerEntity) == nil
+		}
+		entry.fetched = time.Now()
+		cacheDelegatedRevocation(key, entry)
+	}
+	if !entry.revoked {
+		return false, nil
+	}
+	if werr := checkRevoker(ctx, s, entry.revokerEntity); werr != nil {
+		return false, werr
+	}
+	return true, nil
+}
+
+//RevocationStatement is a delegated revoker's signed statement that an
+//ID is revoked
+type RevocationStatement struct {
+	CanonicalForm *serdes.WaveRevocationStatement
+	Revoker       HashSchemeInstance
+}
+
+func (st *RevocationStatement) SetCanonicalForm(cf *serdes.WaveRevocationStatement) wve.WVE {
+	revoker := HashSchemeInstanceFor(&cf.TBS.Revoker)
+	if !revoker.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported revoker hash scheme")
+	}
+	st.CanonicalForm = cf
+	st.Revoker = revoker
+	return nil
+}
+func (st *RevocationStatement) DER() ([]byte, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	wo.Content = asn1.NewExternal(*st.CanonicalForm)
+	rv, err := asn1.Marshal(wo.Content)
+	if err != nil {
+		return nil, wve.Err(wve.MalformedDER, "could not produce DER")
+	}
+	return rv, nil
+}
+func (st *RevocationStatement) ID() []byte {
+	return st.CanonicalForm.TBS.ID
+}
+
+//Verify checks that the statement was signed by the given revoker
+func (st *RevocationStatement) Verify(ctx context.Context, revoker *Entity) wve.WVE {
+	if !HashSchemeInstanceEqual(revoker.Keccak256HI(), st.Revoker) {
+		return wve.Err(wve.InvalidParameter, "entity is not the revoker named in the statement")
+	}
+	tbs, err := asn1.Marshal(st.CanonicalForm.TBS)
+	if err != nil {
+		return wve.Err(wve.MalformedObject, "could not marshal revocation statement")
+	}
+	err = revoker.VerifyingKey.VerifyCertify(ctx, tbs, st.CanonicalForm.Signature)
+	if err != nil {
+		return wve.Err(wve.InvalidSignature, "revocation statement signature failed check")
+	}
+	return nil
+}
+
+type PCreateRevocationStatement struct {
+	Revoker    *EntitySecrets
+	Revocation *DelegatedRevocationSchemeInstance
+}
+type RCreateRevocationStatement struct {
+	Statement *RevocationStatement
+	DER       []byte
+}
+
+//CreateRevocationStatement revokes a delegated revocation option. The
+//statement takes effect once it is enqueued in the option's
+//DelegatedRevocationQueue at the revoker location
+func CreateRevocationStatement(ctx context.Context, p *PCreateRevocationStatement) (*RCreateRevocationStatement, wve.WVE) {
+	if p.Revoker == nil || p.Revocation == nil {
+		return nil, wve.Err(wve.MissingParameter, "revoker and revocation must be specified")
+	}
+	revoker := p.Revoker.Entity.Keccak256HI()
+	if !HashSchemeInstanceEqual(revoker, p.Revocation.Revoker()) {
+		return nil, wve.Err(wve.InvalidParameter, "entity is not the revoker named in the revocation option")
+	}
+	cf := serdes.WaveRevocationStatement{}
+	cf.TBS.Revoker = *revoker.CanonicalForm()
+	cf.TBS.ID = p.Revocation.DRBody.ID
+	cf.TBS.Created = time.Now()
+	tbsDER, err := asn1.Marshal(cf.TBS)
+	if err != nil {
+		panic(err)
+	}
+	sig, err := p.Revoker.PrimarySigningKey().SignCertify(ctx, tbsDER)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not sign revocation statement", err)
+	}
+	cf.Signature = sig
+
+	st := &RevocationStatement{}
+	if werr := st.SetCanonicalForm(&cf); werr != nil {
+		return nil, werr
+	}
+	der, werr := st.DER()
+	if werr != nil {
+		return nil, werr
+	}
+	return &RCreateRevocationStatement{
+		Statement: st,
+		DER:       der,
+	}, nil
+}
+
+type PParseRevocationStatement struct {
+	DER []byte
+	//If present, the statement signature is checked against this entity
+	Revoker *Entity
+}
+type RParseRevocationStatement struct {
+	Statement   *RevocationStatement
+	IsMalformed bool
+}
+
+func ParseRevocationStatement(ctx context.Context, p *PParseRevocationStatement) (*RParseRevocationStatement, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	rest, err := asn1.Unmarshal(p.DER, &wo.Content)
+	if err != nil || len(rest) != 0 {
+		return &RParseRevocationStatement{IsMalformed: true}, wve.Err(wve.MalformedDER, "DER did not parse")
+	}
+	cf, ok := wo.Content.Content.(serdes.WaveRevocationStatement)
+	if !ok {
+		return &RParseRevocationStatement{IsMalformed: true}, wve.Err(wve.UnexpectedObject, "DER is not a wave revocation statement")
+	}
+	st := &RevocationStatement{}
+	if werr := st.SetCanonicalForm(&cf); werr != nil {
+		return &RParseRevocationStatement{IsMalformed: true}, werr
+	}
+	if p.Revoker != nil {
+		if werr := st.Verify(ctx, p.Revoker); werr != nil {
+			return &RParseRevocationStatement{IsMalformed: true}, werr
+		}
+	}
+	return &RParseRevocationStatement{
+		Statement: st,
+	}, nil
+}
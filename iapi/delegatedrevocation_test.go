@@ -0,0 +1,124 @@
+package iapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationStatement(t *testing.T) {
+	ctx := context.Background()
+	revoker, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	other, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	loc := NewLocationSchemeInstanceURL("http://localhost", 1)
+	dr := NewDelegatedRevocationSchemeInstance(revoker.Entity.Keccak256HI(), loc, true)
+
+	//The option survives a round trip through its canonical form
+	cf := dr.CanonicalForm()
+	parsedOption, ok := RevocationSchemeInstanceFor(&cf).(*DelegatedRevocationSchemeInstance)
+	require.True(t, ok)
+	require.Equal(t, dr.Id(), parsedOption.Id())
+
+	_, werr = CreateRevocationStatement(ctx, &PCreateRevocationStatement{
+		Revoker:    other.EntitySecrets,
+		Revocation: dr,
+	})
+	require.Error(t, werr)
+
+	rv, werr := CreateRevocationStatement(ctx, &PCreateRevocationStatement{
+		Revoker:    revoker.EntitySecrets,
+		Revocation: dr,
+	})
+	require.NoError(t, werr)
+	parsed, werr := ParseRevocationStatement(ctx, &PParseRevocationStatement{
+		DER:     rv.DER,
+		Revoker: revoker.Entity,
+	})
+	require.NoError(t, werr)
+	require.Equal(t, dr.DRBody.ID, parsed.Statement.ID())
+
+	parsed, werr = ParseRevocationStatement(ctx, &PParseRevocationStatement{
+		DER:     rv.DER,
+		Revoker: other.Entity,
+	})
+	require.Error(t, werr)
+	require.True(t, parsed.IsMalformed)
+
+	//A tampered statement does not verify
+	forged := *rv.Statement.CanonicalForm
+	forged.Signature = append([]byte{}, forged.Signature...)
+	forged.Signature[0] ^= 1
+	st := &RevocationStatement{}
+	require.NoError(t, st.SetCanonicalForm(&forged))
+	require.Error(t, st.Verify(ctx, revoker.Entity))
+}
+
+func publishRevocationStatement(t *testing.T, s *memoryQueueStorage, revoker *EntitySecrets, dr *DelegatedRevocationSchemeInstance) {
+	rv, werr := CreateRevocationStatement(context.Background(), &PCreateRevocationStatement{
+		Revoker:    revoker,
+		Revocation: dr,
+	})
+	require.NoError(t, werr)
+	hi := KECCAK256.Instance(rv.DER)
+	s.blobs[hi.MultihashString()] = rv.DER
+	queue := DelegatedRevocationQueue(KECCAK256, dr.Revoker(), dr.DRBody.ID)
+	s.queues[queue.MultihashString()] = append(s.queues[queue.MultihashString()], hi)
+	s.entities[revoker.Entity.Keccak256HI().MultihashString()] = revoker.Entity
+	ForgetDelegatedRevocation(queue, dr.RevokerLocation())
+}
+
+func TestDelegatedRevocationIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	loc := NewLocationSchemeInstanceURL("test", 1)
+	s := newMemoryQueueStorage()
+	revoker, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dr := NewDelegatedRevocationSchemeInstance(revoker.Entity.Keccak256HI(), loc, true)
+
+	revoked, werr := dr.IsRevoked(ctx, s)
+	require.NoError(t, werr)
+	require.False(t, revoked)
+
+	//The result is cached, so the queue is not read again
+	s.iterated = 0
+	revoked, werr = dr.IsRevoked(ctx, s)
+	require.NoError(t, werr)
+	require.False(t, revoked)
+	require.Equal(t, 0, s.iterated)
+
+	publishRevocationStatement(t, s, revoker.EntitySecrets, dr)
+	revoked, werr = dr.IsRevoked(ctx, s)
+	require.NoError(t, werr)
+	require.True(t, revoked)
+	s.iterated = 0
+	revoked, werr = dr.IsRevoked(ctx, s)
+	require.NoError(t, werr)
+	require.True(t, revoked)
+	require.Equal(t, 0, s.iterated)
+
+	//Once the revoker is revoked its statements are no longer relied on
+	authority, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	opt := NewRevocationListSchemeInstance(authority.Entity.Keccak256HI(), loc, []byte("revokers"), false)
+	revoker.Entity.Revocations = append(revoker.Entity.Revocations, opt)
+	s.publish(t, authority.EntitySecrets, []byte("revokers"), 1, opt.RLBody.ID)
+	_, werr = dr.IsRevoked(ctx, s)
+	require.Error(t, werr)
+}
+
+func TestDelegatedRevocationRevokerNotValid(t *testing.T) {
+	ctx := context.Background()
+	loc := NewLocationSchemeInstanceURL("test", 1)
+	s := newMemoryQueueStorage()
+	later := time.Now().Add(time.Hour)
+	revoker, werr := NewParsedEntitySecrets(ctx, &PNewEntity{ValidFrom: &later})
+	require.NoError(t, werr)
+	dr := NewDelegatedRevocationSchemeInstance(revoker.Entity.Keccak256HI(), loc, true)
+	publishRevocationStatement(t, s, revoker.EntitySecrets, dr)
+	_, werr = dr.IsRevoked(ctx, s)
+	require.Error(t, werr)
+}
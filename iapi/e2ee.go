@@ -3,7 +3,6 @@ package iapi
 import (
 	"context"
 	"crypto/rand"
-	"fmt"
 	"time"
 
 	"github.com/immesys/asn1"
@@ -20,7 +19,21 @@ type PEncryptMessage struct {
 	Resource          string
 	ValidAfter        *time.Time
 	ValidBefore       *time.Time
-	Content           []byte
+	//Additional direct encryption recipients
+	Subjects []*Entity
+	//Additional OAQUE encryption targets
+	Targets []*EncryptionTarget
+	Content []byte
+}
+
+//EncryptionTarget is a namespace and resource that a message is
+//encrypted to using OAQUE
+type EncryptionTarget struct {
+	Namespace         *Entity
+	NamespaceLocation LocationSchemeInstance
+	Resource          string
+	ValidAfter        *time.Time
+	ValidBefore       *time.Time
 }
 type REncryptMessage struct {
 	Ciphertext []byte
@@ -35,93 +48,150 @@ func EncryptMessage(ctx context.Context, p *PEncryptMessage) (*REncryptMessage,
 	rand.Read(contentKey)
 	contentCiphertext := aesGCMEncrypt(contentKey[:16], p.Content, contentKey[16:])
 
-	canonicalForm := serdes.WaveEncryptedMessage{}
-	canonicalForm.Contents = contentCiphertext
+	keys, werr := messageKeys(ctx, p, contentKey)
+	if werr != nil {
+		return nil, werr
+	}
+	canonicalForm := serdes.WaveEncryptedMessage{
+		Contents: contentCiphertext,
+		Keys:     keys,
+	}
+	wireObject := serdes.WaveWireObject{
+		Content: asn1.NewExternal(canonicalForm),
+	}
+	der, err := asn1.Marshal(wireObject.Content)
+	if err != nil {
+		panic(err)
+	}
+	return &REncryptMessage{
+		Ciphertext: der,
+	}, nil
+}
 
+//messageKeys encrypts the content key for each of the recipients in p
+func messageKeys(ctx context.Context, p *PEncryptMessage, contentKey []byte) ([]asn1.External, wve.WVE) {
+	subjects := p.Subjects
 	if p.Subject != nil {
-		key, err := p.Subject.WR1_DirectEncryptionKey()
-		if err != nil {
-			return nil, wve.Err(wve.InvalidParameter, "subject has no direct encryption key")
-		}
-		contentKeyCiphertext, err := key.EncryptMessage(ctx, contentKey)
-		directKey := serdes.MessageKeyCurve25519ECDH{
-			Ciphertext: contentKeyCiphertext,
-		}
-		canonicalForm.Keys = append(canonicalForm.Keys, asn1.NewExternal(directKey))
+		subjects = append([]*Entity{p.Subject}, subjects...)
 	}
+	targets := p.Targets
 	if p.Namespace != nil {
-		pprefixMinusFirst, werr := pprefixFromResource(p.Resource, true)
+		targets = append([]*EncryptionTarget{{
+			Namespace:         p.Namespace,
+			NamespaceLocation: p.NamespaceLocation,
+			Resource:          p.Resource,
+			ValidAfter:        p.ValidAfter,
+			ValidBefore:       p.ValidBefore,
+		}}, targets...)
+	}
+	keys := []asn1.External{}
+	for _, subject := range subjects {
+		if subject == nil {
+			return nil, wve.Err(wve.InvalidParameter, "subject is missing")
+		}
+		key, werr := directMessageKey(ctx, subject, contentKey)
 		if werr != nil {
 			return nil, werr
 		}
-		pprefix := append([][]byte{[]byte("\x00e2ee")}, pprefixMinusFirst...)
-		if p.ValidBefore == nil || p.ValidAfter == nil {
-			return nil, wve.Err(wve.InvalidParameter, "valid times are required if encrypting on a namespace")
-		}
-		if p.ValidBefore.Add(-3 * 365 * 24 * time.Hour).After(*p.ValidAfter) {
-			return nil, wve.Err(wve.InvalidParameter, "valid range cannot exceed roughly 3 years")
+		keys = append(keys, key)
+	}
+	for _, target := range targets {
+		if target == nil || target.Namespace == nil {
+			return nil, wve.Err(wve.InvalidParameter, "namespace is missing")
 		}
-		partition, werr := CalculateWR1Partition(*p.ValidAfter, *p.ValidBefore, pprefix)
+		key, werr := wr1MessageKey(ctx, target, contentKey)
 		if werr != nil {
 			return nil, werr
 		}
-		outerkey, err := p.Namespace.WR1_DomainVisiblityParams()
-		if err != nil {
-			return nil, wve.Err(wve.InvalidParameter, "namespace missing WR1 parameters")
-		}
-		//	id, _ := outerkey.IdentifyingBlob(context.Background())
-		//	fmt.Printf("outerkey enc: %x\n", id)
-		innerkey, err := p.Namespace.WR1_BodyParams()
-		if err != nil {
-			return nil, wve.Err(wve.InvalidParameter, "namespace missing WR1 parameters")
-		}
-		wr1Key := serdes.MessageKeyWR1{}
-		ns := p.Namespace.Keccak256HI().CanonicalForm()
-		wr1Key.Namespace = *ns
-		nsloc := p.NamespaceLocation.CanonicalForm()
-		wr1Key.NamespaceLocation = *nsloc
-		wr1Envelope := serdes.MessageKeyWR1Envelope{
-			Partition: partition,
-		}
-		oaqueKey, err := innerkey.GenerateChildKey(ctx, partition)
-		if err != nil {
-			panic(err)
-		}
-		oaqueCiphertext, err := oaqueKey.EncryptMessage(ctx, contentKey)
-		if err != nil {
-			panic(err)
-		}
-		wr1Envelope.ContentsKey = oaqueCiphertext
-		der, err := asn1.Marshal(wr1Envelope)
-		if err != nil {
-			panic(err)
-		}
-		envelopeKey := make([]byte, 16+12)
-		rand.Read(envelopeKey)
-		encryptedEnvelope := aesGCMEncrypt(envelopeKey[:16], der, envelopeKey[16:])
-		wr1Key.Envelope = encryptedEnvelope
-		envelopeKeyCiphertextKey, err := outerkey.GenerateChildKey(ctx, []byte(p.Namespace.Keccak256HI().MultihashString()))
-		if err != nil {
-			panic(err)
-		}
-		envelopeKeyCiphertext, err := envelopeKeyCiphertextKey.EncryptMessage(ctx, envelopeKey)
-		if err != nil {
-			panic(err)
-		}
-		wr1Key.EnvelopeKeyIBEBN256 = envelopeKeyCiphertext
-		canonicalForm.Keys = append(canonicalForm.Keys, asn1.NewExternal(wr1Key))
+		keys = append(keys, key)
 	}
+	return keys, nil
+}
 
-	wireObject := serdes.WaveWireObject{
-		Content: asn1.NewExternal(canonicalForm),
+//directMessageKey encrypts the content key to the subject's direct
+//encryption key
+func directMessageKey(ctx context.Context, subject *Entity, contentKey []byte) (asn1.External, wve.WVE) {
+	key, err := subject.WR1_DirectEncryptionKey()
+	if err != nil {
+		return asn1.External{}, wve.Err(wve.InvalidParameter, "subject has no direct encryption key")
 	}
-	der, err := asn1.Marshal(wireObject.Content)
+	contentKeyCiphertext, err := key.EncryptMessage(ctx, contentKey)
 	if err != nil {
 		panic(err)
 	}
-	return &REncryptMessage{
-		Ciphertext: der,
-	}, nil
+	directKey := serdes.MessageKeyCurve25519ECDH{
+		Ciphertext: contentKeyCiphertext,
+	}
+	return asn1.NewExternal(directKey), nil
+}
+
+//wr1MessageKey encrypts the content key to the OAQUE partition for the
+//target's resource and validity range
+func wr1MessageKey(ctx context.Context, p *EncryptionTarget, contentKey []byte) (asn1.External, wve.WVE) {
+	partition, werr := messageTargetPartition(p)
+	if werr != nil {
+		return asn1.External{}, werr
+	}
+	outerkey, err := p.Namespace.WR1_DomainVisiblityParams()
+	if err != nil {
+		return asn1.External{}, wve.Err(wve.InvalidParameter, "namespace missing WR1 parameters")
+	}
+	innerkey, err := p.Namespace.WR1_BodyParams()
+	if err != nil {
+		return asn1.External{}, wve.Err(wve.InvalidParameter, "namespace missing WR1 parameters")
+	}
+	if p.NamespaceLocation == nil {
+		return asn1.External{}, wve.Err(wve.InvalidParameter, "namespace location is missing")
+	}
+	wr1Key := serdes.MessageKeyWR1{}
+	ns := p.Namespace.Keccak256HI().CanonicalForm()
+	wr1Key.Namespace = *ns
+	nsloc := p.NamespaceLocation.CanonicalForm()
+	wr1Key.NamespaceLocation = *nsloc
+	wr1Envelope := serdes.MessageKeyWR1Envelope{
+		Partition: partition,
+	}
+	oaqueKey, err := innerkey.GenerateChildKey(ctx, partition)
+	if err != nil {
+		panic(err)
+	}
+	oaqueCiphertext, err := oaqueKey.EncryptMessage(ctx, contentKey)
+	if err != nil {
+		panic(err)
+	}
+	wr1Envelope.ContentsKey = oaqueCiphertext
+	der, err := asn1.Marshal(wr1Envelope)
+	if err != nil {
+		panic(err)
+	}
+	envelopeKey := make([]byte, 16+12)
+	rand.Read(envelopeKey)
+	encryptedEnvelope := aesGCMEncrypt(envelopeKey[:16], der, envelopeKey[16:])
+	wr1Key.Envelope = encryptedEnvelope
+	envelopeKeyCiphertextKey, err := outerkey.GenerateChildKey(ctx, []byte(p.Namespace.Keccak256HI().MultihashString()))
+	if err != nil {
+		panic(err)
+	}
+	envelopeKeyCiphertext, err := envelopeKeyCiphertextKey.EncryptMessage(ctx, envelopeKey)
+	if err != nil {
+		panic(err)
+	}
+	wr1Key.EnvelopeKeyIBEBN256 = envelopeKeyCiphertext
+	return asn1.NewExternal(wr1Key), nil
+}
+
+//messageTargetPartition returns the OAQUE partition that a message for the
+//target is encrypted to
+func messageTargetPartition(p *EncryptionTarget) ([][]byte, wve.WVE) {
+	pprefixMinusFirst, werr := pprefixFromResource(p.Resource, true)
+	if werr != nil {
+		return nil, werr
+	}
+	pprefix := append([][]byte{[]byte("\x00e2ee")}, pprefixMinusFirst...)
+	if p.ValidBefore == nil || p.ValidAfter == nil {
+		return nil, wve.Err(wve.InvalidParameter, "valid times are required if encrypting on a namespace")
+	}
+	return CalculateWR1Partition(*p.ValidAfter, *p.ValidBefore, pprefix, p.Namespace.WR1_PartitionSchedule())
 }
 
 type WR1MessageDecryptionContext interface {
@@ -149,7 +219,21 @@ func DecryptMessage(ctx context.Context, p *PDecryptMessage) (*RDecryptMessage,
 	if !ok {
 		return nil, wve.Err(wve.InvalidParameter, "ciphertext is not a wave encrypted message")
 	}
-	for _, k := range msg.Keys {
+	contentsKey, werr := messageContentKey(ctx, p, msg.Keys)
+	if werr != nil {
+		return nil, werr
+	}
+	content, ok := aesGCMDecrypt(contentsKey[:16], msg.Contents, contentsKey[16:])
+	if !ok {
+		return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
+	}
+	return &RDecryptMessage{Content: content}, nil
+}
+
+//messageContentKey decrypts the content key from the first of the keys
+//that the decryptor can open. Keys for other recipients are skipped
+func messageContentKey(ctx context.Context, p *PDecryptMessage, keys []asn1.External) ([]byte, wve.WVE) {
+	for _, k := range keys {
 		directkey, ok := k.Content.(serdes.MessageKeyCurve25519ECDH)
 		if ok {
 			ddk, err := p.Decryptor.WR1DirectDecryptionKey(ctx)
@@ -163,11 +247,7 @@ func DecryptMessage(ctx context.Context, p *PDecryptMessage) (*RDecryptMessage,
 			if len(contentsKey) != 16+12 {
 				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
 			}
-			content, ok := aesGCMDecrypt(contentsKey[:16], msg.Contents, contentsKey[16:])
-			if !ok {
-				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
-			}
-			return &RDecryptMessage{Content: content}, nil
+			return contentsKey, nil
 		}
 
 		wr1key, ok := k.Content.(serdes.MessageKeyWR1)
@@ -177,57 +257,18 @@ func DecryptMessage(ctx context.Context, p *PDecryptMessage) (*RDecryptMessage,
 				continue
 			}
 			ns := HashSchemeInstanceFor(&wr1key.Namespace)
-			var envelopeKey []byte
-
-			if ns.MultihashString() == p.Decryptor.Entity.Keccak256HI().MultihashString() {
-				//Instead of consulting the dctx, lets do it ourselves
-				sk, err := p.Decryptor.WR1LabelKey(ctx, []byte(ns.MultihashString()))
-				if err != nil {
-					panic(err)
-				}
-				envelopeKey, err = sk.DecryptMessage(ctx, wr1key.EnvelopeKeyIBEBN256)
-				if err != nil {
-					return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
-				}
+			envelope, werr := wr1MessageEnvelope(ctx, p, &wr1key)
+			if werr != nil {
+				return nil, werr
 			}
-
-			if envelopeKey == nil {
-				//First get IBE key for namespace
-				p.Dctx.WR1IBEKeysForPartitionLabel(ctx, ns, func(k EntitySecretKeySchemeInstance) bool {
-					//fmt.Printf("trying outer key\n")
-					contents, err := k.DecryptMessage(ctx, wr1key.EnvelopeKeyIBEBN256)
-					if err != nil {
-						return true
-					}
-					envelopeKey = contents
-					return false
-				})
-			}
-			if envelopeKey == nil {
-				fmt.Printf("E2EE no outer key\n")
+			if envelope == nil {
+				//The wrap is for someone else
 				continue
 			}
-			if len(envelopeKey) != 16+12 {
-				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
-			}
-			envelopeDER, ok := aesGCMDecrypt(envelopeKey[:16], wr1key.Envelope, envelopeKey[16:])
-			if !ok {
-				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
-			}
-			envelope := serdes.MessageKeyWR1Envelope{}
-			rest, err := asn1.Unmarshal(envelopeDER, &envelope)
-			if err != nil || len(rest) != 0 {
-				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
-			}
 
 			//Now decrypt oaque
 			var contentsKey []byte
-			realpartition := make([][]byte, 20)
-			for idx, p := range envelope.Partition {
-				if len(p) != 0 {
-					realpartition[idx] = p
-				}
-			}
+			realpartition := envelopePartition(envelope)
 
 			if ns.MultihashString() == p.Decryptor.Entity.Keccak256HI().MultihashString() {
 				//Instead of consulting the dctx, lets do it ourselves
@@ -237,7 +278,7 @@ func DecryptMessage(ctx context.Context, p *PDecryptMessage) (*RDecryptMessage,
 				}
 				contentsKey, err = sk.DecryptMessage(ctx, envelope.ContentsKey)
 				if err != nil {
-					return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
+					continue
 				}
 			}
 
@@ -246,28 +287,78 @@ func DecryptMessage(ctx context.Context, p *PDecryptMessage) (*RDecryptMessage,
 				p.Dctx.WR1OAQUEKeysForContent(ctx, ns, false, realpartition, func(k SlottedSecretKey) bool {
 					var err error
 					contentsKey, err = k.DecryptMessageAsChild(ctx, envelope.ContentsKey, realpartition)
-					if err == nil {
-						return false
-					}
-					fmt.Printf("inner key failed\n")
-					return true
+					return err != nil
 				})
 			}
 			if contentsKey == nil {
-				fmt.Printf("E2EE no inner key\n")
 				continue
 			}
 			if len(contentsKey) != 16+12 {
 				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
 			}
-			contents, ok := aesGCMDecrypt(contentsKey[:16], msg.Contents, contentsKey[16:])
-			if !ok {
-				return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
-			}
-			return &RDecryptMessage{
-				Content: contents,
-			}, nil
+			return contentsKey, nil
 		}
 	}
 	return nil, wve.Err(wve.MessageDecryptFailed, "could not decrypt message")
 }
+
+//wr1MessageEnvelope opens the envelope of a WR1 message key, which holds
+//the OAQUE partition and the encrypted content key. It returns nil if the
+//decryptor has no key for the envelope
+func wr1MessageEnvelope(ctx context.Context, p *PDecryptMessage, wr1key *serdes.MessageKeyWR1) (*serdes.MessageKeyWR1Envelope, wve.WVE) {
+	ns := HashSchemeInstanceFor(&wr1key.Namespace)
+	var envelopeKey []byte
+
+	if ns.MultihashString() == p.Decryptor.Entity.Keccak256HI().MultihashString() {
+		//Instead of consulting the dctx, lets do it ourselves
+		sk, err := p.Decryptor.WR1LabelKey(ctx, []byte(ns.MultihashString()))
+		if err != nil {
+			panic(err)
+		}
+		envelopeKey, err = sk.DecryptMessage(ctx, wr1key.EnvelopeKeyIBEBN256)
+		if err != nil {
+			//This key may be for a different recipient
+			return nil, nil
+		}
+	}
+
+	if envelopeKey == nil && p.Dctx != nil {
+		//First get IBE key for namespace
+		p.Dctx.WR1IBEKeysForPartitionLabel(ctx, ns, func(k EntitySecretKeySchemeInstance) bool {
+			contents, err := k.DecryptMessage(ctx, wr1key.EnvelopeKeyIBEBN256)
+			if err != nil {
+				return true
+			}
+			envelopeKey = contents
+			return false
+		})
+	}
+	if envelopeKey == nil {
+		return nil, nil
+	}
+	if len(envelopeKey) != 16+12 {
+		return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
+	}
+	envelopeDER, ok := aesGCMDecrypt(envelopeKey[:16], wr1key.Envelope, envelopeKey[16:])
+	if !ok {
+		return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
+	}
+	envelope := serdes.MessageKeyWR1Envelope{}
+	rest, err := asn1.Unmarshal(envelopeDER, &envelope)
+	if err != nil || len(rest) != 0 {
+		return nil, wve.Err(wve.MalformedObject, "ciphertext is not correctly constructed")
+	}
+	return &envelope, nil
+}
+
+//envelopePartition returns the partition of an envelope with the empty
+//slots set to nil
+func envelopePartition(envelope *serdes.MessageKeyWR1Envelope) [][]byte {
+	realpartition := make([][]byte, 20)
+	for idx, p := range envelope.Partition {
+		if len(p) != 0 {
+			realpartition[idx] = p
+		}
+	}
+	return realpartition
+}
@@ -69,3 +69,154 @@ func TestOAQUEE2EE(t *testing.T) {
 	require.Equal(t, msg, r2.Content)
 
 }
+
+func TestMultiRecipientE2EE(t *testing.T) {
+	ctx := context.Background()
+	ns1, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	ns2, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dst1, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dst2, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	other, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+
+	msg := make([]byte, 512)
+	rand.Read(msg)
+	target := func(ns *RParseEntitySecrets) *EncryptionTarget {
+		return &EncryptionTarget{
+			Namespace:         ns.Entity,
+			NamespaceLocation: NewLocationSchemeInstanceURL("test", 1),
+			Resource:          "foo",
+			ValidAfter:        Time(time.Now()),
+			ValidBefore:       Time(time.Now().Add(30 * 24 * time.Hour)),
+		}
+	}
+	r, err := EncryptMessage(ctx, &PEncryptMessage{
+		Subjects: []*Entity{dst1.Entity, dst2.Entity},
+		Targets:  []*EncryptionTarget{target(ns1), target(ns2)},
+		Content:  msg,
+	})
+	require.NoError(t, err)
+
+	for _, dst := range []*RParseEntitySecrets{dst1, dst2} {
+		r2, err := DecryptMessage(ctx, &PDecryptMessage{
+			Decryptor:  dst.EntitySecrets,
+			Ciphertext: r.Ciphertext,
+		})
+		require.NoError(t, err)
+		require.Equal(t, msg, r2.Content)
+	}
+	for _, ns := range []*RParseEntitySecrets{ns1, ns2} {
+		kpdc := NewKeyPoolDecryptionContext()
+		kpdc.AddEntity(ns.EntitySecrets.Entity)
+		kpdc.AddEntitySecret(ns.EntitySecrets, true)
+		kpdc.AddDomainVisibilityID([]byte(ns.Entity.Keccak256HI().MultihashString()))
+		r2, err := DecryptMessage(ctx, &PDecryptMessage{
+			Decryptor:  other.EntitySecrets,
+			Ciphertext: r.Ciphertext,
+			Dctx:       kpdc,
+		})
+		require.NoError(t, err)
+		require.Equal(t, msg, r2.Content)
+	}
+
+	_, err = DecryptMessage(ctx, &PDecryptMessage{
+		Decryptor:  other.EntitySecrets,
+		Ciphertext: r.Ciphertext,
+	})
+	require.Error(t, err)
+}
+
+func TestReencryptE2EE(t *testing.T) {
+	ctx := context.Background()
+	ns1, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	ns2, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dst, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+
+	msg := make([]byte, 512)
+	rand.Read(msg)
+	from := time.Now()
+	until := from.Add(30 * 24 * time.Hour)
+	target := func(ns *RParseEntitySecrets) *EncryptionTarget {
+		return &EncryptionTarget{
+			Namespace:         ns.Entity,
+			NamespaceLocation: NewLocationSchemeInstanceURL("test", 1),
+			Resource:          "foo/bar",
+			ValidAfter:        &from,
+			ValidBefore:       &until,
+		}
+	}
+	r, err := EncryptMessage(ctx, &PEncryptMessage{
+		Subject: dst.Entity,
+		Targets: []*EncryptionTarget{target(ns1)},
+		Content: msg,
+	})
+	require.NoError(t, err)
+	kpdc := NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(ns1.EntitySecrets.Entity)
+	kpdc.AddEntitySecret(ns1.EntitySecrets, true)
+	kpdc.AddDomainVisibilityID([]byte(ns1.Entity.Keccak256HI().MultihashString()))
+
+	recipients, err := ReadMessageRecipients(ctx, &PDecryptMessage{
+		Decryptor:  ns1.EntitySecrets,
+		Ciphertext: r.Ciphertext,
+		Dctx:       kpdc,
+	})
+	require.NoError(t, err)
+	require.Len(t, recipients, 2)
+	require.True(t, recipients[0].Direct)
+	require.Nil(t, recipients[0].Subject)
+	require.Equal(t, "foo/bar", recipients[1].Resource)
+
+	//Moving to a new namespace loses the old namespace and the direct
+	//recipient that ns1 can not identify
+	rr, err := ReencryptMessage(ctx, &PReencryptMessage{
+		Decryptor:  ns1.EntitySecrets,
+		Dctx:       kpdc,
+		Ciphertext: r.Ciphertext,
+		Recipients: &PEncryptMessage{
+			Targets: []*EncryptionTarget{target(ns2)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, rr.LostAccess, 2)
+	require.True(t, rr.LostAccess[0].Direct)
+	require.Equal(t, ns1.Entity.Keccak256HI().MultihashString(), rr.LostAccess[1].Namespace.MultihashString())
+
+	kpdc2 := NewKeyPoolDecryptionContext()
+	kpdc2.AddEntity(ns2.EntitySecrets.Entity)
+	kpdc2.AddEntitySecret(ns2.EntitySecrets, true)
+	kpdc2.AddDomainVisibilityID([]byte(ns2.Entity.Keccak256HI().MultihashString()))
+	r2, err := DecryptMessage(ctx, &PDecryptMessage{
+		Decryptor:  dst.EntitySecrets,
+		Ciphertext: rr.Ciphertext,
+		Dctx:       kpdc2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, msg, r2.Content)
+	_, err = DecryptMessage(ctx, &PDecryptMessage{
+		Decryptor:  dst.EntitySecrets,
+		Ciphertext: rr.Ciphertext,
+	})
+	require.Error(t, err)
+
+	//The same target and window keeps access
+	rr, err = ReencryptMessage(ctx, &PReencryptMessage{
+		Decryptor:  ns1.EntitySecrets,
+		Dctx:       kpdc,
+		Ciphertext: r.Ciphertext,
+		Recipients: &PEncryptMessage{
+			Subject: dst.Entity,
+			Targets: []*EncryptionTarget{target(ns1)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, rr.LostAccess, 1)
+	require.True(t, rr.LostAccess[0].Direct)
+}
@@ -0,0 +1,334 @@
+package iapi
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+	"golang.org/x/crypto/sha3"
+)
+
+//An encrypted stream is a four byte big endian header length, the DER of
+//a WaveEncryptedStream header and then the segments. Each segment is
+//sealed with AES-GCM under the content key. The nonce holds the segment
+//number and a flag marking the last segment, so segments cannot be
+//reordered and the stream cannot be truncated at a segment boundary. The
+//header hash is the additional data, binding the segments to the keys
+
+//The plaintext segment size used if none is given
+const DefaultStreamSegmentSize = 64 * 1024
+
+//The largest allowed plaintext segment
+const MaxStreamSegmentSize = 16 * 1024 * 1024
+
+//The largest allowed header, which holds the key wraps
+const maxStreamHeaderSize = 1024 * 1024
+
+const streamTagSize = 16
+
+type PEncryptStream struct {
+	//Direct encryption key
+	Subject *Entity
+	//OAQUE encryption
+	Namespace         *Entity
+	NamespaceLocation LocationSchemeInstance
+	Resource          string
+	ValidAfter        *time.Time
+	ValidBefore       *time.Time
+	//Additional direct encryption recipients
+	Subjects []*Entity
+	//Additional OAQUE encryption targets
+	Targets []*EncryptionTarget
+	//If zero, DefaultStreamSegmentSize
+	SegmentSize int
+	//Where the ciphertext is written
+	Output io.Writer
+}
+type REncryptStream struct {
+	//Plaintext written to this is encrypted to the output. It must be
+	//closed to write the last segment
+	Writer io.WriteCloser
+}
+
+//EncryptStream writes the header of a streamed encrypted message and
+//returns a writer for the content
+func EncryptStream(ctx context.Context, p *PEncryptStream) (*REncryptStream, wve.WVE) {
+	if p.Output == nil {
+		return nil, wve.Err(wve.MissingParameter, "missing output")
+	}
+	segmentSize := p.SegmentSize
+	if segmentSize == 0 {
+		segmentSize = DefaultStreamSegmentSize
+	}
+	if segmentSize < 0 || segmentSize > MaxStreamSegmentSize {
+		return nil, wve.Err(wve.InvalidParameter, "invalid segment size")
+	}
+	contentKey := make([]byte, 16+12)
+	rand.Read(contentKey)
+	keys, werr := messageKeys(ctx, &PEncryptMessage{
+		Subject:           p.Subject,
+		Namespace:         p.Namespace,
+		NamespaceLocation: p.NamespaceLocation,
+		Resource:          p.Resource,
+		ValidAfter:        p.ValidAfter,
+		ValidBefore:       p.ValidBefore,
+		Subjects:          p.Subjects,
+		Targets:           p.Targets,
+	}, contentKey)
+	if werr != nil {
+		return nil, werr
+	}
+	if len(keys) == 0 {
+		return nil, wve.Err(wve.InvalidParameter, "the stream has no recipients")
+	}
+	header := serdes.WaveEncryptedStream{
+		SegmentSize: segmentSize,
+		Keys:        keys,
+	}
+	wireObject := serdes.WaveWireObject{
+		Content: asn1.NewExternal(header),
+	}
+	der, err := asn1.Marshal(wireObject.Content)
+	if err != nil {
+		panic(err)
+	}
+	lenbuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(lenbuf, uint32(len(der)))
+	if _, err := p.Output.Write(lenbuf); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not write stream header", err)
+	}
+	if _, err := p.Output.Write(der); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not write stream header", err)
+	}
+	return &REncryptStream{
+		Writer: &streamWriter{
+			segments: newStreamSegments(contentKey, der),
+			size:     segmentSize,
+			out:      p.Output,
+			buf:      make([]byte, 0, segmentSize),
+		},
+	}, nil
+}
+
+type PDecryptStream struct {
+	Decryptor *EntitySecrets
+	Dctx      WR1MessageDecryptionContext
+	//Where the ciphertext is read from
+	Input io.Reader
+}
+type RDecryptStream struct {
+	//Reads the plaintext. An error is returned if any segment fails
+	//authentication or the stream ends early
+	Reader io.Reader
+}
+
+//DecryptStream reads the header of a streamed encrypted message and
+//returns a reader for the content
+func DecryptStream(ctx context.Context, p *PDecryptStream) (*RDecryptStream, wve.WVE) {
+	if p.Input == nil || p.Decryptor == nil {
+		return nil, wve.Err(wve.MissingParameter, "missing input or decryptor")
+	}
+	in := bufio.NewReader(p.Input)
+	lenbuf := make([]byte, 4)
+	if _, err := io.ReadFull(in, lenbuf); err != nil {
+		return nil, wve.ErrW(wve.MalformedObject, "could not read stream header", err)
+	}
+	hlen := binary.BigEndian.Uint32(lenbuf)
+	if hlen > maxStreamHeaderSize {
+		return nil, wve.Err(wve.MalformedObject, "stream header is too large")
+	}
+	der := make([]byte, hlen)
+	if _, err := io.ReadFull(in, der); err != nil {
+		return nil, wve.ErrW(wve.MalformedObject, "could not read stream header", err)
+	}
+	header, werr := parseStreamHeader(der)
+	if werr != nil {
+		return nil, werr
+	}
+	contentKey, werr := messageContentKey(ctx, &PDecryptMessage{
+		Decryptor: p.Decryptor,
+		Dctx:      p.Dctx,
+	}, header.Keys)
+	if werr != nil {
+		return nil, werr
+	}
+	return &RDecryptStream{
+		Reader: &streamReader{
+			segments: newStreamSegments(contentKey, der),
+			in:       in,
+			buf:      make([]byte, header.SegmentSize+streamTagSize),
+		},
+	}, nil
+}
+
+//parseStreamHeader parses the DER of a WaveEncryptedStream header
+func parseStreamHeader(der []byte) (*serdes.WaveEncryptedStream, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	rest, err := asn1.Unmarshal(der, &wo.Content)
+	if len(rest) != 0 || err != nil {
+		return nil, wve.Err(wve.MalformedObject, "stream header is malformed")
+	}
+	header, ok := wo.Content.Content.(serdes.WaveEncryptedStream)
+	if !ok {
+		return nil, wve.Err(wve.InvalidParameter, "ciphertext is not a wave encrypted stream")
+	}
+	if header.SegmentSize <= 0 || header.SegmentSize > MaxStreamSegmentSize {
+		return nil, wve.Err(wve.MalformedObject, "invalid segment size")
+	}
+	return &header, nil
+}
+
+//streamSegments seals and opens the numbered segments of a stream
+type streamSegments struct {
+	aead   cipher.AEAD
+	prefix []byte
+	ad     []byte
+	next   uint32
+}
+
+func newStreamSegments(contentKey []byte, headerDER []byte) *streamSegments {
+	block, err := aes.NewCipher(contentKey[:16])
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	ad := sha3.Sum256(headerDER)
+	return &streamSegments{
+		aead:   aead,
+		prefix: contentKey[16:23],
+		ad:     ad[:],
+	}
+}
+
+func (s *streamSegments) nonce(last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, s.prefix)
+	binary.BigEndian.PutUint32(nonce[7:11], s.next)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+func (s *streamSegments) seal(plaintext []byte, last bool) ([]byte, error) {
+	if s.next == ^uint32(0) {
+		return nil, wve.Err(wve.InvalidParameter, "stream has too many segments")
+	}
+	rv := s.aead.Seal(nil, s.nonce(last), plaintext, s.ad)
+	s.next++
+	return rv, nil
+}
+
+func (s *streamSegments) open(ciphertext []byte, last bool) ([]byte, error) {
+	rv, err := s.aead.Open(nil, s.nonce(last), ciphertext, s.ad)
+	if err != nil {
+		return nil, wve.Err(wve.MalformedObject, "stream segment failed authentication")
+	}
+	s.next++
+	return rv, nil
+}
+
+type streamWriter struct {
+	segments *streamSegments
+	size     int
+	out      io.Writer
+	buf      []byte
+	closed   bool
+}
+
+func (w *streamWriter) Write(b []byte) (int, error) {
+	if w.closed {
+		return 0, wve.Err(wve.InvalidParameter, "write to closed stream")
+	}
+	n := len(b)
+	for len(b) > 0 {
+		//A full segment is only sealed once more content arrives, because
+		//the last segment must be marked as such
+		if len(w.buf) == w.size {
+			if err := w.flush(false); err != nil {
+				return 0, err
+			}
+		}
+		take := w.size - len(w.buf)
+		if take > len(b) {
+			take = len(b)
+		}
+		w.buf = append(w.buf, b[:take]...)
+		b = b[take:]
+	}
+	return n, nil
+}
+
+func (w *streamWriter) flush(last bool) error {
+	ct, err := w.segments.seal(w.buf, last)
+	if err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	_, err = w.out.Write(ct)
+	return err
+}
+
+func (w *streamWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+type streamReader struct {
+	segments *streamSegments
+	in       *bufio.Reader
+	buf      []byte
+	pending  []byte
+	done     bool
+	err      error
+}
+
+func (r *streamReader) Read(b []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.pending, r.err = r.readSegment()
+	}
+	n := copy(b, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *streamReader) readSegment() ([]byte, error) {
+	n, err := io.ReadFull(r.in, r.buf)
+	last := false
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		last = true
+	} else if err != nil {
+		return nil, err
+	} else if _, perr := r.in.Peek(1); perr == io.EOF {
+		last = true
+	}
+	if n < streamTagSize {
+		return nil, wve.Err(wve.MalformedObject, "stream is truncated")
+	}
+	rv, err := r.segments.open(r.buf[:n], last)
+	if err != nil {
+		return nil, err
+	}
+	r.done = last
+	return rv, nil
+}
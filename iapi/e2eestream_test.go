@@ -0,0 +1,123 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encryptTestStream(t *testing.T, dst *Entity, msg []byte, segmentSize int) []byte {
+	out := &bytes.Buffer{}
+	r, werr := EncryptStream(context.Background(), &PEncryptStream{
+		Subject:     dst,
+		SegmentSize: segmentSize,
+		Output:      out,
+	})
+	require.NoError(t, werr)
+	//Write in uneven pieces
+	for len(msg) > 0 {
+		n := 7
+		if n > len(msg) {
+			n = len(msg)
+		}
+		_, err := r.Writer.Write(msg[:n])
+		require.NoError(t, err)
+		msg = msg[n:]
+	}
+	require.NoError(t, r.Writer.Close())
+	return out.Bytes()
+}
+
+func decryptTestStream(dst *EntitySecrets, ciphertext []byte) ([]byte, error) {
+	r, werr := DecryptStream(context.Background(), &PDecryptStream{
+		Decryptor: dst,
+		Input:     bytes.NewReader(ciphertext),
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	return ioutil.ReadAll(r.Reader)
+}
+
+func TestStreamE2EE(t *testing.T) {
+	dst, werr := NewParsedEntitySecrets(context.Background(), &PNewEntity{})
+	require.NoError(t, werr)
+	for _, size := range []int{0, 1, 64, 65, 64*3 + 5} {
+		msg := make([]byte, size)
+		rand.Read(msg)
+		ciphertext := encryptTestStream(t, dst.Entity, msg, 64)
+		content, err := decryptTestStream(dst.EntitySecrets, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, msg, content)
+	}
+}
+
+func TestStreamE2EETampering(t *testing.T) {
+	dst, werr := NewParsedEntitySecrets(context.Background(), &PNewEntity{})
+	require.NoError(t, werr)
+	other, werr := NewParsedEntitySecrets(context.Background(), &PNewEntity{})
+	require.NoError(t, werr)
+	msg := make([]byte, 64*3+5)
+	rand.Read(msg)
+	ciphertext := encryptTestStream(t, dst.Entity, msg, 64)
+	headerEnd := 4 + int(binary.BigEndian.Uint32(ciphertext))
+	segment := 64 + streamTagSize
+
+	_, err := decryptTestStream(other.EntitySecrets, ciphertext)
+	require.Error(t, err)
+
+	modified := append([]byte{}, ciphertext...)
+	modified[headerEnd+segment+3] ^= 1
+	_, err = decryptTestStream(dst.EntitySecrets, modified)
+	require.Error(t, err)
+
+	//Truncated at a segment boundary
+	_, err = decryptTestStream(dst.EntitySecrets, ciphertext[:headerEnd+2*segment])
+	require.Error(t, err)
+
+	//Reordered segments
+	reordered := append([]byte{}, ciphertext[:headerEnd]...)
+	reordered = append(reordered, ciphertext[headerEnd+segment:headerEnd+2*segment]...)
+	reordered = append(reordered, ciphertext[headerEnd:headerEnd+segment]...)
+	reordered = append(reordered, ciphertext[headerEnd+2*segment:]...)
+	_, err = decryptTestStream(dst.EntitySecrets, reordered)
+	require.Error(t, err)
+}
+
+func TestReencryptStream(t *testing.T) {
+	ctx := context.Background()
+	src, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dst, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	msg := make([]byte, 64*3+5)
+	rand.Read(msg)
+	ciphertext := encryptTestStream(t, src.Entity, msg, 64)
+
+	rr, err := ReencryptMessage(ctx, &PReencryptMessage{
+		Decryptor:  src.EntitySecrets,
+		Ciphertext: ciphertext,
+		Recipients: &PEncryptMessage{
+			Subject: dst.Entity,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, rr.LostAccess, 1)
+	require.Equal(t, src.Entity.Keccak256HI().MultihashString(), rr.LostAccess[0].Subject.MultihashString())
+
+	//The result is still a stream with the same segment size
+	hlen := binary.BigEndian.Uint32(rr.Ciphertext)
+	header, err := parseStreamHeader(rr.Ciphertext[4 : 4+hlen])
+	require.NoError(t, err)
+	require.Equal(t, 64, header.SegmentSize)
+	content, derr := decryptTestStream(dst.EntitySecrets, rr.Ciphertext)
+	require.NoError(t, derr)
+	require.Equal(t, msg, content)
+	_, derr = decryptTestStream(src.EntitySecrets, rr.Ciphertext)
+	require.Error(t, derr)
+}
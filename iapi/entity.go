@@ -16,6 +16,12 @@ type PNewEntity struct {
 	ValidUntil                   *time.Time
 	CommitmentRevocationLocation LocationSchemeInstance
 	Passphrase                   *string
+	//The scheme of the signing keys, either serdes.EntityEd25519OID or
+	//serdes.EntityECDSA_P256OID. If not specified defaults to Ed25519
+	SigningScheme asn1.ObjectIdentifier
+	//The WR1 partition schedule for this entity as a namespace. If not
+	//specified the default schedule is used
+	PartitionSchedule *WR1PartitionSchedule
 }
 type RNewEntity struct {
 	PublicDER []byte
@@ -40,25 +46,33 @@ func NewEntity(ctx context.Context, p *PNewEntity) (*RNewEntity, wve.WVE) {
 		en.Entity.TBS.Validity.NotAfter = time.Now().Add(30 * 24 * time.Hour)
 	}
 
+	signingScheme := serdes.EntityEd25519OID
+	if p.SigningScheme != nil {
+		if !p.SigningScheme.Equal(serdes.EntityEd25519OID) && !p.SigningScheme.Equal(serdes.EntityECDSA_P256OID) {
+			return nil, wve.Err(wve.UnsupportedKeyScheme, "signing scheme must be Ed25519 or ECDSA P-256")
+		}
+		signingScheme = p.SigningScheme
+	}
+
 	//add the WR1 keys
 	kr := serdes.EntityKeyring{}
 
-	//Ed25519 attest/certify
-	ed25519KE, err := NewEntityKeySchemeInstance(serdes.EntityEd25519OID, CapCertification, CapAttestation)
+	//Attest/certify
+	signingKE, err := NewEntityKeySchemeInstance(signingScheme, CapCertification, CapAttestation)
 	if err != nil {
 		panic(err)
 	}
-	cf := ed25519KE.SecretCanonicalForm()
+	cf := signingKE.SecretCanonicalForm()
 	kr.Keys = append(kr.Keys, *cf)
-	rsecret := cf.Private.Content.(serdes.EntitySecretEd25519)
+	rsecret := keyringEntrySecret(cf)
 
-	//Ed25519 message signing
+	//Message signing
 	{
-		ed25519KE, err := NewEntityKeySchemeInstance(serdes.EntityEd25519OID, CapSigning)
+		signingKE, err := NewEntityKeySchemeInstance(signingScheme, CapSigning)
 		if err != nil {
 			panic(err)
 		}
-		cf := ed25519KE.SecretCanonicalForm()
+		cf := signingKE.SecretCanonicalForm()
 		kr.Keys = append(kr.Keys, *cf)
 	}
 
@@ -95,7 +109,7 @@ func NewEntity(ctx context.Context, p *PNewEntity) (*RNewEntity, wve.WVE) {
 		en.Keyring = asn1.NewExternal(kr)
 	} else {
 		//Encrypt the keyring
-		krs, err := NewEntityKeyringSchemeInstance(serdes.KeyringAES128_GCM_PBKDF2OID)
+		krs, err := NewEntityKeyringSchemeInstance(serdes.KeyringAES256_GCM_Argon2idOID)
 		if err != nil {
 			panic(err)
 		}
@@ -117,12 +131,28 @@ func NewEntity(ctx context.Context, p *PNewEntity) (*RNewEntity, wve.WVE) {
 		ro := NewCommitmentRevocationSchemeInstance(p.CommitmentRevocationLocation, true, rsecret)
 		en.Entity.TBS.Revocations = append(en.Entity.TBS.Revocations, ro.CanonicalForm())
 	}
+	if p.PartitionSchedule != nil && !p.PartitionSchedule.IsDefault() {
+		if _, werr := NewWR1PartitionSchedule(p.PartitionSchedule.CanonicalForm()); werr != nil {
+			return nil, werr
+		}
+		der, err := asn1.Marshal(*p.PartitionSchedule.CanonicalForm())
+		if err != nil {
+			panic(err)
+		}
+		//This is critical because entities that do not understand the
+		//schedule would create attestations with the wrong partitions
+		en.Entity.TBS.Extensions = append(en.Entity.TBS.Extensions, serdes.Extension{
+			ExtensionID: serdes.WR1PartitionScheduleOID,
+			Critical:    true,
+			Value:       der,
+		})
+	}
 	//Serialize TBS and sign it
 	der, err := asn1.Marshal(en.Entity.TBS)
 	if err != nil {
 		panic(err)
 	}
-	en.Entity.Signature, err = ed25519KE.SignCertify(context.Background(), der)
+	en.Entity.Signature, err = signingKE.SignCertify(context.Background(), der)
 	if err != nil {
 		panic(err)
 	}
@@ -190,8 +220,32 @@ func parseEntityFromObject(ctx context.Context, en *serdes.WaveEntity) (*Entity,
 		}
 		rv.Keys = append(rv.Keys, ks)
 	}
+	if _, werr := entityPartitionSchedule(en); werr != nil {
+		return nil, werr
+	}
 	return rv, nil
 }
+
+//entityPartitionSchedule returns the schedule in the WR1PartitionSchedule
+//extension of the entity, or nil if there is none
+func entityPartitionSchedule(en *serdes.WaveEntity) (*WR1PartitionSchedule, wve.WVE) {
+	for _, ext := range en.TBS.Extensions {
+		if !ext.ExtensionID.Equal(serdes.WR1PartitionScheduleOID) {
+			continue
+		}
+		cf := serdes.WR1PartitionSchedule{}
+		rest, err := asn1.Unmarshal(ext.Value, &cf)
+		if err != nil || len(rest) != 0 {
+			return nil, wve.Err(wve.MalformedObject, "entity has a malformed partition schedule")
+		}
+		rv, werr := NewWR1PartitionSchedule(&cf)
+		if werr != nil {
+			return nil, wve.ErrW(wve.MalformedObject, "entity has an invalid partition schedule", werr)
+		}
+		return rv, nil
+	}
+	return nil, nil
+}
 func ParseEntity(ctx context.Context, p *PParseEntity) (*RParseEntity, wve.WVE) {
 	wo := serdes.WaveWireObject{}
 	trailing, uerr := asn1.Unmarshal(p.DER, &wo.Content)
@@ -258,7 +312,7 @@ func ParseEntitySecrets(ctx context.Context, p *PParseEntitySecrets) (*RParseEnt
 		}, wve.Err(wve.UnsupportedKeyScheme, "keyring scheme is unsupported")
 	}
 	//Try
-	if _, ok := krscheme.(*AESKeyring); ok && p.Passphrase == nil {
+	if KeyringRequiresPassphrase(krscheme) && p.Passphrase == nil {
 		return &RParseEntitySecrets{
 			Entity: en,
 		}, wve.Err(wve.PassphraseRequired, "passphrase required")
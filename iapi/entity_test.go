@@ -3,7 +3,9 @@ package iapi
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/immesys/wave/serdes"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,6 +23,44 @@ func TestCreateEntity(t *testing.T) {
 	require.NotNil(t, readback.Entity.VerifyingKey)
 }
 
+func TestCreateEntityWithPartitionSchedule(t *testing.T) {
+	schedule := &WR1PartitionSchedule{
+		Tiers:    []int64{int64(time.Hour), int64(10 * time.Minute)},
+		SlotSize: 4,
+		MaxRange: 24 * time.Hour,
+	}
+	R, err := NewEntity(context.Background(), &PNewEntity{
+		PartitionSchedule: schedule,
+	})
+	require.NoError(t, err)
+	readback, err := ParseEntity(context.Background(), &PParseEntity{
+		DER: R.PublicDER,
+	})
+	require.NoError(t, err)
+	require.Equal(t, schedule, readback.Entity.WR1_PartitionSchedule())
+
+	R, err = NewEntity(context.Background(), &PNewEntity{})
+	require.NoError(t, err)
+	readback, err = ParseEntity(context.Background(), &PParseEntity{
+		DER: R.PublicDER,
+	})
+	require.NoError(t, err)
+	require.True(t, readback.Entity.WR1_PartitionSchedule().IsDefault())
+}
+
+func TestCreateEntityWithOversizedPartitionSchedule(t *testing.T) {
+	//Twelve hours plus sixty minutes on either end is more than a bundle
+	//can hold
+	_, err := NewEntity(context.Background(), &PNewEntity{
+		PartitionSchedule: &WR1PartitionSchedule{
+			Tiers:    []int64{int64(time.Hour), int64(time.Minute)},
+			SlotSize: 4,
+			MaxRange: 12 * time.Hour,
+		},
+	})
+	require.Error(t, err)
+}
+
 func TestCreateEntityAndParseSecrets(t *testing.T) {
 	R, err := NewEntity(context.Background(), &PNewEntity{})
 	require.NoError(t, err)
@@ -56,3 +96,43 @@ func TestCreateEntityAndParseSecretsWithWrongPassphrase(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, es.EntitySecrets)
 }
+
+func TestCreateECDSAP256Entity(t *testing.T) {
+	ctx := context.Background()
+	source, err := NewParsedEntitySecrets(ctx, &PNewEntity{
+		SigningScheme: serdes.EntityECDSA_P256OID,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &EntityKey_ECDSA_P256{}, source.Entity.VerifyingKey)
+	require.IsType(t, &EntityKey_ECDSA_P256{}, source.Entity.MessageVerifyingKey())
+
+	//The entity can attest and its attestations verify
+	dst, err := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, err)
+	pol, uerr := NewTrustLevelPolicy(3)
+	require.NoError(t, uerr)
+	rv, err := CreateAttestation(ctx, &PCreateAttestation{
+		Policy:            pol,
+		HashScheme:        KECCAK256,
+		BodyScheme:        NewPlaintextBodyScheme(),
+		EncryptionContext: NewKeyPoolDecryptionContext(),
+		Attester:          source.EntitySecrets,
+		AttesterLocation:  NewLocationSchemeInstanceURL("test", 1),
+		Subject:           dst.Entity,
+		SubjectLocation:   NewLocationSchemeInstanceURL("test", 1),
+	})
+	require.NoError(t, err)
+	kpdc := NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(source.Entity)
+	readback, err := ParseAttestation(ctx, &PParseAttestation{
+		DER:               rv.DER,
+		DecryptionContext: kpdc,
+	})
+	require.NoError(t, err)
+	require.False(t, readback.IsMalformed)
+
+	_, err = NewEntity(ctx, &PNewEntity{
+		SigningScheme: serdes.EntityCurve25519OID,
+	})
+	require.Error(t, err)
+}
@@ -6,6 +6,8 @@ func init() {
 	gob.Register(&EntityKey_Ed25519{})
 	gob.Register(&EntitySecretKey_Ed25519{})
 	gob.Register(&EntityKey_Curve25519{})
+	gob.Register(&EntityKey_ECDSA_P256{})
+	gob.Register(&EntitySecretKey_ECDSA_P256{})
 	gob.Register(&EntitySecretKey_Ed25519{})
 	gob.Register(&EntityKey_IBE_Params_BLS12381{})
 	gob.Register(&EntitySecretKey_IBE_Master_BLS12381{})
@@ -19,4 +21,6 @@ func init() {
 	gob.Register(&HashSchemeInstance_Keccak_256{})
 	gob.Register(&HashSchemeInstance_Sha3_256{})
 	gob.Register(&CommitmentRevocationSchemeInstance{})
+	gob.Register(&RevocationListSchemeInstance{})
+	gob.Register(&DelegatedRevocationSchemeInstance{})
 }
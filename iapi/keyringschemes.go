@@ -9,6 +9,7 @@ import (
 
 	"github.com/immesys/asn1"
 	"github.com/immesys/wave/serdes"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/sha3"
 )
@@ -23,6 +24,12 @@ func EntityKeyringSchemeInstanceFor(e asn1.External) (EntityKeyringSchemeInstanc
 			return nil, fmt.Errorf("invalid keyring")
 		}
 		return &AESKeyring{SerdesForm: &e, ciphertext: ct}, nil
+	case e.OID.Equal(serdes.KeyringAES256_GCM_Argon2idOID):
+		ct, ok := e.Content.(serdes.KeyringArgon2idCiphertext)
+		if !ok {
+			return nil, fmt.Errorf("invalid keyring")
+		}
+		return &Argon2idKeyring{SerdesForm: &e, ciphertext: ct}, nil
 	}
 	return &UnsupportedKeyringScheme{}, nil
 }
@@ -32,10 +39,21 @@ func NewEntityKeyringSchemeInstance(oid asn1.ObjectIdentifier) (EntityKeyringSch
 		return &KeyringPlaintext{}, nil
 	case oid.Equal(serdes.KeyringAES128_GCM_PBKDF2OID):
 		return &AESKeyring{}, nil
+	case oid.Equal(serdes.KeyringAES256_GCM_Argon2idOID):
+		return &Argon2idKeyring{}, nil
 	}
 	return &UnsupportedKeyringScheme{}, nil
 }
 
+//KeyringRequiresPassphrase returns true if the keyring is encrypted
+func KeyringRequiresPassphrase(kr EntityKeyringSchemeInstance) bool {
+	switch kr.(type) {
+	case *AESKeyring, *Argon2idKeyring:
+		return true
+	}
+	return false
+}
+
 type UnsupportedKeyringScheme struct {
 }
 
@@ -147,3 +165,96 @@ func (kr *AESKeyring) EncryptKeyring(ctx context.Context, plaintext *serdes.Enti
 	rv := asn1.NewExternal(ciphertext)
 	return &rv, nil
 }
+
+//The Argon2id parameters used for new keyrings
+const Argon2idTime = 3
+const Argon2idMemory = 64 * 1024
+const Argon2idThreads = 4
+
+//Keyrings with parameters above these are rejected rather than risk
+//exhausting memory on a malicious file
+const maxArgon2idTime = 10
+const maxArgon2idMemory = 256 * 1024
+
+//Argon2idKeyring is like AESKeyring but derives the key with Argon2id,
+//which is much more expensive to brute force on GPUs than PBKDF2
+type Argon2idKeyring struct {
+	SerdesForm *asn1.External
+	ciphertext serdes.KeyringArgon2idCiphertext
+}
+
+func (kr *Argon2idKeyring) Supported() bool {
+	return true
+}
+func (kr *Argon2idKeyring) DecryptKeyring(ctx context.Context, params interface{}) (decodedForm *serdes.EntityKeyring, err error) {
+	if kr.SerdesForm == nil {
+		return nil, fmt.Errorf("this is not a curried keyring instance")
+	}
+	ppassphrase, ok := params.(*string)
+	if !ok || ppassphrase == nil {
+		return nil, fmt.Errorf("params must be a passphrase string")
+	}
+	ct := kr.ciphertext
+	if ct.Time < 1 || ct.Time > maxArgon2idTime ||
+		ct.Memory < 8 || ct.Memory > maxArgon2idMemory ||
+		ct.Threads < 1 || ct.Threads > 255 {
+		return nil, fmt.Errorf("keyring has invalid KDF parameters")
+	}
+	aesk := argon2.IDKey([]byte(*ppassphrase), ct.Salt, uint32(ct.Time), uint32(ct.Memory), uint8(ct.Threads), 32)
+	block, err := aes.NewCipher(aesk)
+	if err != nil {
+		panic(err)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err.Error())
+	}
+	//We only use the key once, so the nonce is zero
+	nonce := make([]byte, aesgcm.NonceSize())
+	plaintext, err := aesgcm.Open(nil, nonce, ct.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed")
+	}
+	rv := serdes.EntityKeyring{}
+	trailing, err := asn1.Unmarshal(plaintext, &rv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal")
+	}
+	if len(trailing) != 0 {
+		return nil, fmt.Errorf("trailing bytes")
+	}
+	return &rv, nil
+}
+func (kr *Argon2idKeyring) EncryptKeyring(ctx context.Context, plaintext *serdes.EntityKeyring, params interface{}) (encodedForm *asn1.External, err error) {
+	passphrase, ok := params.(string)
+	if !ok {
+		return nil, fmt.Errorf("requires a string passphrase")
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	ciphertext := serdes.KeyringArgon2idCiphertext{
+		Salt:    salt,
+		Time:    Argon2idTime,
+		Memory:  Argon2idMemory,
+		Threads: Argon2idThreads,
+	}
+	aesk := argon2.IDKey([]byte(passphrase), salt, Argon2idTime, Argon2idMemory, Argon2idThreads, 32)
+	block, err := aes.NewCipher(aesk)
+	if err != nil {
+		panic(err)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err.Error())
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	der, err := asn1.Marshal(*plaintext)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext.Ciphertext = aesgcm.Seal(nil, nonce, der, nil)
+	rv := asn1.NewExternal(ciphertext)
+	return &rv, nil
+}
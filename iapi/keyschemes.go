@@ -5,7 +5,10 @@ import (
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/gob"
 	"fmt"
@@ -44,6 +47,15 @@ func EntityKeySchemeInstanceFor(e *serdes.EntityPublicKey) (EntityKeySchemeInsta
 			SerdesForm: e,
 			PublicKey:  ba,
 		}, nil
+	case e.Key.OID.Equal(serdes.EntityECDSA_P256OID):
+		pub := e.Key.Content.(serdes.EntityPublicECDSA_P256)
+		if _, err := p256PublicKey(pub); err != nil {
+			return nil, err
+		}
+		return &EntityKey_ECDSA_P256{
+			SerdesForm: e,
+			PublicKey:  pub,
+		}, nil
 	case e.Key.OID.Equal(serdes.EntityIBE_BLS12381_ParamsOID):
 		rv := &EntityKey_IBE_Params_BLS12381{
 			SerdesForm: e,
@@ -112,6 +124,23 @@ func EntitySecretKeySchemeInstanceFor(e *serdes.EntityKeyringEntry) (EntitySecre
 			PublicKey:  pub,
 			PrivateKey: prv,
 		}, nil
+	case e.Private.OID.Equal(serdes.EntitySecretECDSA_P256OID):
+		pub, ok := e.Public.Key.Content.(serdes.EntityPublicECDSA_P256)
+		if !ok {
+			return nil, fmt.Errorf("public key is not a P-256 key")
+		}
+		if _, err := p256PublicKey(pub); err != nil {
+			return nil, err
+		}
+		prv := e.Private.Content.(serdes.EntitySecretECDSA_P256)
+		if len(prv) != 32 {
+			return nil, fmt.Errorf("key length is incorrect")
+		}
+		return &EntitySecretKey_ECDSA_P256{
+			SerdesForm: e,
+			PublicKey:  pub,
+			PrivateKey: prv,
+		}, nil
 	case e.Private.OID.Equal(serdes.EntitySecretIBE_BLS12381_MasterOID):
 		mk := lqibe.MasterKey{}
 		ok := mk.Unmarshal(e.Private.Content.(serdes.EntitySecretMasterIBE_BLS12381), wkdIBECompressed, wkdIBEChecked)
@@ -218,6 +247,29 @@ func NewEntityKeySchemeInstance(oid asn1.ObjectIdentifier, capabilities ...Capab
 		return &EntitySecretKey_Ed25519{SerdesForm: &ke,
 			PublicKey:  publicEd25519,
 			PrivateKey: privateEd25519}, nil
+	case oid.Equal(serdes.EntityECDSA_P256OID):
+		capz, err := checkcap(CapSigning, CapAttestation, CapCertification, CapAuthentication)
+		if err != nil {
+			return nil, err
+		}
+		sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		public := elliptic.Marshal(elliptic.P256(), sk.X, sk.Y)
+		private := make([]byte, 32)
+		d := sk.D.Bytes()
+		copy(private[32-len(d):], d)
+		ke := serdes.EntityKeyringEntry{
+			Public: serdes.EntityPublicKey{
+				Capabilities: capz,
+				Key:          asn1.NewExternal(serdes.EntityPublicECDSA_P256(public)),
+			},
+			Private: asn1.NewExternal(serdes.EntitySecretECDSA_P256(private)),
+		}
+		return &EntitySecretKey_ECDSA_P256{SerdesForm: &ke,
+			PublicKey:  public,
+			PrivateKey: private}, nil
 	case oid.Equal(serdes.EntityCurve25519OID):
 		capz, err := checkcap(CapEncryption)
 		if err != nil {
@@ -468,6 +520,178 @@ func (ek *EntitySecretKey_Ed25519) SignAttestation(ctx context.Context, content
 	return sig, nil
 }
 
+var _ EntityKeySchemeInstance = &EntityKey_ECDSA_P256{}
+
+//EntityKey_ECDSA_P256 signatures are the 64 byte concatenation of r and s
+//over the SHA-256 digest of the content, the same encoding as JWS ES256
+type EntityKey_ECDSA_P256 struct {
+	SerdesForm *serdes.EntityPublicKey
+	//An uncompressed SEC 1 point
+	PublicKey []byte
+}
+
+func p256PublicKey(pub []byte) (*ecdsa.PublicKey, error) {
+	x, y := elliptic.Unmarshal(elliptic.P256(), pub)
+	if x == nil {
+		return nil, fmt.Errorf("invalid P-256 public key")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+func p256Verify(pub []byte, data []byte, signature []byte) error {
+	pk, err := p256PublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if len(signature) != 64 {
+		return fmt.Errorf("ecdsa signature length is incorrect")
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	ss := new(big.Int).SetBytes(signature[32:])
+	digest := sha256.Sum256(data)
+	if ecdsa.Verify(pk, digest[:], r, ss) {
+		return nil
+	}
+	return fmt.Errorf("ecdsa signature invalid")
+}
+func p256Sign(pub []byte, private []byte, data []byte) ([]byte, error) {
+	pk, err := p256PublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	sk := &ecdsa.PrivateKey{PublicKey: *pk, D: new(big.Int).SetBytes(private)}
+	digest := sha256.Sum256(data)
+	r, ss, err := ecdsa.Sign(rand.Reader, sk, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 64)
+	rb, sb := r.Bytes(), ss.Bytes()
+	copy(sig[32-len(rb):32], rb)
+	copy(sig[64-len(sb):], sb)
+	return sig, nil
+}
+
+func (ek *EntityKey_ECDSA_P256) Supported() bool {
+	return true
+}
+func (ek *EntityKey_ECDSA_P256) IdentifyingBlob(ctx context.Context) (string, error) {
+	return string(ek.PublicKey), nil
+}
+func (ek *EntityKey_ECDSA_P256) SystemIdentifyingBlob(ctx context.Context) (string, error) {
+	return "", fmt.Errorf("this key is not part of a system")
+}
+func (ek *EntityKey_ECDSA_P256) HasCapability(c Capability) bool {
+	for _, has := range ek.SerdesForm.Capabilities {
+		if has == int(c) {
+			return true
+		}
+	}
+	return false
+}
+func (ek *EntityKey_ECDSA_P256) VerifyCertify(ctx context.Context, data []byte, signature []byte) error {
+	if !ek.HasCapability(CapCertification) {
+		return fmt.Errorf("this key cannot perform certifications")
+	}
+	return p256Verify(ek.PublicKey, data, signature)
+}
+func (ek *EntityKey_ECDSA_P256) VerifyAttestation(ctx context.Context, data []byte, signature []byte) error {
+	if !ek.HasCapability(CapAttestation) {
+		return fmt.Errorf("this key cannot perform attestations")
+	}
+	return p256Verify(ek.PublicKey, data, signature)
+}
+func (ek *EntityKey_ECDSA_P256) VerifyMessage(ctx context.Context, data []byte, signature []byte) error {
+	if !ek.HasCapability(CapSigning) {
+		return fmt.Errorf("this key cannot perform signing")
+	}
+	return p256Verify(ek.PublicKey, data, signature)
+}
+func (ek *EntityKey_ECDSA_P256) GenerateChildKey(ctx context.Context, identity interface{}) (EntityKeySchemeInstance, error) {
+	return nil, fmt.Errorf("this key cannot generate child keys")
+}
+func (ek *EntityKey_ECDSA_P256) EncryptMessage(ctx context.Context, data []byte) ([]byte, error) {
+	return nil, fmt.Errorf("this key cannot perform encryption")
+}
+func (ek *EntityKey_ECDSA_P256) CanonicalForm() *serdes.EntityPublicKey {
+	return ek.SerdesForm
+}
+
+//ECDSAPublicKey returns the key in the form used by crypto/ecdsa, for use
+//with other tooling
+func (ek *EntityKey_ECDSA_P256) ECDSAPublicKey() *ecdsa.PublicKey {
+	pk, err := p256PublicKey(ek.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return pk
+}
+
+var _ EntitySecretKeySchemeInstance = &EntitySecretKey_ECDSA_P256{}
+
+type EntitySecretKey_ECDSA_P256 struct {
+	SerdesForm *serdes.EntityKeyringEntry
+	PublicKey  []byte
+	PrivateKey []byte
+}
+
+func (ek *EntitySecretKey_ECDSA_P256) Supported() bool {
+	return true
+}
+func (ek *EntitySecretKey_ECDSA_P256) HasCapability(c Capability) bool {
+	for _, has := range ek.SerdesForm.Public.Capabilities {
+		if has == int(c) {
+			return true
+		}
+	}
+	return false
+}
+func (ek *EntitySecretKey_ECDSA_P256) CanonicalForm() *serdes.EntityPublicKey {
+	return &ek.SerdesForm.Public
+}
+func (ek *EntitySecretKey_ECDSA_P256) SecretCanonicalForm() *serdes.EntityKeyringEntry {
+	return ek.SerdesForm
+}
+func (ek *EntitySecretKey_ECDSA_P256) DecryptMessage(ctx context.Context, data []byte) ([]byte, error) {
+	return nil, fmt.Errorf("this key cannot perform encryption")
+}
+func (ek *EntitySecretKey_ECDSA_P256) DecryptMessageAsChild(ctx context.Context, ciphertext []byte, identity interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("this key does not support such decryption")
+}
+func (ek *EntitySecretKey_ECDSA_P256) GenerateChildSecretKey(ctx context.Context, identity interface{}, delegable bool) (EntitySecretKeySchemeInstance, error) {
+	return nil, fmt.Errorf("this key cannot generate child keys")
+}
+func (ek *EntitySecretKey_ECDSA_P256) Equal(rhs EntitySecretKeySchemeInstance) bool {
+	ekrhs, ok := rhs.(*EntitySecretKey_ECDSA_P256)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(ek.SerdesForm.Private.Bytes, ekrhs.SerdesForm.Private.Bytes)
+}
+func (ek *EntitySecretKey_ECDSA_P256) Public() EntityKeySchemeInstance {
+	return &EntityKey_ECDSA_P256{
+		SerdesForm: &ek.SerdesForm.Public,
+		PublicKey:  ek.PublicKey,
+	}
+}
+func (ek *EntitySecretKey_ECDSA_P256) SignMessage(ctx context.Context, content []byte) ([]byte, error) {
+	if !ek.HasCapability(CapSigning) {
+		return nil, fmt.Errorf("this key cannot perform signing")
+	}
+	return p256Sign(ek.PublicKey, ek.PrivateKey, content)
+}
+func (ek *EntitySecretKey_ECDSA_P256) SignCertify(ctx context.Context, content []byte) ([]byte, error) {
+	if !ek.HasCapability(CapCertification) {
+		return nil, fmt.Errorf("this key cannot perform certification")
+	}
+	return p256Sign(ek.PublicKey, ek.PrivateKey, content)
+}
+func (ek *EntitySecretKey_ECDSA_P256) SignAttestation(ctx context.Context, content []byte) ([]byte, error) {
+	if !ek.HasCapability(CapAttestation) {
+		return nil, fmt.Errorf("this key cannot perform attestation")
+	}
+	return p256Sign(ek.PublicKey, ek.PrivateKey, content)
+}
+
 var _ EntityKeySchemeInstance = &EntityKey_Curve25519{}
 
 type EntityKey_Curve25519 struct {
@@ -2,7 +2,10 @@ package iapi
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
 	"testing"
 
 	"github.com/immesys/asn1"
@@ -72,6 +75,41 @@ func TestEd25519Caps(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestECDSAP256(t *testing.T) {
+	eks, err := NewEntityKeySchemeInstance(serdes.EntityECDSA_P256OID, CapCertification, CapSigning)
+	require.NoError(t, err)
+	msg := make([]byte, 32)
+	rand.Read(msg)
+	sig, err := eks.SignMessage(context.Background(), msg)
+	require.NoError(t, err)
+	require.Equal(t, 64, len(sig))
+
+	//verify after a round trip through the canonical form
+	pub, err := EntityKeySchemeInstanceFor(eks.Public().CanonicalForm())
+	require.NoError(t, err)
+	require.NoError(t, pub.VerifyMessage(context.Background(), msg, sig))
+	require.Error(t, pub.VerifyAttestation(context.Background(), msg, sig))
+	msg[0] ^= 1
+	require.Error(t, pub.VerifyMessage(context.Background(), msg, sig))
+	msg[0] ^= 1
+
+	//the signature is what JWS ES256 expects
+	digest := sha256.Sum256(msg)
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	require.True(t, ecdsa.Verify(pub.(*EntityKey_ECDSA_P256).ECDSAPublicKey(), digest[:], r, s))
+
+	eks2, err := EntitySecretKeySchemeInstanceFor(eks.SecretCanonicalForm())
+	require.NoError(t, err)
+	require.True(t, eks.Equal(eks2))
+	sig2, err := eks2.SignCertify(context.Background(), msg)
+	require.NoError(t, err)
+	require.NoError(t, eks.Public().VerifyCertify(context.Background(), msg, sig2))
+
+	_, err = NewEntityKeySchemeInstance(serdes.EntityECDSA_P256OID, CapEncryption)
+	require.Error(t, err)
+}
+
 func TestCurve25519(t *testing.T) {
 	eks, err := NewEntityKeySchemeInstance(serdes.EntityCurve25519OID)
 	require.NoError(t, err)
@@ -0,0 +1,69 @@
+package iapi
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/immesys/wave/serdes"
+)
+
+//HasContentFilters returns true if the filter needs the decrypted body of
+//an attestation to be evaluated
+func (f *LookupFromFilter) HasContentFilters() bool {
+	return len(f.Namespace) != 0 || len(f.PermissionSet) != 0 ||
+		f.Permission != "" || f.ResourcePrefix != "" ||
+		f.ExpiresAfter != nil || f.ExpiresBefore != nil
+}
+
+//MatchesContent checks the attestation against the namespace, policy and
+//expiry filters. Attestations that are not decrypted only match a filter
+//without such conditions
+func (f *LookupFromFilter) MatchesContent(att *Attestation) bool {
+	if !f.HasContentFilters() {
+		return true
+	}
+	if att.DecryptedBody == nil {
+		return false
+	}
+	validity := att.DecryptedBody.VerifierBody.Validity
+	if f.ExpiresAfter != nil && validity.NotAfter.Before(*f.ExpiresAfter) {
+		return false
+	}
+	if f.ExpiresBefore != nil && validity.NotAfter.After(*f.ExpiresBefore) {
+		return false
+	}
+	if len(f.Namespace) == 0 && len(f.PermissionSet) == 0 && f.Permission == "" && f.ResourcePrefix == "" {
+		return true
+	}
+	rtree, ok := att.DecryptedBody.VerifierBody.Policy.Content.(serdes.RTreePolicy)
+	if !ok {
+		return false
+	}
+	if len(f.Namespace) != 0 && !bytes.Equal(HashSchemeInstanceFor(&rtree.Namespace).Multihash(), f.Namespace) {
+		return false
+	}
+	for _, st := range rtree.Statements {
+		if f.matchesStatement(&st) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *LookupFromFilter) matchesStatement(st *serdes.RTreeStatement) bool {
+	if len(f.PermissionSet) != 0 && !bytes.Equal(HashSchemeInstanceFor(&st.PermissionSet).Multihash(), f.PermissionSet) {
+		return false
+	}
+	if f.ResourcePrefix != "" && !strings.HasPrefix(st.Resource, f.ResourcePrefix) {
+		return false
+	}
+	if f.Permission == "" {
+		return true
+	}
+	for _, perm := range st.Permissions {
+		if perm == f.Permission {
+			return true
+		}
+	}
+	return false
+}
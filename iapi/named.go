@@ -72,7 +72,11 @@ func CreateNameDeclaration(ctx context.Context, p *PCreateNameDeclaration) (*RCr
 	if p.ValidUntil != nil {
 		body.Validity.NotAfter = *p.ValidUntil
 	} else {
-		body.Validity.NotAfter = time.Now().Add(3 * 365 * 24 * time.Hour)
+		maxRange := 3 * 365 * 24 * time.Hour
+		if p.Namespace != nil && p.Namespace.WR1_PartitionSchedule().MaxRange < maxRange {
+			maxRange = p.Namespace.WR1_PartitionSchedule().MaxRange
+		}
+		body.Validity.NotAfter = body.Validity.NotBefore.Add(maxRange)
 	}
 	if body.Validity.NotBefore.After(body.Validity.NotAfter) {
 		return nil, wve.Err(wve.InvalidParameter, "invalid validity times")
@@ -94,7 +98,7 @@ func CreateNameDeclaration(ctx context.Context, p *PCreateNameDeclaration) (*RCr
 	} else {
 		expandedPartition, uerr := CalculateWR1Partition(body.Validity.NotBefore,
 			body.Validity.NotAfter,
-			p.Partition)
+			p.Partition, p.Namespace.WR1_PartitionSchedule())
 		if uerr != nil {
 			return nil, wve.ErrW(wve.InvalidParameter, "could not form partition", uerr)
 		}
@@ -162,7 +166,7 @@ func CreateNameDeclaration(ctx context.Context, p *PCreateNameDeclaration) (*RCr
 		panic(err)
 	}
 
-	secret1 := p.Attester.Keyring[0].SecretCanonicalForm().Private.Content.(serdes.EntitySecretEd25519)
+	secret1 := p.Attester.primarySecret()
 	ro := NewCommitmentRevocationSchemeInstance(p.SubjectLocation, true, secret1, tbsDER)
 	outer.TBS.Revocations = append(outer.TBS.Revocations, ro.CanonicalForm())
 
@@ -120,6 +120,67 @@ func (e *Entity) MessageVerifyingKey() EntityKeySchemeInstance {
 	}
 	return &UnsupportedKeyScheme{}
 }
+type EntitySuccession struct {
+	CanonicalForm       *serdes.WaveEntitySuccession
+	Predecessor         HashSchemeInstance
+	PredecessorLocation LocationSchemeInstance
+	Successor           HashSchemeInstance
+	SuccessorLocation   LocationSchemeInstance
+}
+
+func (s *EntitySuccession) SetCanonicalForm(cf *serdes.WaveEntitySuccession) wve.WVE {
+	pred := HashSchemeInstanceFor(&cf.TBS.Predecessor)
+	if !pred.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported predecessor hash scheme")
+	}
+	predloc := LocationSchemeInstanceFor(&cf.TBS.PredecessorLocation)
+	if !predloc.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported predecessor location scheme")
+	}
+	succ := HashSchemeInstanceFor(&cf.TBS.Successor)
+	if !succ.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported successor hash scheme")
+	}
+	succloc := LocationSchemeInstanceFor(&cf.TBS.SuccessorLocation)
+	if !succloc.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported successor location scheme")
+	}
+	s.CanonicalForm = cf
+	s.Predecessor = pred
+	s.PredecessorLocation = predloc
+	s.Successor = succ
+	s.SuccessorLocation = succloc
+	return nil
+}
+func (s *EntitySuccession) DER() ([]byte, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	wo.Content = asn1.NewExternal(*s.CanonicalForm)
+	rv, err := asn1.Marshal(wo.Content)
+	if err != nil {
+		return nil, wve.Err(wve.MalformedDER, "could not produce DER")
+	}
+	return rv, nil
+}
+func (s *EntitySuccession) Hash(scheme HashScheme) HashSchemeInstance {
+	der, err := s.DER()
+	if err != nil {
+		panic(err)
+	}
+	return scheme.Instance(der)
+}
+func (s *EntitySuccession) Keccak256HI() HashSchemeInstance {
+	return s.Hash(KECCAK256)
+}
+func (s *EntitySuccession) GraceUntil() time.Time {
+	return s.CanonicalForm.TBS.GraceUntil
+}
+
+//InGrace returns true if attestations to the predecessor may still be
+//used by the successor at the given time
+func (s *EntitySuccession) InGrace(t time.Time) bool {
+	return !t.Before(s.CanonicalForm.TBS.Created) && t.Before(s.CanonicalForm.TBS.GraceUntil)
+}
+
 func (e *Entity) Hash(scheme HashScheme) HashSchemeInstance {
 	der, err := e.DER()
 	if err != nil {
@@ -154,6 +215,16 @@ func (e *Entity) WR1_BodyParams() (EntityKeySchemeInstance, error) {
 	}
 	return nil, fmt.Errorf("no WR1 OAQUE params found")
 }
+
+//WR1_PartitionSchedule returns the partition schedule used for attestations
+//and messages on the namespace this entity represents
+func (e *Entity) WR1_PartitionSchedule() *WR1PartitionSchedule {
+	rv, err := entityPartitionSchedule(e.CanonicalForm)
+	if err != nil || rv == nil {
+		return DefaultWR1PartitionSchedule
+	}
+	return rv
+}
 func (e *Entity) WR1_DirectEncryptionKey() (EntityKeySchemeInstance, error) {
 	//curve25519
 	for _, kr := range e.Keys {
@@ -199,7 +270,7 @@ type EntitySecrets struct {
 }
 
 func (e *EntitySecrets) CommitmentRevocationDetails() (content []byte, loc []LocationSchemeInstance) {
-	secret := e.Keyring[0].SecretCanonicalForm().Private.Content.(serdes.EntitySecretEd25519)
+	secret := e.primarySecret()
 	hash := []byte("revocation")
 	hash = append(hash, secret...)
 	hi := KECCAK256.Instance(hash)
@@ -218,7 +289,7 @@ func (e *EntitySecrets) CommitmentRevocationDetails() (content []byte, loc []Loc
 	return hi.Value(), locs
 }
 func (e *EntitySecrets) AttestationRevocationDetails(att *Attestation) ([]byte, LocationSchemeInstance, wve.WVE) {
-	secret1 := e.Keyring[0].SecretCanonicalForm().Private.Content.(serdes.EntitySecretEd25519)
+	secret1 := e.primarySecret()
 	hash := []byte("revocation")
 	hash = append(hash, secret1...)
 	os, ok := att.CanonicalForm.OuterSignature.Content.(serdes.Ed25519OuterSignature)
@@ -242,7 +313,7 @@ func (e *EntitySecrets) AttestationRevocationDetails(att *Attestation) ([]byte,
 	return hi.Value(), subjloc, nil
 }
 func (e *EntitySecrets) NameDeclarationRevocationDetails(nd *NameDeclaration) ([]byte, LocationSchemeInstance, wve.WVE) {
-	secret1 := e.Keyring[0].SecretCanonicalForm().Private.Content.(serdes.EntitySecretEd25519)
+	secret1 := e.primarySecret()
 
 	modified_tbs := nd.CanonicalForm.TBS
 	modified_tbs.Revocations = nil
@@ -260,6 +331,21 @@ func (e *EntitySecrets) NameDeclarationRevocationDetails(nd *NameDeclaration) ([
 func (e *EntitySecrets) PrimarySigningKey() EntitySecretKeySchemeInstance {
 	return e.Keyring[0]
 }
+
+//primarySecret returns the private key material of the primary signing key.
+//Revocation secrets are derived from it
+func (e *EntitySecrets) primarySecret() []byte {
+	return keyringEntrySecret(e.Keyring[0].SecretCanonicalForm())
+}
+func keyringEntrySecret(ke *serdes.EntityKeyringEntry) []byte {
+	switch secret := ke.Private.Content.(type) {
+	case serdes.EntitySecretEd25519:
+		return secret
+	case serdes.EntitySecretECDSA_P256:
+		return secret
+	}
+	panic("primary signing key has an unsupported scheme")
+}
 func (e *EntitySecrets) MessageSigningKey() EntitySecretKeySchemeInstance {
 	for _, k := range e.Keyring {
 		if k.Public().HasCapability(CapSigning) {
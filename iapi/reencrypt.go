@@ -0,0 +1,303 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+)
+
+//MessageRecipient is one of the keys of an encrypted message
+type MessageRecipient struct {
+	//Direct recipients are only identified if they are the decryptor
+	Direct  bool
+	Subject HashSchemeInstance
+	//The namespace is always known for OAQUE recipients, the partition and
+	//resource only if the decryptor could open the envelope
+	Namespace         HashSchemeInstance
+	NamespaceLocation LocationSchemeInstance
+	Resource          string
+	Partition         [][]byte
+}
+
+//ReadMessageRecipients lists the recipients of an encrypted message, with
+//as much detail as the decryptor can see
+func ReadMessageRecipients(ctx context.Context, p *PDecryptMessage) ([]*MessageRecipient, wve.WVE) {
+	keys, _, werr := messageKeyWraps(p.Ciphertext)
+	if werr != nil {
+		return nil, werr
+	}
+	rv := []*MessageRecipient{}
+	for _, k := range keys {
+		switch key := k.Content.(type) {
+		case serdes.MessageKeyCurve25519ECDH:
+			r := &MessageRecipient{Direct: true}
+			ddk, err := p.Decryptor.WR1DirectDecryptionKey(ctx)
+			if err == nil {
+				if _, err := ddk.DecryptMessage(ctx, key.Ciphertext); err == nil {
+					r.Subject = p.Decryptor.Entity.Keccak256HI()
+				}
+			}
+			rv = append(rv, r)
+		case serdes.MessageKeyWR1:
+			r := &MessageRecipient{
+				Namespace:         HashSchemeInstanceFor(&key.Namespace),
+				NamespaceLocation: LocationSchemeInstanceFor(&key.NamespaceLocation),
+			}
+			envelope, werr := wr1MessageEnvelope(ctx, p, &key)
+			if werr != nil {
+				return nil, werr
+			}
+			if envelope != nil {
+				r.Partition = envelopePartition(envelope)
+				r.Resource = messageResource(r.Partition)
+			}
+			rv = append(rv, r)
+		default:
+			return nil, wve.Err(wve.UnsupportedKeyScheme, "message has an unsupported key")
+		}
+	}
+	return rv, nil
+}
+
+//messageKeyWraps returns the keys of a message or of the header of a
+//streamed message. Streamed messages start with the header length, which
+//begins with a zero byte, and DER encoded messages never do. The header is
+//only returned for streamed messages
+func messageKeyWraps(ciphertext []byte) ([]asn1.External, *serdes.WaveEncryptedStream, wve.WVE) {
+	if len(ciphertext) >= 4 && ciphertext[0] == 0 {
+		hlen := binary.BigEndian.Uint32(ciphertext)
+		if hlen > maxStreamHeaderSize || uint32(len(ciphertext)-4) < hlen {
+			return nil, nil, wve.Err(wve.MalformedObject, "stream header is truncated")
+		}
+		header, werr := parseStreamHeader(ciphertext[4 : 4+hlen])
+		if werr != nil {
+			return nil, nil, werr
+		}
+		return header.Keys, header, nil
+	}
+	wo := serdes.WaveWireObject{}
+	rest, err := asn1.Unmarshal(ciphertext, &wo.Content)
+	if len(rest) != 0 || err != nil {
+		return nil, nil, wve.Err(wve.InvalidParameter, "message is malformed")
+	}
+	msg, ok := wo.Content.Content.(serdes.WaveEncryptedMessage)
+	if !ok {
+		return nil, nil, wve.Err(wve.InvalidParameter, "ciphertext is not a wave encrypted message")
+	}
+	return msg.Keys, nil, nil
+}
+
+//messageResource recovers the resource from a message partition, which
+//never has wildcards in it
+func messageResource(partition [][]byte) string {
+	parts := []string{}
+	for i := 1; i < 12 && i < len(partition); i++ {
+		if len(partition[i]) == 0 || bytes.Equal(partition[i], []byte("\x00")) {
+			break
+		}
+		parts = append(parts, string(partition[i]))
+	}
+	return strings.Join(parts, "/")
+}
+
+//encryptedRecipients lists the recipients that EncryptMessage would
+//produce for p
+func encryptedRecipients(p *PEncryptMessage) ([]*MessageRecipient, wve.WVE) {
+	subjects := p.Subjects
+	if p.Subject != nil {
+		subjects = append([]*Entity{p.Subject}, subjects...)
+	}
+	targets := p.Targets
+	if p.Namespace != nil {
+		targets = append([]*EncryptionTarget{{
+			Namespace:         p.Namespace,
+			NamespaceLocation: p.NamespaceLocation,
+			Resource:          p.Resource,
+			ValidAfter:        p.ValidAfter,
+			ValidBefore:       p.ValidBefore,
+		}}, targets...)
+	}
+	rv := []*MessageRecipient{}
+	for _, s := range subjects {
+		rv = append(rv, &MessageRecipient{
+			Direct:  true,
+			Subject: s.Keccak256HI(),
+		})
+	}
+	for _, t := range targets {
+		partition, werr := messageTargetPartition(t)
+		if werr != nil {
+			return nil, werr
+		}
+		rv = append(rv, &MessageRecipient{
+			Namespace:         t.Namespace.Keccak256HI(),
+			NamespaceLocation: t.NamespaceLocation,
+			Resource:          t.Resource,
+			Partition:         partition,
+		})
+	}
+	return rv, nil
+}
+
+//sameRecipient returns true if the new recipient can decrypt with exactly
+//the keys that could decrypt for the old one
+func sameRecipient(prev *MessageRecipient, next *MessageRecipient) bool {
+	if prev.Direct != next.Direct {
+		return false
+	}
+	if prev.Direct {
+		return prev.Subject != nil && next.Subject != nil &&
+			prev.Subject.MultihashString() == next.Subject.MultihashString()
+	}
+	if prev.Partition == nil || next.Partition == nil ||
+		prev.Namespace.MultihashString() != next.Namespace.MultihashString() ||
+		len(prev.Partition) != len(next.Partition) {
+		return false
+	}
+	for i := range prev.Partition {
+		if !bytes.Equal(prev.Partition[i], next.Partition[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type PReencryptMessage struct {
+	Decryptor  *EntitySecrets
+	Dctx       WR1MessageDecryptionContext
+	Ciphertext []byte
+	//The recipients of the new message, the content is ignored
+	Recipients *PEncryptMessage
+}
+type RReencryptMessage struct {
+	Ciphertext []byte
+	Recipients []*MessageRecipient
+	//The recipients of the original message that are not recipients of the
+	//new one. Direct recipients that the decryptor could not identify are
+	//always included, as are OAQUE recipients whose envelope it could not
+	//open
+	LostAccess []*MessageRecipient
+}
+
+//ReencryptMessage decrypts a message and encrypts its content again for a
+//new set of recipients, typically a fresh validity window or a new
+//namespace after decryption keys for the old one have leaked. Streamed
+//messages are re-encrypted as streams with the same segment size
+func ReencryptMessage(ctx context.Context, p *PReencryptMessage) (*RReencryptMessage, wve.WVE) {
+	if p.Recipients == nil {
+		return nil, wve.Err(wve.InvalidParameter, "new recipients are required")
+	}
+	dec := &PDecryptMessage{
+		Decryptor:  p.Decryptor,
+		Ciphertext: p.Ciphertext,
+		Dctx:       p.Dctx,
+	}
+	_, stream, werr := messageKeyWraps(p.Ciphertext)
+	if werr != nil {
+		return nil, werr
+	}
+	content, werr := reencryptContent(ctx, dec, stream != nil)
+	if werr != nil {
+		return nil, werr
+	}
+	old, werr := ReadMessageRecipients(ctx, dec)
+	if werr != nil {
+		return nil, werr
+	}
+	params := *p.Recipients
+	params.Content = content
+	var ciphertext []byte
+	if stream != nil {
+		ciphertext, werr = encryptStreamContent(ctx, &params, stream.SegmentSize)
+	} else {
+		var enc *REncryptMessage
+		enc, werr = EncryptMessage(ctx, &params)
+		if enc != nil {
+			ciphertext = enc.Ciphertext
+		}
+	}
+	if werr != nil {
+		return nil, werr
+	}
+	recipients, werr := encryptedRecipients(&params)
+	if werr != nil {
+		return nil, werr
+	}
+	rv := &RReencryptMessage{
+		Ciphertext: ciphertext,
+		Recipients: recipients,
+	}
+	for _, o := range old {
+		kept := false
+		for _, n := range recipients {
+			if sameRecipient(o, n) {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			rv.LostAccess = append(rv.LostAccess, o)
+		}
+	}
+	return rv, nil
+}
+
+//reencryptContent decrypts a message or a streamed message
+func reencryptContent(ctx context.Context, p *PDecryptMessage, stream bool) ([]byte, wve.WVE) {
+	if !stream {
+		plain, werr := DecryptMessage(ctx, p)
+		if werr != nil {
+			return nil, werr
+		}
+		return plain.Content, nil
+	}
+	rd, werr := DecryptStream(ctx, &PDecryptStream{
+		Decryptor: p.Decryptor,
+		Dctx:      p.Dctx,
+		Input:     bytes.NewReader(p.Ciphertext),
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	content, err := ioutil.ReadAll(rd.Reader)
+	if werr, ok := err.(wve.WVE); ok {
+		return nil, werr
+	}
+	if err != nil {
+		return nil, wve.ErrW(wve.MessageDecryptionError, "could not decrypt stream", err)
+	}
+	return content, nil
+}
+
+//encryptStreamContent encrypts the content of p as a streamed message
+func encryptStreamContent(ctx context.Context, p *PEncryptMessage, segmentSize int) ([]byte, wve.WVE) {
+	out := &bytes.Buffer{}
+	enc, werr := EncryptStream(ctx, &PEncryptStream{
+		Subject:           p.Subject,
+		Namespace:         p.Namespace,
+		NamespaceLocation: p.NamespaceLocation,
+		Resource:          p.Resource,
+		ValidAfter:        p.ValidAfter,
+		ValidBefore:       p.ValidBefore,
+		Subjects:          p.Subjects,
+		Targets:           p.Targets,
+		SegmentSize:       segmentSize,
+		Output:            out,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	if _, err := enc.Writer.Write(p.Content); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not encrypt stream", err)
+	}
+	if err := enc.Writer.Close(); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not encrypt stream", err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,410 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+)
+
+//How long a list published without an explicit next update is relied on
+const DefaultRevocationListValidity = 24 * time.Hour
+
+//How long a fetched revocation list is used before storage is checked for
+//a newer version
+var RevocationListCacheTime = 5 * time.Minute
+
+//RevocationListQueue returns the storage queue that versions of a
+//revocation list are published to
+func RevocationListQueue(scheme HashScheme, revoker HashSchemeInstance, listID []byte) HashSchemeInstance {
+	content := []byte("revocationlist")
+	content = append(content, revoker.Multihash()...)
+	content = append(content, listID...)
+	return scheme.Instance(content)
+}
+
+type RevocationListSchemeInstance struct {
+	SerdesForm *serdes.RevocationOption
+	RLBody     *serdes.RevocationListRevocation
+}
+
+//NewRevocationListSchemeInstance creates a revocation option with a new
+//random ID that the revoker can add to the named list
+func NewRevocationListSchemeInstance(revoker HashSchemeInstance, revokerLocation LocationSchemeInstance, listID []byte, critical bool) *RevocationListSchemeInstance {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	RB := serdes.RevocationListRevocation{
+		Revoker:         *revoker.CanonicalForm(),
+		RevokerLocation: *revokerLocation.CanonicalForm(),
+		ListID:          listID,
+		ID:              id,
+	}
+	SDF := serdes.RevocationOption{
+		Critical: critical,
+		Scheme:   asn1.NewExternal(RB),
+	}
+	return &RevocationListSchemeInstance{
+		SerdesForm: &SDF,
+		RLBody:     &RB,
+	}
+}
+
+func (rs *RevocationListSchemeInstance) Supported() bool {
+	return true
+}
+
+func (rs *RevocationListSchemeInstance) Critical() bool {
+	return rs.SerdesForm.Critical
+}
+
+func (rs *RevocationListSchemeInstance) CanonicalForm() serdes.RevocationOption {
+	return *rs.SerdesForm
+}
+
+func (rs *RevocationListSchemeInstance) Id() string {
+	return "rl:" + base64.URLEncoding.EncodeToString(rs.RLBody.ID)
+}
+
+func (rs *RevocationListSchemeInstance) Revoker() HashSchemeInstance {
+	return HashSchemeInstanceFor(&rs.RLBody.Revoker)
+}
+
+func (rs *RevocationListSchemeInstance) RevokerLocation() LocationSchemeInstance {
+	return LocationSchemeInstanceFor(&rs.RLBody.RevokerLocation)
+}
+
+func (rs *RevocationListSchemeInstance) IsRevoked(ctx context.Context, s StorageInterface) (bool, wve.WVE) {
+	revoker := rs.Revoker()
+	loc := rs.RevokerLocation()
+	if !revoker.Supported() || !loc.Supported() {
+		return rs.Critical(), nil
+	}
+	list, werr := LatestRevocationList(ctx, s, revoker, loc, rs.RLBody.ListID)
+	if werr != nil {
+		return false, werr
+	}
+	if list == nil {
+		//The revoker has not revoked anything yet
+		return false, nil
+	}
+	if time.Now().After(list.NextUpdate()) {
+		return false, wve.Err(wve.StorageError, "revocation list is stale")
+	}
+	return list.Contains(rs.RLBody.ID), nil
+}
+
+type RevocationList struct {
+	CanonicalForm *serdes.WaveRevocationList
+	Revoker       HashSchemeInstance
+	revoked       map[string]bool
+}
+
+func (l *RevocationList) SetCanonicalForm(cf *serdes.WaveRevocationList) wve.WVE {
+	revoker := HashSchemeInstanceFor(&cf.TBS.Revoker)
+	if !revoker.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported revoker hash scheme")
+	}
+	l.CanonicalForm = cf
+	l.Revoker = revoker
+	l.revoked = make(map[string]bool)
+	for _, id := range cf.TBS.Revoked {
+		l.revoked[string(id)] = true
+	}
+	return nil
+}
+func (l *RevocationList) DER() ([]byte, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	wo.Content = asn1.NewExternal(*l.CanonicalForm)
+	rv, err := asn1.Marshal(wo.Content)
+	if err != nil {
+		return nil, wve.Err(wve.MalformedDER, "could not produce DER")
+	}
+	return rv, nil
+}
+func (l *RevocationList) Version() int {
+	return l.CanonicalForm.TBS.Version
+}
+func (l *RevocationList) ListID() []byte {
+	return l.CanonicalForm.TBS.ListID
+}
+func (l *RevocationList) NextUpdate() time.Time {
+	return l.CanonicalForm.TBS.NextUpdate
+}
+func (l *RevocationList) Revoked() [][]byte {
+	return l.CanonicalForm.TBS.Revoked
+}
+func (l *RevocationList) Contains(id []byte) bool {
+	return l.revoked[string(id)]
+}
+
+//Verify checks that the list was signed by the given revoker
+func (l *RevocationList) Verify(ctx context.Context, revoker *Entity) wve.WVE {
+	if !HashSchemeInstanceEqual(revoker.Keccak256HI(), l.Revoker) {
+		return wve.Err(wve.InvalidParameter, "entity is not the revoker named in the list")
+	}
+	tbs, err := asn1.Marshal(l.CanonicalForm.TBS)
+	if err != nil {
+		return wve.Err(wve.MalformedObject, "could not marshal revocation list")
+	}
+	err = revoker.VerifyingKey.VerifyCertify(ctx, tbs, l.CanonicalForm.Signature)
+	if err != nil {
+		return wve.Err(wve.InvalidSignature, "revocation list signature failed check")
+	}
+	return nil
+}
+
+type PCreateRevocationList struct {
+	Revoker *EntitySecrets
+	ListID  []byte
+	//Must be higher than the version of the list being replaced
+	Version int
+	Revoked [][]byte
+	//If not specified defaults to Now+DefaultRevocationListValidity. It is
+	//capped at the expiry of the revoker
+	NextUpdate *time.Time
+}
+type RCreateRevocationList struct {
+	List *RevocationList
+	DER  []byte
+}
+
+func CreateRevocationList(ctx context.Context, p *PCreateRevocationList) (*RCreateRevocationList, wve.WVE) {
+	if p.Revoker == nil || len(p.ListID) == 0 {
+		return nil, wve.Err(wve.MissingParameter, "revoker and list ID must be specified")
+	}
+	now := time.Now()
+	nextUpdate := now.Add(DefaultRevocationListValidity)
+	if p.NextUpdate != nil {
+		nextUpdate = *p.NextUpdate
+	}
+	revokerExpiry := p.Revoker.Entity.CanonicalForm.TBS.Validity.NotAfter
+	if nextUpdate.After(revokerExpiry) {
+		nextUpdate = revokerExpiry
+	}
+	if !nextUpdate.After(now) {
+		return nil, wve.Err(wve.InvalidParameter, "next update is in the past")
+	}
+	cf := serdes.WaveRevocationList{}
+	cf.TBS.Revoker = *p.Revoker.Entity.Keccak256HI().CanonicalForm()
+	cf.TBS.ListID = p.ListID
+	cf.TBS.Version = p.Version
+	cf.TBS.Created = now
+	cf.TBS.NextUpdate = nextUpdate
+	cf.TBS.Revoked = p.Revoked
+	if cf.TBS.Revoked == nil {
+		cf.TBS.Revoked = [][]byte{}
+	}
+	tbsDER, err := asn1.Marshal(cf.TBS)
+	if err != nil {
+		panic(err)
+	}
+	sig, err := p.Revoker.PrimarySigningKey().SignCertify(ctx, tbsDER)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not sign revocation list", err)
+	}
+	cf.Signature = sig
+
+	l := &RevocationList{}
+	if werr := l.SetCanonicalForm(&cf); werr != nil {
+		return nil, werr
+	}
+	der, werr := l.DER()
+	if werr != nil {
+		return nil, werr
+	}
+	return &RCreateRevocationList{
+		List: l,
+		DER:  der,
+	}, nil
+}
+
+type PParseRevocationList struct {
+	DER []byte
+	//If present, the list signature is checked against this entity
+	Revoker *Entity
+}
+type RParseRevocationList struct {
+	List        *RevocationList
+	IsMalformed bool
+}
+
+func ParseRevocationList(ctx context.Context, p *PParseRevocationList) (*RParseRevocationList, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	rest, err := asn1.Unmarshal(p.DER, &wo.Content)
+	if err != nil || len(rest) != 0 {
+		return &RParseRevocationList{IsMalformed: true}, wve.Err(wve.MalformedDER, "DER did not parse")
+	}
+	cf, ok := wo.Content.Content.(serdes.WaveRevocationList)
+	if !ok {
+		return &RParseRevocationList{IsMalformed: true}, wve.Err(wve.UnexpectedObject, "DER is not a wave revocation list")
+	}
+	l := &RevocationList{}
+	if werr := l.SetCanonicalForm(&cf); werr != nil {
+		return &RParseRevocationList{IsMalformed: true}, werr
+	}
+	if p.Revoker != nil {
+		if werr := l.Verify(ctx, p.Revoker); werr != nil {
+			return &RParseRevocationList{IsMalformed: true}, werr
+		}
+	}
+	return &RParseRevocationList{
+		List: l,
+	}, nil
+}
+
+//The most lists that are cached. When full the least recently fetched
+//list is dropped
+const revocationListCacheSize = 4096
+
+type revocationListCacheEntry struct {
+	list    *RevocationList
+	fetched time.Time
+	//The queue is append only, so a refresh resumes from here
+	token         string
+	revokerEntity *Entity
+}
+
+var revocationListCache = make(map[string]*revocationListCacheEntry)
+var revocationListCacheMu sync.Mutex
+
+//ForgetRevocationList makes the next check of a cached list go to
+//storage, used after publishing a new version
+func ForgetRevocationList(revoker HashSchemeInstance, loc LocationSchemeInstance, listID []byte) {
+	key := revocationListCacheKey(revoker, loc, listID)
+	revocationListCacheMu.Lock()
+	if cached, ok := revocationListCache[key]; ok {
+		cached.fetched = time.Time{}
+	}
+	revocationListCacheMu.Unlock()
+}
+
+func revocationListCacheKey(revoker HashSchemeInstance, loc LocationSchemeInstance, listID []byte) string {
+	lochash := loc.IdHash()
+	return revoker.MultihashString() + "/" + base64.URLEncoding.EncodeToString(lochash[:]) + "/" + base64.URLEncoding.EncodeToString(listID)
+}
+
+func cacheRevocationList(key string, entry *revocationListCacheEntry) {
+	revocationListCacheMu.Lock()
+	defer revocationListCacheMu.Unlock()
+	if _, ok := revocationListCache[key]; !ok && len(revocationListCache) >= revocationListCacheSize {
+		oldest := ""
+		for k, e := range revocationListCache {
+			if oldest == "" || e.fetched.Before(revocationListCache[oldest].fetched) {
+				oldest = k
+			}
+		}
+		delete(revocationListCache, oldest)
+	}
+	revocationListCache[key] = entry
+}
+
+type revokersCheckingKey struct{}
+
+//checkRevoker returns an error if the revoker is expired, not yet valid or
+//revoked, in which case nothing it publishes can be relied on. A revoker
+//whose revocation options lead back to itself is not checked again while
+//it is being checked
+func checkRevoker(ctx context.Context, s StorageInterface, revoker *Entity) wve.WVE {
+	if revoker.Expired() || revoker.CanonicalForm.TBS.Validity.NotBefore.After(time.Now()) {
+		return wve.Err(wve.InvalidParameter, "revoker is expired or not yet valid")
+	}
+	id := revoker.Keccak256HI().MultihashString()
+	checking, _ := ctx.Value(revokersCheckingKey{}).(map[string]bool)
+	if checking[id] {
+		return nil
+	}
+	next := map[string]bool{id: true}
+	for k := range checking {
+		next[k] = true
+	}
+	ctx = context.WithValue(ctx, revokersCheckingKey{}, next)
+	for _, r := range revoker.Revocations {
+		revoked, werr := r.IsRevoked(ctx, s)
+		if werr != nil {
+			return werr
+		}
+		if revoked {
+			return wve.Err(wve.InvalidParameter, "revoker has been revoked")
+		}
+	}
+	return nil
+}
+
+//LatestRevocationList returns the highest versioned, correctly signed
+//list in the revoker's queue, or nil if none has been published. Lists
+//are cached for RevocationListCacheTime, after which only the part of the
+//queue added since is read. It is an error if the revoker is no longer
+//valid
+func LatestRevocationList(ctx context.Context, s StorageInterface, revoker HashSchemeInstance, loc LocationSchemeInstance, listID []byte) (*RevocationList, wve.WVE) {
+	key := revocationListCacheKey(revoker, loc, listID)
+	revocationListCacheMu.Lock()
+	cached, ok := revocationListCache[key]
+	entry := &revocationListCacheEntry{}
+	if ok {
+		*entry = *cached
+	}
+	revocationListCacheMu.Unlock()
+	if ok && time.Now().Sub(entry.fetched) < RevocationListCacheTime {
+		if entry.list == nil || time.Now().Before(entry.list.NextUpdate()) {
+			return entry.list, nil
+		}
+	}
+
+	scheme, err := s.HashSchemeFor(loc)
+	if err != nil {
+		return nil, wve.ErrW(wve.StorageError, "could not get revoker location hash scheme", err)
+	}
+	queue := RevocationListQueue(scheme, revoker, listID)
+	for {
+		object, nextToken, err := s.IterateQeueue(ctx, loc, queue, entry.token)
+		if err == ErrNoMore || (err == nil && object == nil) {
+			break
+		}
+		if err != nil {
+			return nil, wve.ErrW(wve.StorageError, "could not read revocation list queue", err)
+		}
+		entry.token = nextToken
+		der, err := s.GetBlob(ctx, loc, object)
+		if err != nil {
+			return nil, wve.ErrW(wve.StorageError, "could not get revocation list", err)
+		}
+		rv, werr := ParseRevocationList(ctx, &PParseRevocationList{DER: der})
+		if werr != nil {
+			//Anyone can add to the queue, so junk is skipped
+			continue
+		}
+		list := rv.List
+		if !HashSchemeInstanceEqual(list.Revoker, revoker) || !bytes.Equal(list.ListID(), listID) {
+			continue
+		}
+		if entry.list != nil && list.Version() <= entry.list.Version() {
+			continue
+		}
+		if entry.revokerEntity == nil {
+			entry.revokerEntity, err = s.GetEntity(ctx, loc, revoker)
+			if err != nil {
+				return nil, wve.ErrW(wve.StorageError, "could not get revoker entity", err)
+			}
+		}
+		if list.Verify(ctx, entry.revokerEntity) != nil {
+			continue
+		}
+		entry.list = list
+	}
+	if entry.revokerEntity != nil {
+		if werr := checkRevoker(ctx, s, entry.revokerEntity); werr != nil {
+			return nil, werr
+		}
+	}
+	entry.fetched = time.Now()
+	cacheRevocationList(key, entry)
+	return entry.list, nil
+}
@@ -0,0 +1,147 @@
+package iapi
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationList(t *testing.T) {
+	ctx := context.Background()
+	revoker, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	other, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	rv, werr := CreateRevocationList(ctx, &PCreateRevocationList{
+		Revoker: revoker.EntitySecrets,
+		ListID:  []byte("staff"),
+		Version: 3,
+		Revoked: [][]byte{[]byte("id1"), []byte("id2")},
+	})
+	require.NoError(t, werr)
+
+	parsed, werr := ParseRevocationList(ctx, &PParseRevocationList{
+		DER:     rv.DER,
+		Revoker: revoker.Entity,
+	})
+	require.NoError(t, werr)
+	require.Equal(t, 3, parsed.List.Version())
+	require.Equal(t, []byte("staff"), parsed.List.ListID())
+	require.True(t, parsed.List.Contains([]byte("id2")))
+	require.False(t, parsed.List.Contains([]byte("id3")))
+
+	//Only the revoker can sign the list
+	parsed, werr = ParseRevocationList(ctx, &PParseRevocationList{
+		DER:     rv.DER,
+		Revoker: other.Entity,
+	})
+	require.Error(t, werr)
+	require.True(t, parsed.IsMalformed)
+
+	_, werr = ParseRevocationList(ctx, &PParseRevocationList{
+		DER: []byte("junk"),
+	})
+	require.Error(t, werr)
+}
+
+//memoryQueueStorage holds blobs, entities and queues in memory. Only the
+//methods used to read revocation lists are implemented
+type memoryQueueStorage struct {
+	StorageInterface
+	blobs    map[string][]byte
+	entities map[string]*Entity
+	queues   map[string][]HashSchemeInstance
+	iterated int
+}
+
+func newMemoryQueueStorage() *memoryQueueStorage {
+	return &memoryQueueStorage{
+		blobs:    make(map[string][]byte),
+		entities: make(map[string]*Entity),
+		queues:   make(map[string][]HashSchemeInstance),
+	}
+}
+func (m *memoryQueueStorage) HashSchemeFor(loc LocationSchemeInstance) (HashScheme, error) {
+	return KECCAK256, nil
+}
+func (m *memoryQueueStorage) GetBlob(ctx context.Context, loc LocationSchemeInstance, hash HashSchemeInstance) ([]byte, error) {
+	return m.blobs[hash.MultihashString()], nil
+}
+func (m *memoryQueueStorage) GetEntity(ctx context.Context, loc LocationSchemeInstance, hash HashSchemeInstance) (*Entity, error) {
+	return m.entities[hash.MultihashString()], nil
+}
+func (m *memoryQueueStorage) IterateQeueue(ctx context.Context, loc LocationSchemeInstance, queueId HashSchemeInstance, token string) (HashSchemeInstance, string, error) {
+	m.iterated++
+	idx := 0
+	if token != "" {
+		idx, _ = strconv.Atoi(token)
+	}
+	q := m.queues[queueId.MultihashString()]
+	if idx >= len(q) {
+		return nil, "", ErrNoMore
+	}
+	return q[idx], strconv.Itoa(idx + 1), nil
+}
+func (m *memoryQueueStorage) publish(t *testing.T, revoker *EntitySecrets, listID []byte, version int, revoked ...[]byte) {
+	rv, werr := CreateRevocationList(context.Background(), &PCreateRevocationList{
+		Revoker: revoker,
+		ListID:  listID,
+		Version: version,
+		Revoked: revoked,
+	})
+	require.NoError(t, werr)
+	hi := KECCAK256.Instance(rv.DER)
+	m.blobs[hi.MultihashString()] = rv.DER
+	queue := RevocationListQueue(KECCAK256, revoker.Entity.Keccak256HI(), listID)
+	m.queues[queue.MultihashString()] = append(m.queues[queue.MultihashString()], hi)
+	m.entities[revoker.Entity.Keccak256HI().MultihashString()] = revoker.Entity
+}
+
+func TestLatestRevocationList(t *testing.T) {
+	ctx := context.Background()
+	loc := NewLocationSchemeInstanceURL("test", 1)
+	s := newMemoryQueueStorage()
+	revoker, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	revokerHI := revoker.Entity.Keccak256HI()
+	listID := []byte("staff")
+
+	s.publish(t, revoker.EntitySecrets, listID, 1, []byte("id1"))
+	list, werr := LatestRevocationList(ctx, s, revokerHI, loc, listID)
+	require.NoError(t, werr)
+	require.Equal(t, 1, list.Version())
+
+	//A refresh only reads the part of the queue added since
+	s.publish(t, revoker.EntitySecrets, listID, 2, []byte("id1"), []byte("id2"))
+	ForgetRevocationList(revokerHI, loc, listID)
+	s.iterated = 0
+	list, werr = LatestRevocationList(ctx, s, revokerHI, loc, listID)
+	require.NoError(t, werr)
+	require.Equal(t, 2, list.Version())
+	require.Equal(t, 2, s.iterated)
+
+	//Once the revoker is revoked its lists are no longer relied on
+	authority, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	opt := NewRevocationListSchemeInstance(authority.Entity.Keccak256HI(), loc, []byte("revokers"), false)
+	revoker.Entity.Revocations = append(revoker.Entity.Revocations, opt)
+	s.publish(t, authority.EntitySecrets, []byte("revokers"), 1, opt.RLBody.ID)
+	ForgetRevocationList(revokerHI, loc, listID)
+	_, werr = LatestRevocationList(ctx, s, revokerHI, loc, listID)
+	require.Error(t, werr)
+}
+
+func TestLatestRevocationListRevokerNotValid(t *testing.T) {
+	ctx := context.Background()
+	loc := NewLocationSchemeInstanceURL("test", 1)
+	s := newMemoryQueueStorage()
+	later := time.Now().Add(time.Hour)
+	revoker, werr := NewParsedEntitySecrets(ctx, &PNewEntity{ValidFrom: &later})
+	require.NoError(t, werr)
+	s.publish(t, revoker.EntitySecrets, []byte("staff"), 1, []byte("id1"))
+	_, werr = LatestRevocationList(ctx, s, revoker.Entity.Keccak256HI(), loc, []byte("staff"))
+	require.Error(t, werr)
+}
@@ -20,12 +20,46 @@ func RevocationSchemeInstanceFor(op *serdes.RevocationOption) RevocationSchemeIn
 			CRBody:     &crb,
 		}
 	}
+	if op.Scheme.OID.Equal(serdes.RevocationListOID) {
+		rlb, ok := op.Scheme.Content.(serdes.RevocationListRevocation)
+		if !ok || len(rlb.ID) == 0 {
+			goto unsupported
+		}
+		return &RevocationListSchemeInstance{
+			SerdesForm: op,
+			RLBody:     &rlb,
+		}
+	}
+	if op.Scheme.OID.Equal(serdes.DelegatedRevocationOID) {
+		drb, ok := op.Scheme.Content.(serdes.DelegatedRevocation)
+		if !ok || len(drb.ID) == 0 {
+			goto unsupported
+		}
+		return &DelegatedRevocationSchemeInstance{
+			SerdesForm: op,
+			DRBody:     &drb,
+		}
+	}
 unsupported:
 	return &UnsupportedRevocationSchemeInstance{
 		SerdesForm: op,
 	}
 }
 
+//RevocationSchemeLocation returns the storage location that is checked
+//for the revocation, or nil if the scheme is unsupported
+func RevocationSchemeLocation(r RevocationSchemeInstance) LocationSchemeInstance {
+	switch rs := r.(type) {
+	case *CommitmentRevocationSchemeInstance:
+		return LocationSchemeInstanceFor(&rs.CRBody.Location)
+	case *RevocationListSchemeInstance:
+		return rs.RevokerLocation()
+	case *DelegatedRevocationSchemeInstance:
+		return rs.RevokerLocation()
+	}
+	return nil
+}
+
 type UnsupportedRevocationSchemeInstance struct {
 	SerdesForm *serdes.RevocationOption
 }
@@ -0,0 +1,126 @@
+package iapi
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+//Shamir secret sharing over GF(2^8), using the AES field polynomial
+//x^8 + x^4 + x^3 + x + 1. Each byte of the secret is shared with its own
+//random polynomial and share i is every polynomial evaluated at x=i
+
+var gfExp [510]byte
+var gfLog [256]byte
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		gfExp[i] = x
+		gfLog[x] = byte(i)
+		//Multiply by the generator 3
+		x ^= gfXtime(x)
+	}
+	for i := 255; i < 510; i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfXtime(a byte) byte {
+	if a&0x80 != 0 {
+		return (a << 1) ^ 0x1b
+	}
+	return a << 1
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if b == 0 {
+		panic("division by zero")
+	}
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+//shamirSplit returns n shares of secret, any k of which recover it. The
+//share at index i has x coordinate i+1
+func shamirSplit(secret []byte, k int, n int) ([][]byte, error) {
+	if k < 1 || n < k || n > 255 {
+		return nil, fmt.Errorf("invalid threshold %d of %d", k, n)
+	}
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret))
+	}
+	coeffs := make([]byte, k)
+	for idx, b := range secret {
+		if _, err := rand.Read(coeffs[1:]); err != nil {
+			return nil, err
+		}
+		coeffs[0] = b
+		for i := 0; i < n; i++ {
+			x := byte(i + 1)
+			//Horner's method
+			y := byte(0)
+			for c := k - 1; c >= 0; c-- {
+				y = gfMul(y, x) ^ coeffs[c]
+			}
+			shares[i][idx] = y
+		}
+	}
+	for i := range coeffs {
+		coeffs[i] = 0
+	}
+	return shares, nil
+}
+
+//shamirCombine interpolates the shares with the given x coordinates at
+//zero. It does not know the threshold, so it will silently return the
+//wrong secret if too few shares are given
+func shamirCombine(xs []byte, ys [][]byte) ([]byte, error) {
+	if len(xs) == 0 || len(xs) != len(ys) {
+		return nil, fmt.Errorf("no shares")
+	}
+	length := len(ys[0])
+	for i := range xs {
+		if xs[i] == 0 {
+			return nil, fmt.Errorf("share has x coordinate zero")
+		}
+		if len(ys[i]) != length {
+			return nil, fmt.Errorf("shares have different lengths")
+		}
+		for j := 0; j < i; j++ {
+			if xs[i] == xs[j] {
+				return nil, fmt.Errorf("duplicate share %d", xs[i])
+			}
+		}
+	}
+	//The Lagrange basis polynomials at zero
+	basis := make([]byte, len(xs))
+	for i := range xs {
+		l := byte(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			l = gfMul(l, gfDiv(xs[j], xs[j]^xs[i]))
+		}
+		basis[i] = l
+	}
+	secret := make([]byte, length)
+	for idx := range secret {
+		v := byte(0)
+		for i := range xs {
+			v ^= gfMul(basis[i], ys[i][idx])
+		}
+		secret[idx] = v
+	}
+	return secret, nil
+}
@@ -15,6 +15,10 @@ func OuterSignatureBindingSchemeFor(e *asn1.External) OuterSignatureBindingSchem
 	if ok {
 		return &OuterSignatureBindingScheme_SignedOuterKey{}
 	}
+	_, ok = e.Content.(serdes.ThresholdOuterKey)
+	if ok {
+		return &OuterSignatureBindingScheme_ThresholdOuterKey{}
+	}
 	return &UnsupportedOuterSignatureBindingScheme{}
 }
 
@@ -67,6 +71,65 @@ func (sbs *OuterSignatureBindingScheme_SignedOuterKey) VerifyBinding(ctx context
 	return nil
 }
 
+var _ OuterSignatureBindingScheme = &OuterSignatureBindingScheme_ThresholdOuterKey{}
+
+type OuterSignatureBindingScheme_ThresholdOuterKey struct {
+}
+
+func (sbs *OuterSignatureBindingScheme_ThresholdOuterKey) Supported() bool {
+	return true
+}
+
+//VerifyBinding checks the attester's signature and that the proposal
+//describes this attestation. The co-signatures are not checked here because
+//the co-signers may not be resolvable yet, see ThresholdProposal.ValidCoSigners
+func (sbs *OuterSignatureBindingScheme_ThresholdOuterKey) VerifyBinding(ctx context.Context, att *Attestation, attester *Entity) wve.WVE {
+	if attester == nil {
+		panic("nil attester")
+	}
+	if att == nil {
+		panic("nil attestation")
+	}
+	cform := att.CanonicalForm
+	osig, ok := cform.OuterSignature.Content.(serdes.Ed25519OuterSignature)
+	if !ok {
+		return wve.Err(wve.UnsupportedSignatureScheme, "unknown outer signature type")
+	}
+	tp := att.ThresholdProposal()
+	if tp == nil {
+		return wve.Err(wve.UnsupportedSignatureScheme, "this is not really a threshold outer key")
+	}
+	if werr := tp.VerifyAttester(ctx, attester); werr != nil {
+		return wve.Err(wve.InvalidSignature, "outer signature binding invalid")
+	}
+	tbs := &tp.CanonicalForm.TBS
+	if !tbs.OuterSignatureScheme.Equal(serdes.EphemeralEd25519OID) {
+		return wve.Err(wve.InvalidSignature, "outer signature scheme invalid")
+	}
+	if !bytes.Equal(tbs.VerifyingKey, osig.VerifyingKey) {
+		return wve.Err(wve.InvalidSignature, "bound key does not match")
+	}
+	vb := &att.DecryptedBody.VerifierBody
+	if !sameDER(tbs.Subject, cform.TBS.Subject) || !sameDER(tbs.SubjectLocation, cform.TBS.SubjectLocation) ||
+		!sameDER(tbs.Attester, vb.Attester) || !sameDER(tbs.AttesterLocation, vb.AttesterLocation) ||
+		!sameDER(tbs.Validity, vb.Validity) || !sameDER(tbs.Policy, vb.Policy) {
+		return wve.Err(wve.InvalidSignature, "attestation does not match the co-signed proposal")
+	}
+	return nil
+}
+
+func sameDER(lhs interface{}, rhs interface{}) bool {
+	lder, err := asn1.Marshal(lhs)
+	if err != nil {
+		return false
+	}
+	rder, err := asn1.Marshal(rhs)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(lder, rder)
+}
+
 var _ OuterSignatureBindingScheme = &UnsupportedOuterSignatureBindingScheme{}
 
 type UnsupportedOuterSignatureBindingScheme struct {
@@ -0,0 +1,180 @@
+package iapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/immesys/wave/wve"
+	"golang.org/x/crypto/ssh"
+)
+
+//SSHPermission is the permission a proof must grant before an SSH
+//certificate is issued
+type SSHPermission struct {
+	Namespace     HashSchemeInstance
+	PermissionSet HashSchemeInstance
+	Permission    string
+	//A path.Match pattern e.g. hosts/web*. Every proven resource that
+	//matches it becomes a principal, named by its last element
+	Resource string
+}
+
+//How far in the past certificates are valid from, to allow for clock skew
+const SSHCertificateClockSkew = 5 * time.Minute
+
+//The extensions given to every certificate, the same as ssh-keygen gives
+var sshCertificateExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+//SSHCertificateRequestContent is what the proof subject signs to show that
+//it wants a certificate for the given key
+func SSHCertificateRequestContent(publicKey ssh.PublicKey) []byte {
+	return append([]byte("WAVE SSH CERTIFICATE REQUEST\n"), publicKey.Marshal()...)
+}
+
+//SSHPrincipals returns the principals that a policy grants under the
+//given permission
+func SSHPrincipals(policy *RTreePolicy, perm *SSHPermission) []string {
+	ns := HashSchemeInstanceFor(&policy.SerdesForm.Namespace)
+	if !ns.Supported() || !HashSchemeInstanceEqual(ns, perm.Namespace) {
+		return nil
+	}
+	seen := make(map[string]bool)
+	rv := []string{}
+	for _, st := range policy.SerdesForm.Statements {
+		pset := HashSchemeInstanceFor(&st.PermissionSet)
+		if !pset.Supported() || !HashSchemeInstanceEqual(pset, perm.PermissionSet) {
+			continue
+		}
+		granted := false
+		for _, p := range st.Permissions {
+			if p == perm.Permission {
+				granted = true
+			}
+		}
+		if !granted {
+			continue
+		}
+		//A wildcard does not name a single principal
+		wildcard := false
+		for _, part := range strings.Split(st.Resource, "/") {
+			if part == "*" || part == "+" {
+				wildcard = true
+			}
+		}
+		if wildcard {
+			continue
+		}
+		if ok, _ := path.Match(perm.Resource, st.Resource); !ok {
+			continue
+		}
+		principal := path.Base(st.Resource)
+		//OpenSSH treats these as patterns or separators
+		if strings.ContainsAny(principal, "*?,[!") {
+			continue
+		}
+		if !seen[principal] {
+			seen[principal] = true
+			rv = append(rv, principal)
+		}
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+type PCreateSSHCertificate struct {
+	ProofDER []byte
+	VCtx     VerificationContext
+	//The key to certify
+	PublicKey ssh.PublicKey
+	//A signature by the proof subject over SSHCertificateRequestContent
+	Signature  []byte
+	Permission *SSHPermission
+	CA         ssh.Signer
+	//If not specified the certificate is valid until the proof expires. It
+	//never outlives the proof
+	ValidFor time.Duration
+}
+type RCreateSSHCertificate struct {
+	Certificate *ssh.Certificate
+	Principals  []string
+	Subject     HashSchemeInstance
+}
+
+//CreateSSHCertificate issues an OpenSSH user certificate to the subject of
+//a proof. The principals are the resources the proof grants under the
+//configured permission
+func CreateSSHCertificate(ctx context.Context, p *PCreateSSHCertificate) (*RCreateSSHCertificate, wve.WVE) {
+	if p.PublicKey == nil || p.CA == nil || p.Permission == nil || p.VCtx == nil || len(p.ProofDER) == 0 {
+		return nil, wve.Err(wve.MissingParameter, "missing required parameters")
+	}
+	proof, werr := VerifyRTreeProof(ctx, &PVerifyRTreeProof{
+		DER:  p.ProofDER,
+		VCtx: p.VCtx,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	//Only the subject of the proof may use it
+	_, werr = VerifySignature(ctx, &PVerifySignature{
+		DER:            p.Signature,
+		Content:        SSHCertificateRequestContent(p.PublicKey),
+		Signer:         proof.Subject,
+		SignerLocation: proof.SubjectLocation,
+		VCtx:           p.VCtx,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	principals := SSHPrincipals(proof.Policy, p.Permission)
+	if len(principals) == 0 {
+		return nil, wve.Err(wve.ProofInvalid, "the proof does not grant any SSH principals")
+	}
+	now := time.Now()
+	validBefore := proof.Expires
+	if p.ValidFor != 0 && now.Add(p.ValidFor).Before(validBefore) {
+		validBefore = now.Add(p.ValidFor)
+	}
+	if !validBefore.After(now) {
+		return nil, wve.Err(wve.ProofInvalid, "the proof has expired")
+	}
+	serial := make([]byte, 8)
+	if _, err := rand.Read(serial); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not generate serial number", err)
+	}
+	extensions := make(map[string]string)
+	for k, v := range sshCertificateExtensions {
+		extensions[k] = v
+	}
+	cert := &ssh.Certificate{
+		Key:             p.PublicKey,
+		Serial:          binary.BigEndian.Uint64(serial),
+		CertType:        ssh.UserCert,
+		KeyId:           "wave:" + base64.URLEncoding.EncodeToString(proof.Subject.Multihash()),
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(now.Add(-SSHCertificateClockSkew).Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+		Permissions: ssh.Permissions{
+			Extensions: extensions,
+		},
+	}
+	if err := cert.SignCert(rand.Reader, p.CA); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not sign certificate", err)
+	}
+	return &RCreateSSHCertificate{
+		Certificate: cert,
+		Principals:  principals,
+		Subject:     proof.Subject,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package iapi
+
+import (
+	"testing"
+
+	"github.com/immesys/wave/serdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSHPrincipals(t *testing.T) {
+	ns := KECCAK256.Instance([]byte("ns"))
+	pset := KECCAK256.Instance([]byte("pset"))
+	other := KECCAK256.Instance([]byte("other"))
+	statement := func(pset HashSchemeInstance, perm string, resource string) serdes.RTreeStatement {
+		return serdes.RTreeStatement{
+			PermissionSet: *pset.CanonicalForm(),
+			Permissions:   []string{"read", perm},
+			Resource:      resource,
+		}
+	}
+	policy := &RTreePolicy{
+		SerdesForm: serdes.RTreePolicy{
+			Namespace: *ns.CanonicalForm(),
+			Statements: []serdes.RTreeStatement{
+				statement(pset, "login", "hosts/web2"),
+				statement(pset, "login", "hosts/web1"),
+				statement(pset, "login", "hosts/web1"),
+				statement(pset, "login", "hosts/db1"),
+				statement(pset, "login", "hosts/web*"),
+				statement(pset, "login", "hosts/+"),
+				statement(pset, "admin", "hosts/web3"),
+				statement(other, "login", "hosts/web4"),
+			},
+		},
+	}
+	perm := &SSHPermission{
+		Namespace:     ns,
+		PermissionSet: pset,
+		Permission:    "login",
+		Resource:      "hosts/web*",
+	}
+	require.Equal(t, []string{"web1", "web2"}, SSHPrincipals(policy, perm))
+
+	perm.Namespace = other
+	require.Empty(t, SSHPrincipals(policy, perm))
+}
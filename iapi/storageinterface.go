@@ -90,6 +90,7 @@ type StorageDriverStatus struct {
 type GetResult struct {
 	Attestation     *Attestation
 	NameDeclaration *NameDeclaration
+	Succession      *EntitySuccession
 }
 type StorageInterface interface {
 	PutBlob(ctx context.Context, loc LocationSchemeInstance, content []byte) (HashSchemeInstance, error)
@@ -0,0 +1,140 @@
+package iapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+)
+
+//The grace window used if none is given when creating a succession
+const DefaultSuccessionGrace = 30 * 24 * time.Hour
+
+type PCreateEntitySuccession struct {
+	//The entity being replaced. Its certification key signs the succession
+	Predecessor         *EntitySecrets
+	PredecessorLocation LocationSchemeInstance
+	Successor           *Entity
+	SuccessorLocation   LocationSchemeInstance
+	//If not specified, defaults to Now+DefaultSuccessionGrace. It is capped
+	//at the expiry of the predecessor
+	GraceUntil *time.Time
+}
+type RCreateEntitySuccession struct {
+	Succession *EntitySuccession
+	DER        []byte
+}
+
+func CreateEntitySuccession(ctx context.Context, p *PCreateEntitySuccession) (*RCreateEntitySuccession, wve.WVE) {
+	if p.Predecessor == nil || p.Successor == nil {
+		return nil, wve.Err(wve.MissingParameter, "predecessor and successor must be specified")
+	}
+	if p.PredecessorLocation == nil || p.SuccessorLocation == nil {
+		return nil, wve.Err(wve.MissingParameter, "predecessor and successor locations must be specified")
+	}
+	pred := p.Predecessor.Entity.Keccak256HI()
+	succ := p.Successor.Keccak256HI()
+	if HashSchemeInstanceEqual(pred, succ) {
+		return nil, wve.Err(wve.InvalidParameter, "an entity cannot succeed itself")
+	}
+	if p.Successor.Expired() {
+		return nil, wve.Err(wve.InvalidParameter, "successor entity has expired")
+	}
+	now := time.Now()
+	graceUntil := now.Add(DefaultSuccessionGrace)
+	if p.GraceUntil != nil {
+		graceUntil = *p.GraceUntil
+	}
+	predExpiry := p.Predecessor.Entity.CanonicalForm.TBS.Validity.NotAfter
+	if graceUntil.After(predExpiry) {
+		graceUntil = predExpiry
+	}
+	if !graceUntil.After(now) {
+		return nil, wve.Err(wve.InvalidParameter, "grace window has already ended")
+	}
+
+	cf := serdes.WaveEntitySuccession{}
+	cf.TBS.Predecessor = *pred.CanonicalForm()
+	cf.TBS.PredecessorLocation = *p.PredecessorLocation.CanonicalForm()
+	cf.TBS.Successor = *succ.CanonicalForm()
+	cf.TBS.SuccessorLocation = *p.SuccessorLocation.CanonicalForm()
+	cf.TBS.Created = now
+	cf.TBS.GraceUntil = graceUntil
+	tbsDER, err := asn1.Marshal(cf.TBS)
+	if err != nil {
+		panic(err)
+	}
+	sig, err := p.Predecessor.PrimarySigningKey().SignCertify(ctx, tbsDER)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not sign succession", err)
+	}
+	cf.Signature = sig
+
+	s := &EntitySuccession{}
+	if werr := s.SetCanonicalForm(&cf); werr != nil {
+		return nil, werr
+	}
+	der, werr := s.DER()
+	if werr != nil {
+		return nil, werr
+	}
+	return &RCreateEntitySuccession{
+		Succession: s,
+		DER:        der,
+	}, nil
+}
+
+type PParseEntitySuccession struct {
+	DER []byte
+	//If present, the succession signature is checked against this entity
+	Predecessor *Entity
+}
+type RParseEntitySuccession struct {
+	Succession  *EntitySuccession
+	IsMalformed bool
+}
+
+func ParseEntitySuccession(ctx context.Context, p *PParseEntitySuccession) (*RParseEntitySuccession, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	rest, err := asn1.Unmarshal(p.DER, &wo.Content)
+	if err != nil || len(rest) != 0 {
+		return &RParseEntitySuccession{IsMalformed: true}, wve.Err(wve.MalformedDER, "DER did not parse")
+	}
+	cf, ok := wo.Content.Content.(serdes.WaveEntitySuccession)
+	if !ok {
+		return &RParseEntitySuccession{IsMalformed: true}, wve.Err(wve.UnexpectedObject, "DER is not a wave entity succession")
+	}
+	s := &EntitySuccession{}
+	if werr := s.SetCanonicalForm(&cf); werr != nil {
+		return &RParseEntitySuccession{IsMalformed: true}, werr
+	}
+	if p.Predecessor != nil {
+		if werr := s.Verify(ctx, p.Predecessor); werr != nil {
+			return &RParseEntitySuccession{IsMalformed: true}, werr
+		}
+	}
+	return &RParseEntitySuccession{
+		Succession: s,
+	}, nil
+}
+
+//Verify checks that the succession was signed by the given predecessor
+func (s *EntitySuccession) Verify(ctx context.Context, predecessor *Entity) wve.WVE {
+	if !HashSchemeInstanceEqual(predecessor.Keccak256HI(), s.Predecessor) {
+		return wve.Err(wve.InvalidParameter, "entity is not the predecessor named in the succession")
+	}
+	tbs, err := asn1.Marshal(s.CanonicalForm.TBS)
+	if err != nil {
+		return wve.Err(wve.MalformedObject, "could not marshal succession")
+	}
+	err = predecessor.VerifyingKey.VerifyCertify(ctx, tbs, s.CanonicalForm.Signature)
+	if err != nil {
+		return wve.Err(wve.InvalidSignature, "succession signature failed check")
+	}
+	if !s.CanonicalForm.TBS.GraceUntil.After(s.CanonicalForm.TBS.Created) {
+		return wve.Err(wve.MalformedObject, "succession grace window is empty")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package iapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/immesys/wave/wve"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntitySuccession(t *testing.T) {
+	ctx := context.Background()
+	old, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	succ, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	other, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+
+	grace := time.Now().Add(time.Hour)
+	crv, werr := CreateEntitySuccession(ctx, &PCreateEntitySuccession{
+		Predecessor:         old.EntitySecrets,
+		PredecessorLocation: NewLocationSchemeInstanceURL("test", 1),
+		Successor:           succ.Entity,
+		SuccessorLocation:   NewLocationSchemeInstanceURL("test", 1),
+		GraceUntil:          &grace,
+	})
+	require.NoError(t, werr)
+
+	prv, werr := ParseEntitySuccession(ctx, &PParseEntitySuccession{
+		DER:         crv.DER,
+		Predecessor: old.Entity,
+	})
+	require.NoError(t, werr)
+	require.False(t, prv.IsMalformed)
+	require.Equal(t, old.Entity.Keccak256HI().Multihash(), prv.Succession.Predecessor.Multihash())
+	require.Equal(t, succ.Entity.Keccak256HI().Multihash(), prv.Succession.Successor.Multihash())
+	require.True(t, prv.Succession.InGrace(time.Now()))
+	require.False(t, prv.Succession.InGrace(grace.Add(time.Second)))
+
+	//Only the predecessor's signature is acceptable
+	_, werr = ParseEntitySuccession(ctx, &PParseEntitySuccession{
+		DER:         crv.DER,
+		Predecessor: other.Entity,
+	})
+	require.Error(t, werr)
+
+	prv.Succession.CanonicalForm.TBS.GraceUntil = grace.Add(time.Hour)
+	require.Equal(t, wve.InvalidSignature, prv.Succession.Verify(ctx, old.Entity).Code())
+}
+
+func TestEntitySuccessionInvalid(t *testing.T) {
+	ctx := context.Background()
+	old, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+
+	_, werr = CreateEntitySuccession(ctx, &PCreateEntitySuccession{
+		Predecessor:         old.EntitySecrets,
+		PredecessorLocation: NewLocationSchemeInstanceURL("test", 1),
+		Successor:           old.Entity,
+		SuccessorLocation:   NewLocationSchemeInstanceURL("test", 1),
+	})
+	require.Error(t, werr)
+
+	succ, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	past := time.Now().Add(-time.Hour)
+	_, werr = CreateEntitySuccession(ctx, &PCreateEntitySuccession{
+		Predecessor:         old.EntitySecrets,
+		PredecessorLocation: NewLocationSchemeInstanceURL("test", 1),
+		Successor:           succ.Entity,
+		SuccessorLocation:   NewLocationSchemeInstanceURL("test", 1),
+		GraceUntil:          &past,
+	})
+	require.Error(t, werr)
+
+	//A name declaration is not a succession
+	nd, werr := CreateNameDeclaration(ctx, &PCreateNameDeclaration{
+		Attester:         old.EntitySecrets,
+		AttesterLocation: NewLocationSchemeInstanceURL("test", 1),
+		Subject:          succ.Entity,
+		SubjectLocation:  NewLocationSchemeInstanceURL("test", 1),
+		Name:             "foo",
+	})
+	require.NoError(t, werr)
+	_, werr = ParseEntitySuccession(ctx, &PParseEntitySuccession{
+		DER: nd.DER,
+	})
+	require.Equal(t, wve.UnexpectedObject, werr.Code())
+}
@@ -0,0 +1,457 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/sha3"
+)
+
+//A threshold attestation is created in three steps. The attester creates a
+//proposal that describes the attestation and names the co-signers. Each
+//co-signer checks the proposal and adds its signature. Once enough
+//co-signatures have been collected, the attester creates the attestation,
+//which carries the proposal as its outer signature binding
+
+//ThresholdProposal is a ThresholdOuterKey, either on its own while
+//co-signatures are collected or inside an attestation
+type ThresholdProposal struct {
+	CanonicalForm *serdes.ThresholdOuterKey
+	Subject       HashSchemeInstance
+	Attester      HashSchemeInstance
+	CoSigners     []HashSchemeInstance
+	//The locations of the co-signers, in the same order
+	CoSignerLocations []LocationSchemeInstance
+}
+
+func (tp *ThresholdProposal) SetCanonicalForm(cf *serdes.ThresholdOuterKey) wve.WVE {
+	tbs := &cf.TBS
+	subject := HashSchemeInstanceFor(&tbs.Subject)
+	attester := HashSchemeInstanceFor(&tbs.Attester)
+	if !subject.Supported() || !attester.Supported() {
+		return wve.Err(wve.MalformedObject, "unsupported hash scheme")
+	}
+	if tbs.Threshold < 1 || tbs.Threshold > len(tbs.CoSigners) {
+		return wve.Err(wve.MalformedObject, "threshold is not satisfiable")
+	}
+	tp.CanonicalForm = cf
+	tp.Subject = subject
+	tp.Attester = attester
+	tp.CoSigners = nil
+	tp.CoSignerLocations = nil
+	for idx := range tbs.CoSigners {
+		h := HashSchemeInstanceFor(&tbs.CoSigners[idx].Entity)
+		if !h.Supported() {
+			return wve.Err(wve.MalformedObject, "unsupported co-signer hash scheme")
+		}
+		loc := LocationSchemeInstanceFor(&tbs.CoSigners[idx].Location)
+		if !loc.Supported() {
+			return wve.Err(wve.MalformedObject, "unsupported co-signer location scheme")
+		}
+		tp.CoSigners = append(tp.CoSigners, h)
+		tp.CoSignerLocations = append(tp.CoSignerLocations, loc)
+	}
+	return nil
+}
+func (tp *ThresholdProposal) DER() ([]byte, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	wo.Content = asn1.NewExternal(*tp.CanonicalForm)
+	rv, err := asn1.Marshal(wo.Content)
+	if err != nil {
+		return nil, wve.Err(wve.MalformedDER, "could not produce DER")
+	}
+	return rv, nil
+}
+func (tp *ThresholdProposal) Threshold() int {
+	return tp.CanonicalForm.TBS.Threshold
+}
+func (tp *ThresholdProposal) ValidFrom() time.Time {
+	return tp.CanonicalForm.TBS.Validity.NotBefore
+}
+func (tp *ThresholdProposal) ValidUntil() time.Time {
+	return tp.CanonicalForm.TBS.Validity.NotAfter
+}
+func (tp *ThresholdProposal) tbsDER() []byte {
+	der, err := asn1.Marshal(tp.CanonicalForm.TBS)
+	if err != nil {
+		panic(err)
+	}
+	return der
+}
+
+//VerifyAttester checks the attester's signature on the proposal
+func (tp *ThresholdProposal) VerifyAttester(ctx context.Context, attester *Entity) wve.WVE {
+	if !HashSchemeInstanceEqual(attester.Keccak256HI(), tp.Attester) {
+		return wve.Err(wve.InvalidParameter, "entity is not the attester named in the proposal")
+	}
+	err := attester.VerifyingKey.VerifyCertify(ctx, tp.tbsDER(), tp.CanonicalForm.Signature)
+	if err != nil {
+		return wve.Err(wve.InvalidSignature, "threshold proposal signature invalid")
+	}
+	return nil
+}
+
+//ValidCoSigners returns the distinct co-signers that have a valid signature
+//on the proposal. Co-signers that the context cannot resolve are skipped
+func (tp *ThresholdProposal) ValidCoSigners(ctx context.Context, dctx BodyDecryptionContext) []*Entity {
+	rv, _ := tp.validCoSignatures(ctx, dctx)
+	return rv
+}
+
+//validCoSignatures also returns the signature that was accepted from each
+//co-signer
+func (tp *ThresholdProposal) validCoSignatures(ctx context.Context, dctx BodyDecryptionContext) ([]*Entity, []serdes.ThresholdCoSignature) {
+	tbs := tp.tbsDER()
+	seen := make(map[int]bool)
+	signers := []*Entity{}
+	sigs := []serdes.ThresholdCoSignature{}
+	for _, cosig := range tp.CanonicalForm.CoSignatures {
+		if cosig.Index < 0 || cosig.Index >= len(tp.CoSigners) || seen[cosig.Index] {
+			continue
+		}
+		ent, werr := dctx.EntityByHashLoc(ctx, tp.CoSigners[cosig.Index], tp.CoSignerLocations[cosig.Index])
+		if werr != nil || ent == nil {
+			continue
+		}
+		if !HashSchemeInstanceEqual(ent.Keccak256HI(), tp.CoSigners[cosig.Index]) {
+			continue
+		}
+		if ent.VerifyingKey.VerifyCertify(ctx, tbs, cosig.Signature) != nil {
+			continue
+		}
+		seen[cosig.Index] = true
+		signers = append(signers, ent)
+		sigs = append(sigs, cosig)
+	}
+	return signers, sigs
+}
+
+//SignedBy returns the co-signers that have a signature on the proposal,
+//without checking the signatures
+func (tp *ThresholdProposal) SignedBy() []HashSchemeInstance {
+	rv := []HashSchemeInstance{}
+	for _, cosig := range tp.CanonicalForm.CoSignatures {
+		if cosig.Index >= 0 && cosig.Index < len(tp.CoSigners) {
+			rv = append(rv, tp.CoSigners[cosig.Index])
+		}
+	}
+	return rv
+}
+
+//ThresholdProposal returns the proposal that binds the outer signature of a
+//threshold attestation, or nil if the attestation is not a threshold
+//attestation or has not been decrypted
+func (e *Attestation) ThresholdProposal() *ThresholdProposal {
+	if e.DecryptedBody == nil {
+		return nil
+	}
+	cf, ok := e.DecryptedBody.VerifierBody.OuterSignatureBinding.Content.(serdes.ThresholdOuterKey)
+	if !ok {
+		return nil
+	}
+	tp := &ThresholdProposal{}
+	if tp.SetCanonicalForm(&cf) != nil {
+		return nil
+	}
+	return tp
+}
+
+//thresholdOuterKey derives the outer signing key of a threshold attestation
+//from the attester's secrets, so that it does not have to be kept between
+//creating the proposal and creating the attestation
+func thresholdOuterKey(attester *EntitySecrets, nonce []byte) EntitySecretKeySchemeInstance {
+	secret := attester.primarySecret()
+	h := sha3.New256()
+	h.Write([]byte("wave threshold outer key"))
+	h.Write(secret)
+	h.Write(nonce)
+	public, private, err := ed25519.GenerateKey(bytes.NewReader(h.Sum(nil)))
+	if err != nil {
+		panic(err)
+	}
+	ke := serdes.EntityKeyringEntry{
+		Public: serdes.EntityPublicKey{
+			Capabilities: []int{int(CapAttestation)},
+			Key:          asn1.NewExternal(serdes.EntityPublicEd25519(public)),
+		},
+		Private: asn1.NewExternal(serdes.EntitySecretEd25519(private)),
+	}
+	return &EntitySecretKey_Ed25519{
+		SerdesForm: &ke,
+		PublicKey:  public,
+		PrivateKey: private,
+	}
+}
+
+type PCreateThresholdProposal struct {
+	Policy           PolicySchemeInstance
+	Attester         *EntitySecrets
+	AttesterLocation LocationSchemeInstance
+	Subject          *Entity
+	SubjectLocation  LocationSchemeInstance
+	//If not specified, defaults to Now
+	ValidFrom *time.Time
+	//If not specified defaults to Now+30 days
+	ValidUntil *time.Time
+	//The number of co-signatures required
+	Threshold         int
+	CoSigners         []*Entity
+	CoSignerLocations []LocationSchemeInstance
+}
+type RCreateThresholdProposal struct {
+	Proposal *ThresholdProposal
+	DER      []byte
+}
+
+//CreateThresholdProposal creates a proposal for a threshold attestation,
+//signed by the attester. It must be co-signed by Threshold of the
+//co-signers before the attestation can be created
+func CreateThresholdProposal(ctx context.Context, p *PCreateThresholdProposal) (*RCreateThresholdProposal, wve.WVE) {
+	if p.Policy == nil || p.Attester == nil || p.Subject == nil || p.SubjectLocation == nil || p.AttesterLocation == nil {
+		return nil, wve.Err(wve.MissingParameter, "missing required parameters")
+	}
+	if len(p.CoSigners) != len(p.CoSignerLocations) {
+		return nil, wve.Err(wve.InvalidParameter, "each co-signer needs a location")
+	}
+	if p.Threshold < 1 || p.Threshold > len(p.CoSigners) {
+		return nil, wve.Err(wve.InvalidParameter, "threshold must be between one and the number of co-signers")
+	}
+	if err := p.Policy.CheckValid(); err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "policy is invalid", err)
+	}
+	cf := serdes.ThresholdOuterKey{}
+	tbs := &cf.TBS
+	tbs.OuterSignatureScheme = serdes.EphemeralEd25519OID
+	tbs.Subject = *p.Subject.Keccak256HI().CanonicalForm()
+	tbs.SubjectLocation = *p.SubjectLocation.CanonicalForm()
+	tbs.Attester = *p.Attester.Entity.Keccak256HI().CanonicalForm()
+	tbs.AttesterLocation = *p.AttesterLocation.CanonicalForm()
+
+	//Apply the same limits as CreateAttestation now, so that the validity
+	//the co-signers approve is exactly the validity of the attestation.
+	//Times are truncated to the precision of the encoding
+	tbs.Validity.NotBefore = time.Now()
+	if p.ValidFrom != nil {
+		tbs.Validity.NotBefore = *p.ValidFrom
+	}
+	tbs.Validity.NotAfter = time.Now().Add(30 * 24 * time.Hour)
+	if p.ValidUntil != nil {
+		tbs.Validity.NotAfter = *p.ValidUntil
+	}
+	if attesterExpiry := p.Attester.Entity.CanonicalForm.TBS.Validity.NotAfter; tbs.Validity.NotAfter.After(attesterExpiry) {
+		tbs.Validity.NotAfter = attesterExpiry
+	}
+	if subjectExpiry := p.Subject.CanonicalForm.TBS.Validity.NotAfter; tbs.Validity.NotAfter.After(subjectExpiry) {
+		tbs.Validity.NotAfter = subjectExpiry
+	}
+	tbs.Validity.NotBefore = tbs.Validity.NotBefore.Truncate(time.Second)
+	tbs.Validity.NotAfter = tbs.Validity.NotAfter.Truncate(time.Second)
+	if tbs.Validity.NotAfter.Before(tbs.Validity.NotBefore) {
+		return nil, wve.Err(wve.InvalidParameter, "invalid validity times")
+	}
+	tbs.Policy = *p.Policy.CanonicalForm()
+	tbs.Threshold = p.Threshold
+	seen := make(map[string]bool)
+	for idx, cs := range p.CoSigners {
+		h := cs.Keccak256HI()
+		if seen[h.MultihashString()] {
+			return nil, wve.Err(wve.InvalidParameter, "co-signers must be distinct")
+		}
+		seen[h.MultihashString()] = true
+		tbs.CoSigners = append(tbs.CoSigners, serdes.ThresholdCoSigner{
+			Entity:   *h.CanonicalForm(),
+			Location: *p.CoSignerLocations[idx].CanonicalForm(),
+		})
+	}
+	tbs.Nonce = make([]byte, 32)
+	if _, err := rand.Read(tbs.Nonce); err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not generate nonce", err)
+	}
+	outerKey := thresholdOuterKey(p.Attester, tbs.Nonce)
+	tbs.VerifyingKey = []byte(outerKey.Public().(*EntityKey_Ed25519).PublicKey)
+
+	tbsDER, err := asn1.Marshal(cf.TBS)
+	if err != nil {
+		panic(err)
+	}
+	cf.Signature, err = p.Attester.PrimarySigningKey().SignCertify(ctx, tbsDER)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not sign proposal", err)
+	}
+	tp := &ThresholdProposal{}
+	if werr := tp.SetCanonicalForm(&cf); werr != nil {
+		return nil, werr
+	}
+	der, werr := tp.DER()
+	if werr != nil {
+		return nil, werr
+	}
+	return &RCreateThresholdProposal{
+		Proposal: tp,
+		DER:      der,
+	}, nil
+}
+
+type PParseThresholdProposal struct {
+	DER []byte
+	//If present, the attester's signature is checked against this entity
+	Attester *Entity
+}
+type RParseThresholdProposal struct {
+	Proposal *ThresholdProposal
+}
+
+func ParseThresholdProposal(ctx context.Context, p *PParseThresholdProposal) (*RParseThresholdProposal, wve.WVE) {
+	wo := serdes.WaveWireObject{}
+	rest, err := asn1.Unmarshal(p.DER, &wo.Content)
+	if err != nil || len(rest) != 0 {
+		return nil, wve.Err(wve.MalformedDER, "DER did not parse")
+	}
+	cf, ok := wo.Content.Content.(serdes.ThresholdOuterKey)
+	if !ok {
+		return nil, wve.Err(wve.UnexpectedObject, "DER is not a threshold attestation proposal")
+	}
+	tp := &ThresholdProposal{}
+	if werr := tp.SetCanonicalForm(&cf); werr != nil {
+		return nil, werr
+	}
+	if p.Attester != nil {
+		if werr := tp.VerifyAttester(ctx, p.Attester); werr != nil {
+			return nil, werr
+		}
+	}
+	return &RParseThresholdProposal{
+		Proposal: tp,
+	}, nil
+}
+
+type PCoSignThresholdProposal struct {
+	Proposal *ThresholdProposal
+	CoSigner *EntitySecrets
+}
+type RCoSignThresholdProposal struct {
+	//The proposal with the new co-signature added
+	Proposal *ThresholdProposal
+	DER      []byte
+}
+
+//CoSignThresholdProposal adds the co-signer's signature to a proposal. The
+//caller is expected to have checked the attester's signature and the
+//contents of the proposal first
+func CoSignThresholdProposal(ctx context.Context, p *PCoSignThresholdProposal) (*RCoSignThresholdProposal, wve.WVE) {
+	if p.Proposal == nil || p.CoSigner == nil {
+		return nil, wve.Err(wve.MissingParameter, "missing required parameters")
+	}
+	index := -1
+	me := p.CoSigner.Entity.Keccak256HI()
+	for idx, h := range p.Proposal.CoSigners {
+		if HashSchemeInstanceEqual(h, me) {
+			index = idx
+		}
+	}
+	if index == -1 {
+		return nil, wve.Err(wve.InvalidParameter, "entity is not a co-signer of this proposal")
+	}
+	sig, err := p.CoSigner.PrimarySigningKey().SignCertify(ctx, p.Proposal.tbsDER())
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not sign proposal", err)
+	}
+	cf := *p.Proposal.CanonicalForm
+	cf.CoSignatures = nil
+	for _, cosig := range p.Proposal.CanonicalForm.CoSignatures {
+		if cosig.Index != index {
+			cf.CoSignatures = append(cf.CoSignatures, cosig)
+		}
+	}
+	cf.CoSignatures = append(cf.CoSignatures, serdes.ThresholdCoSignature{
+		Index:     index,
+		Signature: sig,
+	})
+	tp := &ThresholdProposal{}
+	if werr := tp.SetCanonicalForm(&cf); werr != nil {
+		return nil, werr
+	}
+	der, werr := tp.DER()
+	if werr != nil {
+		return nil, werr
+	}
+	return &RCoSignThresholdProposal{
+		Proposal: tp,
+		DER:      der,
+	}, nil
+}
+
+type PCreateThresholdAttestation struct {
+	//Copies of the same proposal, carrying between them at least Threshold
+	//co-signatures
+	Proposals         []*ThresholdProposal
+	BodyScheme        AttestationBodyScheme
+	EncryptionContext BodyEncryptionContext
+	Attester          *EntitySecrets
+	Subject           *Entity
+	//Used to resolve the co-signers to check their signatures
+	CoSignerContext BodyDecryptionContext
+}
+
+//CreateThresholdAttestation merges the co-signatures on the proposals and,
+//if there are enough of them, creates the attestation
+func CreateThresholdAttestation(ctx context.Context, p *PCreateThresholdAttestation) (*RCreateAttestation, wve.WVE) {
+	if len(p.Proposals) == 0 || p.Attester == nil || p.Subject == nil || p.BodyScheme == nil || p.CoSignerContext == nil {
+		return nil, wve.Err(wve.MissingParameter, "missing required parameters")
+	}
+	first := p.Proposals[0]
+	if werr := first.VerifyAttester(ctx, p.Attester.Entity); werr != nil {
+		return nil, werr
+	}
+	if !HashSchemeInstanceEqual(p.Subject.Keccak256HI(), first.Subject) {
+		return nil, wve.Err(wve.InvalidParameter, "entity is not the subject named in the proposal")
+	}
+	tbsDER := first.tbsDER()
+	merged := *first.CanonicalForm
+	merged.CoSignatures = nil
+	for _, tp := range p.Proposals {
+		if !bytes.Equal(tp.tbsDER(), tbsDER) {
+			return nil, wve.Err(wve.InvalidParameter, "proposals are not all copies of the same proposal")
+		}
+		merged.CoSignatures = append(merged.CoSignatures, tp.CanonicalForm.CoSignatures...)
+	}
+	mp := &ThresholdProposal{}
+	if werr := mp.SetCanonicalForm(&merged); werr != nil {
+		return nil, werr
+	}
+	valid, sigs := mp.validCoSignatures(ctx, p.CoSignerContext)
+	if len(valid) < mp.Threshold() {
+		return nil, wve.Err(wve.InvalidParameter, fmt.Sprintf("only %d of the %d required co-signatures are valid", len(valid), mp.Threshold()))
+	}
+	//Only keep the valid co-signatures, once each
+	merged.CoSignatures = sigs
+
+	policy, err := PolicySchemeInstanceFor(&merged.TBS.Policy)
+	if err != nil {
+		return nil, wve.ErrW(wve.MalformedObject, "proposal policy is invalid", err)
+	}
+	validFrom := merged.TBS.Validity.NotBefore
+	validUntil := merged.TBS.Validity.NotAfter
+	return createAttestation(ctx, &PCreateAttestation{
+		Policy:            policy,
+		HashScheme:        KECCAK256,
+		BodyScheme:        p.BodyScheme,
+		EncryptionContext: p.EncryptionContext,
+		Attester:          p.Attester,
+		AttesterLocation:  LocationSchemeInstanceFor(&merged.TBS.AttesterLocation),
+		Subject:           p.Subject,
+		SubjectLocation:   LocationSchemeInstanceFor(&merged.TBS.SubjectLocation),
+		ValidFrom:         &validFrom,
+		ValidUntil:        &validUntil,
+	}, &thresholdBinding{
+		outerKey: thresholdOuterKey(p.Attester, merged.TBS.Nonce),
+		binding:  merged,
+	})
+}
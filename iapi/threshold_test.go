@@ -0,0 +1,167 @@
+package iapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func thresholdProposal(t *testing.T, threshold int, ncosigners int) (*EntitySecrets, *Entity, []*EntitySecrets, *KeyPoolDecryptionContext, *RCreateThresholdProposal) {
+	ctx := context.Background()
+	source, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dst, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	pol, err := NewTrustLevelPolicy(3)
+	require.NoError(t, err)
+	kpdc := NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(source.EntitySecrets.Entity)
+	cosigners := []*EntitySecrets{}
+	entities := []*Entity{}
+	locations := []LocationSchemeInstance{}
+	for i := 0; i < ncosigners; i++ {
+		cs, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+		require.NoError(t, werr)
+		cosigners = append(cosigners, cs.EntitySecrets)
+		entities = append(entities, cs.EntitySecrets.Entity)
+		locations = append(locations, NewLocationSchemeInstanceURL("test", 1))
+		kpdc.AddEntity(cs.EntitySecrets.Entity)
+	}
+	rv, werr := CreateThresholdProposal(ctx, &PCreateThresholdProposal{
+		Policy:            pol,
+		Attester:          source.EntitySecrets,
+		AttesterLocation:  NewLocationSchemeInstanceURL("test", 1),
+		Subject:           dst.EntitySecrets.Entity,
+		SubjectLocation:   NewLocationSchemeInstanceURL("test", 1),
+		Threshold:         threshold,
+		CoSigners:         entities,
+		CoSignerLocations: locations,
+	})
+	require.NoError(t, werr)
+	return source.EntitySecrets, dst.EntitySecrets.Entity, cosigners, kpdc, rv
+}
+
+func coSign(t *testing.T, der []byte, attester *Entity, cosigner *EntitySecrets) []byte {
+	ctx := context.Background()
+	parsed, werr := ParseThresholdProposal(ctx, &PParseThresholdProposal{
+		DER:      der,
+		Attester: attester,
+	})
+	require.NoError(t, werr)
+	rv, werr := CoSignThresholdProposal(ctx, &PCoSignThresholdProposal{
+		Proposal: parsed.Proposal,
+		CoSigner: cosigner,
+	})
+	require.NoError(t, werr)
+	return rv.DER
+}
+
+func TestThresholdAttestation(t *testing.T) {
+	ctx := context.Background()
+	attester, subject, cosigners, kpdc, prop := thresholdProposal(t, 2, 3)
+
+	//Co-signers sign their own copies, which are merged at the end
+	copyA := coSign(t, prop.DER, attester.Entity, cosigners[0])
+	copyB := coSign(t, prop.DER, attester.Entity, cosigners[2])
+	proposals := []*ThresholdProposal{}
+	for _, der := range [][]byte{copyA, copyB} {
+		parsed, werr := ParseThresholdProposal(ctx, &PParseThresholdProposal{DER: der})
+		require.NoError(t, werr)
+		require.Equal(t, 1, len(parsed.Proposal.SignedBy()))
+		proposals = append(proposals, parsed.Proposal)
+	}
+	rv, werr := CreateThresholdAttestation(ctx, &PCreateThresholdAttestation{
+		Proposals:         proposals,
+		BodyScheme:        NewPlaintextBodyScheme(),
+		EncryptionContext: NewKeyPoolDecryptionContext(),
+		Attester:          attester,
+		Subject:           subject,
+		CoSignerContext:   kpdc,
+	})
+	require.NoError(t, werr)
+
+	readback, werr := ParseAttestation(ctx, &PParseAttestation{
+		DER:               rv.DER,
+		DecryptionContext: kpdc,
+	})
+	require.NoError(t, werr)
+	require.False(t, readback.IsMalformed)
+	tp := readback.Attestation.ThresholdProposal()
+	require.NotNil(t, tp)
+	require.Equal(t, 2, len(tp.ValidCoSigners(ctx, kpdc)))
+	require.True(t, prop.Proposal.ValidUntil().Equal(readback.Attestation.DecryptedBody.VerifierBody.Validity.NotAfter))
+
+	//A co-signer that cannot be resolved does not count
+	partial := NewKeyPoolDecryptionContext()
+	partial.AddEntity(cosigners[0].Entity)
+	require.Equal(t, 1, len(tp.ValidCoSigners(ctx, partial)))
+}
+
+func TestThresholdAttestationBelowThreshold(t *testing.T) {
+	ctx := context.Background()
+	attester, subject, cosigners, kpdc, prop := thresholdProposal(t, 2, 3)
+
+	//The same co-signer signing twice is counted once
+	signed := coSign(t, prop.DER, attester.Entity, cosigners[1])
+	signed = coSign(t, signed, attester.Entity, cosigners[1])
+	parsed, werr := ParseThresholdProposal(ctx, &PParseThresholdProposal{DER: signed})
+	require.NoError(t, werr)
+	require.Equal(t, 1, len(parsed.Proposal.SignedBy()))
+	_, werr = CreateThresholdAttestation(ctx, &PCreateThresholdAttestation{
+		Proposals:         []*ThresholdProposal{parsed.Proposal, parsed.Proposal},
+		BodyScheme:        NewPlaintextBodyScheme(),
+		EncryptionContext: NewKeyPoolDecryptionContext(),
+		Attester:          attester,
+		Subject:           subject,
+		CoSignerContext:   kpdc,
+	})
+	require.Error(t, werr)
+
+	//Entities that are not co-signers cannot sign
+	outsider, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	_, werr = CoSignThresholdProposal(ctx, &PCoSignThresholdProposal{
+		Proposal: parsed.Proposal,
+		CoSigner: outsider.EntitySecrets,
+	})
+	require.Error(t, werr)
+}
+
+func TestThresholdProposalTampered(t *testing.T) {
+	ctx := context.Background()
+	attester, _, cosigners, _, prop := thresholdProposal(t, 1, 2)
+
+	//Raising the trust level invalidates the attester's signature
+	cf := *prop.Proposal.CanonicalForm
+	pol, err := NewTrustLevelPolicy(4)
+	require.NoError(t, err)
+	cf.TBS.Policy = *pol.CanonicalForm()
+	tampered := &ThresholdProposal{}
+	require.NoError(t, tampered.SetCanonicalForm(&cf))
+	require.Error(t, tampered.VerifyAttester(ctx, attester.Entity))
+
+	//A co-signature on the tampered proposal does not verify against the
+	//original
+	rv, werr := CoSignThresholdProposal(ctx, &PCoSignThresholdProposal{
+		Proposal: tampered,
+		CoSigner: cosigners[0],
+	})
+	require.NoError(t, werr)
+	orig := *prop.Proposal.CanonicalForm
+	orig.CoSignatures = rv.Proposal.CanonicalForm.CoSignatures
+	mixed := &ThresholdProposal{}
+	require.NoError(t, mixed.SetCanonicalForm(&orig))
+	kpdc := NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(cosigners[0].Entity)
+	require.Equal(t, 0, len(mixed.ValidCoSigners(ctx, kpdc)))
+
+	//The proposal is only accepted from the named attester
+	other, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	_, werr = ParseThresholdProposal(ctx, &PParseThresholdProposal{
+		DER:      prop.DER,
+		Attester: other.EntitySecrets.Entity,
+	})
+	require.Error(t, werr)
+}
@@ -86,6 +86,18 @@ type RVerifyRTreeProof struct {
 	Paths           [][]int
 	Subject         HashSchemeInstance
 	SubjectLocation LocationSchemeInstance
+	//The successions the proof follows. The caller should check that the
+	//predecessors and successors have not been revoked
+	Successions []*EntitySuccession
+}
+
+//entityCurrentlyValid checks the validity window of an entity. Revocation
+//needs storage, so it is left to the caller
+func entityCurrentlyValid(ent *Entity) bool {
+	if ent.Expired() {
+		return false
+	}
+	return !ent.CanonicalForm.TBS.Validity.NotBefore.After(time.Now())
 }
 
 func VerifyRTreeProof(ctx context.Context, p *PVerifyRTreeProof) (*RVerifyRTreeProof, wve.WVE) {
@@ -175,6 +187,13 @@ func VerifyRTreeProof(ctx context.Context, p *PVerifyRTreeProof) (*RVerifyRTreeP
 		if rpa.Attestation.DecryptedBody == nil {
 			return nil, wve.ErrW(wve.ProofInvalid, fmt.Sprintf("attestation %d is not decryptable", idx), err)
 		}
+		if tp := rpa.Attestation.ThresholdProposal(); tp != nil {
+			//The co-signers must be included in the proof or resolvable
+			valid := tp.ValidCoSigners(ctx, dctx)
+			if len(valid) < tp.Threshold() {
+				return nil, wve.Err(wve.ProofInvalid, fmt.Sprintf("attestation %d has %d of %d required co-signatures", idx, len(valid), tp.Threshold()))
+			}
+		}
 		mapping[idx] = rpa.Attestation
 		attExpiry := rpa.Attestation.DecryptedBody.VerifierBody.Validity.NotAfter
 		if !expiryset || attExpiry.Before(expiry) {
@@ -183,6 +202,53 @@ func VerifyRTreeProof(ctx context.Context, p *PVerifyRTreeProof) (*RVerifyRTreeP
 		}
 	}
 
+	//A succession lets a path that ends at the predecessor be used by the
+	//successor during the grace window
+	successions := make(map[string]*EntitySuccession)
+	usedSuccessions := make(map[string]bool)
+	for _, ext := range exp.Extensions {
+		if !ext.ExtensionID.Equal(serdes.ProofSuccessionOID) {
+			if ext.Critical {
+				return nil, wve.Err(wve.ProofInvalid, "proof has unsupported critical extension")
+			}
+			continue
+		}
+		rps, werr := ParseEntitySuccession(ctx, &PParseEntitySuccession{
+			DER: ext.Value,
+		})
+		if werr != nil {
+			return nil, wve.ErrW(wve.ProofInvalid, "could not parse succession", werr)
+		}
+		succession := rps.Succession
+		pred, werr := dctx.EntityByHashLoc(ctx, succession.Predecessor, succession.PredecessorLocation)
+		if werr != nil {
+			return nil, wve.ErrW(wve.ProofInvalid, "could not resolve succession predecessor", werr)
+		}
+		if pred == nil {
+			return nil, wve.Err(wve.ProofInvalid, "could not resolve succession predecessor")
+		}
+		if werr := succession.Verify(ctx, pred); werr != nil {
+			return nil, wve.ErrW(wve.ProofInvalid, "succession is invalid", werr)
+		}
+		succ, werr := dctx.EntityByHashLoc(ctx, succession.Successor, succession.SuccessorLocation)
+		if werr != nil {
+			return nil, wve.ErrW(wve.ProofInvalid, "could not resolve succession successor", werr)
+		}
+		if succ == nil {
+			return nil, wve.Err(wve.ProofInvalid, "could not resolve succession successor")
+		}
+		if !entityCurrentlyValid(pred) {
+			return nil, wve.Err(wve.ProofInvalid, "succession predecessor is expired or not yet valid")
+		}
+		if !entityCurrentlyValid(succ) {
+			return nil, wve.Err(wve.ProofInvalid, "succession successor is expired or not yet valid")
+		}
+		if !succession.InGrace(time.Now()) {
+			return nil, wve.Err(wve.ProofInvalid, "succession grace window has ended")
+		}
+		successions[succession.Predecessor.MultihashString()] = succession
+	}
+
 	//TODO revocation checks
 	//todo check end to end and check all paths have same subject
 	//then fill in subject here and make it get printed by cli
@@ -240,6 +306,13 @@ func VerifyRTreeProof(ctx context.Context, p *PVerifyRTreeProof) (*RVerifyRTreeP
 			currAtt = nextAtt
 			cursubj, cursubloc = nextAtt.Subject()
 		}
+		if succession, ok := successions[cursubj.MultihashString()]; ok {
+			usedSuccessions[cursubj.MultihashString()] = true
+			cursubj, cursubloc = succession.Successor, succession.SuccessorLocation
+			if succession.GraceUntil().Before(expiry) {
+				expiry = succession.GraceUntil()
+			}
+		}
 		pathpolicies = append(pathpolicies, rtreePolicy)
 		pathEndEntities = append(pathEndEntities, cursubj)
 		subjectLocation = cursubloc
@@ -270,6 +343,9 @@ func VerifyRTreeProof(ctx context.Context, p *PVerifyRTreeProof) (*RVerifyRTreeP
 		Subject:         finalsubject,
 		SubjectLocation: subjectLocation,
 	}
+	for pred := range usedSuccessions {
+		rv.Successions = append(rv.Successions, successions[pred])
+	}
 	for idx, att := range mapping {
 		rv.Attestations[idx] = att
 	}
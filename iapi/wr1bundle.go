@@ -0,0 +1,148 @@
+package iapi
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/immesys/wave/wve"
+)
+
+//WR1BodyKeyCache can optionally be implemented by a body encryption context.
+//Generating the delegable body keys for the key bundle is most of the cost
+//of creating a WR1 attestation, and the keys for a given partition do not
+//depend on the subject, so they can be reused across attestations
+type WR1BodyKeyCache interface {
+	//Return nil if there is no key for exactly this partition
+	WR1CachedBodyKey(ctx context.Context, attester HashSchemeInstance, partition [][]byte) (SlottedSecretKey, error)
+	WR1CacheBodyKey(ctx context.Context, attester HashSchemeInstance, key SlottedSecretKey) error
+}
+
+//WR1BundleCacheStats counts the body key cache lookups since startup
+type WR1BundleCacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+var wr1BundleCacheHits uint64
+var wr1BundleCacheMisses uint64
+
+func GetWR1BundleCacheStats() WR1BundleCacheStats {
+	return WR1BundleCacheStats{
+		Hits:   atomic.LoadUint64(&wr1BundleCacheHits),
+		Misses: atomic.LoadUint64(&wr1BundleCacheMisses),
+	}
+}
+
+//wr1BundleKeys returns delegable body keys for the given partitions. If the
+//encryption context is a WR1BodyKeyCache, cached keys are used and the
+//missing keys are added to the cache. It also returns how many keys had to
+//be generated
+func wr1BundleKeys(ctx context.Context, ec BodyEncryptionContext, attester *EntitySecrets, partitions [][][]byte) ([]SlottedSecretKey, int, error) {
+	cache, ok := ec.(WR1BodyKeyCache)
+	if !ok {
+		rv, err := attester.CalculateWR1Batch(partitions, true)
+		return rv, len(partitions), err
+	}
+	attesterHI := attester.Entity.Keccak256HI()
+	rv := make([]SlottedSecretKey, len(partitions))
+	missing := [][][]byte{}
+	missingIdx := []int{}
+	for idx, p := range partitions {
+		k, err := cache.WR1CachedBodyKey(ctx, attesterHI, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		if k != nil {
+			atomic.AddUint64(&wr1BundleCacheHits, 1)
+			rv[idx] = k
+			continue
+		}
+		atomic.AddUint64(&wr1BundleCacheMisses, 1)
+		missing = append(missing, p)
+		missingIdx = append(missingIdx, idx)
+	}
+	if len(missing) == 0 {
+		return rv, 0, nil
+	}
+	kz, err := attester.CalculateWR1Batch(missing, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, k := range kz {
+		rv[missingIdx[i]] = k
+		if err := cache.WR1CacheBodyKey(ctx, attesterHI, k); err != nil {
+			return nil, 0, err
+		}
+	}
+	return rv, len(missing), nil
+}
+
+type PPrecomputeWR1Bundle struct {
+	//Must be a WR1BodyKeyCache
+	EncryptionContext BodyEncryptionContext
+	Attester          *EntitySecrets
+	Policy            PolicySchemeInstance
+	ValidFrom         time.Time
+	ValidUntil        time.Time
+}
+type RPrecomputeWR1Bundle struct {
+	//The number of keys in the bundle
+	Keys int
+	//The number of keys that were not already cached
+	Generated int
+}
+
+//PrecomputeWR1Bundle fills the body key cache with the keys that an
+//attestation with the given policy and validity would delegate, so that
+//creating the attestation later is fast
+func PrecomputeWR1Bundle(ctx context.Context, p *PPrecomputeWR1Bundle) (*RPrecomputeWR1Bundle, wve.WVE) {
+	if p.Attester == nil || p.Policy == nil {
+		return nil, wve.Err(wve.InvalidParameter, "missing required parameters")
+	}
+	ec, ok := p.EncryptionContext.(WR1BodyEncryptionContext)
+	if !ok {
+		return nil, wve.Err(wve.InvalidParameter, "encryption context does not support WR1")
+	}
+	if _, ok := p.EncryptionContext.(WR1BodyKeyCache); !ok {
+		return nil, wve.Err(wve.InvalidParameter, "encryption context does not cache body keys")
+	}
+	schedule, werr := policySchedule(ctx, ec, p.Attester.Entity, p.Policy)
+	if werr != nil {
+		return nil, werr
+	}
+	partitions, err := CalculateKeyBundlePartitions(p.ValidFrom, p.ValidUntil, p.Policy.WR1PartitionPrefix(false), schedule)
+	if err != nil {
+		return nil, err
+	}
+	workers := runtime.NumCPU()
+	if workers > len(partitions) {
+		workers = len(partitions)
+	}
+	generated := make([]int, workers)
+	errs := make([]error, workers)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			//Contiguous batches are cheaper because CalculateWR1Batch
+			//adjusts each key from the previous one
+			batch := partitions[i*len(partitions)/workers : (i+1)*len(partitions)/workers]
+			_, generated[i], errs[i] = wr1BundleKeys(ctx, p.EncryptionContext, p.Attester, batch)
+		}(i)
+	}
+	wg.Wait()
+	rv := &RPrecomputeWR1Bundle{
+		Keys: len(partitions),
+	}
+	for i := 0; i < workers; i++ {
+		if errs[i] != nil {
+			return nil, wve.ErrW(wve.InternalError, "could not generate body keys", errs[i])
+		}
+		rv.Generated += generated[i]
+	}
+	return rv, nil
+}
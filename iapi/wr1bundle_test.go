@@ -0,0 +1,165 @@
+package iapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/immesys/wave/serdes"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryBodyKeyCache struct {
+	*KeyPoolDecryptionContext
+	mu   sync.Mutex
+	keys map[string]SlottedSecretKey
+}
+
+func (c *memoryBodyKeyCache) WR1CachedBodyKey(ctx context.Context, attester HashSchemeInstance, partition [][]byte) (SlottedSecretKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.keys[attester.MultihashString()+WR1PartitionToIntString(partition)], nil
+}
+
+func (c *memoryBodyKeyCache) WR1CacheBodyKey(ctx context.Context, attester HashSchemeInstance, key SlottedSecretKey) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys[attester.MultihashString()+WR1PartitionToIntString(key.Slots())] = key
+	return nil
+}
+
+func TestWR1BundleCache(t *testing.T) {
+	ctx := context.Background()
+	source, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	dst, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	pol, err := NewTrustLevelPolicy(3)
+	require.NoError(t, err)
+	ec := &memoryBodyKeyCache{
+		KeyPoolDecryptionContext: NewKeyPoolDecryptionContext(),
+		keys:                     make(map[string]SlottedSecretKey),
+	}
+	validFrom := time.Now().Truncate(time.Second)
+	validUntil := validFrom.Add(90 * 24 * time.Hour)
+
+	pre, werr := PrecomputeWR1Bundle(ctx, &PPrecomputeWR1Bundle{
+		EncryptionContext: ec,
+		Attester:          source.EntitySecrets,
+		Policy:            pol,
+		ValidFrom:         validFrom,
+		ValidUntil:        validUntil,
+	})
+	require.NoError(t, werr)
+	require.True(t, pre.Keys > 0)
+	require.Equal(t, pre.Keys, pre.Generated)
+	require.Equal(t, pre.Keys, len(ec.keys))
+
+	pre, werr = PrecomputeWR1Bundle(ctx, &PPrecomputeWR1Bundle{
+		EncryptionContext: ec,
+		Attester:          source.EntitySecrets,
+		Policy:            pol,
+		ValidFrom:         validFrom,
+		ValidUntil:        validUntil,
+	})
+	require.NoError(t, werr)
+	require.Equal(t, 0, pre.Generated)
+
+	//Creating the attestation uses only cached keys
+	before := GetWR1BundleCacheStats()
+	rv, werr := CreateAttestation(ctx, &PCreateAttestation{
+		Policy:            pol,
+		HashScheme:        KECCAK256,
+		BodyScheme:        &WR1BodyScheme{},
+		EncryptionContext: ec,
+		Attester:          source.EntitySecrets,
+		AttesterLocation:  NewLocationSchemeInstanceURL("test", 1),
+		Subject:           dst.Entity,
+		SubjectLocation:   NewLocationSchemeInstanceURL("test", 1),
+		ValidFrom:         &validFrom,
+		ValidUntil:        &validUntil,
+	})
+	require.NoError(t, werr)
+	after := GetWR1BundleCacheStats()
+	require.EqualValues(t, pre.Keys, after.Hits-before.Hits)
+	require.EqualValues(t, 0, after.Misses-before.Misses)
+
+	//The reused keys are valid delegations
+	kpdc := NewKeyPoolDecryptionContext()
+	kpdc.AddEntity(source.Entity)
+	kpdc.AddEntitySecret(dst.EntitySecrets, false)
+	readback, werr := ParseAttestation(ctx, &PParseAttestation{
+		DER:               rv.DER,
+		DecryptionContext: kpdc,
+	})
+	require.NoError(t, werr)
+	require.NotNil(t, readback.Attestation.DecryptedBody)
+	params, err := source.Entity.WR1_BodyParams()
+	require.NoError(t, err)
+	for _, k := range ec.keys {
+		pk, err := params.GenerateChildKey(ctx, k.Slots())
+		require.NoError(t, err)
+		ciphertext, err := pk.EncryptMessage(ctx, []byte("hello"))
+		require.NoError(t, err)
+		plaintext, err := k.DecryptMessage(ctx, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, []byte("hello"), plaintext)
+		break
+	}
+}
+
+func TestWR1BundleUnknownNamespace(t *testing.T) {
+	ctx := context.Background()
+	source, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	ns, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	spol := serdes.RTreePolicy{
+		Namespace:         *ns.Entity.Keccak256HI().CanonicalForm(),
+		NamespaceLocation: *NewLocationSchemeInstanceURL("test", 1).CanonicalForm(),
+	}
+	pol, err := NewRTreePolicyScheme(spol, nil)
+	require.NoError(t, err)
+	ec := &memoryBodyKeyCache{
+		KeyPoolDecryptionContext: NewKeyPoolDecryptionContext(),
+		keys:                     make(map[string]SlottedSecretKey),
+	}
+	validFrom := time.Now().Truncate(time.Second)
+	validUntil := validFrom.Add(24 * time.Hour)
+
+	//The namespace is not known, so its schedule can not be used
+	_, werr = PrecomputeWR1Bundle(ctx, &PPrecomputeWR1Bundle{
+		EncryptionContext: ec,
+		Attester:          source.EntitySecrets,
+		Policy:            pol,
+		ValidFrom:         validFrom,
+		ValidUntil:        validUntil,
+	})
+	require.Error(t, werr)
+	require.Equal(t, 0, len(ec.keys))
+
+	ec.AddEntity(ns.Entity)
+	pre, werr := PrecomputeWR1Bundle(ctx, &PPrecomputeWR1Bundle{
+		EncryptionContext: ec,
+		Attester:          source.EntitySecrets,
+		Policy:            pol,
+		ValidFrom:         validFrom,
+		ValidUntil:        validUntil,
+	})
+	require.NoError(t, werr)
+	require.True(t, pre.Keys > 0)
+
+	//A namespace granting on itself does not need to be looked up
+	_, werr = PrecomputeWR1Bundle(ctx, &PPrecomputeWR1Bundle{
+		EncryptionContext: &memoryBodyKeyCache{
+			KeyPoolDecryptionContext: NewKeyPoolDecryptionContext(),
+			keys:                     make(map[string]SlottedSecretKey),
+		},
+		Attester:   ns.EntitySecrets,
+		Policy:     pol,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	})
+	require.NoError(t, werr)
+}
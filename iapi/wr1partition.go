@@ -10,32 +10,24 @@ import (
 	"github.com/immesys/wave/wve"
 )
 
-func CalculateWR1Partition(validFrom time.Time, validUntil time.Time, userPrefix [][]byte) ([][]byte, wve.WVE) {
+func CalculateWR1Partition(validFrom time.Time, validUntil time.Time, userPrefix [][]byte, schedule *WR1PartitionSchedule) ([][]byte, wve.WVE) {
 	if len(userPrefix) > 12 {
 		return nil, wve.Err(wve.InvalidParameter, "user prefix partition must be < 12 elements")
 	}
-	tiers := WR1PartitionTiers
+	if schedule == nil {
+		schedule = DefaultWR1PartitionSchedule
+	}
+	if validUntil.Sub(validFrom) > schedule.MaxRange {
+		return nil, wve.Err(wve.InvalidParameter, "valid range exceeds the partition schedule of the namespace")
+	}
+	tiers := schedule.Tiers
+	finest := tiers[len(tiers)-1]
 	endNS := validUntil.UnixNano()
-	endNS -= endNS % tiers[3]
-	endNS += tiers[3]
+	endNS -= endNS % finest
+	endNS += finest
 	startNS := validFrom.UnixNano()
-	startNS -= startNS % tiers[3]
-
-	intstart := make([]int64, 4)
-	intend := make([]int64, 4)
-	bytestart := make([][]byte, 4)
-	byteend := make([][]byte, 4)
-	for i := 0; i < 4; i++ {
-		intstart[i] = startNS / tiers[i]
-		intend[i] = endNS / tiers[i]
-		bytestart[i] = make([]byte, 2)
-		binary.BigEndian.PutUint16(bytestart[i], uint16(intstart[i]))
-		byteend[i] = make([]byte, 2)
-		binary.BigEndian.PutUint16(byteend[i], uint16(intend[i]))
-	}
-	// fmt.Printf("calculated wr1 partition is [%d %d %d %d] [%d %d %d %d]\n",
-	// 	intstart[0], intstart[1], intstart[2], intstart[3],
-	// 	intend[0], intend[1], intend[2], intend[3])
+	startNS -= startNS % finest
+
 	rv := make([][]byte, 20)
 	for i := 0; i < 12; i++ {
 		if i >= len(userPrefix) {
@@ -45,16 +37,16 @@ func CalculateWR1Partition(validFrom time.Time, validUntil time.Time, userPrefix
 			rv[i] = userPrefix[i]
 		}
 	}
-	for i := 0; i < 4; i++ {
-		rv[12+i] = bytestart[i]
-		rv[16+i] = byteend[i]
+	for i := 0; i < len(tiers); i++ {
+		rv[12+i] = schedule.slot(startNS / tiers[i])
+		rv[16+i] = schedule.slot(endNS / tiers[i])
 	}
 	return rv, nil
 }
 
 //This generates the partitions and calculates the differences to generate the keyring bundle entries, but it does not generate the keys
-func CalculateEmptyKeyBundleEntries(startDat time.Time, endDat time.Time, userPrefix [][]byte) ([][][]byte, []serdes.BLS12381OAQUEKeyringBundleEntry, wve.WVE) {
-	partitions, err := CalculateKeyBundlePartitions(startDat, endDat, userPrefix)
+func CalculateEmptyKeyBundleEntries(startDat time.Time, endDat time.Time, userPrefix [][]byte, schedule *WR1PartitionSchedule) ([][][]byte, []serdes.BLS12381OAQUEKeyringBundleEntry, wve.WVE) {
+	partitions, err := CalculateKeyBundlePartitions(startDat, endDat, userPrefix, schedule)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,24 +101,149 @@ func DecodeKeyBundleEntries(be []serdes.BLS12381OAQUEKeyringBundleEntry) ([][][]
 // long.
 var WR1PartitionTiers []int64 = []int64{int64(64 * 7 * 24 * time.Hour), int64(16 * 7 * 24 * time.Hour), int64(4 * 7 * 24 * time.Hour), int64(7 * 24 * time.Hour)}
 
-func CalculateKeyBundlePartitions(startDat time.Time, endDat time.Time, userPrefix [][]byte) ([][][]byte, wve.WVE) {
-	//round up the end date by a week
-	tiers := WR1PartitionTiers
+//WR1PartitionSchedule describes how validity ranges map onto the time
+//slots of a WR1 partition. A namespace can choose its own schedule with
+//the WR1PartitionSchedule entity extension, and attestations on that
+//namespace carry it
+type WR1PartitionSchedule struct {
+	//Tier lengths in nanoseconds, coarsest first. Each tier must be a
+	//multiple of the next
+	Tiers []int64
+	//The number of bytes in each time slot
+	SlotSize int
+	//The longest validity range that can be encrypted or delegated
+	MaxRange time.Duration
+}
+
+//The schedule used by namespaces that do not specify one, and by all
+//attestations using the original WR1 body scheme
+var DefaultWR1PartitionSchedule = &WR1PartitionSchedule{
+	Tiers:    WR1PartitionTiers,
+	SlotSize: 2,
+	MaxRange: 3 * 365 * 24 * time.Hour,
+}
+
+//The partition has four start slots and four end slots
+const wr1MaxTiers = 4
+
+//The longest validity range any schedule may allow
+const wr1MaxRange = 100 * 365 * 24 * time.Hour
+
+//Schedules must be able to represent times up to this point
+var wr1ScheduleHorizon = time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)
+
+//The largest number of tier entries on either side of a key bundle. This
+//keeps key bundles to a size that can be generated
+const wr1MaxBundleSide = 64
+
+//NewWR1PartitionSchedule checks and returns the schedule described by the
+//canonical form
+func NewWR1PartitionSchedule(cf *serdes.WR1PartitionSchedule) (*WR1PartitionSchedule, wve.WVE) {
+	rv := &WR1PartitionSchedule{
+		Tiers:    cf.Tiers,
+		SlotSize: cf.SlotSize,
+		MaxRange: time.Duration(cf.MaxRange),
+	}
+	if len(rv.Tiers) == 0 || len(rv.Tiers) > wr1MaxTiers {
+		return nil, wve.Err(wve.InvalidParameter, "partition schedule must have between one and four tiers")
+	}
+	for i, t := range rv.Tiers {
+		if t <= 0 {
+			return nil, wve.Err(wve.InvalidParameter, "partition schedule tiers must be positive")
+		}
+		if i > 0 && (t >= rv.Tiers[i-1] || rv.Tiers[i-1]%t != 0) {
+			return nil, wve.Err(wve.InvalidParameter, "each partition schedule tier must divide the previous tier")
+		}
+	}
+	if rv.SlotSize < 1 || rv.SlotSize > 8 {
+		return nil, wve.Err(wve.InvalidParameter, "partition schedule slot size must be between one and eight bytes")
+	}
+	finest := rv.Tiers[len(rv.Tiers)-1]
+	if rv.MaxRange < time.Duration(finest) || rv.MaxRange > wr1MaxRange {
+		return nil, wve.Err(wve.InvalidParameter, "partition schedule maximum range is out of bounds")
+	}
+	if rv.SlotSize < 8 && (wr1ScheduleHorizon.UnixNano()/finest)>>uint(8*rv.SlotSize) != 0 {
+		return nil, wve.Err(wve.InvalidParameter, "partition schedule slots are too small for the finest tier")
+	}
+	//Each side of the bundle walks the coarsest tier across the range and
+	//then at most one step of each finer tier on either end
+	side := int64(rv.MaxRange)/rv.Tiers[0] + 2
+	for i := 1; i < len(rv.Tiers); i++ {
+		side += 2 * (rv.Tiers[i-1] / rv.Tiers[i])
+	}
+	if side > wr1MaxBundleSide {
+		return nil, wve.Err(wve.InvalidParameter, "partition schedule would produce oversized key bundles")
+	}
+	return rv, nil
+}
+
+func (s *WR1PartitionSchedule) CanonicalForm() *serdes.WR1PartitionSchedule {
+	return &serdes.WR1PartitionSchedule{
+		Tiers:    s.Tiers,
+		SlotSize: s.SlotSize,
+		MaxRange: int64(s.MaxRange),
+	}
+}
+
+//IsDefault returns true if the schedule is the same as the default
+//schedule, and can therefore use the original WR1 body scheme
+func (s *WR1PartitionSchedule) IsDefault() bool {
+	d := DefaultWR1PartitionSchedule
+	if s.SlotSize != d.SlotSize || s.MaxRange != d.MaxRange || len(s.Tiers) != len(d.Tiers) {
+		return false
+	}
+	for i := range s.Tiers {
+		if s.Tiers[i] != d.Tiers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *WR1PartitionSchedule) slot(v int64) []byte {
+	rv := make([]byte, 8)
+	binary.BigEndian.PutUint64(rv, uint64(v))
+	return rv[8-s.SlotSize:]
+}
+
+func (s *WR1PartitionSchedule) slotValue(b []byte) (int64, bool) {
+	if len(b) != s.SlotSize {
+		return 0, false
+	}
+	return slotInt(b), true
+}
+
+func slotInt(b []byte) int64 {
+	v := int64(0)
+	for _, e := range b {
+		v = v<<8 | int64(e)
+	}
+	return v
+}
+
+func CalculateKeyBundlePartitions(startDat time.Time, endDat time.Time, userPrefix [][]byte, schedule *WR1PartitionSchedule) ([][][]byte, wve.WVE) {
+	if schedule == nil {
+		schedule = DefaultWR1PartitionSchedule
+	}
+	//round up the end date by the finest tier
+	tiers := schedule.Tiers
+	finest := tiers[len(tiers)-1]
+	maxRange := schedule.MaxRange
 	left := [][]int64{}
 	leftTimeRanges := []DateRange{}
 	right := [][]int64{}
 	rightTimeRanges := []DateRange{}
 	_ = left
 	_ = right
-	//The left side, or beginrange, covers all times that permitted ranges can start in. Therefore it must cover (startDate-3years)..(endDate)
+	//The left side, or beginrange, covers all times that permitted ranges can start in. Therefore it must cover (startDate-maxRange)..(endDate)
 	endNS := endDat.UnixNano()
-	endNS -= endNS % tiers[3]
-	endNS += tiers[3]
+	endNS -= endNS % finest
+	endNS += finest
 	startNS := startDat.UnixNano()
-	startNS -= startNS % tiers[3]
+	startNS -= startNS % finest
 
 	//First calculate the left
-	cursor := startNS - int64(3*365*24*time.Hour)
+	cursor := startNS - int64(maxRange)
 	cursor -= (cursor % tiers[0])
 	current := make([]int64, len(tiers))
 	current[0] = cursor / tiers[0]
@@ -161,7 +278,7 @@ func CalculateKeyBundlePartitions(startDat time.Time, endDat time.Time, userPref
 		}
 	}
 
-	cursor = endNS + int64(3*365*24*time.Hour)
+	cursor = endNS + int64(maxRange)
 	cursor -= cursor % tiers[0]
 	cursor += tiers[0]
 	//fmt.Printf("corrected end date for start is %s\n", time.Unix(0, cursor))
@@ -209,30 +326,28 @@ func CalculateKeyBundlePartitions(startDat time.Time, endDat time.Time, userPref
 	results := make([][][]byte, 0, len(left)*len(right))
 	for li := 0; li < len(left); li++ {
 		for ri := 0; ri < len(right); ri++ {
-			//First check that the right start is not more than three years after
+			//First check that the right start is not more than the maximum range after
 			//the left end. In that case this combination could never be used because
-			//attestations can not be longer than 3 years
-			if leftTimeRanges[li].End.Add(3 * 365 * 24 * time.Hour).Before(rightTimeRanges[ri].Start) {
+			//attestations can not be longer than the maximum range
+			if leftTimeRanges[li].End.Add(maxRange).Before(rightTimeRanges[ri].Start) {
 				continue
 			}
 
 			r := make([][]byte, 20)
 			copy(r[:], userPrefix)
 
-			for i := 0; i < 4; i++ {
+			for i := 0; i < len(tiers); i++ {
 				var e []byte
 				if left[li][i] != 0 {
-					e = make([]byte, 2)
-					binary.BigEndian.PutUint16(e, uint16(left[li][i]))
+					e = schedule.slot(left[li][i])
 				}
 				r[12+i] = e
 			}
 
-			for i := 0; i < 4; i++ {
+			for i := 0; i < len(tiers); i++ {
 				var e []byte
 				if right[ri][i] != 0 {
-					e = make([]byte, 2)
-					binary.BigEndian.PutUint16(e, uint16(right[ri][i]))
+					e = schedule.slot(right[ri][i])
 				}
 				r[16+i] = e
 			}
@@ -258,10 +373,7 @@ func WR1PartitionToIntString(p [][]byte) string {
 			if len(chunk[i]) == 0 {
 				break
 			}
-			if len(chunk[i]) != 2 {
-				panic("this is just a debug function")
-			}
-			ichunk[i] = int64(binary.BigEndian.Uint16(chunk[i]))
+			ichunk[i] = slotInt(chunk[i])
 			if ichunk[i] == 0 {
 				panic("it was literally zero\n")
 			}
@@ -274,7 +386,7 @@ func WR1PartitionToIntString(p [][]byte) string {
 	result.WriteString(fmt.Sprintf("(%d %d %d %d)", right[0], right[1], right[2], right[3]))
 	return result.String()
 }
-func WR1PartitionToString(p [][]byte) string {
+func WR1PartitionToString(p [][]byte, schedule *WR1PartitionSchedule) string {
 	tfmt := "2006-01-02 15:04:05"
 	result := bytes.Buffer{}
 	result.WriteString("[")
@@ -285,7 +397,7 @@ func WR1PartitionToString(p [][]byte) string {
 		result.WriteString(fmt.Sprintf("%q/", string(p[i])))
 	}
 	result.WriteString("] ")
-	startRange, startErr := WR1PartitionChunkToDateRange(p[12:16])
+	startRange, startErr := WR1PartitionChunkToDateRange(p[12:16], schedule)
 	if startErr != nil {
 		result.WriteString("(start range invalid) ")
 	} else {
@@ -293,7 +405,7 @@ func WR1PartitionToString(p [][]byte) string {
 			startRange.Start.Format(tfmt),
 			startRange.End.Format(tfmt)))
 	}
-	endRange, endErr := WR1PartitionChunkToDateRange(p[16:])
+	endRange, endErr := WR1PartitionChunkToDateRange(p[16:], schedule)
 	if endErr != nil {
 		result.WriteString("(end range invalid)")
 	} else {
@@ -309,34 +421,42 @@ type DateRange struct {
 	End   time.Time
 }
 
-func ParseWR1Partition(p [][]byte) (start *DateRange, end *DateRange, user [][]byte, err wve.WVE) {
-	startRange, startErr := WR1PartitionChunkToDateRange(p[12:16])
+//ParseWR1Partition returns the ranges that the start and end of a validity
+//range encrypted with the partition lie in. If schedule is nil, the default
+//schedule is used
+func ParseWR1Partition(p [][]byte, schedule *WR1PartitionSchedule) (start *DateRange, end *DateRange, user [][]byte, err wve.WVE) {
+	startRange, startErr := WR1PartitionChunkToDateRange(p[12:16], schedule)
 	if startErr != nil {
 		return nil, nil, nil, startErr
 	}
-	endRange, endErr := WR1PartitionChunkToDateRange(p[16:])
+	endRange, endErr := WR1PartitionChunkToDateRange(p[16:], schedule)
 	if endErr != nil {
 		return nil, nil, nil, endErr
 	}
 	return startRange, endRange, p[0:12], nil
 }
-func WR1PartitionChunkToDateRange(chunk [][]byte) (*DateRange, wve.WVE) {
-	ichunk := make([]int64, 4)
-	for i := 0; i < 4; i++ {
+func WR1PartitionChunkToDateRange(chunk [][]byte, schedule *WR1PartitionSchedule) (*DateRange, wve.WVE) {
+	if schedule == nil {
+		schedule = DefaultWR1PartitionSchedule
+	}
+	tiers := schedule.Tiers
+	ichunk := make([]int64, len(tiers))
+	for i := 0; i < len(tiers); i++ {
 		if len(chunk[i]) == 0 {
 			break
 		}
-		if len(chunk[i]) != 2 {
+		v, ok := schedule.slotValue(chunk[i])
+		if !ok {
 			return nil, wve.Err(wve.MalformedPartition, "not valid WR1 partition")
 		}
-		ichunk[i] = int64(binary.BigEndian.Uint16(chunk[i]))
+		ichunk[i] = v
 	}
-	for i := 3; i >= 0; i-- {
+	for i := len(tiers) - 1; i >= 0; i-- {
 		if ichunk[i] != 0 {
 			rv := DateRange{
-				Start: time.Unix(0, ichunk[i]*WR1PartitionTiers[i]),
+				Start: time.Unix(0, ichunk[i]*tiers[i]),
 			}
-			rv.End = rv.Start.Add(time.Duration(WR1PartitionTiers[i]))
+			rv.End = rv.Start.Add(time.Duration(tiers[i]))
 			return &rv, nil
 		}
 	}
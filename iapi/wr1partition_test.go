@@ -1,25 +1,27 @@
 package iapi
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/immesys/wave/serdes"
 	"github.com/stretchr/testify/require"
 )
 
 func TestWR1Partition(t *testing.T) {
 	t.Skip()
-	rv, err := CalculateKeyBundlePartitions(time.Now(), time.Now().Add(700*24*time.Hour), [][]byte{[]byte("foo"), []byte("bar")})
+	rv, err := CalculateKeyBundlePartitions(time.Now(), time.Now().Add(700*24*time.Hour), [][]byte{[]byte("foo"), []byte("bar")}, nil)
 	require.NoError(t, err)
 
 	for idx, e := range rv {
-		desc := WR1PartitionToString(e)
+		desc := WR1PartitionToString(e, nil)
 		fmt.Printf("%3d :  %s\n", idx, desc)
 	}
 	for idx, e := range rv {
-		desc := WR1PartitionToString(e)
-		sr, er, _, err := ParseWR1Partition(e)
+		desc := WR1PartitionToString(e, nil)
+		sr, er, _, err := ParseWR1Partition(e, nil)
 		require.NoError(t, err)
 		if sr.End.Add(3 * 365 * 24 * time.Hour).Before(er.Start) {
 			fmt.Printf("could prune: %3d : %s\n", idx, desc)
@@ -28,7 +30,7 @@ func TestWR1Partition(t *testing.T) {
 }
 
 func TestWR1PartitionCompress(t *testing.T) {
-	rvp, rvb, err := CalculateEmptyKeyBundleEntries(time.Now(), time.Now().Add(700*24*time.Hour), [][]byte{[]byte("foo"), []byte("bar")})
+	rvp, rvb, err := CalculateEmptyKeyBundleEntries(time.Now(), time.Now().Add(700*24*time.Hour), [][]byte{[]byte("foo"), []byte("bar")}, nil)
 	require.NoError(t, err)
 	require.NotNil(t, rvp)
 
@@ -36,3 +38,62 @@ func TestWR1PartitionCompress(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, parts, rvp)
 }
+
+func TestWR1PartitionSchedule(t *testing.T) {
+	schedule, err := NewWR1PartitionSchedule(&serdes.WR1PartitionSchedule{
+		Tiers:    []int64{int64(time.Hour), int64(10 * time.Minute), int64(time.Minute)},
+		SlotSize: 4,
+		MaxRange: int64(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.False(t, schedule.IsDefault())
+
+	from := time.Now()
+	until := from.Add(90 * time.Minute)
+	p, err := CalculateWR1Partition(from, until, [][]byte{[]byte("foo")}, schedule)
+	require.NoError(t, err)
+	sr, er, _, err := ParseWR1Partition(p, schedule)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, sr.End.Sub(sr.Start))
+	require.False(t, sr.Start.After(from))
+	require.True(t, er.End.After(until))
+
+	//The encryption partition must be covered by the key bundle
+	bundle, err := CalculateKeyBundlePartitions(from, until, [][]byte{[]byte("foo")}, schedule)
+	require.NoError(t, err)
+	found := false
+	for _, b := range bundle {
+		if partitionCovers(b, p) {
+			found = true
+			break
+		}
+	}
+	require.True(t, found)
+
+	_, err = CalculateWR1Partition(from, from.Add(48*time.Hour), [][]byte{[]byte("foo")}, schedule)
+	require.Error(t, err)
+}
+
+func TestWR1PartitionScheduleInvalid(t *testing.T) {
+	for _, cf := range []serdes.WR1PartitionSchedule{
+		{Tiers: nil, SlotSize: 2, MaxRange: int64(time.Hour)},
+		{Tiers: []int64{int64(time.Hour), int64(7 * time.Minute)}, SlotSize: 4, MaxRange: int64(time.Hour)},
+		{Tiers: []int64{int64(time.Minute)}, SlotSize: 2, MaxRange: int64(time.Hour)},
+		{Tiers: []int64{int64(time.Minute)}, SlotSize: 4, MaxRange: int64(365 * 24 * time.Hour)},
+	} {
+		lcf := cf
+		_, err := NewWR1PartitionSchedule(&lcf)
+		require.Error(t, err)
+	}
+}
+
+//partitionCovers returns true if a key for the given partition can be
+//used to decrypt content on the target partition
+func partitionCovers(key [][]byte, target [][]byte) bool {
+	for i := range key {
+		if len(key[i]) != 0 && !bytes.Equal(key[i], target[i]) {
+			return false
+		}
+	}
+	return true
+}
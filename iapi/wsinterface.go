@@ -2,6 +2,7 @@ package iapi
 
 import (
 	"context"
+	"time"
 )
 
 type KeyValue struct {
@@ -63,6 +64,19 @@ type LookupFromFilter struct {
 	Valid     *bool
 	Namespace []byte
 	GlobalNS  *bool
+	//The policy filters match if any one statement of an RTree policy
+	//matches all of them
+	PermissionSet  []byte
+	Permission     string
+	ResourcePrefix string
+	//Only attestations expiring in this window
+	ExpiresAfter  *time.Time
+	ExpiresBefore *time.Time
+	//Only revoked (or unrevoked) attestations
+	Revoked *bool
+	//Only attestations that sort after this one. Results are returned in a
+	//stable order so this can be used as a cursor
+	After HashSchemeInstance
 }
 
 type LocationResult struct {
@@ -111,6 +125,12 @@ type WaveState interface {
 	//powerful one already
 	InsertWR1KeysForP(ctx context.Context, attester HashSchemeInstance, k SlottedSecretKey) error
 
+	//Delegable WR1 body keys that the perspective generated for its own
+	//attestations, kept so that they can be reused. Returns nil if there
+	//is no key for exactly this partition
+	WR1BundleKeyP(ctx context.Context, attester HashSchemeInstance, partition [][]byte) (SlottedSecretKey, error)
+	InsertWR1BundleKeyP(ctx context.Context, attester HashSchemeInstance, k SlottedSecretKey) error
+
 	MoveAttestationPendingP(ctx context.Context, at *Attestation, labelKeyIndex int) error
 	//Assume dot already inserted into pending, but update the labelKeyIndex
 	UpdateAttestationPendingP(ctx context.Context, at *Attestation, labelKeyIndex int) error
@@ -139,9 +159,23 @@ type WaveState interface {
 	//Interact with active namedecls
 	//Results should be sorted with the latest start date appearing first
 	ResolveNameDeclarationsP(ctx context.Context, attester HashSchemeInstance, name string) chan ResolveResult
+	//Every name declaration that has been active, including those that have
+	//since expired or been revoked. By attester the results are sorted by name
+	ListNameDeclarationsByAttesterP(ctx context.Context, attester HashSchemeInstance) chan ResolveResult
+	ListNameDeclarationsBySubjectP(ctx context.Context, subject HashSchemeInstance) chan ResolveResult
 	ResolveReverseName(ctx context.Context, hi HashSchemeInstance) (name string, err error)
 	InsertReverseName(ctx context.Context, name string, hi HashSchemeInstance) (err error)
 	GetNameDeclarationP(ctx context.Context, hi HashSchemeInstance) (nd *NameDeclaration, err error)
+	//A pin records the entity that the perspective trusts for a name by an
+	//attester. GetNamePinP returns nil if the name is not pinned
+	GetNamePinP(ctx context.Context, attester HashSchemeInstance, name string) (HashSchemeInstance, error)
+	SetNamePinP(ctx context.Context, attester HashSchemeInstance, name string, subject HashSchemeInstance) error
+
+	//Successions are only inserted once their signature has been checked
+	InsertSuccessionP(ctx context.Context, s *EntitySuccession) error
+	GetSuccessionP(ctx context.Context, hi HashSchemeInstance) (*EntitySuccession, error)
+	GetSuccessionsFromP(ctx context.Context, predecessor HashSchemeInstance) ([]*EntitySuccession, error)
+	GetSuccessionsToP(ctx context.Context, successor HashSchemeInstance) ([]*EntitySuccession, error)
 
 	GetEntityPartitionLabelKeyIndexP(ctx context.Context, entHashSchemeInstance HashSchemeInstance) (bool, int, error)
 	GetAttestationP(ctx context.Context, HashSchemeInstance HashSchemeInstance) (at *Attestation, s *State, err error)
@@ -0,0 +1,313 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	stdasn1 "encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/immesys/wave/wve"
+)
+
+//An entity certificate is a self-signed CA certificate for the entity's
+//primary signing key that embeds the entity DER. A proof certificate is a
+//leaf issued by the entity certificate that embeds a proof with the entity
+//as its subject, and the policy that the proof grants
+
+//How long a proof certificate is valid for if not specified
+const DefaultProofCertificateValidity = time.Hour
+
+//x509Signer returns the key in a form that crypto/x509 can sign with
+func x509Signer(k EntitySecretKeySchemeInstance) (crypto.Signer, error) {
+	switch key := k.(type) {
+	case *EntitySecretKey_Ed25519:
+		return stded25519.PrivateKey(key.PrivateKey), nil
+	case *EntitySecretKey_ECDSA_P256:
+		pk, err := p256PublicKey(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PrivateKey{PublicKey: *pk, D: new(big.Int).SetBytes(key.PrivateKey)}, nil
+	}
+	return nil, fmt.Errorf("key scheme cannot be used in X.509 certificates")
+}
+
+//x509PublicKey returns the key in the form used by crypto/x509
+func x509PublicKey(k EntityKeySchemeInstance) (crypto.PublicKey, error) {
+	switch key := k.(type) {
+	case *EntityKey_Ed25519:
+		return stded25519.PublicKey(key.PublicKey), nil
+	case *EntityKey_ECDSA_P256:
+		return p256PublicKey(key.PublicKey)
+	}
+	return nil, fmt.Errorf("key scheme cannot be used in X.509 certificates")
+}
+
+func x509SerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func x509Extension(oid asn1.ObjectIdentifier, certs ...*x509.Certificate) []byte {
+	for _, cert := range certs {
+		for _, ext := range cert.Extensions {
+			if ext.Id.Equal(stdasn1.ObjectIdentifier(oid)) {
+				return ext.Value
+			}
+		}
+	}
+	return nil
+}
+
+func entityCommonName(e *Entity) string {
+	return base64.URLEncoding.EncodeToString(e.Keccak256HI().Multihash())
+}
+
+type PCreateEntityCertificate struct {
+	Entity *EntitySecrets
+}
+type RCreateEntityCertificate struct {
+	DER         []byte
+	Certificate *x509.Certificate
+}
+
+//CreateEntityCertificate creates a self-signed certificate for the entity's
+//primary signing key, valid for as long as the entity is
+func CreateEntityCertificate(ctx context.Context, p *PCreateEntityCertificate) (*RCreateEntityCertificate, wve.WVE) {
+	if p.Entity == nil {
+		return nil, wve.Err(wve.MissingParameter, "missing entity")
+	}
+	key := p.Entity.PrimarySigningKey()
+	if !key.HasCapability(CapCertification) {
+		return nil, wve.Err(wve.UnsupportedKeyScheme, "entity key cannot certify")
+	}
+	signer, err := x509Signer(key)
+	if err != nil {
+		return nil, wve.ErrW(wve.UnsupportedKeyScheme, "entity key cannot be exported", err)
+	}
+	entityDER, err := p.Entity.Entity.DER()
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not marshal entity", err)
+	}
+	serial, err := x509SerialNumber()
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not generate serial number", err)
+	}
+	validity := p.Entity.Entity.CanonicalForm.TBS.Validity
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: entityCommonName(p.Entity.Entity)},
+		NotBefore:             validity.NotBefore,
+		NotAfter:              validity.NotAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+		ExtraExtensions: []pkix.Extension{
+			{Id: stdasn1.ObjectIdentifier(serdes.X509EntityOID), Value: entityDER},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not create certificate", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not parse certificate", err)
+	}
+	return &RCreateEntityCertificate{
+		DER:         der,
+		Certificate: cert,
+	}, nil
+}
+
+type PCreateProofCertificate struct {
+	//The subject of the proof, who issues the certificate
+	Subject *EntitySecrets
+	//A proof with the subject as its subject
+	ProofDER []byte
+	VCtx     VerificationContext
+	//The key of the new certificate
+	PublicKey crypto.PublicKey
+	//If not specified defaults to DefaultProofCertificateValidity. The
+	//certificate never outlives the proof
+	ValidFor time.Duration
+}
+type RCreateProofCertificate struct {
+	DER []byte
+	//The leaf and then the entity certificate
+	Chain [][]byte
+}
+
+//CreateProofCertificate issues a short-lived certificate that embeds a
+//proof and the policy it grants, signed by the proof's subject
+func CreateProofCertificate(ctx context.Context, p *PCreateProofCertificate) (*RCreateProofCertificate, wve.WVE) {
+	if p.Subject == nil || p.PublicKey == nil || p.VCtx == nil || len(p.ProofDER) == 0 {
+		return nil, wve.Err(wve.MissingParameter, "missing required parameters")
+	}
+	proof, werr := VerifyRTreeProof(ctx, &PVerifyRTreeProof{
+		DER:  p.ProofDER,
+		VCtx: p.VCtx,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	if !HashSchemeInstanceEqual(proof.Subject, p.Subject.Entity.Keccak256HI()) {
+		return nil, wve.Err(wve.InvalidParameter, "the proof is not for this entity")
+	}
+	policyDER, err := asn1.Marshal(*proof.Policy.CanonicalForm())
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not marshal policy", err)
+	}
+	root, werr := CreateEntityCertificate(ctx, &PCreateEntityCertificate{
+		Entity: p.Subject,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	signer, err := x509Signer(p.Subject.PrimarySigningKey())
+	if err != nil {
+		return nil, wve.ErrW(wve.UnsupportedKeyScheme, "entity key cannot be exported", err)
+	}
+	validFor := p.ValidFor
+	if validFor == 0 {
+		validFor = DefaultProofCertificateValidity
+	}
+	notBefore := time.Now()
+	notAfter := notBefore.Add(validFor)
+	if proof.Expires.Before(notAfter) {
+		notAfter = proof.Expires
+	}
+	if root.Certificate.NotAfter.Before(notAfter) {
+		notAfter = root.Certificate.NotAfter
+	}
+	serial, err := x509SerialNumber()
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not generate serial number", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: entityCommonName(p.Subject.Entity)},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{
+			{Id: stdasn1.ObjectIdentifier(serdes.X509ProofOID), Value: p.ProofDER},
+			{Id: stdasn1.ObjectIdentifier(serdes.X509PolicyOID), Value: policyDER},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, root.Certificate, p.PublicKey, signer)
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidParameter, "could not create certificate", err)
+	}
+	return &RCreateProofCertificate{
+		DER:   der,
+		Chain: [][]byte{der, root.DER},
+	}, nil
+}
+
+type PVerifyProofCertificate struct {
+	//The leaf and then the entity certificate
+	Chain [][]byte
+	VCtx  VerificationContext
+}
+type RVerifyProofCertificate struct {
+	Leaf *x509.Certificate
+	//The proof's subject
+	Entity *Entity
+	Policy *RTreePolicy
+	Proof  *RVerifyRTreeProof
+}
+
+//VerifyProofCertificate checks that the leaf was issued by the entity
+//certificate, that the entity certificate belongs to the embedded entity
+//and that the embedded proof is valid for that entity and grants the
+//embedded policy
+func VerifyProofCertificate(ctx context.Context, p *PVerifyProofCertificate) (*RVerifyProofCertificate, wve.WVE) {
+	if len(p.Chain) != 2 {
+		return nil, wve.Err(wve.InvalidParameter, "expected a leaf and an entity certificate")
+	}
+	certs := []*x509.Certificate{}
+	for _, der := range p.Chain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, wve.ErrW(wve.MalformedDER, "could not parse certificate", err)
+		}
+		certs = append(certs, cert)
+	}
+	leaf, root := certs[0], certs[1]
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, wve.ErrW(wve.InvalidSignature, "certificate chain is invalid", err)
+	}
+
+	entityDER := x509Extension(serdes.X509EntityOID, root)
+	if entityDER == nil {
+		return nil, wve.Err(wve.InvalidParameter, "entity certificate has no entity")
+	}
+	rpe, werr := ParseEntity(ctx, &PParseEntity{DER: entityDER})
+	if werr != nil {
+		return nil, werr
+	}
+	ent := rpe.Entity
+	if ent.Expired() {
+		return nil, wve.Err(wve.InvalidParameter, "entity has expired")
+	}
+	entkey, err := x509PublicKey(ent.VerifyingKey)
+	if err != nil {
+		return nil, wve.ErrW(wve.UnsupportedKeyScheme, "entity key cannot be used in X.509 certificates", err)
+	}
+	want, err := x509.MarshalPKIXPublicKey(entkey)
+	if err != nil {
+		return nil, wve.ErrW(wve.UnsupportedKeyScheme, "could not marshal entity key", err)
+	}
+	have, err := x509.MarshalPKIXPublicKey(root.PublicKey)
+	if err != nil || !bytes.Equal(want, have) {
+		return nil, wve.Err(wve.InvalidSignature, "entity certificate key does not match the entity")
+	}
+
+	proofDER := x509Extension(serdes.X509ProofOID, leaf)
+	policyDER := x509Extension(serdes.X509PolicyOID, leaf)
+	if proofDER == nil || policyDER == nil {
+		return nil, wve.Err(wve.InvalidParameter, "certificate has no proof")
+	}
+	proof, werr := VerifyRTreeProof(ctx, &PVerifyRTreeProof{
+		DER:  proofDER,
+		VCtx: p.VCtx,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	if !HashSchemeInstanceEqual(proof.Subject, ent.Keccak256HI()) {
+		return nil, wve.Err(wve.ProofInvalid, "the proof is not for the certificate's entity")
+	}
+	proofPolicyDER, err := asn1.Marshal(*proof.Policy.CanonicalForm())
+	if err != nil {
+		return nil, wve.ErrW(wve.InternalError, "could not marshal policy", err)
+	}
+	if !bytes.Equal(proofPolicyDER, policyDER) {
+		return nil, wve.Err(wve.ProofInvalid, "the certificate policy is not the policy the proof grants")
+	}
+	return &RVerifyProofCertificate{
+		Leaf:   leaf,
+		Entity: ent,
+		Policy: proof.Policy,
+		Proof:  proof,
+	}, nil
+}
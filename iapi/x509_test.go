@@ -0,0 +1,67 @@
+package iapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/immesys/asn1"
+	"github.com/immesys/wave/serdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityCertificate(t *testing.T) {
+	ctx := context.Background()
+	for _, scheme := range []asn1.ObjectIdentifier{serdes.EntityEd25519OID, serdes.EntityECDSA_P256OID} {
+		ent, werr := NewParsedEntitySecrets(ctx, &PNewEntity{
+			SigningScheme: scheme,
+		})
+		require.NoError(t, werr)
+		rv, werr := CreateEntityCertificate(ctx, &PCreateEntityCertificate{
+			Entity: ent.EntitySecrets,
+		})
+		require.NoError(t, werr)
+		cert, err := x509.ParseCertificate(rv.DER)
+		require.NoError(t, err)
+		require.True(t, cert.IsCA)
+		require.NoError(t, cert.CheckSignatureFrom(cert))
+		require.Equal(t, entityCommonName(ent.Entity), cert.Subject.CommonName)
+
+		//The embedded entity is the one the certificate is for
+		entityDER, err := ent.Entity.DER()
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(entityDER, x509Extension(serdes.X509EntityOID, cert)))
+		pub, err := x509PublicKey(ent.Entity.VerifyingKey)
+		require.NoError(t, err)
+		want, err := x509.MarshalPKIXPublicKey(pub)
+		require.NoError(t, err)
+		have, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+		require.NoError(t, err)
+		require.Equal(t, want, have)
+	}
+}
+
+func TestProofCertificateBadProof(t *testing.T) {
+	ctx := context.Background()
+	ent, werr := NewParsedEntitySecrets(ctx, &PNewEntity{})
+	require.NoError(t, werr)
+	root, werr := CreateEntityCertificate(ctx, &PCreateEntityCertificate{
+		Entity: ent.EntitySecrets,
+	})
+	require.NoError(t, werr)
+	_, werr = CreateProofCertificate(ctx, &PCreateProofCertificate{
+		Subject:   ent.EntitySecrets,
+		ProofDER:  []byte("not a proof"),
+		VCtx:      NewKeyPoolDecryptionContext(),
+		PublicKey: root.Certificate.PublicKey,
+	})
+	require.Error(t, werr)
+
+	//A chain without a proof does not verify
+	_, werr = VerifyProofCertificate(ctx, &PVerifyProofCertificate{
+		Chain: [][]byte{root.DER, root.DER},
+		VCtx:  NewKeyPoolDecryptionContext(),
+	})
+	require.Error(t, werr)
+}
@@ -0,0 +1,375 @@
+//Package partindex is a two way index between WR1 keys and ciphertexts.
+//
+//Both keys and ciphertexts are labelled with partitions of a fixed number
+//of slots where any slot may be empty. A key matches a ciphertext iff for
+//every slot the key slot is empty or equal to the ciphertext slot. We need
+//to find the keys for a ciphertext and the ciphertexts for a key without
+//scanning everything.
+//
+//The set of non empty slots in a key is its mask. In practice there are
+//very few distinct masks: the user slots are filled from the left and the
+//time slots are filled from the left within each range. Keys are stored
+//under their mask and the projection of their slots onto that mask, so the
+//keys for a ciphertext are found with one prefix lookup per known key mask.
+//Ciphertexts are stored under their projection onto every mask that has
+//been used to query them, so the ciphertexts for a key are found with a
+//single prefix lookup. The first query with a new mask indexes all the
+//existing ciphertexts under that mask. The mask is only marked as indexed
+//once that has succeeded, so an interrupted backfill is redone.
+package partindex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/gob"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/immesys/wave/iapi"
+)
+
+const kindKey = "k"
+const kindCiphertext = "c"
+
+//Index stores entries under a prefix of a LowLevelStorage. An index has
+//no state outside the storage, so it is cheap to create one per call
+type Index struct {
+	u      iapi.LowLevelStorage
+	prefix string
+}
+
+//Entry is a key or ciphertext in the index
+type Entry struct {
+	ID    string
+	Slots [][]byte
+	Value []byte
+}
+
+func New(u iapi.LowLevelStorage, prefix string) *Index {
+	return &Index{u: u, prefix: prefix}
+}
+
+//Match returns true if a key with the given slots can decrypt a
+//ciphertext with the given slots
+func Match(key [][]byte, ciphertext [][]byte) bool {
+	if len(key) != len(ciphertext) {
+		return false
+	}
+	for idx, k := range key {
+		if len(k) == 0 {
+			continue
+		}
+		if !bytes.Equal(k, ciphertext[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+//PutKey adds a key to the index. The ID must not contain '/'
+func (ix *Index) PutKey(ctx context.Context, slots [][]byte, id string, value []byte) error {
+	m := mask(slots)
+	if err := ix.store(ctx, kindKey, slots, id, value); err != nil {
+		return err
+	}
+	if err := ix.u.Store(ctx, ix.key("m", kindKey, m), []byte{1}); err != nil {
+		return err
+	}
+	proj, _ := projection(m, slots)
+	return ix.u.Store(ctx, ix.key("i", kindKey, m, proj, id), []byte{1})
+}
+
+//PutCiphertext adds a ciphertext to the index. The ID must not contain '/'
+func (ix *Index) PutCiphertext(ctx context.Context, slots [][]byte, id string, value []byte) error {
+	//The record must be stored before reading the masks so that a
+	//concurrent new mask either sees the record or is seen here
+	if err := ix.store(ctx, kindCiphertext, slots, id, value); err != nil {
+		return err
+	}
+	masks, err := ix.ciphertextMasks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range masks {
+		if err := ix.indexCiphertext(ctx, m, slots, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//RemoveKey removes a key from the index
+func (ix *Index) RemoveKey(ctx context.Context, id string) error {
+	e, err := ix.load(ctx, kindKey, id)
+	if err != nil || e == nil {
+		return err
+	}
+	m := mask(e.Slots)
+	proj, _ := projection(m, e.Slots)
+	if err := ix.u.Remove(ctx, ix.key("i", kindKey, m, proj, id)); err != nil {
+		return err
+	}
+	return ix.u.Remove(ctx, ix.key("r", kindKey, id))
+}
+
+//RemoveCiphertext removes a ciphertext from the index
+func (ix *Index) RemoveCiphertext(ctx context.Context, id string) error {
+	e, err := ix.load(ctx, kindCiphertext, id)
+	if err != nil || e == nil {
+		return err
+	}
+	masks, err := ix.ciphertextMasks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range masks {
+		if len(m) != len(e.Slots) {
+			continue
+		}
+		proj, ok := projection(m, e.Slots)
+		if !ok {
+			continue
+		}
+		if err := ix.u.Remove(ctx, ix.key("i", kindCiphertext, m, proj, id)); err != nil {
+			return err
+		}
+	}
+	return ix.u.Remove(ctx, ix.key("r", kindCiphertext, id))
+}
+
+//MatchingKeys calls onResult for every key that can decrypt the
+//ciphertext, until onResult returns false
+func (ix *Index) MatchingKeys(ctx context.Context, ciphertext [][]byte, onResult func(e *Entry) bool) error {
+	masks, err := ix.masks(ctx, kindKey)
+	if err != nil {
+		return err
+	}
+	for _, m := range masks {
+		if len(m) != len(ciphertext) {
+			continue
+		}
+		proj, ok := projection(m, ciphertext)
+		if !ok {
+			continue
+		}
+		more, err := ix.scan(ctx, kindKey, ix.key("i", kindKey, m, proj)+"/", ciphertext, onResult)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+//MatchingCiphertexts calls onResult for every ciphertext that the key can
+//decrypt, until onResult returns false
+func (ix *Index) MatchingCiphertexts(ctx context.Context, key [][]byte, onResult func(e *Entry) bool) error {
+	m := mask(key)
+	if err := ix.ensureCiphertextMask(ctx, m); err != nil {
+		return err
+	}
+	proj, _ := projection(m, key)
+	_, err := ix.scan(ctx, kindCiphertext, ix.key("i", kindCiphertext, m, proj)+"/", key, onResult)
+	return err
+}
+
+//scan loads the records for the index entries under the prefix. The
+//projection hash identifies the match, but we check the slots anyway
+func (ix *Index) scan(ctx context.Context, kind string, prefix string, slots [][]byte, onResult func(e *Entry) bool) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	kch, ech := ix.u.LoadPrefixKeys(ctx, prefix)
+	for v := range kch {
+		id := v.Key[strings.LastIndex(v.Key, "/")+1:]
+		e, err := ix.load(ctx, kind, id)
+		if err != nil {
+			return false, err
+		}
+		if e == nil {
+			continue
+		}
+		var match bool
+		if kind == kindKey {
+			match = Match(e.Slots, slots)
+		} else {
+			match = Match(slots, e.Slots)
+		}
+		if !match {
+			continue
+		}
+		if !onResult(e) {
+			return false, nil
+		}
+	}
+	return true, <-ech
+}
+
+//Backfills of the same index and mask are serialized. The locks are
+//striped so that they do not grow with the number of indexes
+var backfillLocks [64]sync.Mutex
+
+func backfillLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &backfillLocks[h.Sum32()%uint32(len(backfillLocks))]
+}
+
+//ensureCiphertextMask indexes all ciphertexts under the mask if that has
+//not been done already
+func (ix *Index) ensureCiphertextMask(ctx context.Context, m string) error {
+	mk := ix.key("m", kindCiphertext, m)
+	ba, err := ix.u.Load(ctx, mk)
+	if err != nil || ba != nil {
+		return err
+	}
+	mu := backfillLock(mk)
+	mu.Lock()
+	defer mu.Unlock()
+	//Another backfill may have finished while we waited
+	ba, err = ix.u.Load(ctx, mk)
+	if err != nil || ba != nil {
+		return err
+	}
+	//The pending marker must be stored before the backfill so that a
+	//concurrent PutCiphertext either is seen by the backfill or sees the
+	//marker
+	pk := ix.key("p", kindCiphertext, m)
+	if err := ix.u.Store(ctx, pk, []byte{1}); err != nil {
+		return err
+	}
+	if err := ix.backfill(ctx, m); err != nil {
+		return err
+	}
+	if err := ix.u.Store(ctx, mk, []byte{1}); err != nil {
+		return err
+	}
+	return ix.u.Remove(ctx, pk)
+}
+
+func (ix *Index) backfill(ctx context.Context, m string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	vch, ech := ix.u.LoadPrefix(ctx, ix.key("r", kindCiphertext)+"/")
+	for v := range vch {
+		e := &Entry{}
+		if err := unmarshalGob(v.Value, e); err != nil {
+			return err
+		}
+		if err := ix.indexCiphertext(ctx, m, e.Slots, e.ID); err != nil {
+			return err
+		}
+	}
+	return <-ech
+}
+
+func (ix *Index) indexCiphertext(ctx context.Context, m string, slots [][]byte, id string) error {
+	if len(m) != len(slots) {
+		return nil
+	}
+	proj, ok := projection(m, slots)
+	if !ok {
+		//No key with this mask can match
+		return nil
+	}
+	return ix.u.Store(ctx, ix.key("i", kindCiphertext, m, proj, id), []byte{1})
+}
+
+func (ix *Index) masks(ctx context.Context, kind string) ([]string, error) {
+	return ix.markers(ctx, "m", kind)
+}
+
+//ciphertextMasks returns the masks that ciphertexts are indexed under,
+//including those that are still being backfilled
+func (ix *Index) ciphertextMasks(ctx context.Context) ([]string, error) {
+	done, err := ix.markers(ctx, "m", kindCiphertext)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := ix.markers(ctx, "p", kindCiphertext)
+	if err != nil {
+		return nil, err
+	}
+	return append(done, pending...), nil
+}
+
+func (ix *Index) markers(ctx context.Context, marker string, kind string) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rv := []string{}
+	kch, ech := ix.u.LoadPrefixKeys(ctx, ix.key(marker, kind)+"/")
+	for v := range kch {
+		rv = append(rv, v.Key[strings.LastIndex(v.Key, "/")+1:])
+	}
+	return rv, <-ech
+}
+
+func (ix *Index) store(ctx context.Context, kind string, slots [][]byte, id string, value []byte) error {
+	ba, err := marshalGob(&Entry{ID: id, Slots: slots, Value: value})
+	if err != nil {
+		return err
+	}
+	return ix.u.Store(ctx, ix.key("r", kind, id), ba)
+}
+
+func (ix *Index) load(ctx context.Context, kind string, id string) (*Entry, error) {
+	ba, err := ix.u.Load(ctx, ix.key("r", kind, id))
+	if err != nil || ba == nil {
+		return nil, err
+	}
+	e := &Entry{}
+	if err := unmarshalGob(ba, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (ix *Index) key(parts ...string) string {
+	return ix.prefix + "/" + strings.Join(parts, "/")
+}
+
+//mask returns a string with a '1' for every non empty slot
+func mask(slots [][]byte) string {
+	rv := make([]byte, len(slots))
+	for i, s := range slots {
+		if len(s) == 0 {
+			rv[i] = '0'
+		} else {
+			rv[i] = '1'
+		}
+	}
+	return string(rv)
+}
+
+//projection returns a hash of the slots that are in the mask. It returns
+//false if one of those slots is empty
+func projection(m string, slots [][]byte) (string, bool) {
+	h := sha256.New()
+	l := make([]byte, 4)
+	for i := 0; i < len(m); i++ {
+		if m[i] == '0' {
+			continue
+		}
+		if len(slots[i]) == 0 {
+			return "", false
+		}
+		binary.BigEndian.PutUint32(l, uint32(i))
+		h.Write(l)
+		binary.BigEndian.PutUint32(l, uint32(len(slots[i])))
+		h.Write(l)
+		h.Write(slots[i])
+	}
+	return base64.URLEncoding.EncodeToString(h.Sum(nil)), true
+}
+
+func unmarshalGob(ba []byte, into interface{}) error {
+	return gob.NewDecoder(bytes.NewBuffer(ba)).Decode(into)
+}
+
+func marshalGob(from interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := gob.NewEncoder(buf).Encode(from)
+	return buf.Bytes(), err
+}
@@ -316,6 +316,34 @@ func (p *poc) MoveAttestationActiveP(ctx context.Context, att *iapi.Attestation)
 // 	return true, ds.LabelKeyIndex, nil
 // }
 
+//afterCursor returns true if the attestation whose (base64) hash is the
+//last component of a key should be returned given filter.After. Keys are
+//iterated in order so this implements resumable lookups
+func afterCursor(b64hash string, filter *iapi.LookupFromFilter) bool {
+	if filter.After == nil {
+		return true
+	}
+	return b64hash > ToB64(keccakFromHI(filter.After))
+}
+
+func filterAttestation(ds *AttestationState, filter *iapi.LookupFromFilter) bool {
+	if filter.Valid != nil {
+		if *filter.Valid {
+			if ds.State != StateActive {
+				return false
+			}
+		} else {
+			if ds.State == StateActive {
+				return false
+			}
+			//If we are here, the forward link existed, so it USED to be active
+			//but has since expired or been revoked, presumably thats what the
+			//caller is looking for
+		}
+	}
+	return filter.MatchesContent(ds.Attestation)
+}
+
 //TODO we are not properly cancelling context if there is error
 func (p *poc) GetActiveAttestationsFromP(pctx context.Context, srchi iapi.HashSchemeInstance, filter *iapi.LookupFromFilter) chan iapi.LookupFromResult {
 	src := keccakFromHI(srchi)
@@ -327,6 +355,9 @@ func (p *poc) GetActiveAttestationsFromP(pctx context.Context, srchi iapi.HashSc
 		defer cancel()
 		for v := range vch {
 			parts := split(v.Key)
+			if !afterCursor(parts[len(parts)-1], filter) {
+				continue
+			}
 			dh := FromB64(parts[len(parts)-1])
 			ds, err := p.loadAttestationState(ctx, dh)
 			if err != nil {
@@ -337,35 +368,9 @@ func (p *poc) GetActiveAttestationsFromP(pctx context.Context, srchi iapi.HashSc
 				return
 			}
 
-			//TODO filter dot by filter
-			if filter.Valid != nil {
-				if *filter.Valid {
-					if ds.State != StateActive {
-						continue
-					}
-				} else {
-					if ds.State == StateActive {
-						continue
-					}
-					//If we are here, the forward link existed, so it USED to be active
-					//but has since expired or been revoked, presumably thats what the
-					//caller is looking for
-				}
+			if !filterAttestation(ds, filter) {
+				continue
 			}
-			//TODO
-			/*
-				if filter.GlobalNS != nil && *filter.GlobalNS {
-					//Only return dots with a global namespace
-					if len(ds.Dot.Content.NS) != 0 {
-						continue
-					}
-				} else if filter.Namespace != nil {
-					//Filter only a specific namespace (or global)
-					if len(ds.Dot.Content.NS) != 0 && !bytes.Equal(ds.Dot.Content.NS, filter.Namespace) {
-						continue
-					}
-				}
-			*/
 			lfr := iapi.LookupFromResult{
 				Attestation: ds.Attestation,
 			}
@@ -401,6 +406,9 @@ func (p *poc) GetActiveAttestationsToP(pctx context.Context, dsthi iapi.HashSche
 		defer cancel()
 		for v := range vch {
 			parts := split(v.Key)
+			if !afterCursor(parts[len(parts)-1], filter) {
+				continue
+			}
 			dh := FromB64(parts[len(parts)-1])
 			ds, err := p.loadAttestationState(ctx, dh)
 			if err != nil {
@@ -411,20 +419,8 @@ func (p *poc) GetActiveAttestationsToP(pctx context.Context, dsthi iapi.HashSche
 				return
 			}
 
-			//TODO filter dot by filter
-			if filter.Valid != nil {
-				if *filter.Valid {
-					if ds.State != StateActive {
-						continue
-					}
-				} else {
-					if ds.State == StateActive {
-						continue
-					}
-					//If we are here, the forward link existed, so it USED to be active
-					//but has since expired or been revoked, presumably thats what the
-					//caller is looking for
-				}
+			if !filterAttestation(ds, filter) {
+				continue
 			}
 			lfr := iapi.LookupFromResult{
 				Attestation: ds.Attestation,
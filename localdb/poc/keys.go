@@ -1,14 +1,13 @@
 package poc
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
-	"strings"
 
 	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/localdb/partindex"
 )
 
 //Set the last checked time for the given revocation option id
@@ -117,27 +116,85 @@ func (p *poc) InsertPartitionLabelKeyP(ctx context.Context, ent iapi.HashSchemeI
 
 //This is to facilitate GetLAbelledDotsP. Gets called when moving dots to labelled
 func (p *poc) insertPartitionToAttestationLink(ctx context.Context, dst []byte, partition [][]byte, dt *iapi.Attestation) error {
-	k := p.PKey(ctx, "pdl", ToB64(dst), ToB64(keccakFromAtt(dt)))
-	pl := &PendingLabels{Slots: partition}
-	ba, err := marshalGob(pl)
+	ix, err := p.attestationIndex(ctx, dst)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return p.u.Store(ctx, k, ba)
+	return ix.PutCiphertext(ctx, partition, ToB64(keccakFromAtt(dt)), nil)
 }
 
 //This is to facilitate GetLabelledNameDeclarationsP. Gets called when moving dots to labelled
 func (p *poc) insertPartitionToNameDeclLink(ctx context.Context, ns []byte, partition [][]byte, nd *iapi.NameDeclaration) error {
-	k := p.PKey(ctx, "ndl", ToB64(ns), ToB64(keccakFromND(nd)))
 	if partition == nil {
 		panic(partition)
 	}
-	pl := &PendingLabels{Slots: partition}
-	ba, err := marshalGob(pl)
+	ix, err := p.nameDeclIndex(ctx, ns)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return p.u.Store(ctx, k, ba)
+	return ix.PutCiphertext(ctx, partition, ToB64(keccakFromND(nd)), nil)
+}
+
+//The index accessors move any links stored in the layout that predates the
+//partition index into the index before returning it, so databases written
+//by older versions keep working
+func (p *poc) attestationIndex(ctx context.Context, dst []byte) (*partindex.Index, error) {
+	ix := partindex.New(p.u, p.PKey(ctx, "pdx", ToB64(dst)))
+	return ix, p.migrateLegacyLinks(ctx, ix, p.PKey(ctx, "pdl", ToB64(dst)))
+}
+
+func (p *poc) nameDeclIndex(ctx context.Context, ns []byte) (*partindex.Index, error) {
+	ix := partindex.New(p.u, p.PKey(ctx, "ndx", ToB64(ns)))
+	return ix, p.migrateLegacyLinks(ctx, ix, p.PKey(ctx, "ndl", ToB64(ns)))
+}
+
+func (p *poc) wr1KeyIndex(ctx context.Context, dst []byte) (*partindex.Index, error) {
+	ix := partindex.New(p.u, p.PKey(ctx, "oqx", ToB64(dst)))
+	return ix, p.migrateLegacyKeys(ctx, ix, p.PKey(ctx, "oaq", ToB64(dst)))
+}
+
+//migrateLegacyLinks moves links stored as prefix/<hash> => PendingLabels
+//into the index. Each link is removed only once it is in the index, so an
+//interrupted migration is resumed by the next call
+func (p *poc) migrateLegacyLinks(ctx context.Context, ix *partindex.Index, prefix string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	vch, ech := p.u.LoadPrefix(ctx, prefix+"/")
+	for v := range vch {
+		pl := &PendingLabels{}
+		if err := unmarshalGob(v.Value, pl); err != nil {
+			return err
+		}
+		parts := split(v.Key)
+		if err := ix.PutCiphertext(ctx, pl.Slots, parts[len(parts)-1], nil); err != nil {
+			return err
+		}
+		if err := p.u.Remove(ctx, v.Key); err != nil {
+			return err
+		}
+	}
+	return <-ech
+}
+
+//migrateLegacyKeys moves keys stored as prefix/<slots> => ContentKeyState
+//into the index
+func (p *poc) migrateLegacyKeys(ctx context.Context, ix *partindex.Index, prefix string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	vch, ech := p.u.LoadPrefix(ctx, prefix+"/")
+	for v := range vch {
+		cks := &ContentKeyState{}
+		if err := unmarshalGob(v.Value, cks); err != nil {
+			return err
+		}
+		if err := ix.PutKey(ctx, cks.Slots, slotsID(cks.Slots), v.Value); err != nil {
+			return err
+		}
+		if err := p.u.Remove(ctx, v.Key); err != nil {
+			return err
+		}
+	}
+	return <-ech
 }
 
 //In this case we want dots that are MORE qualified than the given partition
@@ -145,32 +202,24 @@ func (p *poc) GetLabelledAttestationsP(pctx context.Context, dsthi iapi.HashSche
 	dst := keccakFromHI(dsthi)
 	rv := make(chan iapi.PendingAttestation, 10)
 	ctx, cancel := context.WithCancel(pctx)
-	k := p.PKey(ctx, "pdl", ToB64(dst))
-	vch, ech := p.u.LoadPrefix(ctx, k)
 	go func() {
 		defer cancel()
-		for v := range vch {
-
-			pl := &PendingLabels{}
-			err := unmarshalGob(v.Value, pl)
-			if err != nil {
-				panic(err)
-			}
-			//Only return this dot if the given partition is a superset
-			//of the dot
-			if !matchPartition(partition, pl.Slots) {
-				continue
+		//The index only returns dots where the given partition is a
+		//superset of the dot
+		ix, err := p.attestationIndex(ctx, dst)
+		if err != nil {
+			rv <- iapi.PendingAttestation{
+				Err: err,
 			}
-
-			parts := split(v.Key)
-			dh := FromB64(parts[len(parts)-1])
-			ds, err := p.loadAttestationState(ctx, dh)
+			close(rv)
+			return
+		}
+		var ierr error
+		err = ix.MatchingCiphertexts(ctx, partition, func(e *partindex.Entry) bool {
+			ds, err := p.loadAttestationState(ctx, FromB64(e.ID))
 			if err != nil {
-				rv <- iapi.PendingAttestation{
-					Err: err,
-				}
-				close(rv)
-				return
+				ierr = err
+				return false
 			}
 			pdr := iapi.PendingAttestation{
 				Attestation:   ds.Attestation,
@@ -179,112 +228,91 @@ func (p *poc) GetLabelledAttestationsP(pctx context.Context, dsthi iapi.HashSche
 			}
 			select {
 			case rv <- pdr:
+				return true
 			case <-ctx.Done():
-				rv <- iapi.PendingAttestation{
-					Err: ctx.Err(),
-				}
-				close(rv)
-				return
+				ierr = ctx.Err()
+				return false
 			}
+		})
+		if ierr != nil {
+			err = ierr
 		}
-		err := <-ech
 		if err != nil {
 			rv <- iapi.PendingAttestation{
 				Err: err,
 			}
 		}
 		close(rv)
-		return
 	}()
 	return rv
 }
 
-func matchPartition(superset [][]byte, subset [][]byte) bool {
-	if len(superset) != len(subset) {
-		panic("slots not the same length")
-	}
-	for idx, super := range superset {
-		if len(super) == 0 {
-			continue
-		}
-		if bytes.Equal(super, subset[idx]) {
-			continue
-		}
-		return false
-	}
-	return true
-}
-
 func (p *poc) WR1KeysForP(ctx context.Context, dsthi iapi.HashSchemeInstance, slots [][]byte, onResult func(k iapi.SlottedSecretKey) bool) error {
 	dst := keccakFromHI(dsthi)
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-	k := p.PKey(ctx, "oaq", ToB64(dst))
-	kch, ech := p.u.LoadPrefixKeys(ctx, k)
-	for v := range kch {
+	ix, err := p.wr1KeyIndex(ctx, dst)
+	if err != nil {
+		return err
+	}
+	var ierr error
+	err = ix.MatchingKeys(ctx, slots, func(e *partindex.Entry) bool {
 		if ctx.Err() != nil {
-			return ctx.Err()
-		}
-		parts := strings.Split(v.Key, "/")
-		sslots := strings.Split(parts[len(parts)-1], ",")
-		bslots := make([][]byte, len(sslots))
-		for i, s := range sslots {
-			bslots[i], _ = base64.StdEncoding.DecodeString(s)
-		}
-		if !matchPartition(bslots, slots) {
-			continue
-		}
-		val, err := p.u.Load(ctx, v.Key)
-		if err != nil {
-			return err
+			ierr = ctx.Err()
+			return false
 		}
 		cks := &ContentKeyState{}
-		err = unmarshalGob(val, cks)
+		err := unmarshalGob(e.Value, cks)
 		if err != nil {
 			panic(err)
 		}
 		//The key is a superset of the given slots
-		more := onResult(cks.Key)
-		if !more {
-			return nil
-		}
+		return onResult(cks.Key)
+	})
+	if ierr != nil {
+		return ierr
 	}
-	return <-ech
-	//
-	// vch, ech := p.u.LoadPrefix(ctx, k)
-	// for v := range vch {
-	// 	if ctx.Err() != nil {
-	// 		return ctx.Err()
-	// 	}
-	// 	cks := &ContentKeyState{}
-	// 	err := unmarshalGob(v.Value, cks)
-	// 	if err != nil {
-	// 		panic(err)
-	// 	}
-	//
-	// 	//Only return this dot if the given partition is a superset
-	// 	//of the dot
-	// 	if !matchPartition(cks.Slots, slots) {
-	// 		continue
-	// 	}
-	//
-	// 	//The key is a superset of the given slots
-	// 	more := onResult(cks.Key)
-	// 	if !more {
-	// 		return nil
-	// 	}
-	// }
-	return <-ech
+	return err
 }
+
 func (p *poc) InsertWR1KeysForP(ctx context.Context, fromhi iapi.HashSchemeInstance, key iapi.SlottedSecretKey) error {
 	from := keccakFromHI(fromhi)
 	slots := key.Slots()
-	bslots := make([]string, len(slots))
-	for i, s := range slots {
-		bslots[i] = ToB64(s)
+	cks := &ContentKeyState{
+		Slots: slots,
+		Key:   key,
 	}
-	allSlots := strings.Join(bslots, ",")
-	k := p.PKey(ctx, "oaq", ToB64(from), allSlots)
+	ba, err := marshalGob(cks)
+	if err != nil {
+		panic(err)
+	}
+	ix, err := p.wr1KeyIndex(ctx, from)
+	if err != nil {
+		return err
+	}
+	return ix.PutKey(ctx, slots, slotsID(slots), ba)
+}
+
+func (p *poc) WR1BundleKeyP(ctx context.Context, attesterhi iapi.HashSchemeInstance, partition [][]byte) (iapi.SlottedSecretKey, error) {
+	attester := keccakFromHI(attesterhi)
+	k := p.PKey(ctx, "wbk", ToB64(attester), slotsID(partition))
+	val, err := p.u.Load(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+	cks := &ContentKeyState{}
+	err = unmarshalGob(val, cks)
+	if err != nil {
+		panic(err)
+	}
+	return cks.Key, nil
+}
+
+func (p *poc) InsertWR1BundleKeyP(ctx context.Context, attesterhi iapi.HashSchemeInstance, key iapi.SlottedSecretKey) error {
+	attester := keccakFromHI(attesterhi)
+	slots := key.Slots()
+	k := p.PKey(ctx, "wbk", ToB64(attester), slotsID(slots))
 	cks := &ContentKeyState{
 		Slots: slots,
 		Key:   key,
@@ -294,5 +322,14 @@ func (p *poc) InsertWR1KeysForP(ctx context.Context, fromhi iapi.HashSchemeInsta
 		panic(err)
 	}
 	return p.u.Store(ctx, k, ba)
+}
 
+//slotsID is a short identifier for a partition that can be used in a key
+func slotsID(slots [][]byte) string {
+	h := sha256.New()
+	for _, s := range slots {
+		binary.Write(h, binary.BigEndian, uint32(len(s)))
+		h.Write(s)
+	}
+	return ToB64(h.Sum(nil))
 }
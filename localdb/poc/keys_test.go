@@ -2,9 +2,12 @@ package poc
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/localdb/partindex"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,3 +108,106 @@ func TestWR1Keys(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, 0, count)
 }
+
+func TestWR1BundleKeys(t *testing.T) {
+	ctx := getPctx()
+	rne, werr := iapi.NewParsedEntitySecrets(context.Background(), &iapi.PNewEntity{})
+	if werr != nil {
+		panic(werr)
+	}
+	es := rne.EntitySecrets
+	slots := make([][]byte, 20)
+	slots[0] = []byte("foo")
+	slots[1] = []byte("bar")
+	k, err := db.WR1BundleKeyP(ctx, es.Entity.Keccak256HI(), slots)
+	require.NoError(t, err)
+	require.Nil(t, k)
+
+	wr1body, err := es.WR1BodyKey(ctx, slots, true)
+	require.NoError(t, err)
+	err = db.InsertWR1BundleKeyP(ctx, es.Entity.Keccak256HI(), wr1body)
+	require.NoError(t, err)
+	k, err = db.WR1BundleKeyP(ctx, es.Entity.Keccak256HI(), slots)
+	require.NoError(t, err)
+	require.NotNil(t, k)
+	require.Equal(t, slots, k.Slots())
+
+	//Only the exact partition is returned
+	narrow := make([][]byte, 20)
+	narrow[0] = []byte("foo")
+	narrow[1] = []byte("bar")
+	narrow[2] = []byte("baz")
+	k, err = db.WR1BundleKeyP(ctx, es.Entity.Keccak256HI(), narrow)
+	require.NoError(t, err)
+	require.Nil(t, k)
+}
+
+func TestLegacyPartitionLayout(t *testing.T) {
+	ctx := getPctx()
+	rne, werr := iapi.NewParsedEntitySecrets(context.Background(), &iapi.PNewEntity{})
+	if werr != nil {
+		panic(werr)
+	}
+	es := rne.EntitySecrets
+	p := db.(*poc)
+	dst := keccakFromHI(es.Entity.Keccak256HI())
+	slots := make([][]byte, 20)
+	slots[0] = []byte("foo")
+	slots[1] = []byte("bar")
+
+	//Write a key and a link the way versions before the partition index did
+	wr1body, err := es.WR1BodyKey(ctx, slots, true)
+	require.NoError(t, err)
+	ba, err := marshalGob(&ContentKeyState{Slots: slots, Key: wr1body})
+	require.NoError(t, err)
+	bslots := make([]string, len(slots))
+	for i, s := range slots {
+		bslots[i] = base64.StdEncoding.EncodeToString(s)
+	}
+	err = p.u.Store(ctx, p.PKey(ctx, "oaq", ToB64(dst), strings.Join(bslots, ",")), ba)
+	require.NoError(t, err)
+	linkID := ToB64([]byte("legacyattestationhash"))
+	ba, err = marshalGob(&PendingLabels{Slots: slots})
+	require.NoError(t, err)
+	err = p.u.Store(ctx, p.PKey(ctx, "pdl", ToB64(dst), linkID), ba)
+	require.NoError(t, err)
+
+	narrow := make([][]byte, 20)
+	narrow[0] = []byte("foo")
+	narrow[1] = []byte("bar")
+	narrow[2] = []byte("baz")
+	count := 0
+	err = db.WR1KeysForP(ctx, es.Entity.Keccak256HI(), narrow, func(k iapi.SlottedSecretKey) bool {
+		require.Equal(t, slots, k.Slots())
+		count++
+		return true
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	ix, err := p.attestationIndex(ctx, dst)
+	require.NoError(t, err)
+	ids := []string{}
+	err = ix.MatchingCiphertexts(ctx, slots, func(e *partindex.Entry) bool {
+		ids = append(ids, e.ID)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{linkID}, ids)
+
+	//The old records are gone and the migrated ones are not duplicated
+	for _, prefix := range []string{"oaq", "pdl"} {
+		kch, ech := p.u.LoadPrefixKeys(ctx, p.PKey(ctx, prefix, ToB64(dst))+"/")
+		for range kch {
+			t.Fatalf("legacy %s record was not removed", prefix)
+		}
+		require.NoError(t, <-ech)
+	}
+	count = 0
+	err = db.WR1KeysForP(ctx, es.Entity.Keccak256HI(), narrow, func(k iapi.SlottedSecretKey) bool {
+		count++
+		return true
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+}
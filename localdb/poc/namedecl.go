@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/immesys/wave/iapi"
+	"github.com/immesys/wave/localdb/partindex"
 )
 
 func (p *poc) saveNameDeclState(ctx context.Context, ds *NameDeclarationState) error {
@@ -52,6 +53,21 @@ func (p *poc) ResolveReverseName(ctx context.Context, hi iapi.HashSchemeInstance
 	}
 	return string(v), nil
 }
+func (p *poc) GetNamePinP(ctx context.Context, attester iapi.HashSchemeInstance, name string) (iapi.HashSchemeInstance, error) {
+	k := p.PKey(ctx, "npin", ToB64(keccakFromHI(attester)), name)
+	v, err := p.u.Load(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return iapi.HashSchemeInstanceFromMultihash(v), nil
+}
+func (p *poc) SetNamePinP(ctx context.Context, attester iapi.HashSchemeInstance, name string, subject iapi.HashSchemeInstance) error {
+	k := p.PKey(ctx, "npin", ToB64(keccakFromHI(attester)), name)
+	return p.u.Store(ctx, k, subject.Multihash())
+}
 func (p *poc) setNameDeclStateField(ctx context.Context, dh []byte, state int) error {
 	ds, err := p.loadNameDeclState(ctx, dh)
 	if err != nil {
@@ -141,6 +157,10 @@ func (p *poc) MoveNameDeclarationActiveP(ctx context.Context, nd *iapi.NameDecla
 	if err != nil {
 		return err
 	}
+	err = p.insertNDListLinks(ctx, attester, keccakFromHI(nd.Subject), name, hsh)
+	if err != nil {
+		return err
+	}
 	ds := &NameDeclarationState{
 		State:           StateActive,
 		NameDeclaration: nd,
@@ -202,32 +222,24 @@ func (p *poc) GetLabelledNameDeclarationsP(pctx context.Context, nshi iapi.HashS
 	ns := keccakFromHI(nshi)
 	rv := make(chan iapi.PendingNameDeclaration, 10)
 	ctx, cancel := context.WithCancel(pctx)
-	k := p.PKey(ctx, "ndl", ToB64(ns))
-	vch, ech := p.u.LoadPrefix(ctx, k)
 	go func() {
 		defer cancel()
-		for v := range vch {
-
-			pl := &PendingLabels{}
-			err := unmarshalGob(v.Value, pl)
-			if err != nil {
-				panic(err)
-			}
-			//Only return this nd if the given partition is a superset
-			//of the dot
-			if !matchPartition(partition, pl.Slots) {
-				continue
+		//The index only returns nds where the given partition is a
+		//superset of the nd
+		ix, err := p.nameDeclIndex(ctx, ns)
+		if err != nil {
+			rv <- iapi.PendingNameDeclaration{
+				Err: err,
 			}
-
-			parts := split(v.Key)
-			dh := FromB64(parts[len(parts)-1])
-			ds, err := p.loadNameDeclState(ctx, dh)
+			close(rv)
+			return
+		}
+		var ierr error
+		err = ix.MatchingCiphertexts(ctx, partition, func(e *partindex.Entry) bool {
+			ds, err := p.loadNameDeclState(ctx, FromB64(e.ID))
 			if err != nil {
-				rv <- iapi.PendingNameDeclaration{
-					Err: err,
-				}
-				close(rv)
-				return
+				ierr = err
+				return false
 			}
 			pdr := iapi.PendingNameDeclaration{
 				NameDeclaration: ds.NameDeclaration,
@@ -236,22 +248,21 @@ func (p *poc) GetLabelledNameDeclarationsP(pctx context.Context, nshi iapi.HashS
 			}
 			select {
 			case rv <- pdr:
+				return true
 			case <-ctx.Done():
-				rv <- iapi.PendingNameDeclaration{
-					Err: ctx.Err(),
-				}
-				close(rv)
-				return
+				ierr = ctx.Err()
+				return false
 			}
+		})
+		if ierr != nil {
+			err = ierr
 		}
-		err := <-ech
 		if err != nil {
 			rv <- iapi.PendingNameDeclaration{
 				Err: err,
 			}
 		}
 		close(rv)
-		return
 	}()
 	return rv
 }
@@ -319,8 +330,33 @@ func (p *poc) removeNDActiveLink(ctx context.Context, attester []byte, name stri
 	return p.u.Remove(ctx, k)
 }
 
+//insertNDListLinks indexes a name declaration by attester and by subject.
+//Unlike the active links these are never removed, so that expired and
+//revoked declarations are still listed
+func (p *poc) insertNDListLinks(ctx context.Context, attester []byte, subject []byte, name string, ndhash []byte) error {
+	k := p.PKey(ctx, "ndla", ToB64(attester), name, ToB64(ndhash))
+	if err := p.u.Store(ctx, k, []byte{1}); err != nil {
+		return err
+	}
+	k = p.PKey(ctx, "ndls", ToB64(subject), ToB64(ndhash))
+	return p.u.Store(ctx, k, []byte{1})
+}
+
 func (p *poc) ResolveNameDeclarationsP(pctx context.Context, attester iapi.HashSchemeInstance, name string) chan iapi.ResolveResult {
-	k := p.PKey(pctx, "ndal", ToB64(keccakFromHI(attester)), name)
+	return p.nameDeclarationsUnder(pctx, p.PKey(pctx, "ndal", ToB64(keccakFromHI(attester)), name))
+}
+
+func (p *poc) ListNameDeclarationsByAttesterP(ctx context.Context, attester iapi.HashSchemeInstance) chan iapi.ResolveResult {
+	return p.nameDeclarationsUnder(ctx, p.PKey(ctx, "ndla", ToB64(keccakFromHI(attester))))
+}
+
+func (p *poc) ListNameDeclarationsBySubjectP(ctx context.Context, subject iapi.HashSchemeInstance) chan iapi.ResolveResult {
+	return p.nameDeclarationsUnder(ctx, p.PKey(ctx, "ndls", ToB64(keccakFromHI(subject))))
+}
+
+//nameDeclarationsUnder loads the name declarations for index keys under
+//the prefix, which must end in the name declaration hash
+func (p *poc) nameDeclarationsUnder(pctx context.Context, k string) chan iapi.ResolveResult {
 	rv := make(chan iapi.ResolveResult, 10)
 	ctx, cancel := context.WithCancel(pctx)
 	vch, ech := p.u.LoadPrefixKeys(ctx, k)
@@ -250,3 +250,44 @@ func TestNameDeclPutGetActiveNotEncrypted(t *testing.T) {
 	require.EqualValues(t, count, 0)
 
 }
+
+func TestNameDeclList(t *testing.T) {
+	ctx, c := common(t)
+	ndrv, werr := iapi.CreateNameDeclaration(ctx, &iapi.PCreateNameDeclaration{
+		Attester:         c.Attester,
+		AttesterLocation: c.AttesterLoc,
+		Subject:          c.Target.Entity,
+		SubjectLocation:  c.TargetLoc,
+		Name:             "foo",
+	})
+	require.NoError(t, werr)
+	c.KPDC.AddEntity(c.Attester.Entity)
+	parserv, werr := iapi.ParseNameDeclaration(ctx, &iapi.PParseNameDeclaration{
+		DER:  ndrv.DER,
+		Dctx: c.KPDC,
+	})
+	require.NoError(t, werr)
+	nd := parserv.Result
+	err := db.MoveNameDeclarationActiveP(ctx, nd)
+	require.NoError(t, err)
+
+	h := keccakFromHI(nd.Keccak256HI())
+	count := func(rvc chan iapi.ResolveResult) int {
+		count := 0
+		for e := range rvc {
+			require.NoError(t, e.Err)
+			require.Equal(t, e.NameDeclaration.Keccak256(), h)
+			count += 1
+		}
+		return count
+	}
+	require.EqualValues(t, 1, count(db.ListNameDeclarationsByAttesterP(ctx, c.Attester.Entity.Keccak256HI())))
+	require.EqualValues(t, 1, count(db.ListNameDeclarationsBySubjectP(ctx, c.Target.Entity.Keccak256HI())))
+	require.EqualValues(t, 0, count(db.ListNameDeclarationsBySubjectP(ctx, c.Attester.Entity.Keccak256HI())))
+
+	//Revoked declarations are still listed
+	err = db.MoveNameDeclarationRevokedP(ctx, nd)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count(db.ListNameDeclarationsByAttesterP(ctx, c.Attester.Entity.Keccak256HI())))
+	require.EqualValues(t, 1, count(db.ListNameDeclarationsBySubjectP(ctx, c.Target.Entity.Keccak256HI())))
+}
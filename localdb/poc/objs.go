@@ -38,6 +38,11 @@ type NameDeclarationState struct {
 	LabelKeyIndex   int
 }
 
+type SuccessionState struct {
+	Hash []byte
+	DER  []byte
+}
+
 // type RevocationState struct {
 // 	IsEntity   bool
 // 	TargetHash []byte
@@ -53,6 +58,9 @@ type ContentKeyState struct {
 	Slots [][]byte
 	Key   iapi.SlottedSecretKey
 }
+
+//PendingLabels is the value of a link in the layout before the partition
+//index. It is only read when migrating those links
 type PendingLabels struct {
 	Slots [][]byte
 }
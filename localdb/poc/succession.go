@@ -0,0 +1,93 @@
+package poc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/immesys/wave/iapi"
+)
+
+func (p *poc) loadSuccession(ctx context.Context, hash []byte) (*iapi.EntitySuccession, error) {
+	k := p.PKey(ctx, "succ", ToB64(hash))
+	ba, err := p.u.Load(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	if ba == nil {
+		return nil, nil
+	}
+	ss := &SuccessionState{}
+	err = unmarshalGob(ba, ss)
+	if err != nil {
+		panic(err)
+	}
+	//The signature was checked on insert
+	rv, werr := iapi.ParseEntitySuccession(ctx, &iapi.PParseEntitySuccession{
+		DER: ss.DER,
+	})
+	if werr != nil {
+		return nil, werr
+	}
+	return rv.Succession, nil
+}
+
+func (p *poc) InsertSuccessionP(ctx context.Context, s *iapi.EntitySuccession) error {
+	der, werr := s.DER()
+	if werr != nil {
+		return werr
+	}
+	hash := keccakFromHI(s.Keccak256HI())
+	ss := &SuccessionState{
+		Hash: hash,
+		DER:  der,
+	}
+	ba, err := marshalGob(ss)
+	if err != nil {
+		return err
+	}
+	err = p.u.Store(ctx, p.PKey(ctx, "succ", ToB64(hash)), ba)
+	if err != nil {
+		return err
+	}
+	pred := keccakFromHI(s.Predecessor)
+	err = p.u.Store(ctx, p.PKey(ctx, "succf", ToB64(pred), ToB64(hash)), []byte{1})
+	if err != nil {
+		return err
+	}
+	succ := keccakFromHI(s.Successor)
+	return p.u.Store(ctx, p.PKey(ctx, "succt", ToB64(succ), ToB64(hash)), []byte{1})
+}
+
+func (p *poc) GetSuccessionP(ctx context.Context, hi iapi.HashSchemeInstance) (*iapi.EntitySuccession, error) {
+	return p.loadSuccession(ctx, keccakFromHI(hi))
+}
+
+func (p *poc) GetSuccessionsFromP(ctx context.Context, predecessor iapi.HashSchemeInstance) ([]*iapi.EntitySuccession, error) {
+	return p.loadSuccessionLinks(ctx, p.PKey(ctx, "succf", ToB64(keccakFromHI(predecessor))))
+}
+
+func (p *poc) GetSuccessionsToP(ctx context.Context, successor iapi.HashSchemeInstance) ([]*iapi.EntitySuccession, error) {
+	return p.loadSuccessionLinks(ctx, p.PKey(ctx, "succt", ToB64(keccakFromHI(successor))))
+}
+
+func (p *poc) loadSuccessionLinks(pctx context.Context, k string) ([]*iapi.EntitySuccession, error) {
+	ctx, cancel := context.WithCancel(pctx)
+	defer cancel()
+	//The trailing separator stops a prefix of one hash matching another
+	vch, ech := p.u.LoadPrefixKeys(ctx, k+"/")
+	rv := []*iapi.EntitySuccession{}
+	for v := range vch {
+		parts := strings.Split(v.Key, "/")
+		s, err := p.loadSuccession(ctx, FromB64(parts[len(parts)-1]))
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			rv = append(rv, s)
+		}
+	}
+	if err := <-ech; err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
@@ -0,0 +1,45 @@
+package poc
+
+import (
+	"testing"
+
+	"github.com/immesys/wave/iapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuccessionInsertLookup(t *testing.T) {
+	ctx, c := common(t)
+	srv, werr := iapi.CreateEntitySuccession(ctx, &iapi.PCreateEntitySuccession{
+		Predecessor:         c.Attester,
+		PredecessorLocation: c.AttesterLoc,
+		Successor:           c.Target.Entity,
+		SuccessorLocation:   c.TargetLoc,
+	})
+	require.NoError(t, werr)
+
+	s, err := db.GetSuccessionP(ctx, srv.Succession.Keccak256HI())
+	require.NoError(t, err)
+	require.Nil(t, s)
+
+	err = db.InsertSuccessionP(ctx, srv.Succession)
+	require.NoError(t, err)
+
+	s, err = db.GetSuccessionP(ctx, srv.Succession.Keccak256HI())
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	require.Equal(t, srv.Succession.Keccak256HI().Multihash(), s.Keccak256HI().Multihash())
+
+	from, err := db.GetSuccessionsFromP(ctx, c.Attester.Entity.Keccak256HI())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(from))
+	require.Equal(t, c.Target.Entity.Keccak256HI().Multihash(), from[0].Successor.Multihash())
+
+	to, err := db.GetSuccessionsToP(ctx, c.Target.Entity.Keccak256HI())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(to))
+	require.Equal(t, c.Attester.Entity.Keccak256HI().Multihash(), to[0].Predecessor.Multihash())
+
+	none, err := db.GetSuccessionsToP(ctx, c.Attester.Entity.Keccak256HI())
+	require.NoError(t, err)
+	require.Equal(t, 0, len(none))
+}
@@ -746,3 +746,88 @@ func BenchmarkE2EEDecrypt(t *testing.B) {
 	}
 
 }
+
+//benchmarkCreateAttestation measures rtgrant with a year long validity. If
+//warm, the key bundle is precomputed and every attestation reuses it,
+//otherwise every attestation has a new resource and so new body keys
+func benchmarkCreateAttestation(b *testing.B, warm bool) {
+	ctx := context.Background()
+	srcpub, srcsec := createEntity(b)
+	srcpublish, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      srcpub,
+		Location: &inmem,
+	})
+	require.NoError(b, err)
+	dstpub, _ := createEntity(b)
+	dstpublish, err := eapi.PublishEntity(ctx, &pb.PublishEntityParams{
+		DER:      dstpub,
+		Location: &inmem,
+	})
+	require.NoError(b, err)
+	perspective := &pb.Perspective{
+		EntitySecret: &pb.EntitySecret{
+			DER: srcsec,
+		},
+		Location: &inmem,
+	}
+	mkpolicy := func(resource string) *pb.Policy {
+		return &pb.Policy{
+			RTreePolicy: &pb.RTreePolicy{
+				Namespace:    srcpublish.Hash,
+				Indirections: uint32(5),
+				Statements: []*pb.RTreePolicyStatement{
+					&pb.RTreePolicyStatement{
+						PermissionSet: srcpublish.Hash,
+						Permissions:   []string{"1"},
+						Resource:      resource,
+					},
+				},
+			},
+		}
+	}
+	validFrom := time.Now().UnixNano() / 1e6
+	validUntil := validFrom + 365*24*60*60*1000
+	if warm {
+		pre, err := eapi.PrecomputeKeyBundle(ctx, &pb.PrecomputeKeyBundleParams{
+			Perspective: perspective,
+			Policy:      mkpolicy("common/resource"),
+			ValidFrom:   validFrom,
+			ValidUntil:  validUntil,
+		})
+		require.NoError(b, err)
+		require.Nil(b, pre.Error)
+	}
+	before, err := eapi.KeyBundleCacheStats(ctx, &pb.KeyBundleCacheStatsParams{})
+	require.NoError(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resource := "common/resource"
+		if !warm {
+			resource = fmt.Sprintf("common/resource%d", i)
+		}
+		att, err := eapi.CreateAttestation(ctx, &pb.CreateAttestationParams{
+			Perspective:     perspective,
+			BodyScheme:      exapi.BodySchemeWaveRef1,
+			SubjectHash:     dstpublish.Hash,
+			SubjectLocation: &inmem,
+			Policy:          mkpolicy(resource),
+			ValidFrom:       validFrom,
+			ValidUntil:      validUntil,
+		})
+		require.NoError(b, err)
+		require.Nil(b, att.Error)
+	}
+	b.StopTimer()
+	after, err := eapi.KeyBundleCacheStats(ctx, &pb.KeyBundleCacheStatsParams{})
+	require.NoError(b, err)
+	b.ReportMetric(float64(after.Hits-before.Hits)/float64(b.N), "hits/op")
+	b.ReportMetric(float64(after.Misses-before.Misses)/float64(b.N), "misses/op")
+}
+
+func BenchmarkCreateAttestationColdBundle(b *testing.B) {
+	benchmarkCreateAttestation(b, false)
+}
+
+func BenchmarkCreateAttestationWarmBundle(b *testing.B) {
+	benchmarkCreateAttestation(b, true)
+}
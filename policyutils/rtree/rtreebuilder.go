@@ -20,6 +20,8 @@ type RTreeBuilder struct {
 	finalSolution *Solution
 	ref           *BitsetReference
 	nodes         map[string]*Node
+	//Successions to the subject, keyed by predecessor
+	successions map[string]*iapi.EntitySuccession
 }
 
 type Params struct {
@@ -37,7 +39,11 @@ func NewRTreeBuilder(ctx context.Context, p *Params) (*RTreeBuilder, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &RTreeBuilder{
+	successions, werr := p.Engine.LookupSuccessionsTo(ctx, p.Subject)
+	if werr != nil {
+		return nil, werr
+	}
+	rv := &RTreeBuilder{
 		eng:           p.Engine,
 		ctx:           ctx,
 		outputEnabled: p.EnableOutput,
@@ -45,7 +51,18 @@ func NewRTreeBuilder(ctx context.Context, p *Params) (*RTreeBuilder, error) {
 		start:         p.Start,
 		nodes:         make(map[string]*Node),
 		ref:           ref,
-	}, nil
+		successions:   make(map[string]*iapi.EntitySuccession),
+	}
+	for _, s := range successions {
+		rv.successions[s.Predecessor.MultihashString()] = s
+	}
+	return rv, nil
+}
+
+//Succession returns the succession that allows a solution ending at the
+//given predecessor to be used by the subject, or nil if there is none
+func (tb *RTreeBuilder) Succession(predecessor iapi.HashSchemeInstance) *iapi.EntitySuccession {
+	return tb.successions[predecessor.MultihashString()]
 }
 
 func (tb *RTreeBuilder) Build(msgs chan string) {
@@ -74,6 +91,11 @@ func (tb *RTreeBuilder) build() {
 		tb:   tb,
 	}
 	tb.nodes[tb.subject.MultihashString()] = end
+	//Attestations granted to a predecessor of the subject are usable by
+	//the subject during the grace window, so they also end the search
+	for pred := range tb.successions {
+		tb.nodes[pred] = end
+	}
 	start := &Node{
 		Hash: tb.start,
 		tb:   tb,
@@ -143,6 +165,9 @@ type Edge struct {
 	LRes   *engine.LookupResult
 	Policy *WrappedRTreePolicy
 	Bits   uint64
+	//For threshold attestations, the co-signers that must be included
+	//in a proof using this edge
+	CoSigners []*iapi.Entity
 }
 
 type Solution struct {
@@ -274,6 +299,13 @@ nextAttestation:
 			tb: n.tb,
 		}
 		edge.LRes = lres
+		if tp := lres.Attestation.ThresholdProposal(); tp != nil {
+			edge.CoSigners = n.tb.eng.ValidCoSigners(n.tb.ctx, tp)
+			if len(edge.CoSigners) < tp.Threshold() {
+				//n.tb.wout("skipping %s : not enough co-signers", lres.Attestation.Keccak256HI().MultihashString())
+				continue nextAttestation
+			}
+		}
 		pol, err := iapi.PolicySchemeInstanceFor(&lres.Attestation.DecryptedBody.VerifierBody.Policy)
 		if err != nil {
 			panic(err)
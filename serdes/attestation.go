@@ -61,6 +61,44 @@ type SignedOuterKey struct {
 	Signature []byte
 }
 
+//ThresholdOuterKey binds the outer signature key like SignedOuterKey, but
+//the attestation is only valid if Threshold of the CoSigners have also
+//signed the TBS. The TBS repeats the contents of the attestation so that
+//co-signers know what they are approving
+type ThresholdOuterKey struct {
+	TBS struct {
+		OuterSignatureScheme asn1.ObjectIdentifier
+		VerifyingKey         []byte
+		Subject              asn1.External
+		SubjectLocation      asn1.External
+		Attester             asn1.External
+		AttesterLocation     asn1.External
+		Validity             struct {
+			NotBefore time.Time `asn1:"utc"`
+			NotAfter  time.Time `asn1:"utc"`
+		}
+		Policy    asn1.External
+		Threshold int
+		CoSigners []ThresholdCoSigner
+		//Used by the attester to derive the outer signing key
+		Nonce []byte
+	}
+	//The attester's signature over the TBS
+	Signature    []byte
+	CoSignatures []ThresholdCoSignature
+}
+
+type ThresholdCoSigner struct {
+	Entity   asn1.External //EntityHash
+	Location asn1.External
+}
+
+type ThresholdCoSignature struct {
+	//The index of the signer in CoSigners
+	Index     int
+	Signature []byte
+}
+
 type PSKBodyCiphertext struct {
 	AttestationBodyCiphetext []byte
 	EncryptedUnder           EntityPublicKey
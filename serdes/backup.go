@@ -0,0 +1,23 @@
+package serdes
+
+import "github.com/immesys/asn1"
+
+//EntitySecretShare is one share of an entity secret that has been split
+//so that any Threshold of the Total shares can recover it
+type EntitySecretShare struct {
+	TBS struct {
+		//The hash of the entity the secret belongs to
+		Entity asn1.External
+		//Shares from the same split have the same set ID
+		SetID     []byte
+		Threshold int
+		Total     int
+		//The x coordinate of the share, from 1 to Total
+		Index int
+		Value []byte
+		//The hash of the secret DER, checked after recovery
+		SecretHash asn1.External
+	}
+	//The hash of the TBS DER, used to detect damaged shares
+	Checksum asn1.External
+}
@@ -15,16 +15,23 @@ var (
 	EntitySecretOID                 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 4}
 	WaveEncryptedMessageOID         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 5}
 	WaveNameDeclarationOID          = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 6}
+	WaveEntitySuccessionOID         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 7}
+	EntitySecretShareOID            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 8}
+	WaveRevocationListOID           = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 9}
+	WaveRevocationStatementOID      = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 10}
+	WaveEncryptedStreamOID          = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 2, 11}
 	AttestationBodySchemeOID        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 3}
 	UnencryptedBodyOID              = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 3, 1}
 	WR1BodyOID                      = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 3, 2}
 	PSKBodySchemeOID                = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 3, 3}
+	WR1BodyV2OID                    = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 3, 4}
 	AttestationVerifierKeySchemeOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 4}
 	VerifierKeyAES128OID            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 4, 1}
 	OuterSignatureSchemeOID         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 5}
 	EphemeralEd25519OID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 5, 1}
 	OuterSignatureBindingSchemeOID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 6}
 	SignedOuterKeyOID               = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 6, 1}
+	ThresholdOuterKeyOID            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 6, 2}
 	LocationSchemeOID               = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 8}
 	LocationURLOID                  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 8, 1}
 	LocationEthereumOID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 8, 2}
@@ -33,6 +40,8 @@ var (
 	Keccak_256OID                   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 9, 2}
 	RevocationSchemeOID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 10}
 	CommitmentRevocationOID         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 10, 1}
+	RevocationListOID               = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 10, 2}
+	DelegatedRevocationOID          = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 10, 3}
 	EntityKeySchemeOID              = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11}
 	EntityEd25519OID                = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11, 1}
 	EntityCurve25519OID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11, 2}
@@ -46,6 +55,7 @@ var (
 	EntityOAQUE_BLS12381_S20_ParamsOID       = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11, 8}
 	EntityIBE_BLS12381_ParamsOID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11, 9}
 	EntityIBE_BLS12381_PublicOID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11, 10}
+	EntityECDSA_P256OID                      = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 11, 11}
 
 	PolicySchemeOID                        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 12}
 	TrustLevelPolicyOID                    = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 12, 1}
@@ -67,16 +77,26 @@ var (
 	EntitySecretOAQUE_BLS12381_S20_MasterOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 14, 8}
 	EntitySecretIBE_BLS12381_MasterOID       = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 14, 9}
 	EntitySecretIBE_BLS12381OID              = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 14, 10}
-
-	EntityKeyringSchemeOID       = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15}
-	PlaintextKeyringOID          = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15, 1}
-	KeyringAES128_GCM_PBKDF2OID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15, 2}
-	E2EEMessageKeySchemesOID     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 16}
-	MessageKeyCurve25519ECDHOID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 16, 1}
-	MessageKeyWR1OID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 16, 2}
-	NameDeclarationKeySchemesOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 17}
-	NameDeclarationKeyWR1OID     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 17, 1}
-	NameDeclarationKeyNoneOID    = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 17, 2}
+	EntitySecretECDSA_P256OID                = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 14, 11}
+
+	EntityKeyringSchemeOID        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15}
+	PlaintextKeyringOID           = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15, 1}
+	KeyringAES128_GCM_PBKDF2OID   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15, 2}
+	KeyringAES256_GCM_Argon2idOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 15, 3}
+	E2EEMessageKeySchemesOID      = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 16}
+	MessageKeyCurve25519ECDHOID   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 16, 1}
+	MessageKeyWR1OID              = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 16, 2}
+	NameDeclarationKeySchemesOID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 17}
+	NameDeclarationKeyWR1OID      = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 17, 1}
+	NameDeclarationKeyNoneOID     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 17, 2}
+	ProofExtensionsOID            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 18}
+	ProofSuccessionOID            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 18, 1}
+	X509ExtensionsOID             = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 19}
+	X509EntityOID                 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 19, 1}
+	X509ProofOID                  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 19, 2}
+	X509PolicyOID                 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 19, 3}
+	EntityExtensionsOID           = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 20}
+	WR1PartitionScheduleOID       = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51157, 20, 1}
 )
 
 const CapCertification = 1
@@ -98,7 +118,10 @@ func init() {
 	}{
 		{EntityOID, WaveEntity{}},
 		{WaveEncryptedMessageOID, WaveEncryptedMessage{}},
+		{WaveEncryptedStreamOID, WaveEncryptedStream{}},
 		{CommitmentRevocationOID, CommitmentRevocation{}},
+		{RevocationListOID, RevocationListRevocation{}},
+		{DelegatedRevocationOID, DelegatedRevocation{}},
 		{Sha3_256OID, Sha3_256{}},
 		{Keccak_256OID, Keccak_256{}},
 		{LocationURLOID, LocationURL{}},
@@ -117,12 +140,14 @@ func init() {
 		{EntityOAQUE_BLS12381_S20_ParamsOID, EntityParamsOQAUE_BLS12381_s20{}},
 		{EntityIBE_BLS12381_ParamsOID, EntityParamsIBE_BLS12381{}},
 		{EntityIBE_BLS12381_PublicOID, EntityPublicIBE_BLS12381{}},
+		{EntityECDSA_P256OID, EntityPublicECDSA_P256{}},
 
 		{AttestationOID, WaveAttestation{}},
 		{UnencryptedBodyOID, AttestationBody{}},
 		{TrustLevelPolicyOID, TrustLevel{}},
 		{ResourceTreePolicyOID, RTreePolicy{}},
 		{SignedOuterKeyOID, SignedOuterKey{}},
+		{ThresholdOuterKeyOID, ThresholdOuterKey{}},
 
 		{EntitySecretEd25519OID, EntitySecretEd25519{}},
 		{EntitySecretCurve25519OID, EntitySecretCurve25519{}},
@@ -130,6 +155,7 @@ func init() {
 		{EntitySecretOAQUE_BLS12381_S20_MasterOID, EntitySecretMasterOQAUE_BLS12381_s20{}},
 		{EntitySecretIBE_BLS12381_MasterOID, EntitySecretMasterIBE_BLS12381{}},
 		{EntitySecretIBE_BLS12381OID, EntitySecretIBE_BLS12381{}},
+		{EntitySecretECDSA_P256OID, EntitySecretECDSA_P256{}},
 
 		{WR1DomainVisibilityKey_IBE_BLS12381OID, WR1DomainVisibilityKey_IBE_BLS12381{}},
 		{WR1PartitionKey_OAQUE_BLS12381_s20OID, WR1PartitionKey_OAQUE_BLS12381_s20{}},
@@ -138,8 +164,10 @@ func init() {
 		{PlaintextKeyringOID, EntityKeyring{}},
 		{EntitySecretOID, WaveEntitySecret{}},
 		{KeyringAES128_GCM_PBKDF2OID, KeyringAESCiphertext{}},
+		{KeyringAES256_GCM_Argon2idOID, KeyringArgon2idCiphertext{}},
 		{PSKBodySchemeOID, PSKBodyCiphertext{}},
 		{WR1BodyOID, WR1BodyCiphertext{}},
+		{WR1BodyV2OID, WR1BodyCiphertextV2{}},
 		{ExplicitProofOID, WaveExplicitProof{}},
 		{VerifierKeyAES128OID, AVKeyAES128GCM{}},
 		{MessageKeyCurve25519ECDHOID, MessageKeyCurve25519ECDH{}},
@@ -147,6 +175,10 @@ func init() {
 		{WaveNameDeclarationOID, WaveNameDeclaration{}},
 		{NameDeclarationKeyWR1OID, NameDeclarationKeyWR1{}},
 		{NameDeclarationKeyNoneOID, NameDeclarationKeyNone{}},
+		{WaveEntitySuccessionOID, WaveEntitySuccession{}},
+		{EntitySecretShareOID, EntitySecretShare{}},
+		{WaveRevocationListOID, WaveRevocationList{}},
+		{WaveRevocationStatementOID, WaveRevocationStatement{}},
 	}
 	for _, t := range tpz {
 		asn1.RegisterExternalType(t.O, t.I)
@@ -0,0 +1,27 @@
+package serdes
+
+import (
+	"time"
+
+	"github.com/immesys/asn1"
+)
+
+//DelegatedRevocation is a revocation option that is triggered when the
+//named revoker publishes a signed revocation statement for the ID
+type DelegatedRevocation struct {
+	Revoker         asn1.External
+	RevokerLocation asn1.External
+	ID              []byte
+}
+
+//WaveRevocationStatement is signed by a delegated revoker's certification
+//key and published to a queue named by the revoker and ID
+type WaveRevocationStatement struct {
+	TBS struct {
+		Revoker    asn1.External
+		ID         []byte
+		Created    time.Time `asn1:"utc"`
+		Extensions []Extension
+	}
+	Signature []byte
+}
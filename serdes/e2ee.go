@@ -7,6 +7,15 @@ type WaveEncryptedMessage struct {
 	Keys       []asn1.External
 	Extensions []Extension
 }
+
+//WaveEncryptedStream is the header of a streamed encrypted message. It is
+//followed by the content in authenticated segments of SegmentSize bytes
+//of plaintext, the last of which may be shorter
+type WaveEncryptedStream struct {
+	SegmentSize int
+	Keys        []asn1.External
+	Extensions  []Extension
+}
 type MessageKeyCurve25519ECDH struct {
 	Ciphertext []byte
 }
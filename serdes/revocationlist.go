@@ -0,0 +1,37 @@
+package serdes
+
+import (
+	"time"
+
+	"github.com/immesys/asn1"
+)
+
+//RevocationListRevocation is a revocation option that is triggered when
+//the ID appears in the latest revocation list published by the revoker
+type RevocationListRevocation struct {
+	Revoker         asn1.External
+	RevokerLocation asn1.External
+	//A revoker may keep several lists
+	ListID []byte
+	//The ID of the object in the list
+	ID []byte
+}
+
+//WaveRevocationList is a versioned list of revoked IDs. It is signed by
+//the revoker's certification key and published to a queue named by the
+//revoker and list ID
+type WaveRevocationList struct {
+	TBS struct {
+		Revoker asn1.External
+		ListID  []byte
+		//Each new list has a higher version than the one it replaces
+		Version int
+		Created time.Time `asn1:"utc"`
+		//The list must not be relied on after this time. The revoker
+		//publishes a new version before then
+		NextUpdate time.Time `asn1:"utc"`
+		Revoked    [][]byte
+		Extensions []Extension
+	}
+	Signature []byte
+}
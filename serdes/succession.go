@@ -0,0 +1,24 @@
+package serdes
+
+import (
+	"time"
+
+	"github.com/immesys/asn1"
+)
+
+//WaveEntitySuccession names a new entity that takes over from an old
+//one. It is signed by the predecessor's certification key
+type WaveEntitySuccession struct {
+	TBS struct {
+		Predecessor         asn1.External
+		PredecessorLocation asn1.External
+		Successor           asn1.External
+		SuccessorLocation   asn1.External
+		Created             time.Time `asn1:"utc"`
+		//Until this time, attestations granted to the predecessor may
+		//be used by the successor
+		GraceUntil time.Time `asn1:"utc"`
+		Extensions []Extension
+	}
+	Signature []byte
+}
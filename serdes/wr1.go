@@ -11,6 +11,23 @@ type WR1BodyCiphertext struct {
 	EnvelopeKey_Curve25519Attester []byte
 }
 
+//The second version of the WR1 body carries the partition schedule of the
+//namespace alongside the ciphertext
+type WR1BodyCiphertextV2 struct {
+	Schedule   WR1PartitionSchedule
+	Ciphertext WR1BodyCiphertext
+}
+
+//This is also the value of the WR1PartitionSchedule entity extension
+type WR1PartitionSchedule struct {
+	//Tier lengths in nanoseconds, coarsest first
+	Tiers []int64
+	//The number of bytes in each time slot
+	SlotSize int
+	//The longest validity range in nanoseconds
+	MaxRange int64
+}
+
 type WR1Envelope struct {
 	BodyKeys_OAQUE []byte
 	Partition      [][]byte
@@ -43,6 +60,9 @@ type EntityPublicCurve25519 []byte
 type EntityParamsOQAUE_BLS12381_s20 []byte
 type EntityParamsIBE_BLS12381 []byte
 
+//An uncompressed SEC 1 point
+type EntityPublicECDSA_P256 []byte
+
 type EntitySecretEd25519 []byte
 type EntitySecretCurve25519 []byte
 type EntitySecretOQAUE_BLS12381_s20 []byte
@@ -50,12 +70,24 @@ type EntitySecretMasterOQAUE_BLS12381_s20 []byte
 type EntitySecretMasterIBE_BLS12381 []byte
 type EntitySecretIBE_BLS12381 []byte
 
+//The 32 byte big endian private scalar
+type EntitySecretECDSA_P256 []byte
+
 type KeyringAESCiphertext struct {
 	Ciphertext []byte
 	Salt       []byte
 	Iterations int
 }
 
+type KeyringArgon2idCiphertext struct {
+	Ciphertext []byte
+	Salt       []byte
+	Time       int
+	//In KiB
+	Memory  int
+	Threads int
+}
+
 type WR1DomainVisibilityKey_IBE_BLS12381 EntityKeyringEntry
 type WR1PartitionKey_OAQUE_BLS12381_s20 EntityKeyringEntry
 
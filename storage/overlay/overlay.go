@@ -164,6 +164,18 @@ func (ov *Overlay) GetAttestationOrDeclaration(ctx context.Context, loc iapi.Loc
 		nda, err := iapi.ParseNameDeclaration(ctx, &iapi.PParseNameDeclaration{
 			DER: der,
 		})
+		if err != nil && err.Code() == wve.UnexpectedObject {
+			//Try parse as entity succession
+			sr, err := iapi.ParseEntitySuccession(ctx, &iapi.PParseEntitySuccession{
+				DER: der,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &iapi.GetResult{
+				Succession: sr.Succession,
+			}, nil
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -2,6 +2,7 @@ package waved
 
 import (
 	"github.com/BurntSushi/toml"
+	"github.com/immesys/wave/eapi"
 )
 
 type Configuration struct {
@@ -11,6 +12,10 @@ type Configuration struct {
 	ListenUnix         string
 	DefaultToUnrevoked bool
 	Storage            map[string]map[string]string
+	Auth               eapi.AuthConfig
+	TLS                eapi.TLSConfig
+	Audit              eapi.AuditConfig
+	SSH                eapi.SSHConfig
 }
 
 func ParseConfig(file string) (*Configuration, error) {
@@ -60,8 +60,33 @@ func MainWithConfig(c *Configuration) {
 
 	ws := poc.NewPOC(llsdb)
 	api := eapi.NewEAPI(ws)
-	api.StartServer(c.ListenIP, c.HTTPListenIP)
-	fmt.Printf("server started on %s\n", c.ListenIP)
+	api.StartServerWithConfig(&eapi.ServerConfig{
+		ListenIP:     c.ListenIP,
+		HTTPListenIP: c.HTTPListenIP,
+		ListenUnix:   c.ListenUnix,
+		Auth:         &c.Auth,
+		TLS:          &c.TLS,
+		Audit:        &c.Audit,
+		SSH:          &c.SSH,
+	})
+	if c.Audit.Enabled() {
+		fmt.Printf("writing audit log to %s\n", c.Audit.File)
+	}
+	if c.SSH.Enabled() {
+		fmt.Printf("issuing SSH certificates for %s\n", c.SSH.Permission)
+	}
+	if c.TLS.Enabled() {
+		fmt.Printf("TLS enabled using %s\n", c.TLS.CertFile)
+	}
+	if c.ListenIP != "" {
+		fmt.Printf("server started on %s\n", c.ListenIP)
+	}
+	if c.ListenUnix != "" {
+		fmt.Printf("server started on unix socket %s\n", c.ListenUnix)
+	}
+	if !c.Auth.Enabled() {
+		fmt.Printf("no [auth] rules configured: any client that can connect may use any entity\n")
+	}
 	for {
 		time.Sleep(10 * time.Second)
 	}
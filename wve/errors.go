@@ -175,3 +175,9 @@ const MalformedPartition = 914
 const InvalidE2EEGrant = 915
 
 const ProofNotCached = 916
+
+//An attester has valid declarations of a name for more than one entity
+const NameConflict = 917
+
+//A name no longer resolves to the entity that was pinned for it
+const NamePinMismatch = 918